@@ -34,6 +34,7 @@ func Convert_v1alpha1_NetworkSpec_To_v1alpha2_NetworkSpec(in *NetworkSpec, out *
 }
 
 func Convert_v1alpha2_NetworkSpec_To_v1alpha1_NetworkSpec(in *infrastructurev1alpha2.NetworkSpec, out *NetworkSpec, s conversion.Scope) error {
+	// DNSAliases does not exist in v1alpha1, it is restored from the conversion annotation by restoreNetworkSpec
 	out.NodeBalancerConfigID = in.ApiserverNodeBalancerConfigID
 	out.LoadBalancerPort = in.ApiserverLoadBalancerPort
 	out.LoadBalancerType = in.LoadBalancerType
@@ -41,11 +42,74 @@ func Convert_v1alpha2_NetworkSpec_To_v1alpha1_NetworkSpec(in *infrastructurev1al
 	return nil
 }
 
+// restoreNetworkSpec copies the NetworkSpec fields that v1alpha1 cannot hold from the hub data preserved on
+// down-conversion.
+func restoreNetworkSpec(restored, dst *infrastructurev1alpha2.NetworkSpec) {
+	dst.DNSAliases = restored.DNSAliases
+}
+
 func Convert_v1alpha2_LinodeMachineSpec_To_v1alpha1_LinodeMachineSpec(in *infrastructurev1alpha2.LinodeMachineSpec, out *LinodeMachineSpec, s conversion.Scope) error {
-	// Ok to use the auto-generated conversion function, it simply drops the PlacementGroupRef, and copies everything else
+	// Ok to use the auto-generated conversion function, it simply drops the PlacementGroupRef, ExternalInstance, BackupSchedule, LabelTemplate, RootFSLabel, AuthorizedKeyLabels, Volumes, VPCIPv4, AllowRunningRename, FallbackTypes, FirewallPolicy, DefaultRoute, DNSPriority, MaintenanceWindow, DatabaseID, AdditionalIPv4Count, SplitHorizonDNS, ClusterFirewallRules, PowerOnWindows, ChildAccountEUUID, RequiredCapabilities, FirewallRules, CreateFailurePolicy, TXTRecords, RebootPolicy, NAT1To1 and BootTimeout, and copies everything else.
+	// Fields added after v1alpha1 are restored from the conversion annotation by restoreLinodeMachineSpec.
 	return autoConvert_v1alpha2_LinodeMachineSpec_To_v1alpha1_LinodeMachineSpec(in, out, s)
 }
 
+// restoreLinodeMachineSpec copies the LinodeMachineSpec fields that v1alpha1 cannot hold from the hub
+// data preserved on down-conversion.
+func restoreLinodeMachineSpec(restored, dst *infrastructurev1alpha2.LinodeMachineSpec) {
+	dst.ExternalInstance = restored.ExternalInstance
+	dst.BackupSchedule = restored.BackupSchedule
+	dst.LabelTemplate = restored.LabelTemplate
+	dst.RootFSLabel = restored.RootFSLabel
+	dst.AuthorizedKeyLabels = restored.AuthorizedKeyLabels
+	dst.Volumes = restored.Volumes
+	dst.VPCIPv4 = restored.VPCIPv4
+	dst.AllowRunningRename = restored.AllowRunningRename
+	dst.FallbackTypes = restored.FallbackTypes
+	dst.FirewallPolicy = restored.FirewallPolicy
+	dst.DefaultRoute = restored.DefaultRoute
+	dst.DNSPriority = restored.DNSPriority
+	dst.MaintenanceWindow = restored.MaintenanceWindow
+	dst.DatabaseID = restored.DatabaseID
+	dst.AdditionalIPv4Count = restored.AdditionalIPv4Count
+	dst.SplitHorizonDNS = restored.SplitHorizonDNS
+	dst.ClusterFirewallRules = restored.ClusterFirewallRules
+	dst.PowerOnWindows = restored.PowerOnWindows
+	dst.ChildAccountEUUID = restored.ChildAccountEUUID
+	dst.RequiredCapabilities = restored.RequiredCapabilities
+	dst.FirewallRules = restored.FirewallRules
+	dst.CreateFailurePolicy = restored.CreateFailurePolicy
+	dst.TXTRecords = restored.TXTRecords
+	dst.RebootPolicy = restored.RebootPolicy
+	dst.NAT1To1 = restored.NAT1To1
+	dst.BootTimeout = restored.BootTimeout
+}
+
+func Convert_v1alpha2_LinodeMachineStatus_To_v1alpha1_LinodeMachineStatus(in *infrastructurev1alpha2.LinodeMachineStatus, out *LinodeMachineStatus, s conversion.Scope) error {
+	// Ok to use the auto-generated conversion function, it simply drops the InstanceType, LongviewClientID, ManagedTags, ManagedFirewallIDs, ManagedFirewallRules, ManagedTXTRecords, ManagedDatabaseAllowList, AdditionalIPv4s, UnhealthySince, Transfer, RebootPendingSince, LastPowerTransition, ObjectStorageKeyID and StackScriptUDFHash, and copies everything else.
+	// Fields added after v1alpha1 are restored from the conversion annotation by restoreLinodeMachineStatus.
+	return autoConvert_v1alpha2_LinodeMachineStatus_To_v1alpha1_LinodeMachineStatus(in, out, s)
+}
+
+// restoreLinodeMachineStatus copies the LinodeMachineStatus fields that v1alpha1 cannot hold from the hub
+// data preserved on down-conversion.
+func restoreLinodeMachineStatus(restored, dst *infrastructurev1alpha2.LinodeMachineStatus) {
+	dst.LongviewClientID = restored.LongviewClientID
+	dst.ManagedTags = restored.ManagedTags
+	dst.UnhealthySince = restored.UnhealthySince
+	dst.ManagedFirewallIDs = restored.ManagedFirewallIDs
+	dst.InstanceType = restored.InstanceType
+	dst.Transfer = restored.Transfer
+	dst.ObjectStorageKeyID = restored.ObjectStorageKeyID
+	dst.RebootPendingSince = restored.RebootPendingSince
+	dst.ManagedDatabaseAllowList = restored.ManagedDatabaseAllowList
+	dst.StackScriptUDFHash = restored.StackScriptUDFHash
+	dst.AdditionalIPv4s = restored.AdditionalIPv4s
+	dst.LastPowerTransition = restored.LastPowerTransition
+	dst.ManagedFirewallRules = restored.ManagedFirewallRules
+	dst.ManagedTXTRecords = restored.ManagedTXTRecords
+}
+
 func Convert_v1alpha1_LinodeObjectStorageBucketSpec_To_v1alpha2_LinodeObjectStorageBucketSpec(in *LinodeObjectStorageBucketSpec, out *infrastructurev1alpha2.LinodeObjectStorageBucketSpec, s conversion.Scope) error {
 	// WARNING: in.Cluster requires manual conversion: does not exist in peer-type
 	out.Region = in.Cluster
@@ -37,10 +37,11 @@ func (src *LinodeCluster) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	// Manually restore data from annotations
-	restored := &LinodeCluster{}
+	restored := &infrastructurev1alpha2.LinodeCluster{}
 	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
 		return err
 	}
+	restoreNetworkSpec(&restored.Spec.Network, &dst.Spec.Network)
 
 	return nil
 }
@@ -190,3 +190,36 @@ func TestConvertLinodeClusterFrom(t *testing.T) {
 		),
 	)
 }
+
+// hubLinodeClusterSpec sets every LinodeClusterSpec field that only exists in v1alpha2 and is preserved
+// through the conversion annotation.
+func hubLinodeClusterSpec() infrav1alpha2.LinodeClusterSpec {
+	return infrav1alpha2.LinodeClusterSpec{
+		Network: infrav1alpha2.NetworkSpec{
+			LoadBalancerType: "NodeBalancer",
+			AdditionalPorts:  []infrav1alpha2.LinodeNBPortConfig{},
+			DNSAliases:       []string{"api"},
+		},
+		Region: "us-ord",
+	}
+}
+
+func TestConvertLinodeClusterRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	hub := &infrav1alpha2.LinodeCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		Spec:       hubLinodeClusterSpec(),
+	}
+	spoke := &LinodeCluster{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom failed: %v", err)
+	}
+	restored := &infrav1alpha2.LinodeCluster{}
+	if err := spoke.ConvertTo(restored); err != nil {
+		t.Fatalf("ConvertTo failed: %v", err)
+	}
+	if diff := cmp.Diff(hub.Spec, restored.Spec); diff != "" {
+		t.Errorf("round trip spec mismatch (-expected +got):\n%s", diff)
+	}
+}
@@ -37,10 +37,11 @@ func (src *LinodeClusterTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	// Manually restore data from annotations
-	restored := &LinodeClusterTemplate{}
+	restored := &infrastructurev1alpha2.LinodeClusterTemplate{}
 	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
 		return err
 	}
+	restoreNetworkSpec(&restored.Spec.Template.Spec.Network, &dst.Spec.Template.Spec.Network)
 
 	return nil
 }
@@ -203,3 +203,25 @@ func TestLinodeClusterTemplateConvertFrom(t *testing.T) {
 		),
 	)
 }
+
+func TestLinodeClusterTemplateConvertRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	hub := &infrav1alpha2.LinodeClusterTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-template"},
+		Spec: infrav1alpha2.LinodeClusterTemplateSpec{
+			Template: infrav1alpha2.LinodeClusterTemplateResource{Spec: hubLinodeClusterSpec()},
+		},
+	}
+	spoke := &LinodeClusterTemplate{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom failed: %v", err)
+	}
+	restored := &infrav1alpha2.LinodeClusterTemplate{}
+	if err := spoke.ConvertTo(restored); err != nil {
+		t.Fatalf("ConvertTo failed: %v", err)
+	}
+	if diff := cmp.Diff(hub.Spec, restored.Spec); diff != "" {
+		t.Errorf("round trip spec mismatch (-expected +got):\n%s", diff)
+	}
+}
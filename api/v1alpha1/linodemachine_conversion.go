@@ -37,10 +37,12 @@ func (src *LinodeMachine) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	// Manually restore data from annotations
-	restored := &LinodeMachine{}
+	restored := &infrastructurev1alpha2.LinodeMachine{}
 	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
 		return err
 	}
+	restoreLinodeMachineSpec(&restored.Spec, &dst.Spec)
+	restoreLinodeMachineStatus(&restored.Status, &dst.Status)
 
 	return nil
 }
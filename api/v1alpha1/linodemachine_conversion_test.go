@@ -19,8 +19,10 @@ package v1alpha1
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/linode/linodego"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -301,3 +303,81 @@ func TestLinodeMachineConvertFrom(t *testing.T) {
 		),
 	)
 }
+
+// hubLinodeMachineSpec sets every LinodeMachineSpec field that only exists in v1alpha2.
+func hubLinodeMachineSpec() infrav1alpha2.LinodeMachineSpec {
+	return infrav1alpha2.LinodeMachineSpec{
+		Region:               "us-ord",
+		Type:                 "g6-standard-2",
+		ExternalInstance:     &infrav1alpha2.ExternalInstance{IPAddress: "192.0.2.10"},
+		BackupSchedule:       &infrav1alpha2.BackupSchedule{Window: "W2", Day: "Sunday"},
+		LabelTemplate:        "{{ .ClusterName }}-{{ .MachineName }}",
+		RootFSLabel:          "rootfs",
+		AuthorizedKeyLabels:  []string{"ops"},
+		Volumes:              []infrav1alpha2.InstanceVolume{{VolumeID: 3, DeviceSlot: "sdc"}},
+		VPCIPv4:              "10.0.0.20",
+		AllowRunningRename:   true,
+		FallbackTypes:        []string{"g6-standard-4"},
+		FirewallPolicy:       &infrav1alpha2.FirewallPolicy{Inbound: "DROP", Outbound: "ACCEPT"},
+		DefaultRoute:         &infrav1alpha2.DefaultRoute{IPv4: linodego.InterfacePurposeVPC},
+		DNSPriority:          20,
+		MaintenanceWindow:    &infrav1alpha2.TimeWindow{Days: []string{"Sat"}, Start: "02:00", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+		DatabaseID:           ptr.To(91),
+		AdditionalIPv4Count:  1,
+		SplitHorizonDNS:      &infrav1alpha2.SplitHorizonDNS{InternalDomain: "internal.example.com", ExternalDomain: "example.com"},
+		ClusterFirewallRules: true,
+		PowerOnWindows:       []infrav1alpha2.TimeWindow{{Start: "08:00", Duration: metav1.Duration{Duration: 10 * time.Hour}}},
+		ChildAccountEUUID:    "A1B2C3D4-0000-0000-0000000000000000",
+		RequiredCapabilities: []string{"GPU Linodes"},
+		FirewallRules:        []infrav1alpha2.FirewallRule{{Label: "ssh", Direction: "inbound", Action: "ACCEPT", Protocol: "TCP", Ports: "22"}},
+		CreateFailurePolicy:  infrav1alpha2.CreateFailurePolicyRollback,
+		TXTRecords:           []infrav1alpha2.TXTRecord{{Name: "_acme", Value: "token"}},
+		RebootPolicy:         infrav1alpha2.RebootPolicyMaintenanceWindow,
+		NAT1To1:              ptr.To("any"),
+		BootTimeout:          &metav1.Duration{Duration: 5 * time.Minute},
+	}
+}
+
+// hubLinodeMachineStatus sets every LinodeMachineStatus field that only exists in v1alpha2.
+func hubLinodeMachineStatus() infrav1alpha2.LinodeMachineStatus {
+	return infrav1alpha2.LinodeMachineStatus{
+		LongviewClientID:         ptr.To(12),
+		ManagedTags:              []string{"env:prod"},
+		UnhealthySince:           ptr.To(metav1.NewTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))),
+		ManagedFirewallIDs:       []int{41},
+		InstanceType:             "g6-standard-4",
+		Transfer:                 &infrav1alpha2.TransferStatus{Quota: 4000, Used: 12, PoolQuota: 8000, PoolUsed: 30},
+		ObjectStorageKeyID:       ptr.To(77),
+		RebootPendingSince:       ptr.To(metav1.NewTime(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC))),
+		ManagedDatabaseAllowList: []string{"192.0.2.10/32"},
+		StackScriptUDFHash:       "5d41402abc4b2a76",
+		AdditionalIPv4s:          []string{"192.0.2.11"},
+		LastPowerTransition:      ptr.To(metav1.NewTime(time.Date(2024, 1, 4, 8, 0, 0, 0, time.UTC))),
+		ManagedFirewallRules:     []string{"ssh"},
+		ManagedTXTRecords:        []infrav1alpha2.TXTRecord{{Name: "_acme", Value: "token"}},
+	}
+}
+
+func TestLinodeMachineConvertRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	hub := &infrav1alpha2.LinodeMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-machine"},
+		Spec:       hubLinodeMachineSpec(),
+		Status:     hubLinodeMachineStatus(),
+	}
+	spoke := &LinodeMachine{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom failed: %v", err)
+	}
+	restored := &infrav1alpha2.LinodeMachine{}
+	if err := spoke.ConvertTo(restored); err != nil {
+		t.Fatalf("ConvertTo failed: %v", err)
+	}
+	if diff := cmp.Diff(hub.Spec, restored.Spec); diff != "" {
+		t.Errorf("round trip spec mismatch (-expected +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(hub.Status, restored.Status); diff != "" {
+		t.Errorf("round trip status mismatch (-expected +got):\n%s", diff)
+	}
+}
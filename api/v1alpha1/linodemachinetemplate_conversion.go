@@ -37,10 +37,11 @@ func (src *LinodeMachineTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	// Manually restore data from annotations
-	restored := &LinodeMachineTemplate{}
+	restored := &infrastructurev1alpha2.LinodeMachineTemplate{}
 	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
 		return err
 	}
+	restoreLinodeMachineSpec(&restored.Spec.Template.Spec, &dst.Spec.Template.Spec)
 
 	return nil
 }
@@ -307,3 +307,25 @@ func TestLinodeMachineTemplateConvertFrom(t *testing.T) {
 		),
 	)
 }
+
+func TestLinodeMachineTemplateConvertRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	hub := &infrav1alpha2.LinodeMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-machine"},
+		Spec: infrav1alpha2.LinodeMachineTemplateSpec{
+			Template: infrav1alpha2.LinodeMachineTemplateResource{Spec: hubLinodeMachineSpec()},
+		},
+	}
+	spoke := &LinodeMachineTemplate{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom failed: %v", err)
+	}
+	restored := &infrav1alpha2.LinodeMachineTemplate{}
+	if err := spoke.ConvertTo(restored); err != nil {
+		t.Fatalf("ConvertTo failed: %v", err)
+	}
+	if diff := cmp.Diff(hub.Spec, restored.Spec); diff != "" {
+		t.Errorf("round trip spec mismatch (-expected +got):\n%s", diff)
+	}
+}
@@ -180,11 +180,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha2.LinodeMachineStatus)(nil), (*LinodeMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha2_LinodeMachineStatus_To_v1alpha1_LinodeMachineStatus(a.(*v1alpha2.LinodeMachineStatus), b.(*LinodeMachineStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*LinodeMachineTemplate)(nil), (*v1alpha2.LinodeMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_LinodeMachineTemplate_To_v1alpha2_LinodeMachineTemplate(a.(*LinodeMachineTemplate), b.(*v1alpha2.LinodeMachineTemplate), scope)
 	}); err != nil {
@@ -325,6 +320,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha2.LinodeMachineStatus)(nil), (*LinodeMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_LinodeMachineStatus_To_v1alpha1_LinodeMachineStatus(a.(*v1alpha2.LinodeMachineStatus), b.(*LinodeMachineStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha2.LinodeObjectStorageBucketSpec)(nil), (*LinodeObjectStorageBucketSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_LinodeObjectStorageBucketSpec_To_v1alpha1_LinodeObjectStorageBucketSpec(a.(*v1alpha2.LinodeObjectStorageBucketSpec), b.(*LinodeObjectStorageBucketSpec), scope)
 	}); err != nil {
@@ -775,23 +775,49 @@ func autoConvert_v1alpha2_LinodeMachineSpec_To_v1alpha1_LinodeMachineSpec(in *v1
 	out.InstanceID = (*int)(unsafe.Pointer(in.InstanceID))
 	out.Region = in.Region
 	out.Type = in.Type
+	// WARNING: in.FallbackTypes requires manual conversion: does not exist in peer-type
 	out.Group = in.Group
 	out.RootPass = in.RootPass
 	out.AuthorizedKeys = *(*[]string)(unsafe.Pointer(&in.AuthorizedKeys))
 	out.AuthorizedUsers = *(*[]string)(unsafe.Pointer(&in.AuthorizedUsers))
+	// WARNING: in.AuthorizedKeyLabels requires manual conversion: does not exist in peer-type
 	out.BackupID = in.BackupID
 	out.Image = in.Image
 	out.Interfaces = *(*[]InstanceConfigInterfaceCreateOptions)(unsafe.Pointer(&in.Interfaces))
+	// WARNING: in.DefaultRoute requires manual conversion: does not exist in peer-type
+	// WARNING: in.DNSPriority requires manual conversion: does not exist in peer-type
+	// WARNING: in.MaintenanceWindow requires manual conversion: does not exist in peer-type
+	// WARNING: in.RebootPolicy requires manual conversion: does not exist in peer-type
+	// WARNING: in.PowerOnWindows requires manual conversion: does not exist in peer-type
+	// WARNING: in.AdditionalIPv4Count requires manual conversion: does not exist in peer-type
+	// WARNING: in.SplitHorizonDNS requires manual conversion: does not exist in peer-type
+	// WARNING: in.TXTRecords requires manual conversion: does not exist in peer-type
+	// WARNING: in.DatabaseID requires manual conversion: does not exist in peer-type
 	out.BackupsEnabled = in.BackupsEnabled
+	// WARNING: in.BackupSchedule requires manual conversion: does not exist in peer-type
 	out.PrivateIP = (*bool)(unsafe.Pointer(in.PrivateIP))
 	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
 	out.FirewallID = in.FirewallID
+	// WARNING: in.FirewallPolicy requires manual conversion: does not exist in peer-type
+	// WARNING: in.ClusterFirewallRules requires manual conversion: does not exist in peer-type
+	// WARNING: in.FirewallRules requires manual conversion: does not exist in peer-type
 	out.OSDisk = (*InstanceDisk)(unsafe.Pointer(in.OSDisk))
 	out.DataDisks = *(*map[string]*InstanceDisk)(unsafe.Pointer(&in.DataDisks))
 	// WARNING: in.DiskEncryption requires manual conversion: does not exist in peer-type
+	// WARNING: in.RequiredCapabilities requires manual conversion: does not exist in peer-type
+	// WARNING: in.CreateFailurePolicy requires manual conversion: does not exist in peer-type
+	// WARNING: in.BootTimeout requires manual conversion: does not exist in peer-type
 	out.CredentialsRef = (*v1.SecretReference)(unsafe.Pointer(in.CredentialsRef))
+	// WARNING: in.ChildAccountEUUID requires manual conversion: does not exist in peer-type
 	// WARNING: in.Configuration requires manual conversion: does not exist in peer-type
 	// WARNING: in.PlacementGroupRef requires manual conversion: does not exist in peer-type
+	// WARNING: in.ExternalInstance requires manual conversion: does not exist in peer-type
+	// WARNING: in.LabelTemplate requires manual conversion: does not exist in peer-type
+	// WARNING: in.AllowRunningRename requires manual conversion: does not exist in peer-type
+	// WARNING: in.RootFSLabel requires manual conversion: does not exist in peer-type
+	// WARNING: in.VPCIPv4 requires manual conversion: does not exist in peer-type
+	// WARNING: in.NAT1To1 requires manual conversion: does not exist in peer-type
+	// WARNING: in.Volumes requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -814,17 +840,26 @@ func autoConvert_v1alpha2_LinodeMachineStatus_To_v1alpha1_LinodeMachineStatus(in
 	out.Ready = in.Ready
 	out.Addresses = *(*[]v1beta1.MachineAddress)(unsafe.Pointer(&in.Addresses))
 	out.InstanceState = (*linodego.InstanceStatus)(unsafe.Pointer(in.InstanceState))
+	// WARNING: in.InstanceType requires manual conversion: does not exist in peer-type
+	// WARNING: in.LongviewClientID requires manual conversion: does not exist in peer-type
+	// WARNING: in.ManagedTags requires manual conversion: does not exist in peer-type
+	// WARNING: in.ManagedFirewallIDs requires manual conversion: does not exist in peer-type
+	// WARNING: in.ManagedFirewallRules requires manual conversion: does not exist in peer-type
+	// WARNING: in.ManagedTXTRecords requires manual conversion: does not exist in peer-type
+	// WARNING: in.ManagedDatabaseAllowList requires manual conversion: does not exist in peer-type
+	// WARNING: in.AdditionalIPv4s requires manual conversion: does not exist in peer-type
+	// WARNING: in.UnhealthySince requires manual conversion: does not exist in peer-type
+	// WARNING: in.Transfer requires manual conversion: does not exist in peer-type
+	// WARNING: in.RebootPendingSince requires manual conversion: does not exist in peer-type
+	// WARNING: in.LastPowerTransition requires manual conversion: does not exist in peer-type
+	// WARNING: in.ObjectStorageKeyID requires manual conversion: does not exist in peer-type
+	// WARNING: in.StackScriptUDFHash requires manual conversion: does not exist in peer-type
 	out.FailureReason = (*errors.MachineStatusError)(unsafe.Pointer(in.FailureReason))
 	out.FailureMessage = (*string)(unsafe.Pointer(in.FailureMessage))
 	out.Conditions = *(*v1beta1.Conditions)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
-// Convert_v1alpha2_LinodeMachineStatus_To_v1alpha1_LinodeMachineStatus is an autogenerated conversion function.
-func Convert_v1alpha2_LinodeMachineStatus_To_v1alpha1_LinodeMachineStatus(in *v1alpha2.LinodeMachineStatus, out *LinodeMachineStatus, s conversion.Scope) error {
-	return autoConvert_v1alpha2_LinodeMachineStatus_To_v1alpha1_LinodeMachineStatus(in, out, s)
-}
-
 func autoConvert_v1alpha1_LinodeMachineTemplate_To_v1alpha2_LinodeMachineTemplate(in *LinodeMachineTemplate, out *v1alpha2.LinodeMachineTemplate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha1_LinodeMachineTemplateSpec_To_v1alpha2_LinodeMachineTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1182,6 +1217,7 @@ func autoConvert_v1alpha2_NetworkSpec_To_v1alpha1_NetworkSpec(in *v1alpha2.Netwo
 	// WARNING: in.DNSRootDomain requires manual conversion: does not exist in peer-type
 	// WARNING: in.DNSUniqueIdentifier requires manual conversion: does not exist in peer-type
 	// WARNING: in.DNSTTLSec requires manual conversion: does not exist in peer-type
+	// WARNING: in.DNSAliases requires manual conversion: does not exist in peer-type
 	// WARNING: in.ApiserverLoadBalancerPort requires manual conversion: does not exist in peer-type
 	out.NodeBalancerID = (*int)(unsafe.Pointer(in.NodeBalancerID))
 	// WARNING: in.ApiserverNodeBalancerConfigID requires manual conversion: does not exist in peer-type
@@ -108,26 +108,32 @@ type NetworkSpec struct {
 	// +optional
 	LoadBalancerType string `json:"loadBalancerType,omitempty"`
 	// DNSProvider is provider who manages the domain
-	// Ignored if the LoadBalancerType is set to anything other than dns
+	// Ignored if DNSRootDomain is not set
 	// If not set, defaults linode dns
 	// +kubebuilder:validation:Enum=linode;akamai
 	// +optional
 	DNSProvider string `json:"dnsProvider,omitempty"`
 	// DNSRootDomain is the root domain used to create a DNS entry for the control-plane endpoint
-	// Ignored if the LoadBalancerType is set to anything other than dns
+	// When the LoadBalancerType is NodeBalancer, an address record of the NodeBalancer IP is created under it
 	// +optional
 	DNSRootDomain string `json:"dnsRootDomain,omitempty"`
 	// DNSUniqueIdentifier is the unique identifier for the DNS. This let clusters with the same name have unique
 	// DNS record
-	// Ignored if the LoadBalancerType is set to anything other than dns
+	// Ignored if DNSRootDomain is not set
 	// If not set, CAPL will create a unique identifier for you
 	// +optional
 	DNSUniqueIdentifier string `json:"dnsUniqueIdentifier,omitempty"`
 	// DNSTTLSec is the TTL for the domain record
-	// Ignored if the LoadBalancerType is set to anything other than dns
+	// Ignored if DNSRootDomain is not set
 	// If not set, defaults to 30
 	// +optional
 	DNSTTLSec int `json:"dnsTTLsec,omitempty"`
+	// DNSAliases are vanity names relative to DNSRootDomain, which are created as CNAME records
+	// of the control-plane endpoint
+	// Ignored if DNSRootDomain is not set
+	// Only supported by the linode DNS provider
+	// +optional
+	DNSAliases []string `json:"dnsAliases,omitempty"`
 	// apiserverLoadBalancerPort used by the api server. It must be valid ports range (1-65535).
 	// If omitted, default value is 6443.
 	// +kubebuilder:validation:Minimum=1
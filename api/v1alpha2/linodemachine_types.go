@@ -29,6 +29,23 @@ const (
 	// MachineFinalizer allows ReconcileLinodeMachine to clean up Linode resources associated
 	// with LinodeMachine before removing it from the apiserver.
 	MachineFinalizer = "linodemachine.infrastructure.cluster.x-k8s.io"
+
+	// InstanceIDAnnotation mirrors the Linode instance ID backing the LinodeMachine.
+	InstanceIDAnnotation = "linodemachine.infrastructure.cluster.x-k8s.io/instance-id"
+	// RegionAnnotation mirrors the region of the Linode instance.
+	RegionAnnotation = "linodemachine.infrastructure.cluster.x-k8s.io/region"
+	// TypeAnnotation mirrors the plan (Linode type) of the Linode instance.
+	TypeAnnotation = "linodemachine.infrastructure.cluster.x-k8s.io/type"
+	// BootConfigAnnotation records the ID of the config profile the instance was last booted into.
+	BootConfigAnnotation = "linodemachine.infrastructure.cluster.x-k8s.io/boot-config"
+	// BootstrapChecksumAnnotation records a checksum of the bootstrap data the instance was provisioned with.
+	BootstrapChecksumAnnotation = "linodemachine.infrastructure.cluster.x-k8s.io/bootstrap-checksum"
+	// RescueAnnotation must be set to "true" to allow the instance to be booted into rescue mode.
+	RescueAnnotation = "linodemachine.infrastructure.cluster.x-k8s.io/rescue"
+	// DiskShrinkAnnotation records the disk shrunk ahead of a resize to a smaller plan, as
+	// <disk ID>:<size in MB>. It is left in place when the disk's data did not fit, so the shrink
+	// is not retried until it is removed.
+	DiskShrinkAnnotation = "linodemachine.infrastructure.cluster.x-k8s.io/disk-shrink"
 )
 
 // LinodeMachineSpec defines the desired state of LinodeMachine
@@ -46,6 +63,12 @@ type LinodeMachineSpec struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	Type string `json:"type"`
+	// FallbackTypes are the types to try, in order, when Type is not available
+	// in the region. The type the instance was created with is recorded in the
+	// status.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	FallbackTypes []string `json:"fallbackTypes,omitempty"`
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	Group string `json:"group,omitempty"`
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
@@ -54,20 +77,94 @@ type LinodeMachineSpec struct {
 	AuthorizedKeys []string `json:"authorizedKeys,omitempty"`
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	AuthorizedUsers []string `json:"authorizedUsers,omitempty"`
+	// AuthorizedKeyLabels are the labels of SSH keys stored in the Linode profile
+	// to authorize for the root user, in addition to AuthorizedKeys.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	// +optional
+	AuthorizedKeyLabels []string `json:"authorizedKeyLabels,omitempty"`
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	BackupID int `json:"backupID,omitempty"`
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	Image string `json:"image,omitempty"`
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	Interfaces []InstanceConfigInterfaceCreateOptions `json:"interfaces,omitempty"`
+	// DefaultRoute selects the interfaces carrying the default routes of the
+	// instance, e.g. to send egress traffic through the VPC's NAT rather than
+	// the public interface.
+	// +optional
+	DefaultRoute *DefaultRoute `json:"defaultRoute,omitempty"`
+	// DNSPriority is the priority of the machine's entry in the weighted control
+	// plane DNS record set. Entries with a lower priority are preferred.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	DNSPriority int `json:"dnsPriority,omitempty"`
+	// MaintenanceWindow is the recurring window in which the instance is rebooted
+	// to apply changes that require a reboot, such as interface changes. When
+	// unset such reboots are left to the user.
+	// +optional
+	MaintenanceWindow *TimeWindow `json:"maintenanceWindow,omitempty"`
+	// RebootPolicy determines when the instance is rebooted to apply configuration
+	// changes which only take effect on boot, such as interface, kernel and config
+	// profile device changes. Immediate reboots right away, MaintenanceWindow in the
+	// maintenance window and Never leaves the reboot to the user. Defaults to
+	// MaintenanceWindow when a maintenance window is set, and Never otherwise.
+	// +kubebuilder:validation:Enum=Immediate;MaintenanceWindow;Never
+	// +optional
+	RebootPolicy RebootPolicy `json:"rebootPolicy,omitempty"`
+	// PowerOnWindows are the recurring windows the instance is powered on in,
+	// e.g. the working hours of a development cluster. Outside of them the
+	// instance is shut down. Note that Linode bills instances while they are
+	// powered off. When empty the instance is always powered on. The power
+	// state is reported in the PoweredOn condition, the machine stays ready
+	// while powered off. MachineHealthChecks on node conditions still see the
+	// node as not ready, so such machines should be excluded from them, e.g.
+	// with the cluster.x-k8s.io/skip-remediation annotation.
+	// +optional
+	PowerOnWindows []TimeWindow `json:"powerOnWindows,omitempty"`
+	// AdditionalIPv4Count is the number of public IPv4 addresses allocated to the
+	// instance in addition to its default one. Additional addresses must be
+	// approved for the account by Linode support.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	AdditionalIPv4Count int `json:"additionalIPv4Count,omitempty"`
+	// SplitHorizonDNS publishes address records of the machine in an internal and
+	// an external zone, with its private and public addresses respectively.
+	// +optional
+	SplitHorizonDNS *SplitHorizonDNS `json:"splitHorizonDNS,omitempty"`
+	// TXTRecords are created in the LinodeCluster's DNS root domain for the machine,
+	// e.g. for DNS-01 challenges. Several records may share a name. Only supported
+	// by the linode DNS provider.
+	// +optional
+	TXTRecords []TXTRecord `json:"txtRecords,omitempty"`
+	// DatabaseID is the ID of a Linode Managed Database whose allow list is kept in
+	// sync with the machine's public addresses and private IPv4 address.
+	// +optional
+	DatabaseID *int `json:"databaseID,omitempty"`
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	BackupsEnabled bool `json:"backupsEnabled,omitempty"`
+	// BackupSchedule is the window and day in which backups are taken when BackupsEnabled is set.
+	// +optional
+	BackupSchedule *BackupSchedule `json:"backupSchedule,omitempty"`
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	PrivateIP *bool `json:"privateIP,omitempty"`
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	Tags []string `json:"tags,omitempty"`
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	FirewallID int `json:"firewallID,omitempty"`
+	// FirewallPolicy is the default inbound and outbound policy of the firewall
+	// referenced by FirewallID.
+	// +optional
+	FirewallPolicy *FirewallPolicy `json:"firewallPolicy,omitempty"`
+	// ClusterFirewallRules adds inbound rules accepting traffic from the Cluster's
+	// pod, service and VPC subnet CIDRs to the firewall referenced by FirewallID,
+	// and keeps them up to date as the CIDRs change.
+	// +optional
+	ClusterFirewallRules bool `json:"clusterFirewallRules,omitempty"`
+	// FirewallRules are rules managed on the firewall referenced by FirewallID. Rules are
+	// identified by their label, so a rule is updated in place when its other fields change.
+	// +optional
+	FirewallRules []FirewallRule `json:"firewallRules,omitempty"`
 	// OSDisk is configuration for the root disk that includes the OS,
 	// if not specified this defaults to whatever space is not taken up by the DataDisks
 	OSDisk *InstanceDisk `json:"osDisk,omitempty"`
@@ -78,6 +175,23 @@ type LinodeMachineSpec struct {
 	// +kubebuilder:validation:Enum=enabled;disabled
 	// DiskEncryption determines if the disks of the instance should be encrypted.
 	DiskEncryption string `json:"diskEncryption,omitempty"`
+	// RequiredCapabilities are the region capabilities, e.g. "GPU Linodes" or "Metadata",
+	// the instance needs. They are validated before the instance is created; "GPU Linodes"
+	// also requires the instance type to have GPUs.
+	// +optional
+	RequiredCapabilities []string `json:"requiredCapabilities,omitempty"`
+	// CreateFailurePolicy determines what happens to a partially created instance
+	// when provisioning it fails. Retain keeps the instance for debugging, Rollback
+	// deletes it so that the next attempt starts clean. Defaults to Retain.
+	// +kubebuilder:validation:Enum=Retain;Rollback
+	// +optional
+	CreateFailurePolicy CreateFailurePolicy `json:"createFailurePolicy,omitempty"`
+	// BootTimeout is how long the instance may take to join the cluster as a Node
+	// after it was created. Once it is exceeded, the instance's recent events and
+	// boot config profile are captured once into the <name>-boot-diagnostics
+	// ConfigMap for root-causing the failed boot. No diagnostics are captured when unset.
+	// +optional
+	BootTimeout *metav1.Duration `json:"bootTimeout,omitempty"`
 
 	// CredentialsRef is a reference to a Secret that contains the credentials
 	// to use for provisioning this machine. If not supplied then these
@@ -88,6 +202,13 @@ type LinodeMachineSpec struct {
 	// +optional
 	CredentialsRef *corev1.SecretReference `json:"credentialsRef,omitempty"`
 
+	// ChildAccountEUUID is the EUUID of the child account the instance is
+	// provisioned in. The credentials must belong to its parent account, and
+	// are exchanged for a short-lived token of the child account.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	// +optional
+	ChildAccountEUUID string `json:"childAccountEUUID,omitempty"`
+
 	// Configuration is the Akamai instance configuration OS,
 	// if not specified this defaults to the default configuration associated to the instance.
 	Configuration *InstanceConfiguration `json:"configuration,omitempty"`
@@ -96,6 +217,179 @@ type LinodeMachineSpec struct {
 	// +optional
 	// PlacementGroupRef is a reference to a placement group object. This makes the linode to be launched in that specific group.
 	PlacementGroupRef *corev1.ObjectReference `json:"placementGroupRef,omitempty"`
+
+	// ExternalInstance marks the machine as backed by a host that is provisioned
+	// outside of CAPL. No Linode instance is managed for it; only DNS and
+	// NodeBalancer registration is performed using the supplied IP address.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	// +optional
+	ExternalInstance *ExternalInstance `json:"externalInstance,omitempty"`
+
+	// LabelTemplate is the template the Linode instance label is rendered from.
+	// The {cluster}, {machine}, {role} and {uid-hash} placeholders are replaced by
+	// the cluster name, machine name, machine role and a short hash of the machine UID.
+	// Defaults to "{machine}".
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	// +optional
+	LabelTemplate string `json:"labelTemplate,omitempty"`
+
+	// AllowRunningRename allows a running instance to be renamed to the label
+	// rendered from LabelTemplate, so it matches the name of its node. The new
+	// hostname only takes effect the next time the instance boots.
+	// +optional
+	AllowRunningRename bool `json:"allowRunningRename,omitempty"`
+
+	// RootFSLabel is the label given to the root disk of the instance, so that it
+	// can be mounted by label regardless of the image's default.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=48
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	// +optional
+	RootFSLabel string `json:"rootFSLabel,omitempty"`
+
+	// VPCIPv4 is the address assigned to the instance's VPC interface. It must be
+	// within one of the VPC's subnets, and the interface is placed in that subnet.
+	// If not provided an address is assigned automatically.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	// +optional
+	VPCIPv4 string `json:"vpcIPv4,omitempty"`
+
+	// NAT1To1 is the public IPv4 address mapped 1:1 to the address of the instance's VPC
+	// interface, giving VPC-only instances public connectivity. "any" maps the instance's
+	// own public address and an empty string removes the mapping. VPC interfaces are
+	// created with the "any" mapping when not provided.
+	// +optional
+	NAT1To1 *string `json:"nat1To1,omitempty"`
+
+	// Volumes are existing block storage volumes to attach to the instance.
+	// +optional
+	Volumes []InstanceVolume `json:"volumes,omitempty"`
+}
+
+// BackupSchedule defines when Linode takes the backups of an instance
+type BackupSchedule struct {
+	// Window is the two-hour window, in UTC, in which daily backups are taken.
+	// +kubebuilder:validation:Enum=Scheduling;W0;W2;W4;W6;W8;W10;W12;W14;W16;W18;W20;W22
+	// +optional
+	Window string `json:"window,omitempty"`
+	// Day is the day of the week on which weekly backups are taken.
+	// +kubebuilder:validation:Enum=Scheduling;Sunday;Monday;Tuesday;Wednesday;Thursday;Friday;Saturday
+	// +optional
+	Day string `json:"day,omitempty"`
+}
+
+// DefaultRoute defines the interfaces carrying the default routes of an instance
+type DefaultRoute struct {
+	// IPv4 is the purpose of the interface carrying the default IPv4 route.
+	// +kubebuilder:validation:Enum=public;vpc
+	// +optional
+	IPv4 linodego.ConfigInterfacePurpose `json:"ipv4,omitempty"`
+	// IPv6 is the purpose of the interface carrying the default IPv6 route.
+	// Only public interfaces have IPv6 addresses.
+	// +kubebuilder:validation:Enum=public
+	// +optional
+	IPv6 linodego.ConfigInterfacePurpose `json:"ipv6,omitempty"`
+}
+
+// TimeWindow defines a recurring window of time, e.g. the maintenance window an instance
+// may be rebooted in or the windows it is powered on in
+type TimeWindow struct {
+	// Days are the days of the week the window opens on. It opens every day when empty.
+	// +kubebuilder:validation:items:Enum=Sunday;Monday;Tuesday;Wednesday;Thursday;Friday;Saturday
+	// +optional
+	Days []string `json:"days,omitempty"`
+	// Start is the time of day the window opens at, in UTC and HH:MM format.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+	// Duration is how long the window stays open.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// SplitHorizonDNS defines the zones the machine's split-horizon DNS records are managed in
+type SplitHorizonDNS struct {
+	// InternalDomain is the Linode domain the record of the machine's private addresses is created in.
+	// +optional
+	InternalDomain string `json:"internalDomain,omitempty"`
+	// ExternalDomain is the Linode domain the record of the machine's public addresses is created in.
+	// +optional
+	ExternalDomain string `json:"externalDomain,omitempty"`
+}
+
+// TXTRecord defines a TXT record in the LinodeCluster's DNS root domain
+type TXTRecord struct {
+	// Name is the name of the record, relative to the root domain.
+	Name string `json:"name"`
+	// Value is the text of the record.
+	Value string `json:"value"`
+}
+
+// FirewallRule defines a rule of a firewall, identified by its label
+type FirewallRule struct {
+	// Label identifies the rule on the firewall, e.g. by the ID of the policy it implements.
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_.-]+$`
+	Label string `json:"label"`
+	// Description of the rule.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Direction is the direction of the traffic the rule applies to.
+	// +kubebuilder:validation:Enum=inbound;outbound
+	Direction string `json:"direction"`
+	// Action is applied to the traffic matched by the rule.
+	// +kubebuilder:validation:Enum=ACCEPT;DROP
+	Action string `json:"action"`
+	// Protocol of the traffic matched by the rule.
+	// +kubebuilder:validation:Enum=TCP;UDP;ICMP;IPENCAP
+	Protocol string `json:"protocol"`
+	// Ports matched by the rule, e.g. "22" or "80,443,8000-9000". All ports when unset.
+	// +optional
+	Ports string `json:"ports,omitempty"`
+	// Addresses are the IPv4 and IPv6 CIDRs matched by the rule.
+	// +optional
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// RebootPolicy is the policy for rebooting an instance to apply configuration changes.
+type RebootPolicy string
+
+const (
+	// RebootPolicyImmediate reboots the instance as soon as a change requires it.
+	RebootPolicyImmediate RebootPolicy = "Immediate"
+	// RebootPolicyMaintenanceWindow reboots the instance in its maintenance window.
+	RebootPolicyMaintenanceWindow RebootPolicy = "MaintenanceWindow"
+	// RebootPolicyNever never reboots the instance.
+	RebootPolicyNever RebootPolicy = "Never"
+)
+
+// CreateFailurePolicy is the policy applied to a partially created instance when
+// provisioning it fails.
+type CreateFailurePolicy string
+
+const (
+	// CreateFailurePolicyRetain keeps the partially created instance.
+	CreateFailurePolicyRetain CreateFailurePolicy = "Retain"
+	// CreateFailurePolicyRollback deletes the partially created instance.
+	CreateFailurePolicyRollback CreateFailurePolicy = "Rollback"
+)
+
+// FirewallPolicy defines the default policy of a firewall for traffic not matched by its rules
+type FirewallPolicy struct {
+	// Inbound is the policy applied to inbound traffic.
+	// +kubebuilder:validation:Enum=ACCEPT;DROP
+	// +optional
+	Inbound string `json:"inbound,omitempty"`
+	// Outbound is the policy applied to outbound traffic.
+	// +kubebuilder:validation:Enum=ACCEPT;DROP
+	// +optional
+	Outbound string `json:"outbound,omitempty"`
+}
+
+// ExternalInstance defines a host that is not provisioned by CAPL
+type ExternalInstance struct {
+	// IPAddress is the address registered with DNS and the NodeBalancer for this host.
+	// +kubebuilder:validation:Required
+	IPAddress string `json:"ipAddress"`
 }
 
 // InstanceDisk defines a list of disks to use for an instance
@@ -112,6 +406,19 @@ type InstanceDisk struct {
 	Filesystem string `json:"filesystem,omitempty"`
 }
 
+// InstanceVolume defines a block storage volume attached to an instance
+type InstanceVolume struct {
+	// VolumeID is the linode assigned ID of the volume
+	// +kubebuilder:validation:Required
+	VolumeID int `json:"volumeID"`
+	// DeviceSlot is the device the volume is attached as, e.g. sdc, so it keeps
+	// the same device path across reboots. If not provided the volume is
+	// attached to the first free device.
+	// +kubebuilder:validation:Enum=sdb;sdc;sdd;sde;sdf;sdg;sdh
+	// +optional
+	DeviceSlot string `json:"deviceSlot,omitempty"`
+}
+
 // InstanceMetadataOptions defines metadata of instance
 type InstanceMetadataOptions struct {
 	// UserData expects a Base64-encoded string
@@ -122,10 +429,48 @@ type InstanceMetadataOptions struct {
 type InstanceConfiguration struct {
 	// Kernel is a Kernel ID to boot a Linode with. (e.g linode/latest-64bit)
 	Kernel string `json:"kernel,omitempty"`
+	// Devices maps devices of the boot config profile to disks and volumes. Devices
+	// which are not set are left as they are.
+	// +optional
+	Devices *InstanceConfigDevices `json:"devices,omitempty"`
+}
+
+// InstanceConfigDevices defines the devices of a config profile
+type InstanceConfigDevices struct {
+	// +optional
+	SDA *InstanceConfigDevice `json:"sda,omitempty"`
+	// +optional
+	SDB *InstanceConfigDevice `json:"sdb,omitempty"`
+	// +optional
+	SDC *InstanceConfigDevice `json:"sdc,omitempty"`
+	// +optional
+	SDD *InstanceConfigDevice `json:"sdd,omitempty"`
+	// +optional
+	SDE *InstanceConfigDevice `json:"sde,omitempty"`
+	// +optional
+	SDF *InstanceConfigDevice `json:"sdf,omitempty"`
+	// +optional
+	SDG *InstanceConfigDevice `json:"sdg,omitempty"`
+	// +optional
+	SDH *InstanceConfigDevice `json:"sdh,omitempty"`
+}
+
+// InstanceConfigDevice defines the disk or volume of a config profile device.
+// Leaving both unset removes the disk or volume from the device.
+type InstanceConfigDevice struct {
+	// DiskID is the ID of an instance disk.
+	// +optional
+	DiskID int `json:"diskID,omitempty"`
+	// VolumeID is the ID of a block storage volume.
+	// +optional
+	VolumeID int `json:"volumeID,omitempty"`
 }
 
 // InstanceConfigInterfaceCreateOptions defines network interface config
 type InstanceConfigInterfaceCreateOptions struct {
+	// IPAMAddress is the static address, in CIDR notation, of a VLAN
+	// interface, e.g. 10.0.0.1/30 for a point-to-point link.
+	// +optional
 	IPAMAddress string `json:"ipamAddress,omitempty"`
 	// +kubebuilder:validation:MinLength=3
 	// +kubebuilder:validation:MaxLength=63
@@ -160,6 +505,80 @@ type LinodeMachineStatus struct {
 	// +optional
 	InstanceState *linodego.InstanceStatus `json:"instanceState,omitempty"`
 
+	// InstanceType is the type the instance was created with, which is either
+	// the spec's Type or one of its FallbackTypes.
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
+
+	// LongviewClientID is the ID of the Longview client created for the machine.
+	// +optional
+	LongviewClientID *int `json:"longviewClientID,omitempty"`
+
+	// ManagedTags are the instance tags set by CAPL. Only these tags are removed
+	// from the instance when they are no longer desired, so tags added by other
+	// tools are preserved.
+	// +optional
+	ManagedTags []string `json:"managedTags,omitempty"`
+
+	// ManagedFirewallIDs are the IDs of the firewalls CAPL attached the instance to
+	// by label. Only these firewalls are detached from the instance when they are
+	// no longer desired.
+	// +optional
+	ManagedFirewallIDs []int `json:"managedFirewallIDs,omitempty"`
+
+	// ManagedFirewallRules are the labels of the firewall rules CAPL set from the spec.
+	// Only these rules are removed from the firewall when they are no longer desired.
+	// +optional
+	ManagedFirewallRules []string `json:"managedFirewallRules,omitempty"`
+
+	// ManagedTXTRecords are the TXT records CAPL created from the spec. Only these
+	// records are removed when they are no longer desired or the machine is deleted.
+	// +optional
+	ManagedTXTRecords []TXTRecord `json:"managedTXTRecords,omitempty"`
+
+	// ManagedDatabaseAllowList are the entries CAPL added to the allow list of a
+	// Managed Database for the machine's addresses. Only these entries are
+	// removed when the addresses change or the machine is deleted.
+	// +optional
+	ManagedDatabaseAllowList []string `json:"managedDatabaseAllowList,omitempty"`
+
+	// AdditionalIPv4s are the additional public IPv4 addresses allocated to the
+	// instance for the spec's AdditionalIPv4Count.
+	// +optional
+	AdditionalIPv4s []string `json:"additionalIPv4s,omitempty"`
+
+	// UnhealthySince is when the instance was first observed unhealthy. It is
+	// cleared once the instance is healthy again.
+	// +optional
+	UnhealthySince *metav1.Time `json:"unhealthySince,omitempty"`
+
+	// Transfer is the network transfer of the instance and of the transfer pool
+	// it contributes to, for the current month.
+	// +optional
+	Transfer *TransferStatus `json:"transfer,omitempty"`
+
+	// RebootPendingSince is when a reboot which was not done right away, because of
+	// the maintenance window or the Never reboot policy, was first required. It is
+	// cleared once the instance is rebooted.
+	// +optional
+	RebootPendingSince *metav1.Time `json:"rebootPendingSince,omitempty"`
+
+	// LastPowerTransition is when the instance was last booted or shut down
+	// for the spec's PowerOnWindows.
+	// +optional
+	LastPowerTransition *metav1.Time `json:"lastPowerTransition,omitempty"`
+
+	// ObjectStorageKeyID is the ID of the Object Storage key created for the
+	// machine's workloads.
+	// +optional
+	ObjectStorageKeyID *int `json:"objectStorageKeyID,omitempty"`
+
+	// StackScriptUDFHash is a checksum of the StackScript UDF values the
+	// instance was bootstrapped with, when it was bootstrapped by the CAPL
+	// StackScript rather than cloud-init metadata.
+	// +optional
+	StackScriptUDFHash string `json:"stackScriptUDFHash,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the Machine and will contain a succinct value suitable
 	// for machine interpretation.
@@ -203,6 +622,23 @@ type LinodeMachineStatus struct {
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
 }
 
+// TransferStatus describes the network transfer usage of an instance and its transfer pool
+type TransferStatus struct {
+	// Quota is the transfer, in GB, the instance adds to the pool.
+	Quota int `json:"quota"`
+	// Used is the transfer, in GB, the instance has used.
+	Used int `json:"used"`
+	// PoolQuota is the transfer, in GB, of the pool the instance belongs to.
+	PoolQuota int `json:"poolQuota"`
+	// PoolUsed is the transfer, in GB, used by all instances of the pool.
+	PoolUsed int `json:"poolUsed"`
+	// PoolBillable is the transfer, in GB, used beyond the pool's quota.
+	PoolBillable int `json:"poolBillable"`
+	// UpdatedAt is when the transfer usage was last refreshed.
+	// +optional
+	UpdatedAt *metav1.Time `json:"updatedAt,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:storageversion
 // +kubebuilder:resource:path=linodemachines,scope=Namespaced,categories=cluster-api,shortName=lm
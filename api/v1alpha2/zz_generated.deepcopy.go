@@ -23,11 +23,162 @@ package v1alpha2
 import (
 	"github.com/linode/linodego"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/errors"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupSchedule) DeepCopyInto(out *BackupSchedule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSchedule.
+func (in *BackupSchedule) DeepCopy() *BackupSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultRoute) DeepCopyInto(out *DefaultRoute) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultRoute.
+func (in *DefaultRoute) DeepCopy() *DefaultRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalInstance) DeepCopyInto(out *ExternalInstance) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalInstance.
+func (in *ExternalInstance) DeepCopy() *ExternalInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallPolicy) DeepCopyInto(out *FirewallPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallPolicy.
+func (in *FirewallPolicy) DeepCopy() *FirewallPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallRule) DeepCopyInto(out *FirewallRule) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallRule.
+func (in *FirewallRule) DeepCopy() *FirewallRule {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceConfigDevice) DeepCopyInto(out *InstanceConfigDevice) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceConfigDevice.
+func (in *InstanceConfigDevice) DeepCopy() *InstanceConfigDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceConfigDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceConfigDevices) DeepCopyInto(out *InstanceConfigDevices) {
+	*out = *in
+	if in.SDA != nil {
+		in, out := &in.SDA, &out.SDA
+		*out = new(InstanceConfigDevice)
+		**out = **in
+	}
+	if in.SDB != nil {
+		in, out := &in.SDB, &out.SDB
+		*out = new(InstanceConfigDevice)
+		**out = **in
+	}
+	if in.SDC != nil {
+		in, out := &in.SDC, &out.SDC
+		*out = new(InstanceConfigDevice)
+		**out = **in
+	}
+	if in.SDD != nil {
+		in, out := &in.SDD, &out.SDD
+		*out = new(InstanceConfigDevice)
+		**out = **in
+	}
+	if in.SDE != nil {
+		in, out := &in.SDE, &out.SDE
+		*out = new(InstanceConfigDevice)
+		**out = **in
+	}
+	if in.SDF != nil {
+		in, out := &in.SDF, &out.SDF
+		*out = new(InstanceConfigDevice)
+		**out = **in
+	}
+	if in.SDG != nil {
+		in, out := &in.SDG, &out.SDG
+		*out = new(InstanceConfigDevice)
+		**out = **in
+	}
+	if in.SDH != nil {
+		in, out := &in.SDH, &out.SDH
+		*out = new(InstanceConfigDevice)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceConfigDevices.
+func (in *InstanceConfigDevices) DeepCopy() *InstanceConfigDevices {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceConfigDevices)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceConfigInterfaceCreateOptions) DeepCopyInto(out *InstanceConfigInterfaceCreateOptions) {
 	*out = *in
@@ -61,6 +212,11 @@ func (in *InstanceConfigInterfaceCreateOptions) DeepCopy() *InstanceConfigInterf
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceConfiguration) DeepCopyInto(out *InstanceConfiguration) {
 	*out = *in
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = new(InstanceConfigDevices)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceConfiguration.
@@ -104,6 +260,21 @@ func (in *InstanceMetadataOptions) DeepCopy() *InstanceMetadataOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceVolume) DeepCopyInto(out *InstanceVolume) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceVolume.
+func (in *InstanceVolume) DeepCopy() *InstanceVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinodeCluster) DeepCopyInto(out *LinodeCluster) {
 	*out = *in
@@ -384,6 +555,11 @@ func (in *LinodeMachineSpec) DeepCopyInto(out *LinodeMachineSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.FallbackTypes != nil {
+		in, out := &in.FallbackTypes, &out.FallbackTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.AuthorizedKeys != nil {
 		in, out := &in.AuthorizedKeys, &out.AuthorizedKeys
 		*out = make([]string, len(*in))
@@ -394,6 +570,11 @@ func (in *LinodeMachineSpec) DeepCopyInto(out *LinodeMachineSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AuthorizedKeyLabels != nil {
+		in, out := &in.AuthorizedKeyLabels, &out.AuthorizedKeyLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Interfaces != nil {
 		in, out := &in.Interfaces, &out.Interfaces
 		*out = make([]InstanceConfigInterfaceCreateOptions, len(*in))
@@ -401,6 +582,43 @@ func (in *LinodeMachineSpec) DeepCopyInto(out *LinodeMachineSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DefaultRoute != nil {
+		in, out := &in.DefaultRoute, &out.DefaultRoute
+		*out = new(DefaultRoute)
+		**out = **in
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(TimeWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PowerOnWindows != nil {
+		in, out := &in.PowerOnWindows, &out.PowerOnWindows
+		*out = make([]TimeWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SplitHorizonDNS != nil {
+		in, out := &in.SplitHorizonDNS, &out.SplitHorizonDNS
+		*out = new(SplitHorizonDNS)
+		**out = **in
+	}
+	if in.TXTRecords != nil {
+		in, out := &in.TXTRecords, &out.TXTRecords
+		*out = make([]TXTRecord, len(*in))
+		copy(*out, *in)
+	}
+	if in.DatabaseID != nil {
+		in, out := &in.DatabaseID, &out.DatabaseID
+		*out = new(int)
+		**out = **in
+	}
+	if in.BackupSchedule != nil {
+		in, out := &in.BackupSchedule, &out.BackupSchedule
+		*out = new(BackupSchedule)
+		**out = **in
+	}
 	if in.PrivateIP != nil {
 		in, out := &in.PrivateIP, &out.PrivateIP
 		*out = new(bool)
@@ -411,6 +629,18 @@ func (in *LinodeMachineSpec) DeepCopyInto(out *LinodeMachineSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.FirewallPolicy != nil {
+		in, out := &in.FirewallPolicy, &out.FirewallPolicy
+		*out = new(FirewallPolicy)
+		**out = **in
+	}
+	if in.FirewallRules != nil {
+		in, out := &in.FirewallRules, &out.FirewallRules
+		*out = make([]FirewallRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.OSDisk != nil {
 		in, out := &in.OSDisk, &out.OSDisk
 		*out = new(InstanceDisk)
@@ -432,6 +662,16 @@ func (in *LinodeMachineSpec) DeepCopyInto(out *LinodeMachineSpec) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.RequiredCapabilities != nil {
+		in, out := &in.RequiredCapabilities, &out.RequiredCapabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BootTimeout != nil {
+		in, out := &in.BootTimeout, &out.BootTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.CredentialsRef != nil {
 		in, out := &in.CredentialsRef, &out.CredentialsRef
 		*out = new(v1.SecretReference)
@@ -440,13 +680,28 @@ func (in *LinodeMachineSpec) DeepCopyInto(out *LinodeMachineSpec) {
 	if in.Configuration != nil {
 		in, out := &in.Configuration, &out.Configuration
 		*out = new(InstanceConfiguration)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.PlacementGroupRef != nil {
 		in, out := &in.PlacementGroupRef, &out.PlacementGroupRef
 		*out = new(v1.ObjectReference)
 		**out = **in
 	}
+	if in.ExternalInstance != nil {
+		in, out := &in.ExternalInstance, &out.ExternalInstance
+		*out = new(ExternalInstance)
+		**out = **in
+	}
+	if in.NAT1To1 != nil {
+		in, out := &in.NAT1To1, &out.NAT1To1
+		*out = new(string)
+		**out = **in
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]InstanceVolume, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinodeMachineSpec.
@@ -472,6 +727,63 @@ func (in *LinodeMachineStatus) DeepCopyInto(out *LinodeMachineStatus) {
 		*out = new(linodego.InstanceStatus)
 		**out = **in
 	}
+	if in.LongviewClientID != nil {
+		in, out := &in.LongviewClientID, &out.LongviewClientID
+		*out = new(int)
+		**out = **in
+	}
+	if in.ManagedTags != nil {
+		in, out := &in.ManagedTags, &out.ManagedTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedFirewallIDs != nil {
+		in, out := &in.ManagedFirewallIDs, &out.ManagedFirewallIDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedFirewallRules != nil {
+		in, out := &in.ManagedFirewallRules, &out.ManagedFirewallRules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedTXTRecords != nil {
+		in, out := &in.ManagedTXTRecords, &out.ManagedTXTRecords
+		*out = make([]TXTRecord, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedDatabaseAllowList != nil {
+		in, out := &in.ManagedDatabaseAllowList, &out.ManagedDatabaseAllowList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalIPv4s != nil {
+		in, out := &in.AdditionalIPv4s, &out.AdditionalIPv4s
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnhealthySince != nil {
+		in, out := &in.UnhealthySince, &out.UnhealthySince
+		*out = (*in).DeepCopy()
+	}
+	if in.Transfer != nil {
+		in, out := &in.Transfer, &out.Transfer
+		*out = new(TransferStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RebootPendingSince != nil {
+		in, out := &in.RebootPendingSince, &out.RebootPendingSince
+		*out = (*in).DeepCopy()
+	}
+	if in.LastPowerTransition != nil {
+		in, out := &in.LastPowerTransition, &out.LastPowerTransition
+		*out = (*in).DeepCopy()
+	}
+	if in.ObjectStorageKeyID != nil {
+		in, out := &in.ObjectStorageKeyID, &out.ObjectStorageKeyID
+		*out = new(int)
+		**out = **in
+	}
 	if in.FailureReason != nil {
 		in, out := &in.FailureReason, &out.FailureReason
 		*out = new(errors.MachineStatusError)
@@ -986,6 +1298,11 @@ func (in *LinodeVPCStatus) DeepCopy() *LinodeVPCStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 	*out = *in
+	if in.DNSAliases != nil {
+		in, out := &in.DNSAliases, &out.DNSAliases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.NodeBalancerID != nil {
 		in, out := &in.NodeBalancerID, &out.NodeBalancerID
 		*out = new(int)
@@ -1015,6 +1332,76 @@ func (in *NetworkSpec) DeepCopy() *NetworkSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SplitHorizonDNS) DeepCopyInto(out *SplitHorizonDNS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SplitHorizonDNS.
+func (in *SplitHorizonDNS) DeepCopy() *SplitHorizonDNS {
+	if in == nil {
+		return nil
+	}
+	out := new(SplitHorizonDNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TXTRecord) DeepCopyInto(out *TXTRecord) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TXTRecord.
+func (in *TXTRecord) DeepCopy() *TXTRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(TXTRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeWindow) DeepCopyInto(out *TimeWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeWindow.
+func (in *TimeWindow) DeepCopy() *TimeWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransferStatus) DeepCopyInto(out *TransferStatus) {
+	*out = *in
+	if in.UpdatedAt != nil {
+		in, out := &in.UpdatedAt, &out.UpdatedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransferStatus.
+func (in *TransferStatus) DeepCopy() *TransferStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TransferStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VPCIPv4) DeepCopyInto(out *VPCIPv4) {
 	*out = *in
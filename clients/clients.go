@@ -18,6 +18,12 @@ type LinodeClient interface {
 	LinodeObjectStorageClient
 	LinodeDNSClient
 	LinodePlacementGroupClient
+	LinodeVolumeClient
+	LinodeLongviewClient
+	LinodeProfileClient
+	LinodeFirewallClient
+	LinodeAccountClient
+	LinodeDatabaseClient
 }
 
 type AkamClient interface {
@@ -34,26 +40,41 @@ type AkamEdgeDNSClient interface {
 // LinodeInstanceClient defines the methods that interact with Linode's Instance service.
 type LinodeInstanceClient interface {
 	GetInstanceIPAddresses(ctx context.Context, linodeID int) (*linodego.InstanceIPAddressResponse, error)
+	AddInstanceIPAddress(ctx context.Context, linodeID int, public bool) (*linodego.InstanceIP, error)
+	DeleteInstanceIPAddress(ctx context.Context, linodeID int, ipAddress string) error
 	ListInstances(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Instance, error)
 	CreateInstance(ctx context.Context, opts linodego.InstanceCreateOptions) (*linodego.Instance, error)
 	BootInstance(ctx context.Context, linodeID int, configID int) error
+	RebootInstance(ctx context.Context, linodeID int, configID int) error
+	RescueInstance(ctx context.Context, linodeID int, opts linodego.InstanceRescueOptions) error
+	ShutdownInstance(ctx context.Context, linodeID int) error
 	ListInstanceConfigs(ctx context.Context, linodeID int, opts *linodego.ListOptions) ([]linodego.InstanceConfig, error)
 	UpdateInstanceConfig(ctx context.Context, linodeID int, configID int, opts linodego.InstanceConfigUpdateOptions) (*linodego.InstanceConfig, error)
 	GetInstanceDisk(ctx context.Context, linodeID int, diskID int) (*linodego.InstanceDisk, error)
+	ListInstanceDisks(ctx context.Context, linodeID int, opts *linodego.ListOptions) ([]linodego.InstanceDisk, error)
 	ResizeInstanceDisk(ctx context.Context, linodeID int, diskID int, size int) error
+	UpdateInstanceDisk(ctx context.Context, linodeID int, diskID int, opts linodego.InstanceDiskUpdateOptions) (*linodego.InstanceDisk, error)
 	CreateInstanceDisk(ctx context.Context, linodeID int, opts linodego.InstanceDiskCreateOptions) (*linodego.InstanceDisk, error)
 	GetInstance(ctx context.Context, linodeID int) (*linodego.Instance, error)
+	GetInstanceTransfer(ctx context.Context, linodeID int) (*linodego.InstanceTransfer, error)
+	UpdateInstance(ctx context.Context, linodeID int, opts linodego.InstanceUpdateOptions) (*linodego.Instance, error)
+	MigrateInstance(ctx context.Context, linodeID int, opts linodego.InstanceMigrateOptions) error
+	ResizeInstance(ctx context.Context, linodeID int, opts linodego.InstanceResizeOptions) error
+	RebuildInstance(ctx context.Context, linodeID int, opts linodego.InstanceRebuildOptions) (*linodego.Instance, error)
 	DeleteInstance(ctx context.Context, linodeID int) error
 	GetRegion(ctx context.Context, regionID string) (*linodego.Region, error)
+	ListRegionsAvailability(ctx context.Context, opts *linodego.ListOptions) ([]linodego.RegionAvailability, error)
 	GetImage(ctx context.Context, imageID string) (*linodego.Image, error)
 	CreateStackscript(ctx context.Context, opts linodego.StackscriptCreateOptions) (*linodego.Stackscript, error)
 	ListStackscripts(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Stackscript, error)
 	GetType(ctx context.Context, typeID string) (*linodego.LinodeType, error)
+	GetKernel(ctx context.Context, kernelID string) (*linodego.LinodeKernel, error)
 }
 
 // LinodeVPCClient defines the methods that interact with Linode's VPC service.
 type LinodeVPCClient interface {
 	GetVPC(ctx context.Context, vpcID int) (*linodego.VPC, error)
+	ListVPCIPAddresses(ctx context.Context, vpcID int, opts *linodego.ListOptions) ([]linodego.VPCIP, error)
 	ListVPCs(ctx context.Context, opts *linodego.ListOptions) ([]linodego.VPC, error)
 	CreateVPC(ctx context.Context, opts linodego.VPCCreateOptions) (*linodego.VPC, error)
 	DeleteVPC(ctx context.Context, vpcID int) error
@@ -63,11 +84,17 @@ type LinodeVPCClient interface {
 type LinodeNodeBalancerClient interface {
 	CreateNodeBalancer(ctx context.Context, opts linodego.NodeBalancerCreateOptions) (*linodego.NodeBalancer, error)
 	GetNodeBalancer(ctx context.Context, nodebalancerID int) (*linodego.NodeBalancer, error)
+	ListNodeBalancers(ctx context.Context, opts *linodego.ListOptions) ([]linodego.NodeBalancer, error)
+	UpdateNodeBalancer(ctx context.Context, nodebalancerID int, opts linodego.NodeBalancerUpdateOptions) (*linodego.NodeBalancer, error)
 	GetNodeBalancerConfig(ctx context.Context, nodebalancerID int, configID int) (*linodego.NodeBalancerConfig, error)
+	ListNodeBalancerConfigs(ctx context.Context, nodebalancerID int, opts *linodego.ListOptions) ([]linodego.NodeBalancerConfig, error)
 	CreateNodeBalancerConfig(ctx context.Context, nodebalancerID int, opts linodego.NodeBalancerConfigCreateOptions) (*linodego.NodeBalancerConfig, error)
+	UpdateNodeBalancerConfig(ctx context.Context, nodebalancerID int, configID int, opts linodego.NodeBalancerConfigUpdateOptions) (*linodego.NodeBalancerConfig, error)
 	DeleteNodeBalancerNode(ctx context.Context, nodebalancerID int, configID int, nodeID int) error
 	DeleteNodeBalancer(ctx context.Context, nodebalancerID int) error
 	CreateNodeBalancerNode(ctx context.Context, nodebalancerID int, configID int, opts linodego.NodeBalancerNodeCreateOptions) (*linodego.NodeBalancerNode, error)
+	ListNodeBalancerNodes(ctx context.Context, nodebalancerID int, configID int, opts *linodego.ListOptions) ([]linodego.NodeBalancerNode, error)
+	ListNodeBalancerFirewalls(ctx context.Context, nodebalancerID int, opts *linodego.ListOptions) ([]linodego.Firewall, error)
 }
 
 // LinodeObjectStorageClient defines the methods that interact with Linode's Object Storage service.
@@ -99,6 +126,51 @@ type LinodePlacementGroupClient interface {
 	UnassignPlacementGroupLinodes(ctx context.Context, id int, options linodego.PlacementGroupUnAssignOptions) (*linodego.PlacementGroup, error)
 }
 
+// LinodeVolumeClient defines the methods that interact with Linode's Block Storage service.
+type LinodeVolumeClient interface {
+	ListVolumes(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Volume, error)
+	GetVolume(ctx context.Context, volumeID int) (*linodego.Volume, error)
+	UpdateVolume(ctx context.Context, volumeID int, opts linodego.VolumeUpdateOptions) (*linodego.Volume, error)
+	DeleteVolume(ctx context.Context, volumeID int) error
+}
+
+// LinodeLongviewClient defines the methods that interact with Linode's Longview service.
+type LinodeLongviewClient interface {
+	GetLongviewClient(ctx context.Context, clientID int) (*linodego.LongviewClient, error)
+	CreateLongviewClient(ctx context.Context, opts linodego.LongviewClientCreateOptions) (*linodego.LongviewClient, error)
+	DeleteLongviewClient(ctx context.Context, clientID int) error
+}
+
+// LinodeProfileClient defines the methods that interact with Linode's Profile service.
+type LinodeProfileClient interface {
+	ListSSHKeys(ctx context.Context, opts *linodego.ListOptions) ([]linodego.SSHKey, error)
+}
+
+// LinodeFirewallClient defines the methods that interact with Linode's Cloud Firewall service.
+type LinodeFirewallClient interface {
+	ListFirewalls(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Firewall, error)
+	ListInstanceFirewalls(ctx context.Context, linodeID int, opts *linodego.ListOptions) ([]linodego.Firewall, error)
+	ListFirewallDevices(ctx context.Context, firewallID int, opts *linodego.ListOptions) ([]linodego.FirewallDevice, error)
+	CreateFirewallDevice(ctx context.Context, firewallID int, opts linodego.FirewallDeviceCreateOptions) (*linodego.FirewallDevice, error)
+	DeleteFirewallDevice(ctx context.Context, firewallID, deviceID int) error
+	GetFirewallRules(ctx context.Context, firewallID int) (*linodego.FirewallRuleSet, error)
+	UpdateFirewallRules(ctx context.Context, firewallID int, rules linodego.FirewallRuleSet) (*linodego.FirewallRuleSet, error)
+}
+
+// LinodeAccountClient defines the methods that interact with Linode's Account service.
+type LinodeAccountClient interface {
+	GetAccountTransfer(ctx context.Context) (*linodego.AccountTransfer, error)
+	CreateChildAccountToken(ctx context.Context, euuid string) (*linodego.ChildAccountToken, error)
+	ListEvents(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Event, error)
+}
+
+// LinodeDatabaseClient defines the methods that interact with Linode's Managed Databases service.
+type LinodeDatabaseClient interface {
+	ListDatabases(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Database, error)
+	UpdateMySQLDatabase(ctx context.Context, databaseID int, opts linodego.MySQLUpdateOptions) (*linodego.MySQLDatabase, error)
+	UpdatePostgresDatabase(ctx context.Context, databaseID int, opts linodego.PostgresUpdateOptions) (*linodego.PostgresDatabase, error)
+}
+
 type K8sClient interface {
 	client.Client
 }
@@ -0,0 +1,126 @@
+package scope
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/linode/cluster-api-provider-linode/util/reconciler"
+)
+
+// CircuitBreakerConfig configures the circuit breakers that track Linode API failures per token.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of failures within Window that opens a breaker.
+	FailureThreshold int
+	// Window is the period over which failures are counted.
+	Window time.Duration
+	// Cooldown is how long reconciles back off for once a breaker opens.
+	Cooldown time.Duration
+}
+
+var (
+	breakerConfigMu sync.RWMutex
+	breakerConfig   = CircuitBreakerConfig{
+		FailureThreshold: reconciler.DefaultCircuitBreakerFailureThreshold,
+		Window:           reconciler.DefaultCircuitBreakerWindow,
+		Cooldown:         reconciler.DefaultCircuitBreakerCooldown,
+	}
+
+	// breakers holds a *circuitBreaker per token, keyed by the token hash so the token itself is never retained.
+	breakers sync.Map
+)
+
+// SetCircuitBreakerConfig overrides the circuit breaker thresholds. Zero values keep the current setting.
+func SetCircuitBreakerConfig(cfg CircuitBreakerConfig) {
+	breakerConfigMu.Lock()
+	defer breakerConfigMu.Unlock()
+
+	if cfg.FailureThreshold > 0 {
+		breakerConfig.FailureThreshold = cfg.FailureThreshold
+	}
+	if cfg.Window > 0 {
+		breakerConfig.Window = cfg.Window
+	}
+	if cfg.Cooldown > 0 {
+		breakerConfig.Cooldown = cfg.Cooldown
+	}
+}
+
+func getCircuitBreakerConfig() CircuitBreakerConfig {
+	breakerConfigMu.RLock()
+	defer breakerConfigMu.RUnlock()
+
+	return breakerConfig
+}
+
+type circuitBreaker struct {
+	mu        sync.Mutex
+	failures  []time.Time
+	openUntil time.Time
+}
+
+// circuitBreakerFor returns the circuit breaker shared by all clients using the token.
+func circuitBreakerFor(token string) *circuitBreaker {
+	sum := sha256.Sum256([]byte(token))
+	breaker, _ := breakers.LoadOrStore(hex.EncodeToString(sum[:]), &circuitBreaker{})
+
+	return breaker.(*circuitBreaker)
+}
+
+func (b *circuitBreaker) recordFailure(now time.Time) {
+	cfg := getCircuitBreakerConfig()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	recent := b.failures[:0]
+	for _, failure := range b.failures {
+		if now.Sub(failure) < cfg.Window {
+			recent = append(recent, failure)
+		}
+	}
+	b.failures = append(recent, now)
+
+	if len(b.failures) >= cfg.FailureThreshold {
+		b.openUntil = now.Add(cfg.Cooldown)
+	}
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = nil
+	b.openUntil = time.Time{}
+}
+
+func (b *circuitBreaker) shouldBackoff(now time.Time) (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if now.Before(b.openUntil) {
+		return true, b.openUntil.Sub(now)
+	}
+
+	return false, 0
+}
+
+// circuitBreakerTransport records the outcome of every Linode API request on a circuit breaker.
+// Server errors, rate limiting and transport errors count as failures.
+type circuitBreakerTransport struct {
+	base    http.RoundTripper
+	breaker *circuitBreaker
+}
+
+func (t *circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+		t.breaker.recordFailure(time.Now())
+	} else {
+		t.breaker.recordSuccess()
+	}
+
+	return resp, err
+}
@@ -0,0 +1,177 @@
+package scope
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linode/cluster-api-provider-linode/util/reconciler"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	tests := []struct {
+		name          string
+		failures      []time.Duration
+		success       bool
+		expectBackoff bool
+	}{
+		{
+			name:          "Success - Closed without failures",
+			expectBackoff: false,
+		},
+		{
+			name:          "Success - Closed below threshold",
+			failures:      []time.Duration{0, 0, 0, 0},
+			expectBackoff: false,
+		},
+		{
+			name:          "Success - Opens at threshold",
+			failures:      []time.Duration{0, 0, 0, 0, 0},
+			expectBackoff: true,
+		},
+		{
+			name:          "Success - Ignores failures outside the window",
+			failures:      []time.Duration{-2 * reconciler.DefaultCircuitBreakerWindow, -2 * reconciler.DefaultCircuitBreakerWindow, 0, 0, 0},
+			expectBackoff: false,
+		},
+		{
+			name:          "Success - Closed by a successful call",
+			failures:      []time.Duration{0, 0, 0, 0, 0},
+			success:       true,
+			expectBackoff: false,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			breaker := &circuitBreaker{}
+			for _, offset := range testcase.failures {
+				breaker.recordFailure(now.Add(offset))
+			}
+			if testcase.success {
+				breaker.recordSuccess()
+			}
+
+			backoff, delay := breaker.shouldBackoff(now)
+			assert.Equal(t, testcase.expectBackoff, backoff)
+			if testcase.expectBackoff {
+				assert.Equal(t, reconciler.DefaultCircuitBreakerCooldown, delay)
+			} else {
+				assert.Zero(t, delay)
+			}
+
+			backoff, _ = breaker.shouldBackoff(now.Add(reconciler.DefaultCircuitBreakerCooldown))
+			assert.False(t, backoff)
+		})
+	}
+}
+
+func TestCircuitBreakerFor(t *testing.T) {
+	t.Parallel()
+
+	assert.Same(t, circuitBreakerFor("breaker-token"), circuitBreakerFor("breaker-token"))
+	assert.NotSame(t, circuitBreakerFor("breaker-token"), circuitBreakerFor("other-breaker-token"))
+	breakers.Range(func(key, _ any) bool {
+		assert.NotEqual(t, "breaker-token", key)
+		return true
+	})
+}
+
+func TestCircuitBreakerTransport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		statusCode    int
+		err           error
+		expectFailure bool
+	}{
+		{
+			name:          "Success - OK is not a failure",
+			statusCode:    http.StatusOK,
+			expectFailure: false,
+		},
+		{
+			name:          "Success - Not found is not a failure",
+			statusCode:    http.StatusNotFound,
+			expectFailure: false,
+		},
+		{
+			name:          "Success - Too many requests is a failure",
+			statusCode:    http.StatusTooManyRequests,
+			expectFailure: true,
+		},
+		{
+			name:          "Success - Server error is a failure",
+			statusCode:    http.StatusBadGateway,
+			expectFailure: true,
+		},
+		{
+			name:          "Success - Transport error is a failure",
+			err:           errors.New("connection reset"),
+			expectFailure: true,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			breaker := &circuitBreaker{}
+			transport := &circuitBreakerTransport{
+				base: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+					if testcase.err != nil {
+						return nil, testcase.err
+					}
+					return &http.Response{StatusCode: testcase.statusCode, Body: http.NoBody}, nil
+				}),
+				breaker: breaker,
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "https://api.linode.com/v4/linode/instances", http.NoBody)
+			require.NoError(t, err)
+			resp, err := transport.RoundTrip(req)
+			if resp != nil {
+				defer resp.Body.Close()
+			}
+			assert.Equal(t, testcase.err, err)
+			if testcase.expectFailure {
+				assert.Len(t, breaker.failures, 1)
+			} else {
+				assert.Empty(t, breaker.failures)
+			}
+		})
+	}
+}
+
+func TestMachineScopeShouldBackoff(t *testing.T) {
+	t.Parallel()
+
+	backoff, delay := (&MachineScope{}).ShouldBackoff()
+	assert.False(t, backoff)
+	assert.Zero(t, delay)
+
+	breaker := &circuitBreaker{}
+	for range reconciler.DefaultCircuitBreakerFailureThreshold {
+		breaker.recordFailure(time.Now())
+	}
+	backoff, delay = (&MachineScope{breaker: breaker}).ShouldBackoff()
+	assert.True(t, backoff)
+	assert.Positive(t, delay)
+}
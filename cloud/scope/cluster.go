@@ -35,6 +35,8 @@ type ClusterScopeParams struct {
 	Client        K8sClient
 	Cluster       *clusterv1.Cluster
 	LinodeCluster *infrav1alpha2.LinodeCluster
+	// HTTPHeaders are added to every request sent by the scope's Linode client.
+	HTTPHeaders map[string]string
 }
 
 func validateClusterScopeParams(params ClusterScopeParams) error {
@@ -50,7 +52,7 @@ func validateClusterScopeParams(params ClusterScopeParams) error {
 
 // NewClusterScope creates a new Scope from the supplied parameters.
 // This is meant to be called for each reconcile iteration.
-func NewClusterScope(ctx context.Context, apiKey string, params ClusterScopeParams) (*ClusterScope, error) {
+func NewClusterScope(ctx context.Context, apiKey, dnsKey string, params ClusterScopeParams) (*ClusterScope, error) {
 	if err := validateClusterScopeParams(params); err != nil {
 		return nil, err
 	}
@@ -63,12 +65,27 @@ func NewClusterScope(ctx context.Context, apiKey string, params ClusterScopePara
 			return nil, fmt.Errorf("credentials from secret ref: %w", err)
 		}
 		apiKey = string(apiToken)
+
+		dnsToken, err := getCredentialDataFromRef(ctx, params.Client, *params.LinodeCluster.Spec.CredentialsRef, params.LinodeCluster.GetNamespace(), "dnsToken")
+		if err != nil || len(dnsToken) == 0 {
+			dnsToken = apiToken
+		}
+		dnsKey = string(dnsToken)
+	}
+	linodeClient, err := CreateLinodeClient(apiKey, defaultClientTimeout, WithHTTPHeaders(params.HTTPHeaders))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create linode client: %w", err)
 	}
-	linodeClient, err := CreateLinodeClient(apiKey, defaultClientTimeout)
+	linodeDomainsClient, err := CreateLinodeClient(dnsKey, defaultClientTimeout, WithHTTPHeaders(params.HTTPHeaders))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create linode client: %w", err)
 	}
 
+	akamDomainsClient, err := setUpEdgeDNSInterface()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create akamai dns client: %w", err)
+	}
+
 	helper, err := patch.NewHelper(params.LinodeCluster, params.Client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to init patch helper: %w", err)
@@ -80,6 +97,9 @@ func NewClusterScope(ctx context.Context, apiKey string, params ClusterScopePara
 		LinodeClient:  linodeClient,
 		LinodeCluster: params.LinodeCluster,
 		PatchHelper:   helper,
+
+		LinodeDomainsClient: linodeDomainsClient,
+		AkamaiDomainsClient: akamDomainsClient,
 	}, nil
 }
 
@@ -90,6 +110,13 @@ type ClusterScope struct {
 	LinodeClient  LinodeClient
 	Cluster       *clusterv1.Cluster
 	LinodeCluster *infrav1alpha2.LinodeCluster
+
+	LinodeDomainsClient LinodeClient
+	AkamaiDomainsClient AkamClient
+
+	// dnsZone and dnsZoneID cache the Linode domain of the DNS root domain.
+	dnsZone   string
+	dnsZoneID int
 }
 
 // PatchObject persists the cluster configuration and status.
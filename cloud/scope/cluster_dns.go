@@ -0,0 +1,212 @@
+package scope
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/dns"
+	"github.com/linode/linodego"
+)
+
+// ReconcileNodeBalancerDNS ensures the control plane FQDN has a single address record
+// pointing at the NodeBalancer IP. When the LinodeCluster is being deleted the record
+// is removed instead.
+func (s *ClusterScope) ReconcileNodeBalancerDNS(ctx context.Context, nbIP string) error {
+	if s.LinodeCluster.Spec.Network.DNSRootDomain == "" {
+		return errors.New("dns root domain is not configured on the LinodeCluster")
+	}
+	addr, err := netip.ParseAddr(nbIP)
+	if err != nil {
+		return fmt.Errorf("not a valid IP %w", err)
+	}
+	recordType := linodego.RecordTypeA
+	if !addr.Is4() {
+		recordType = linodego.RecordTypeAAAA
+	}
+	remove := !s.LinodeCluster.DeletionTimestamp.IsZero()
+
+	if s.LinodeCluster.Spec.Network.DNSProvider == "akamai" {
+		return s.reconcileAkamaiNodeBalancerDNS(ctx, nbIP, recordType, remove)
+	}
+
+	return s.reconcileLinodeNodeBalancerDNS(ctx, nbIP, recordType, remove)
+}
+
+func (s *ClusterScope) reconcileLinodeNodeBalancerDNS(ctx context.Context, nbIP string, recordType linodego.DomainRecordType, remove bool) error {
+	domainID, err := s.validateDNSZone(ctx)
+	if err != nil {
+		return err
+	}
+	hostname := ControlPlaneHostname(s.LinodeCluster)
+
+	filter, err := json.Marshal(map[string]interface{}{"name": hostname, "type": recordType})
+	if err != nil {
+		return err
+	}
+	records, err := s.LinodeDomainsClient.ListDomainRecords(ctx, domainID, linodego.NewListOptions(0, string(filter)))
+	if err != nil {
+		return fmt.Errorf("list domain records: %w", err)
+	}
+
+	if remove {
+		for _, record := range records {
+			if record.Target != nbIP {
+				continue
+			}
+			if err := s.LinodeDomainsClient.DeleteDomainRecord(ctx, domainID, record.ID); err != nil {
+				return fmt.Errorf("delete domain record %d: %w", record.ID, err)
+			}
+		}
+		return nil
+	}
+
+	if len(records) == 0 {
+		if _, err := s.LinodeDomainsClient.CreateDomainRecord(ctx, domainID, linodego.DomainRecordCreateOptions{
+			Type:   recordType,
+			Name:   hostname,
+			Target: nbIP,
+			TTLSec: dnsTTLSec(s.LinodeCluster),
+		}); err != nil {
+			return fmt.Errorf("create domain record: %w", err)
+		}
+		return nil
+	}
+
+	if records[0].Target != nbIP {
+		if _, err := s.LinodeDomainsClient.UpdateDomainRecord(ctx, domainID, records[0].ID, linodego.DomainRecordUpdateOptions{
+			Target: nbIP,
+			TTLSec: dnsTTLSec(s.LinodeCluster),
+		}); err != nil {
+			return fmt.Errorf("update domain record %d: %w", records[0].ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *ClusterScope) reconcileAkamaiNodeBalancerDNS(ctx context.Context, nbIP string, recordType linodego.DomainRecordType, remove bool) error {
+	rootDomain := s.LinodeCluster.Spec.Network.DNSRootDomain
+	fqdn := ControlPlaneHostname(s.LinodeCluster) + "." + rootDomain
+
+	record, err := s.AkamaiDomainsClient.GetRecord(ctx, rootDomain, fqdn, string(recordType))
+	if err != nil {
+		if !strings.Contains(err.Error(), "Not Found") {
+			return fmt.Errorf("get akamai dns record: %w", err)
+		}
+		if remove {
+			return nil
+		}
+		return s.AkamaiDomainsClient.CreateRecord(ctx, &dns.RecordBody{
+			Name:       fqdn,
+			RecordType: string(recordType),
+			TTL:        dnsTTLSec(s.LinodeCluster),
+			Target:     []string{nbIP},
+		}, rootDomain)
+	}
+
+	if remove {
+		return s.AkamaiDomainsClient.DeleteRecord(ctx, record, rootDomain)
+	}
+	if len(record.Target) == 1 && record.Target[0] == nbIP {
+		return nil
+	}
+	record.Target = []string{nbIP}
+
+	return s.AkamaiDomainsClient.UpdateRecord(ctx, record, rootDomain)
+}
+
+// ReconcileCNAME ensures the LinodeCluster's DNS root domain has a CNAME record under the alias,
+// which is relative to the root domain, pointing at the target, e.g. for a vanity name of the
+// control plane endpoint. The target defaults to the control plane FQDN. An error is returned
+// when the alias already has other records, which must not coexist with a CNAME. The record is
+// removed when the LinodeCluster is being deleted. Only the Linode DNS provider is supported.
+func (s *ClusterScope) ReconcileCNAME(ctx context.Context, alias, target string) error {
+	if alias == "" {
+		return errors.New("cname alias is required")
+	}
+	rootDomain := s.LinodeCluster.Spec.Network.DNSRootDomain
+	if rootDomain == "" {
+		return errors.New("dns root domain is not configured on the LinodeCluster")
+	}
+	if s.LinodeCluster.Spec.Network.DNSProvider == "akamai" {
+		return errors.New("cname records are only supported by the linode dns provider")
+	}
+	if target == "" {
+		target = ControlPlaneHostname(s.LinodeCluster) + "." + rootDomain
+	}
+	domainID, err := s.validateDNSZone(ctx)
+	if err != nil {
+		return err
+	}
+
+	filter, err := json.Marshal(map[string]string{"name": alias})
+	if err != nil {
+		return err
+	}
+	records, err := s.LinodeDomainsClient.ListDomainRecords(ctx, domainID, linodego.NewListOptions(0, string(filter)))
+	if err != nil {
+		return fmt.Errorf("list domain records: %w", err)
+	}
+	records = slices.DeleteFunc(records, func(record linodego.DomainRecord) bool { return record.Name != alias })
+	remove := !s.LinodeCluster.DeletionTimestamp.IsZero()
+	if idx := slices.IndexFunc(records, func(record linodego.DomainRecord) bool {
+		return record.Type != linodego.RecordTypeCNAME
+	}); idx >= 0 && !remove {
+		return fmt.Errorf("cname %s conflicts with its existing %s record", alias, records[idx].Type)
+	}
+
+	switch {
+	case remove:
+		for _, record := range records {
+			if record.Type != linodego.RecordTypeCNAME {
+				continue
+			}
+			if err := s.LinodeDomainsClient.DeleteDomainRecord(ctx, domainID, record.ID); err != nil {
+				return fmt.Errorf("delete domain record %d: %w", record.ID, err)
+			}
+		}
+	case len(records) == 0:
+		if _, err := s.LinodeDomainsClient.CreateDomainRecord(ctx, domainID, linodego.DomainRecordCreateOptions{
+			Type:   linodego.RecordTypeCNAME,
+			Name:   alias,
+			Target: target,
+			TTLSec: dnsTTLSec(s.LinodeCluster),
+		}); err != nil {
+			return fmt.Errorf("create domain record: %w", err)
+		}
+	case records[0].Target != target || records[0].TTLSec != dnsTTLSec(s.LinodeCluster):
+		if _, err := s.LinodeDomainsClient.UpdateDomainRecord(ctx, domainID, records[0].ID, linodego.DomainRecordUpdateOptions{
+			Target: target,
+			TTLSec: dnsTTLSec(s.LinodeCluster),
+		}); err != nil {
+			return fmt.Errorf("update domain record %d: %w", records[0].ID, err)
+		}
+	}
+
+	return nil
+}
+
+// validateDNSZone resolves the LinodeCluster's DNS root domain to its Linode domain ID, returning
+// an error if the account does not own the domain. The domain ID is cached for the lifetime
+// of the scope.
+func (s *ClusterScope) validateDNSZone(ctx context.Context) (int, error) {
+	rootDomain := s.LinodeCluster.Spec.Network.DNSRootDomain
+	if s.dnsZone == rootDomain {
+		return s.dnsZoneID, nil
+	}
+
+	domainID, err := lookupDomainID(ctx, s.LinodeDomainsClient, rootDomain)
+	if err != nil {
+		return 0, err
+	}
+
+	s.dnsZone = rootDomain
+	s.dnsZoneID = domainID
+
+	return domainID, nil
+}
@@ -0,0 +1,273 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/dns"
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestClusterScopeReconcileNodeBalancerDNS(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+	linodeCluster := func(provider string, deleting bool) *infrav1alpha2.LinodeCluster {
+		cluster := &infrav1alpha2.LinodeCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+			Spec: infrav1alpha2.LinodeClusterSpec{
+				Network: infrav1alpha2.NetworkSpec{
+					DNSProvider:         provider,
+					DNSRootDomain:       "lkedevs.net",
+					DNSUniqueIdentifier: "abc123",
+				},
+			},
+		}
+		if deleting {
+			cluster.DeletionTimestamp = &now
+		}
+		return cluster
+	}
+
+	tests := []struct {
+		name          string
+		linodeCluster *infrav1alpha2.LinodeCluster
+		nbIP          string
+		expects       func(linode *mock.MockLinodeClient, akamai *mock.MockAkamClient)
+		expectedError string
+	}{
+		{
+			name:          "Create linode record",
+			linodeCluster: linodeCluster("linode", false),
+			nbIP:          "172.0.0.10",
+			expects: func(linode *mock.MockLinodeClient, akamai *mock.MockAkamClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return([]linodego.Domain{{ID: 1, Domain: "lkedevs.net"}}, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).Return(nil, nil)
+				linode.EXPECT().CreateDomainRecord(gomock.Any(), 1, linodego.DomainRecordCreateOptions{
+					Type:   linodego.RecordTypeA,
+					Name:   "test-cluster-abc123",
+					Target: "172.0.0.10",
+					TTLSec: 30,
+				}).Return(&linodego.DomainRecord{}, nil)
+			},
+		},
+		{
+			name:          "Existing linode record is left alone",
+			linodeCluster: linodeCluster("linode", false),
+			nbIP:          "172.0.0.10",
+			expects: func(linode *mock.MockLinodeClient, akamai *mock.MockAkamClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return([]linodego.Domain{{ID: 1, Domain: "lkedevs.net"}}, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).Return([]linodego.DomainRecord{{ID: 5, Target: "172.0.0.10"}}, nil)
+			},
+		},
+		{
+			name:          "Stale linode record is updated",
+			linodeCluster: linodeCluster("linode", false),
+			nbIP:          "172.0.0.10",
+			expects: func(linode *mock.MockLinodeClient, akamai *mock.MockAkamClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return([]linodego.Domain{{ID: 1, Domain: "lkedevs.net"}}, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).Return([]linodego.DomainRecord{{ID: 5, Target: "172.0.0.9"}}, nil)
+				linode.EXPECT().UpdateDomainRecord(gomock.Any(), 1, 5, linodego.DomainRecordUpdateOptions{Target: "172.0.0.10", TTLSec: 30}).Return(&linodego.DomainRecord{}, nil)
+			},
+		},
+		{
+			name:          "Linode record is removed on cluster deletion",
+			linodeCluster: linodeCluster("linode", true),
+			nbIP:          "172.0.0.10",
+			expects: func(linode *mock.MockLinodeClient, akamai *mock.MockAkamClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return([]linodego.Domain{{ID: 1, Domain: "lkedevs.net"}}, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).Return([]linodego.DomainRecord{{ID: 5, Target: "172.0.0.10"}}, nil)
+				linode.EXPECT().DeleteDomainRecord(gomock.Any(), 1, 5).Return(nil)
+			},
+		},
+		{
+			name:          "Create akamai record",
+			linodeCluster: linodeCluster("akamai", false),
+			nbIP:          "172.0.0.10",
+			expects: func(linode *mock.MockLinodeClient, akamai *mock.MockAkamClient) {
+				akamai.EXPECT().GetRecord(gomock.Any(), "lkedevs.net", "test-cluster-abc123.lkedevs.net", "A").Return(nil, errors.New("Not Found"))
+				akamai.EXPECT().CreateRecord(gomock.Any(), &dns.RecordBody{
+					Name:       "test-cluster-abc123.lkedevs.net",
+					RecordType: "A",
+					TTL:        30,
+					Target:     []string{"172.0.0.10"},
+				}, "lkedevs.net").Return(nil)
+			},
+		},
+		{
+			name:          "Akamai record is removed on cluster deletion",
+			linodeCluster: linodeCluster("akamai", true),
+			nbIP:          "172.0.0.10",
+			expects: func(linode *mock.MockLinodeClient, akamai *mock.MockAkamClient) {
+				akamai.EXPECT().GetRecord(gomock.Any(), "lkedevs.net", "test-cluster-abc123.lkedevs.net", "A").Return(&dns.RecordBody{Target: []string{"172.0.0.10"}}, nil)
+				akamai.EXPECT().DeleteRecord(gomock.Any(), gomock.Any(), "lkedevs.net").Return(nil)
+			},
+		},
+		{
+			name:          "Error - invalid IP",
+			linodeCluster: linodeCluster("linode", false),
+			nbIP:          "not-an-ip",
+			expects:       func(linode *mock.MockLinodeClient, akamai *mock.MockAkamClient) {},
+			expectedError: "not a valid IP",
+		},
+		{
+			name:          "Error - domain not found",
+			linodeCluster: linodeCluster("linode", false),
+			nbIP:          "172.0.0.10",
+			expects: func(linode *mock.MockLinodeClient, akamai *mock.MockAkamClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+			expectedError: "domain lkedevs.net not found",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			mockAkamaiClient := mock.NewMockAkamClient(ctrl)
+			testcase.expects(mockLinodeClient, mockAkamaiClient)
+
+			cScope := &ClusterScope{
+				LinodeDomainsClient: mockLinodeClient,
+				AkamaiDomainsClient: mockAkamaiClient,
+				LinodeCluster:       testcase.linodeCluster,
+			}
+
+			err := cScope.ReconcileNodeBalancerDNS(context.Background(), testcase.nbIP)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestClusterScopeReconcileCNAME(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+	linodeCluster := func(provider string, deleting bool) *infrav1alpha2.LinodeCluster {
+		cluster := &infrav1alpha2.LinodeCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+			Spec: infrav1alpha2.LinodeClusterSpec{
+				Network: infrav1alpha2.NetworkSpec{DNSRootDomain: "lkedevs.net", DNSUniqueIdentifier: "abc123", DNSProvider: provider},
+			},
+		}
+		if deleting {
+			cluster.DeletionTimestamp = &now
+			cluster.Finalizers = []string{"test"}
+		}
+		return cluster
+	}
+	domains := []linodego.Domain{{ID: 1, Domain: "lkedevs.net"}}
+	cnameRecord := func(target string) linodego.DomainRecord {
+		return linodego.DomainRecord{ID: 5, Type: linodego.RecordTypeCNAME, Name: "api", Target: target, TTLSec: 30}
+	}
+
+	tests := []struct {
+		name          string
+		linodeCluster *infrav1alpha2.LinodeCluster
+		target        string
+		expects       func(linode *mock.MockLinodeClient)
+		expectedError string
+	}{
+		{
+			name:          "Create cname pointing at the control plane",
+			linodeCluster: linodeCluster("", false),
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).Return(nil, nil)
+				linode.EXPECT().CreateDomainRecord(gomock.Any(), 1, linodego.DomainRecordCreateOptions{
+					Type:   linodego.RecordTypeCNAME,
+					Name:   "api",
+					Target: "test-cluster-abc123.lkedevs.net",
+					TTLSec: 30,
+				}).Return(&linodego.DomainRecord{}, nil)
+			},
+		},
+		{
+			name:          "Existing cname is left alone",
+			linodeCluster: linodeCluster("", false),
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{cnameRecord("test-cluster-abc123.lkedevs.net")}, nil)
+			},
+		},
+		{
+			name:          "Update target of cname",
+			linodeCluster: linodeCluster("", false),
+			target:        "edge.example.com",
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{cnameRecord("test-cluster-abc123.lkedevs.net")}, nil)
+				linode.EXPECT().UpdateDomainRecord(gomock.Any(), 1, 5, linodego.DomainRecordUpdateOptions{Target: "edge.example.com", TTLSec: 30}).
+					Return(&linodego.DomainRecord{}, nil)
+			},
+		},
+		{
+			name:          "Cname is removed on cluster deletion",
+			linodeCluster: linodeCluster("", true),
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{cnameRecord("test-cluster-abc123.lkedevs.net")}, nil)
+				linode.EXPECT().DeleteDomainRecord(gomock.Any(), 1, 5).Return(nil)
+			},
+		},
+		{
+			name:          "Error - alias has an address record",
+			linodeCluster: linodeCluster("", false),
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{{ID: 6, Type: linodego.RecordTypeA, Name: "api", Target: "172.0.0.10"}}, nil)
+			},
+			expectedError: "cname api conflicts with its existing A record",
+		},
+		{
+			name:          "Error - akamai dns provider",
+			linodeCluster: linodeCluster("akamai", false),
+			expects:       func(linode *mock.MockLinodeClient) {},
+			expectedError: "cname records are only supported by the linode dns provider",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			cScope := &ClusterScope{
+				LinodeDomainsClient: mockLinodeClient,
+				LinodeCluster:       testcase.linodeCluster,
+			}
+
+			err := cScope.ReconcileCNAME(context.Background(), "api", testcase.target)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/linode/linodego"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/util"
+)
+
+// OrphanedResource identifies a Linode resource that is tagged for a cluster but no longer in use by it.
+type OrphanedResource struct {
+	ID    int
+	Label string
+}
+
+// OrphanReport lists the Linode resources tagged for a cluster which are candidates for cleanup.
+type OrphanReport struct {
+	Instances     []OrphanedResource
+	Volumes       []OrphanedResource
+	NodeBalancers []OrphanedResource
+}
+
+// Empty reports whether no orphaned resources were found.
+func (r OrphanReport) Empty() bool {
+	return len(r.Instances) == 0 && len(r.Volumes) == 0 && len(r.NodeBalancers) == 0
+}
+
+// FindOrphanedResources lists the instances and volumes tagged with the cluster name and the
+// NodeBalancers tagged with the LinodeCluster UID, and reports those which are not in use by
+// the cluster's LinodeMachines or LinodeCluster. Nothing is deleted; see DeleteOrphanedResources.
+func (s *ClusterScope) FindOrphanedResources(ctx context.Context) (OrphanReport, error) {
+	var report OrphanReport
+
+	var machines infrav1alpha2.LinodeMachineList
+	if err := s.Client.List(ctx, &machines,
+		client.InNamespace(s.LinodeCluster.Namespace),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: s.Cluster.Name},
+	); err != nil {
+		return report, fmt.Errorf("list linodemachines: %w", err)
+	}
+	instanceIDs := make([]int, 0, len(machines.Items))
+	machineNames := make([]string, 0, len(machines.Items))
+	for _, machine := range machines.Items {
+		if machine.Spec.InstanceID != nil {
+			instanceIDs = append(instanceIDs, *machine.Spec.InstanceID)
+		}
+		machineNames = append(machineNames, machine.Name)
+	}
+
+	clusterTag := s.LinodeCluster.Name
+	filter, err := util.Filter{Tags: []string{clusterTag}}.String()
+	if err != nil {
+		return report, err
+	}
+
+	instances, err := s.LinodeClient.ListInstances(ctx, linodego.NewListOptions(0, filter))
+	if err != nil {
+		return report, fmt.Errorf("list instances: %w", err)
+	}
+	for _, instance := range instances {
+		// Instances being created may not have their ID recorded yet, so match them by label too
+		if !slices.Contains(instance.Tags, clusterTag) || slices.Contains(instanceIDs, instance.ID) || slices.Contains(machineNames, instance.Label) {
+			continue
+		}
+		report.Instances = append(report.Instances, OrphanedResource{ID: instance.ID, Label: instance.Label})
+	}
+
+	volumes, err := s.LinodeClient.ListVolumes(ctx, linodego.NewListOptions(0, filter))
+	if err != nil {
+		return report, fmt.Errorf("list volumes: %w", err)
+	}
+	for _, volume := range volumes {
+		if !slices.Contains(volume.Tags, clusterTag) || (volume.LinodeID != nil && slices.Contains(instanceIDs, *volume.LinodeID)) {
+			continue
+		}
+		report.Volumes = append(report.Volumes, OrphanedResource{ID: volume.ID, Label: volume.Label})
+	}
+
+	ownerTag := string(s.LinodeCluster.UID)
+	filter, err = util.Filter{Tags: []string{ownerTag}}.String()
+	if err != nil {
+		return report, err
+	}
+	nodeBalancers, err := s.LinodeClient.ListNodeBalancers(ctx, linodego.NewListOptions(0, filter))
+	if err != nil {
+		return report, fmt.Errorf("list nodebalancers: %w", err)
+	}
+	nbID := s.LinodeCluster.Spec.Network.NodeBalancerID
+	for _, nb := range nodeBalancers {
+		if !slices.Contains(nb.Tags, ownerTag) || (nbID != nil && *nbID == nb.ID) {
+			continue
+		}
+		var label string
+		if nb.Label != nil {
+			label = *nb.Label
+		}
+		report.NodeBalancers = append(report.NodeBalancers, OrphanedResource{ID: nb.ID, Label: label})
+	}
+
+	return report, nil
+}
+
+// DeleteOrphanedResources deletes the resources listed in a report returned by
+// FindOrphanedResources. Resources which no longer exist are skipped, and deletion
+// continues past failures so that all errors are returned together.
+func (s *ClusterScope) DeleteOrphanedResources(ctx context.Context, report OrphanReport) error {
+	var errs []error
+	for _, instance := range report.Instances {
+		if err := util.IgnoreLinodeAPIError(s.LinodeClient.DeleteInstance(ctx, instance.ID), http.StatusNotFound); err != nil {
+			errs = append(errs, fmt.Errorf("delete instance %d: %w", instance.ID, err))
+		}
+	}
+	for _, volume := range report.Volumes {
+		if err := util.IgnoreLinodeAPIError(s.LinodeClient.DeleteVolume(ctx, volume.ID), http.StatusNotFound); err != nil {
+			errs = append(errs, fmt.Errorf("delete volume %d: %w", volume.ID, err))
+		}
+	}
+	for _, nb := range report.NodeBalancers {
+		if err := util.IgnoreLinodeAPIError(s.LinodeClient.DeleteNodeBalancer(ctx, nb.ID), http.StatusNotFound); err != nil {
+			errs = append(errs, fmt.Errorf("delete nodebalancer %d: %w", nb.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
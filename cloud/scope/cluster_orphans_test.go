@@ -0,0 +1,149 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestClusterScopeFindOrphanedResources(t *testing.T) {
+	t.Parallel()
+
+	listMachines := func(k8sClient *mock.MockK8sClient) {
+		k8sClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, list *infrav1alpha2.LinodeMachineList, opts ...client.ListOption) error {
+				list.Items = []infrav1alpha2.LinodeMachine{
+					{ObjectMeta: metav1.ObjectMeta{Name: "machine-1"}, Spec: infrav1alpha2.LinodeMachineSpec{InstanceID: ptr.To(1)}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "machine-2"}},
+				}
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name           string
+		expects        func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient)
+		expectedReport OrphanReport
+		expectedError  string
+	}{
+		{
+			name: "Success - Orphans are reported",
+			expects: func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {
+				listMachines(k8sClient)
+				linodeClient.EXPECT().ListInstances(gomock.Any(), gomock.Any()).Return([]linodego.Instance{
+					{ID: 1, Label: "machine-1", Tags: []string{"test-cluster"}},
+					{ID: 2, Label: "machine-2", Tags: []string{"test-cluster"}},
+					{ID: 3, Label: "machine-3", Tags: []string{"test-cluster"}},
+					{ID: 4, Label: "unrelated", Tags: []string{"test-cluster-2"}},
+				}, nil)
+				linodeClient.EXPECT().ListVolumes(gomock.Any(), gomock.Any()).Return([]linodego.Volume{
+					{ID: 10, Label: "attached", LinodeID: ptr.To(1), Tags: []string{"test-cluster"}},
+					{ID: 11, Label: "detached", Tags: []string{"test-cluster"}},
+					{ID: 12, Label: "attached-to-orphan", LinodeID: ptr.To(3), Tags: []string{"test-cluster"}},
+				}, nil)
+				linodeClient.EXPECT().ListNodeBalancers(gomock.Any(), gomock.Any()).Return([]linodego.NodeBalancer{
+					{ID: 20, Label: ptr.To("current"), Tags: []string{"test-uid"}},
+					{ID: 21, Label: ptr.To("stale"), Tags: []string{"test-uid"}},
+				}, nil)
+			},
+			expectedReport: OrphanReport{
+				Instances:     []OrphanedResource{{ID: 3, Label: "machine-3"}},
+				Volumes:       []OrphanedResource{{ID: 11, Label: "detached"}, {ID: 12, Label: "attached-to-orphan"}},
+				NodeBalancers: []OrphanedResource{{ID: 21, Label: "stale"}},
+			},
+		},
+		{
+			name: "Success - No orphans",
+			expects: func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {
+				listMachines(k8sClient)
+				linodeClient.EXPECT().ListInstances(gomock.Any(), gomock.Any()).Return([]linodego.Instance{{ID: 1, Tags: []string{"test-cluster"}}}, nil)
+				linodeClient.EXPECT().ListVolumes(gomock.Any(), gomock.Any()).Return(nil, nil)
+				linodeClient.EXPECT().ListNodeBalancers(gomock.Any(), gomock.Any()).Return([]linodego.NodeBalancer{{ID: 20, Tags: []string{"test-uid"}}}, nil)
+			},
+		},
+		{
+			name: "Error - Listing machines fails",
+			expects: func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {
+				k8sClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("k8s error"))
+			},
+			expectedError: "list linodemachines: k8s error",
+		},
+		{
+			name: "Error - Listing instances fails",
+			expects: func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {
+				listMachines(k8sClient)
+				linodeClient.EXPECT().ListInstances(gomock.Any(), gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "list instances: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			mockK8sClient := mock.NewMockK8sClient(ctrl)
+			testcase.expects(mockLinodeClient, mockK8sClient)
+
+			cScope := &ClusterScope{
+				Client:       mockK8sClient,
+				LinodeClient: mockLinodeClient,
+				Cluster:      &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+				LinodeCluster: &infrav1alpha2.LinodeCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default", UID: "test-uid"},
+					Spec: infrav1alpha2.LinodeClusterSpec{
+						Network: infrav1alpha2.NetworkSpec{NodeBalancerID: ptr.To(20)},
+					},
+				},
+			}
+
+			report, err := cScope.FindOrphanedResources(context.Background())
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.expectedReport, report)
+			assert.Equal(t, testcase.expectedReport.Empty(), report.Empty())
+		})
+	}
+}
+
+func TestClusterScopeDeleteOrphanedResources(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+	mockLinodeClient.EXPECT().DeleteInstance(gomock.Any(), 3).Return(&linodego.Error{Code: http.StatusNotFound})
+	mockLinodeClient.EXPECT().DeleteVolume(gomock.Any(), 11).Return(errors.New("api error"))
+	mockLinodeClient.EXPECT().DeleteNodeBalancer(gomock.Any(), 21).Return(nil)
+
+	cScope := &ClusterScope{LinodeClient: mockLinodeClient}
+
+	err := cScope.DeleteOrphanedResources(context.Background(), OrphanReport{
+		Instances:     []OrphanedResource{{ID: 3}},
+		Volumes:       []OrphanedResource{{ID: 11}},
+		NodeBalancers: []OrphanedResource{{ID: 21}},
+	})
+	require.ErrorContains(t, err, "delete volume 11: api error")
+	require.NotContains(t, err.Error(), "instance")
+}
@@ -158,6 +158,7 @@ func TestClusterScopeMethods(t *testing.T) {
 			cScope, err := NewClusterScope(
 				context.Background(),
 				"test-key",
+				"test-key",
 				ClusterScopeParams{
 					Cluster:       testcase.fields.Cluster,
 					LinodeCluster: testcase.fields.LinodeCluster,
@@ -240,7 +241,7 @@ func TestNewClusterScope(t *testing.T) {
 					}
 					*obj = cred
 					return nil
-				})
+				}).Times(2)
 			},
 		},
 		{
@@ -316,7 +317,7 @@ func TestNewClusterScope(t *testing.T) {
 
 			testcase.args.params.Client = mockK8sClient
 
-			got, err := NewClusterScope(context.Background(), testcase.args.apiKey, testcase.args.params)
+			got, err := NewClusterScope(context.Background(), testcase.args.apiKey, testcase.args.apiKey, testcase.args.params)
 
 			if testcase.expectedError != nil {
 				assert.ErrorContains(t, err, testcase.expectedError.Error())
@@ -374,7 +375,7 @@ func TestClusterAddCredentialsRefFinalizer(t *testing.T) {
 					*obj = cred
 
 					return nil
-				}).Times(2)
+				}).Times(3)
 				mock.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
 			},
 		},
@@ -412,6 +413,7 @@ func TestClusterAddCredentialsRefFinalizer(t *testing.T) {
 			cScope, err := NewClusterScope(
 				context.Background(),
 				"test-key",
+				"test-key",
 				ClusterScopeParams{
 					Cluster:       testcase.fields.Cluster,
 					LinodeCluster: testcase.fields.LinodeCluster,
@@ -475,7 +477,7 @@ func TestRemoveCredentialsRefFinalizer(t *testing.T) {
 					*obj = cred
 
 					return nil
-				}).Times(2)
+				}).Times(3)
 				mock.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
 			},
 		},
@@ -513,6 +515,7 @@ func TestRemoveCredentialsRefFinalizer(t *testing.T) {
 			cScope, err := NewClusterScope(
 				context.Background(),
 				"test-key",
+				"test-key",
 				ClusterScopeParams{
 					Cluster:       testcase.fields.Cluster,
 					LinodeCluster: testcase.fields.LinodeCluster,
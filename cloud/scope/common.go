@@ -33,7 +33,8 @@ const (
 )
 
 type Option struct {
-	set func(client *linodego.Client)
+	set  func(client *linodego.Client)
+	wrap func(transport http.RoundTripper) http.RoundTripper
 }
 
 func WithRetryCount(c int) Option {
@@ -44,6 +45,36 @@ func WithRetryCount(c int) Option {
 	}
 }
 
+// WithHTTPHeaders adds the headers to every request sent by the client. The headers
+// are set by the underlying transport, so their values never reach the client's debug logs.
+func WithHTTPHeaders(headers map[string]string) Option {
+	return Option{
+		wrap: func(transport http.RoundTripper) http.RoundTripper {
+			return &headerTransport{base: transport, headers: headers}
+		},
+	}
+}
+
+// headerTransport sets a fixed set of headers on every request.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers map[string]string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(t.headers) == 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	// RoundTrippers must not modify the request they were given
+	req = req.Clone(req.Context())
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+
+	return t.base.RoundTrip(req)
+}
+
 func CreateLinodeClient(apiKey string, timeout time.Duration, opts ...Option) (LinodeClient, error) {
 	if apiKey == "" {
 		return nil, errors.New("missing Linode API key")
@@ -51,9 +82,19 @@ func CreateLinodeClient(apiKey string, timeout time.Duration, opts ...Option) (L
 
 	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: apiKey})
 
+	var transport http.RoundTripper = &oauth2.Transport{
+		Source: tokenSource,
+	}
+	for _, opt := range opts {
+		if opt.wrap != nil {
+			transport = opt.wrap(transport)
+		}
+	}
+
 	oauth2Client := &http.Client{
-		Transport: &oauth2.Transport{
-			Source: tokenSource,
+		Transport: &circuitBreakerTransport{
+			base:    transport,
+			breaker: circuitBreakerFor(apiKey),
 		},
 		Timeout: timeout,
 	}
@@ -62,7 +103,9 @@ func CreateLinodeClient(apiKey string, timeout time.Duration, opts ...Option) (L
 	linodeClient.SetUserAgent(fmt.Sprintf("CAPL/%s", version.GetVersion()))
 
 	for _, opt := range opts {
-		opt.set(&linodeClient)
+		if opt.set != nil {
+			opt.set(&linodeClient)
+		}
 	}
 
 	return linodeclient.NewLinodeClientWithTracing(
@@ -3,9 +3,15 @@ package scope
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/linode/linodego"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -54,6 +60,72 @@ func TestCreateLinodeClient(t *testing.T) {
 	}
 }
 
+// TestWithHTTPHeaders checks that the configured headers are set on every request.
+func TestWithHTTPHeaders(t *testing.T) {
+	t.Parallel()
+
+	var got http.Header
+	transport := WithHTTPHeaders(map[string]string{"X-Proxy-Auth": "secret"}).wrap(
+		roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			got = req.Header
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}),
+	)
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.linode.com/v4/linode/instances", http.NoBody)
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "secret", got.Get("X-Proxy-Auth"))
+	assert.Empty(t, req.Header.Get("X-Proxy-Auth"))
+
+	client, err := CreateLinodeClient("test-key", defaultClientTimeout, WithHTTPHeaders(map[string]string{"X-Proxy-Auth": "secret"}))
+	require.NoError(t, err)
+	assert.NotNil(t, client)
+}
+
+// debugLogger collects the debug logs of a Linode client.
+type debugLogger struct {
+	strings.Builder
+}
+
+func (l *debugLogger) Errorf(format string, v ...interface{}) { fmt.Fprintf(l, format+"\n", v...) }
+func (l *debugLogger) Warnf(format string, v ...interface{})  { fmt.Fprintf(l, format+"\n", v...) }
+func (l *debugLogger) Debugf(format string, v ...interface{}) { fmt.Fprintf(l, format+"\n", v...) }
+
+// TestWithHTTPHeadersNotLogged checks that header values never reach the client's debug logs.
+func TestWithHTTPHeadersNotLogged(t *testing.T) {
+	t.Parallel()
+
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("X-Proxy-Auth")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 123}`))
+	}))
+	defer server.Close()
+
+	logs := &debugLogger{}
+	client, err := CreateLinodeClient("test-headers-key", defaultClientTimeout,
+		WithHTTPHeaders(map[string]string{"X-Proxy-Auth": "proxy-secret"}),
+		Option{set: func(client *linodego.Client) {
+			client.SetBaseURL(server.URL)
+			client.SetDebug(true)
+			client.SetLogger(logs)
+		}},
+	)
+	require.NoError(t, err)
+
+	instance, err := client.GetInstance(context.Background(), 123)
+	require.NoError(t, err)
+	assert.Equal(t, 123, instance.ID)
+	assert.Equal(t, "proxy-secret", got)
+	assert.Contains(t, logs.String(), "/linode/instances/123")
+	assert.NotContains(t, logs.String(), "proxy-secret")
+}
+
 // Test_getCredentialDataFromRef tests the getCredentialDataFromRef function.
 func TestGetCredentialDataFromRef(t *testing.T) {
 	t.Parallel()
@@ -2,16 +2,26 @@ package scope
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/linode/linodego"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	kutil "sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/util/reconciler"
 
 	. "github.com/linode/cluster-api-provider-linode/clients"
 )
@@ -22,6 +32,31 @@ type MachineScopeParams struct {
 	Machine       *clusterv1.Machine
 	LinodeCluster *infrav1alpha2.LinodeCluster
 	LinodeMachine *infrav1alpha2.LinodeMachine
+	// HTTPHeaders are added to every request sent by the scope's Linode clients.
+	HTTPHeaders map[string]string
+	// ReadinessGracePeriod is how long an instance must be unhealthy before MarkNotReady
+	// marks the machine not ready.
+	ReadinessGracePeriod time.Duration
+	// ProtectedTags are instance tags ReconcileManagedTags never removes, e.g. because
+	// they are also managed by Terraform.
+	ProtectedTags []string
+	// ClusterLabelTags are the keys of the owner Cluster's labels mirrored onto instance tags.
+	ClusterLabelTags []string
+	// TopologyTags enables tagging instances with the CAPI topology labels of their Machine.
+	TopologyTags bool
+	// RemediationTags enables tagging instances whose Machine is being remediated.
+	RemediationTags bool
+	// EphemeralTags are timestamped instance tags which are refreshed periodically.
+	EphemeralTags []EphemeralTag
+	// TagTemplate references the ConfigMap key holding the tag template of the machines.
+	TagTemplate *ConfigMapReference
+	// ManagementCIDRs are the egress CIDRs of the management cluster AllowManagementAccess
+	// admits through machine firewalls.
+	ManagementCIDRs []string
+	// ChildAccountEUUID is the EUUID of the child account the scope's Linode client acts on.
+	// The credentials must belong to its parent account. DNS is still managed with the
+	// credentials' own account.
+	ChildAccountEUUID string
 }
 
 type MachineScope struct {
@@ -34,6 +69,40 @@ type MachineScope struct {
 	AkamaiDomainsClient AkamClient
 	LinodeCluster       *infrav1alpha2.LinodeCluster
 	LinodeMachine       *infrav1alpha2.LinodeMachine
+	// ReadinessGracePeriod is how long an instance must be unhealthy before MarkNotReady
+	// marks the machine not ready.
+	ReadinessGracePeriod time.Duration
+	// ProtectedTags are instance tags ReconcileManagedTags never removes, e.g. because
+	// they are also managed by Terraform.
+	ProtectedTags []string
+	// ClusterLabelTags are the keys of the owner Cluster's labels ManagedTags mirrors onto
+	// instance tags as key:value.
+	ClusterLabelTags []string
+	// TopologyTags enables the topology tags ManagedTags derives from the CAPI topology labels
+	// of the owner Machine.
+	TopologyTags bool
+	// RemediationTags enables the remediated tag ManagedTags sets on instances whose Machine
+	// is being remediated by a MachineHealthCheck.
+	RemediationTags bool
+	// EphemeralTags are the timestamped tags ReconcileManagedTags refreshes on a throttled
+	// cadence and removes once expired.
+	EphemeralTags []EphemeralTag
+	// TagTemplate references the ConfigMap key in the LinodeMachine's namespace holding the
+	// tag template ReconcileManagedTags merges into the managed tags, see TagsFromConfigMap.
+	TagTemplate *ConfigMapReference
+	// ManagementCIDRs are the egress CIDRs of the management cluster AllowManagementAccess
+	// admits through machine firewalls.
+	ManagementCIDRs []string
+
+	// bootstrapData caches the data returned by the last GetBootstrapData call.
+	bootstrapData []byte
+	// breaker tracks recent Linode API failures for the token used by LinodeClient.
+	breaker *circuitBreaker
+	// dnsZone and dnsZoneID cache the Linode domain resolved by ValidateDNSZone.
+	dnsZone   string
+	dnsZoneID int
+	// region caches the Linode region returned by Region.
+	region *linodego.Region
 }
 
 func validateMachineScopeParams(params MachineScopeParams) error {
@@ -93,14 +162,29 @@ func NewMachineScope(ctx context.Context, apiKey, dnsKey string, params MachineS
 		dnsKey = string(dnsToken)
 	}
 
+	if params.ChildAccountEUUID != "" {
+		parentClient, err := CreateLinodeClient(apiKey, defaultClientTimeout,
+			WithRetryCount(0),
+			WithHTTPHeaders(params.HTTPHeaders),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create linode client: %w", err)
+		}
+		if apiKey, err = childAccountAPIKey(ctx, parentClient, apiKey, params.ChildAccountEUUID); err != nil {
+			return nil, err
+		}
+	}
+
 	linodeClient, err := CreateLinodeClient(apiKey, defaultClientTimeout,
 		WithRetryCount(0),
+		WithHTTPHeaders(params.HTTPHeaders),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create linode client: %w", err)
 	}
 	linodeDomainsClient, err := CreateLinodeClient(dnsKey, defaultClientTimeout,
 		WithRetryCount(0),
+		WithHTTPHeaders(params.HTTPHeaders),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create linode client: %w", err)
@@ -117,18 +201,32 @@ func NewMachineScope(ctx context.Context, apiKey, dnsKey string, params MachineS
 	}
 
 	return &MachineScope{
-		Client:              params.Client,
-		PatchHelper:         helper,
-		Cluster:             params.Cluster,
-		Machine:             params.Machine,
-		LinodeClient:        linodeClient,
-		LinodeDomainsClient: linodeDomainsClient,
-		AkamaiDomainsClient: akamDomainsClient,
-		LinodeCluster:       params.LinodeCluster,
-		LinodeMachine:       params.LinodeMachine,
+		Client:               params.Client,
+		PatchHelper:          helper,
+		Cluster:              params.Cluster,
+		Machine:              params.Machine,
+		LinodeClient:         linodeClient,
+		LinodeDomainsClient:  linodeDomainsClient,
+		AkamaiDomainsClient:  akamDomainsClient,
+		LinodeCluster:        params.LinodeCluster,
+		LinodeMachine:        params.LinodeMachine,
+		ReadinessGracePeriod: params.ReadinessGracePeriod,
+		ProtectedTags:        params.ProtectedTags,
+		ClusterLabelTags:     params.ClusterLabelTags,
+		TopologyTags:         params.TopologyTags,
+		RemediationTags:      params.RemediationTags,
+		EphemeralTags:        params.EphemeralTags,
+		TagTemplate:          params.TagTemplate,
+		ManagementCIDRs:      params.ManagementCIDRs,
+		breaker:              circuitBreakerFor(apiKey),
 	}, nil
 }
 
+// IsControlPlane reports whether the owner Machine is a control plane machine.
+func (s *MachineScope) IsControlPlane() bool {
+	return s.Machine != nil && kutil.IsControlPlaneMachine(s.Machine)
+}
+
 // PatchObject persists the machine configuration and status.
 func (s *MachineScope) PatchObject(ctx context.Context) error {
 	return s.PatchHelper.Patch(ctx, s.LinodeMachine)
@@ -149,10 +247,45 @@ func (s *MachineScope) AddFinalizer(ctx context.Context) error {
 	return nil
 }
 
+// ShouldBackoff reports whether the Linode API has recently been failing for the
+// scope's credentials, and if so how long reconciles should back off for.
+func (s *MachineScope) ShouldBackoff() (bool, time.Duration) {
+	if s.breaker == nil {
+		return false, 0
+	}
+
+	return s.breaker.shouldBackoff(time.Now())
+}
+
 // GetBootstrapData returns the bootstrap data from the secret in the Machine's bootstrap.dataSecretName.
 func (m *MachineScope) GetBootstrapData(ctx context.Context) ([]byte, error) {
-	if m.Machine.Spec.Bootstrap.DataSecretName == nil {
+	secret, err := m.getBootstrapSecret(ctx)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return m.bootstrapValue(secret)
+}
+
+// bootstrapValue extracts the bootstrap data from the bootstrap secret and caches it on the scope.
+func (m *MachineScope) bootstrapValue(secret *corev1.Secret) ([]byte, error) {
+	value, ok := secret.Data["value"]
+	if !ok {
 		return []byte{}, fmt.Errorf(
+			"bootstrap data secret value key is missing for LinodeMachine %s/%s",
+			m.LinodeMachine.Namespace,
+			m.LinodeMachine.Name,
+		)
+	}
+	m.bootstrapData = value
+
+	return value, nil
+}
+
+// getBootstrapSecret fetches the secret referenced by the Machine's bootstrap.dataSecretName.
+func (m *MachineScope) getBootstrapSecret(ctx context.Context) (*corev1.Secret, error) {
+	if m.Machine.Spec.Bootstrap.DataSecretName == nil {
+		return nil, fmt.Errorf(
 			"bootstrap data secret is nil for LinodeMachine %s/%s",
 			m.LinodeMachine.Namespace,
 			m.LinodeMachine.Name,
@@ -162,23 +295,34 @@ func (m *MachineScope) GetBootstrapData(ctx context.Context) ([]byte, error) {
 	secret := &corev1.Secret{}
 	key := types.NamespacedName{Namespace: m.LinodeMachine.Namespace, Name: *m.Machine.Spec.Bootstrap.DataSecretName}
 	if err := m.Client.Get(ctx, key, secret); err != nil {
-		return []byte{}, fmt.Errorf(
+		return nil, fmt.Errorf(
 			"failed to retrieve bootstrap data secret for LinodeMachine %s/%s",
 			m.LinodeMachine.Namespace,
 			m.LinodeMachine.Name,
 		)
 	}
 
-	value, ok := secret.Data["value"]
-	if !ok {
-		return []byte{}, fmt.Errorf(
-			"bootstrap data secret value key is missing for LinodeMachine %s/%s",
+	return secret, nil
+}
+
+// ValidateBootstrapSize checks that the bootstrap data retrieved by GetBootstrapData
+// fits within the size limit, in bytes, of the delivery mechanism used to pass it
+// to the instance. It should be called before attempting to create the instance.
+func (m *MachineScope) ValidateBootstrapSize(limit int) error {
+	if m.bootstrapData == nil {
+		return errors.New("bootstrap data has not been retrieved yet")
+	}
+	if len(m.bootstrapData) > limit {
+		return fmt.Errorf(
+			"bootstrap data too large for LinodeMachine %s/%s: %d bytes exceeds the limit of %d bytes, reduce the size of the bootstrap configuration",
 			m.LinodeMachine.Namespace,
 			m.LinodeMachine.Name,
+			len(m.bootstrapData),
+			limit,
 		)
 	}
 
-	return value, nil
+	return nil
 }
 
 func (s *MachineScope) AddCredentialsRefFinalizer(ctx context.Context) error {
@@ -202,3 +346,259 @@ func (s *MachineScope) RemoveCredentialsRefFinalizer(ctx context.Context) error
 		*s.LinodeMachine.Spec.CredentialsRef, s.LinodeMachine.GetNamespace(),
 		toFinalizer(s.LinodeMachine))
 }
+
+// SetInfoAnnotations mirrors Linode-derived facts about the instance onto the
+// LinodeMachine annotations so tooling watching the CRs can read them. Values are
+// only written when they change, and the annotations are persisted by PatchObject.
+func (s *MachineScope) SetInfoAnnotations() {
+	info := map[string]string{
+		infrav1alpha2.RegionAnnotation: s.LinodeMachine.Spec.Region,
+		infrav1alpha2.TypeAnnotation:   s.InstanceType(),
+	}
+	if s.LinodeMachine.Spec.InstanceID != nil {
+		info[infrav1alpha2.InstanceIDAnnotation] = strconv.Itoa(*s.LinodeMachine.Spec.InstanceID)
+	} else {
+		info[infrav1alpha2.InstanceIDAnnotation] = ""
+	}
+
+	annotations := s.LinodeMachine.GetAnnotations()
+	for key, value := range info {
+		current, ok := annotations[key]
+		switch {
+		case value == "" && ok:
+			delete(annotations, key)
+		case value != "" && current != value:
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[key] = value
+		}
+	}
+	s.LinodeMachine.SetAnnotations(annotations)
+}
+
+// redacted replaces sensitive values in DebugSnapshot.
+const redacted = "<redacted>"
+
+// DebugSnapshot returns the state of the scope as a single structured record that can be
+// logged or attached to an event. Sensitive values such as the root password are redacted,
+// and API tokens and bootstrap data are never included.
+func (s *MachineScope) DebugSnapshot() map[string]any {
+	snapshot := map[string]any{
+		"linodeMachine": s.LinodeMachine.Namespace + "/" + s.LinodeMachine.Name,
+	}
+	if s.LinodeCluster != nil {
+		snapshot["linodeCluster"] = s.LinodeCluster.Namespace + "/" + s.LinodeCluster.Name
+	}
+
+	spec := map[string]any{}
+	if raw, err := json.Marshal(s.LinodeMachine.Spec); err == nil {
+		_ = json.Unmarshal(raw, &spec)
+	}
+	if _, ok := spec["rootPass"]; ok {
+		spec["rootPass"] = redacted
+	}
+	snapshot["spec"] = spec
+
+	image := s.LinodeMachine.Spec.Image
+	if image == "" {
+		image = reconciler.DefaultMachineControllerLinodeImage
+	}
+	tags := slices.Clone(s.LinodeMachine.Spec.Tags)
+	if s.LinodeCluster != nil {
+		tags = append(tags, s.LinodeCluster.Name)
+	}
+	snapshot["resolved"] = map[string]any{
+		"region": s.LinodeMachine.Spec.Region,
+		"type":   s.LinodeMachine.Spec.Type,
+		"image":  image,
+		"tags":   tags,
+	}
+
+	credentialSource := "controller"
+	switch {
+	case s.LinodeMachine.Spec.CredentialsRef != nil:
+		credentialSource = "LinodeMachine secret " + secretRefName(s.LinodeMachine.Spec.CredentialsRef, s.LinodeMachine.Namespace)
+	case s.LinodeCluster != nil && s.LinodeCluster.Spec.CredentialsRef != nil:
+		credentialSource = "LinodeCluster secret " + secretRefName(s.LinodeCluster.Spec.CredentialsRef, s.LinodeCluster.Namespace)
+	}
+	snapshot["credentialSource"] = credentialSource
+
+	if s.LinodeMachine.Spec.ProviderID != nil {
+		snapshot["providerID"] = *s.LinodeMachine.Spec.ProviderID
+	}
+	if s.LinodeMachine.Spec.InstanceID != nil {
+		snapshot["instanceID"] = *s.LinodeMachine.Spec.InstanceID
+	}
+	if s.LinodeMachine.Status.InstanceState != nil {
+		snapshot["instanceState"] = string(*s.LinodeMachine.Status.InstanceState)
+	}
+	snapshot["ready"] = s.LinodeMachine.Status.Ready
+
+	return snapshot
+}
+
+// secretRefName returns the namespaced name of a secret reference, defaulting the namespace.
+func secretRefName(ref *corev1.SecretReference, defaultNamespace string) string {
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+
+	return namespace + "/" + ref.Name
+}
+
+// ComputeReadiness aggregates the signals that make up machine readiness: the
+// instance is running, its disks are ready, and (for control plane machines)
+// its DNS records are registered or its NodeBalancer backends are up. The
+// returned reasons describe every signal that is not yet satisfied.
+func (s *MachineScope) ComputeReadiness(ctx context.Context) (bool, []string, error) {
+	if s.LinodeMachine.Spec.InstanceID == nil {
+		return false, []string{"instance has not been created"}, nil
+	}
+	instanceID := *s.LinodeMachine.Spec.InstanceID
+
+	var reasons []string
+
+	instance, err := s.LinodeClient.GetInstance(ctx, instanceID)
+	if err != nil {
+		return false, nil, fmt.Errorf("get instance %d: %w", instanceID, err)
+	}
+	if instance.Status != linodego.InstanceRunning {
+		reasons = append(reasons, fmt.Sprintf("instance is %s", instance.Status))
+	}
+
+	disks, err := s.LinodeClient.ListInstanceDisks(ctx, instanceID, &linodego.ListOptions{})
+	if err != nil {
+		return false, nil, fmt.Errorf("list instance disks: %w", err)
+	}
+	for _, disk := range disks {
+		if disk.Status != linodego.DiskReady {
+			reasons = append(reasons, fmt.Sprintf("disk %s is %s", disk.Label, disk.Status))
+		}
+	}
+
+	if s.IsControlPlane() {
+		var lbReasons []string
+		if s.LinodeCluster.Spec.Network.LoadBalancerType == "dns" {
+			lbReasons, err = s.dnsReadiness(ctx)
+		} else {
+			lbReasons, err = s.nodeBalancerReadiness(ctx)
+		}
+		if err != nil {
+			return false, nil, err
+		}
+		reasons = append(reasons, lbReasons...)
+	}
+
+	return len(reasons) == 0, reasons, nil
+}
+
+// dnsReadiness reports the external addresses of the machine that are missing
+// from the control plane DNS entry.
+func (s *MachineScope) dnsReadiness(ctx context.Context) ([]string, error) {
+	hostname := ControlPlaneHostname(s.LinodeCluster)
+
+	var reasons []string
+	for _, addr := range s.LinodeMachine.Status.Addresses {
+		if addr.Type != clusterv1.MachineExternalIP {
+			continue
+		}
+		recordType := linodego.RecordTypeA
+		if ip, err := netip.ParseAddr(addr.Address); err == nil && !ip.Is4() {
+			recordType = linodego.RecordTypeAAAA
+		}
+
+		registered, err := s.dnsRecordRegistered(ctx, hostname, addr.Address, recordType)
+		if err != nil {
+			return nil, err
+		}
+		if !registered {
+			reasons = append(reasons, fmt.Sprintf("dns record %s for %s is not registered", recordType, addr.Address))
+		}
+	}
+
+	return reasons, nil
+}
+
+func (s *MachineScope) dnsRecordRegistered(ctx context.Context, hostname, target string, recordType linodego.DomainRecordType) (bool, error) {
+	rootDomain := s.LinodeCluster.Spec.Network.DNSRootDomain
+
+	if s.LinodeCluster.Spec.Network.DNSProvider == "akamai" {
+		record, err := s.AkamaiDomainsClient.GetRecord(ctx, rootDomain, hostname+"."+rootDomain, string(recordType))
+		if err != nil {
+			if strings.Contains(err.Error(), "Not Found") {
+				return false, nil
+			}
+			return false, fmt.Errorf("get akamai dns record: %w", err)
+		}
+		return slices.Contains(record.Target, target), nil
+	}
+
+	domainID, err := s.ValidateDNSZone(ctx)
+	if err != nil {
+		return false, err
+	}
+	filter, err := json.Marshal(map[string]interface{}{"name": hostname, "target": target, "type": recordType})
+	if err != nil {
+		return false, err
+	}
+	records, err := s.LinodeDomainsClient.ListDomainRecords(ctx, domainID, linodego.NewListOptions(0, string(filter)))
+	if err != nil {
+		return false, fmt.Errorf("list domain records: %w", err)
+	}
+
+	return len(records) != 0, nil
+}
+
+// nodeBalancerReadiness reports the NodeBalancer configs on which the machine
+// is not yet an UP backend.
+func (s *MachineScope) nodeBalancerReadiness(ctx context.Context) ([]string, error) {
+	network := s.LinodeCluster.Spec.Network
+	if network.NodeBalancerID == nil || network.ApiserverNodeBalancerConfigID == nil {
+		return []string{"nodebalancer is not yet configured"}, nil
+	}
+
+	configIDs := []int{*network.ApiserverNodeBalancerConfigID}
+	for _, port := range network.AdditionalPorts {
+		if port.NodeBalancerConfigID != nil {
+			configIDs = append(configIDs, *port.NodeBalancerConfigID)
+		}
+	}
+
+	var reasons []string
+	for _, configID := range configIDs {
+		nodes, err := s.LinodeClient.ListNodeBalancerNodes(ctx, *network.NodeBalancerID, configID, &linodego.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("list nodebalancer nodes: %w", err)
+		}
+
+		up := false
+		for _, node := range nodes {
+			if s.isBackendAddress(node.Address) && node.Status == "UP" {
+				up = true
+				break
+			}
+		}
+		if !up {
+			reasons = append(reasons, fmt.Sprintf("nodebalancer backend for config %d is not up", configID))
+		}
+	}
+
+	return reasons, nil
+}
+
+// isBackendAddress reports whether a NodeBalancer node address (ip:port) belongs to this machine.
+func (s *MachineScope) isBackendAddress(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	for _, addr := range s.LinodeMachine.Status.Addresses {
+		if addr.Type == clusterv1.MachineInternalIP && addr.Address == host {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,60 @@
+package scope
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+)
+
+// GetNoCloudSeed returns the bootstrap data as a NoCloud-style seed. The secret's
+// value is returned as user-data, and its metadata key as meta-data when present.
+// Otherwise a minimal meta-data document is synthesized from the LinodeMachine,
+// using its UID as an instance-id that stays stable across reconciles.
+func (m *MachineScope) GetNoCloudSeed(ctx context.Context) ([]byte, []byte, error) {
+	secret, err := m.getBootstrapSecret(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	userData, err := m.bootstrapValue(secret)
+	if err != nil {
+		return nil, nil, err
+	}
+	if metaData, ok := secret.Data["metadata"]; ok {
+		return userData, metaData, nil
+	}
+
+	metaData := fmt.Sprintf("instance-id: %s\nlocal-hostname: %s\n", m.LinodeMachine.UID, m.LinodeMachine.Name)
+
+	return userData, []byte(metaData), nil
+}
+
+// SetBootstrapChecksumAnnotation records a sha256 checksum of the bootstrap data on the
+// LinodeMachine and immediately patches the object, so bootstrap changes show up in diffs.
+// The bootstrap data already retrieved by the scope is used when available. Nothing is
+// patched when the annotation is already up to date.
+func (m *MachineScope) SetBootstrapChecksumAnnotation(ctx context.Context) error {
+	data := m.bootstrapData
+	if data == nil {
+		var err error
+		if data, err = m.GetBootstrapData(ctx); err != nil {
+			return err
+		}
+	}
+	sum := sha256.Sum256(data)
+	checksum := "sha256:" + hex.EncodeToString(sum[:])
+
+	annotations := m.LinodeMachine.GetAnnotations()
+	if annotations[infrav1alpha2.BootstrapChecksumAnnotation] == checksum {
+		return nil
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[infrav1alpha2.BootstrapChecksumAnnotation] = checksum
+	m.LinodeMachine.SetAnnotations(annotations)
+
+	return m.PatchHelper.Patch(ctx, m.LinodeMachine)
+}
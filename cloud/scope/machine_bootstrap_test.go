@@ -0,0 +1,190 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+
+	. "github.com/linode/cluster-api-provider-linode/mock/mocktest"
+)
+
+func TestMachineScopeGetNoCloudSeed(t *testing.T) {
+	t.Parallel()
+
+	newScope := func(mck Mock) MachineScope {
+		return MachineScope{
+			Client: mck.K8sClient,
+			Machine: &clusterv1.Machine{
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To("test-data"),
+					},
+				},
+			},
+			LinodeMachine: &infrav1alpha2.LinodeMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-machine", UID: "test-uid"},
+			},
+		}
+	}
+
+	NewSuite(t, mock.MockK8sClient{}).Run(
+		OneOf(
+			Path(
+				Call("secret has user-data only", func(ctx context.Context, mck Mock) {
+					mck.K8sClient.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, key client.ObjectKey, obj *corev1.Secret, opts ...client.GetOption) error {
+							*obj = corev1.Secret{Data: map[string][]byte{"value": []byte("#cloud-config")}}
+							return nil
+						})
+				}),
+				Result("meta-data is synthesized", func(ctx context.Context, mck Mock) {
+					mScope := newScope(mck)
+
+					userData, metaData, err := mScope.GetNoCloudSeed(ctx)
+					require.NoError(t, err)
+					assert.Equal(t, []byte("#cloud-config"), userData)
+					assert.Equal(t, []byte("instance-id: test-uid\nlocal-hostname: test-machine\n"), metaData)
+				}),
+			),
+			Path(
+				Call("secret has user-data and meta-data", func(ctx context.Context, mck Mock) {
+					mck.K8sClient.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, key client.ObjectKey, obj *corev1.Secret, opts ...client.GetOption) error {
+							*obj = corev1.Secret{Data: map[string][]byte{
+								"value":    []byte("#cloud-config"),
+								"metadata": []byte("instance-id: custom\n"),
+							}}
+							return nil
+						})
+				}),
+				Result("meta-data is passed through", func(ctx context.Context, mck Mock) {
+					mScope := newScope(mck)
+
+					userData, metaData, err := mScope.GetNoCloudSeed(ctx)
+					require.NoError(t, err)
+					assert.Equal(t, []byte("#cloud-config"), userData)
+					assert.Equal(t, []byte("instance-id: custom\n"), metaData)
+				}),
+			),
+			Path(
+				Call("secret is missing data", func(ctx context.Context, mck Mock) {
+					mck.K8sClient.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, key client.ObjectKey, obj *corev1.Secret, opts ...client.GetOption) error {
+							*obj = corev1.Secret{}
+							return nil
+						})
+				}),
+				Result("error", func(ctx context.Context, mck Mock) {
+					mScope := newScope(mck)
+
+					_, _, err := mScope.GetNoCloudSeed(ctx)
+					require.ErrorContains(t, err, "bootstrap data secret value key is missing")
+				}),
+			),
+		),
+	)
+}
+
+func TestMachineScopeSetBootstrapChecksumAnnotation(t *testing.T) {
+	t.Parallel()
+
+	// sha256 of "#cloud-config"
+	checksum := "sha256:a1b0542e7cce032c6d3eeca880fe4c7102c4b74b8ca101b1a85d720d0e62cd7f"
+
+	tests := []struct {
+		name          string
+		bootstrapData []byte
+		annotations   map[string]string
+		expects       func(k8s *mock.MockK8sClient)
+		expectedError string
+	}{
+		{
+			name:          "Annotation is added",
+			bootstrapData: []byte("#cloud-config"),
+			expects: func(k8s *mock.MockK8sClient) {
+				k8s.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:          "Annotation is up to date",
+			bootstrapData: []byte("#cloud-config"),
+			annotations:   map[string]string{infrav1alpha2.BootstrapChecksumAnnotation: checksum},
+			expects:       func(k8s *mock.MockK8sClient) {},
+		},
+		{
+			name: "Bootstrap data is fetched",
+			expects: func(k8s *mock.MockK8sClient) {
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, key client.ObjectKey, obj *corev1.Secret, opts ...client.GetOption) error {
+						*obj = corev1.Secret{Data: map[string][]byte{"value": []byte("#cloud-config")}}
+						return nil
+					})
+				k8s.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "Error - bootstrap secret is missing",
+			expects: func(k8s *mock.MockK8sClient) {
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("not found"))
+			},
+			expectedError: "failed to retrieve bootstrap data secret",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockK8sClient := mock.NewMockK8sClient(ctrl)
+			mockK8sClient.EXPECT().Scheme().DoAndReturn(func() *runtime.Scheme {
+				s := runtime.NewScheme()
+				infrav1alpha2.AddToScheme(s)
+				return s
+			}).AnyTimes()
+			testcase.expects(mockK8sClient)
+
+			linodeMachine := &infrav1alpha2.LinodeMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: "default", Annotations: testcase.annotations},
+			}
+			patchHelper, err := patch.NewHelper(linodeMachine, mockK8sClient)
+			require.NoError(t, err)
+
+			mScope := &MachineScope{
+				Client:      mockK8sClient,
+				PatchHelper: patchHelper,
+				Machine: &clusterv1.Machine{
+					Spec: clusterv1.MachineSpec{
+						Bootstrap: clusterv1.Bootstrap{DataSecretName: ptr.To("test-data")},
+					},
+				},
+				LinodeMachine: linodeMachine,
+				bootstrapData: testcase.bootstrapData,
+			}
+
+			err = mScope.SetBootstrapChecksumAnnotation(context.Background())
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, checksum, mScope.LinodeMachine.Annotations[infrav1alpha2.BootstrapChecksumAnnotation])
+		})
+	}
+}
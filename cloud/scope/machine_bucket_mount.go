@@ -0,0 +1,129 @@
+package scope
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// bucketMountPasswdFile is the s3fs credentials file written on the instance.
+const bucketMountPasswdFile = "/etc/passwd-s3fs"
+
+// BucketMount describes an Object Storage bucket mounted with s3fs on first boot.
+type BucketMount struct {
+	// BucketName is the name of the bucket.
+	BucketName string
+	// Region is the region of the bucket.
+	Region string
+	// MountPoint is the absolute path the bucket is mounted at.
+	MountPoint string
+	// ReadOnly mounts the bucket read-only, and only grants the key read access to it.
+	ReadOnly bool
+}
+
+// validateBucketMounts checks the mounts before they are written to fstab, where a malformed
+// entry would break the mounts following it.
+func validateBucketMounts(mounts []BucketMount) error {
+	seen := map[string]bool{}
+	for _, mount := range mounts {
+		if mount.BucketName == "" || mount.Region == "" {
+			return fmt.Errorf("bucket mount at %q requires a bucket name and region", mount.MountPoint)
+		}
+		if !path.IsAbs(mount.MountPoint) || path.Clean(mount.MountPoint) != mount.MountPoint || mount.MountPoint == "/" ||
+			strings.ContainsAny(mount.MountPoint, " \t\n") {
+			return fmt.Errorf("invalid mount point %q for bucket %s", mount.MountPoint, mount.BucketName)
+		}
+		if seen[mount.MountPoint] {
+			return fmt.Errorf("mount point %s is used by more than one bucket", mount.MountPoint)
+		}
+		seen[mount.MountPoint] = true
+	}
+
+	return nil
+}
+
+// BootstrapDataWithBucketMount returns the bootstrap data with a cloud-config part mounting the
+// Object Storage buckets with s3fs, using the machine's Object Storage key from
+// EnsureObjectStorageKey. The key is only written to a root-only s3fs credentials file on the
+// instance, and never included in errors. The bootstrap data is returned unchanged when no
+// bucket is given.
+func (s *MachineScope) BootstrapDataWithBucketMount(ctx context.Context, mounts []BucketMount) ([]byte, error) {
+	if len(mounts) == 0 {
+		return s.BootstrapUserData(ctx)
+	}
+	if err := validateBucketMounts(mounts); err != nil {
+		return nil, err
+	}
+
+	grants := make([]Grant, 0, len(mounts))
+	for _, mount := range mounts {
+		permissions := "read_write"
+		if mount.ReadOnly {
+			permissions = "read_only"
+		}
+		grants = append(grants, Grant{BucketName: mount.BucketName, Region: mount.Region, Permissions: permissions})
+	}
+	_, secretName, err := s.EnsureObjectStorageKey(ctx, grants)
+	if err != nil {
+		return nil, err
+	}
+	var secret corev1.Secret
+	if err := s.Client.Get(ctx, client.ObjectKey{Namespace: s.LinodeMachine.Namespace, Name: secretName}, &secret); err != nil {
+		return nil, fmt.Errorf("get object storage key secret %s: %w", secretName, err)
+	}
+	accessKey, secretKey := secret.Data["access_key"], secret.Data["secret_key"]
+	if len(accessKey) == 0 || len(secretKey) == 0 {
+		return nil, fmt.Errorf("object storage key secret %s is missing the access_key or secret_key", secretName)
+	}
+
+	fstab := make([][]string, 0, len(mounts))
+	runcmd := make([][]string, 0, len(mounts))
+	for _, mount := range mounts {
+		bucket, err := s.LinodeClient.GetObjectStorageBucket(ctx, mount.Region, mount.BucketName)
+		if err != nil {
+			return nil, fmt.Errorf("get object storage bucket %s: %w", mount.BucketName, err)
+		}
+		endpoint := strings.TrimPrefix(bucket.Hostname, mount.BucketName+".")
+		if endpoint == "" || endpoint == bucket.Hostname {
+			return nil, fmt.Errorf("object storage bucket %s has unexpected hostname %q", mount.BucketName, bucket.Hostname)
+		}
+		options := []string{"_netdev", "nofail", "allow_other", "use_path_request_style", "passwd_file=" + bucketMountPasswdFile, "url=https://" + endpoint}
+		if mount.ReadOnly {
+			options = append(options, "ro")
+		}
+		fstab = append(fstab, []string{mount.BucketName, mount.MountPoint, "fuse.s3fs", strings.Join(options, ","), "0", "0"})
+		// The mounts module runs before packages are installed, so mount again once s3fs is available
+		runcmd = append(runcmd, []string{"mount", mount.MountPoint})
+	}
+
+	document, err := yaml.Marshal(map[string]any{
+		"packages": []string{"s3fs"},
+		"write_files": []map[string]any{{
+			"path":        bucketMountPasswdFile,
+			"owner":       "root:root",
+			"permissions": "0600",
+			"content":     string(accessKey) + ":" + string(secretKey) + "\n",
+		}},
+		"mounts": fstab,
+		"runcmd": runcmd,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal bucket mount config: %w", err)
+	}
+	config := append([]byte("#cloud-config\n"), document...)
+
+	data, err := s.BootstrapUserData(ctx, UserDataPart{ContentType: "text/cloud-config", Filename: "bucket-mounts", Content: config})
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMultipartUserData(data); err != nil {
+		return nil, fmt.Errorf("invalid merged user-data: %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,162 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeBootstrapDataWithBucketMount(t *testing.T) {
+	t.Parallel()
+
+	access := []linodego.ObjectStorageKeyBucketAccess{
+		{BucketName: "logs", Region: "us-ord", Permissions: "read_write"},
+		{BucketName: "assets", Region: "us-ord", Permissions: "read_only"},
+	}
+	mounts := []BucketMount{
+		{BucketName: "logs", Region: "us-ord", MountPoint: "/mnt/logs"},
+		{BucketName: "assets", Region: "us-ord", MountPoint: "/srv/assets", ReadOnly: true},
+	}
+	keySecret := func(data map[string][]byte) func(context.Context, client.ObjectKey, client.Object, ...client.GetOption) error {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+			obj.(*corev1.Secret).Data = data
+			return nil
+		}
+	}
+	existingKey := func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+		mock.EXPECT().GetObjectStorageKey(gomock.Any(), 10).Return(&linodego.ObjectStorageKey{ID: 10, BucketAccess: &access}, nil)
+		k8s.EXPECT().Get(gomock.Any(), client.ObjectKey{Namespace: "default", Name: "test-machine-obj-key"}, gomock.Any()).Return(nil)
+	}
+
+	tests := []struct {
+		name          string
+		mounts        []BucketMount
+		expects       func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient)
+		wantParts     [][2]string
+		wantData      []byte
+		expectedError string
+	}{
+		{
+			name:     "No bucket mounts",
+			expects:  func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {},
+			wantData: []byte("#cloud-config\n"),
+		},
+		{
+			name:   "Bucket mounts merged as a cloud-config part",
+			mounts: mounts,
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				existingKey(mock, k8s)
+				k8s.EXPECT().Get(gomock.Any(), client.ObjectKey{Namespace: "default", Name: "test-machine-obj-key"}, gomock.Any()).
+					DoAndReturn(keySecret(map[string][]byte{"access_key": []byte("access"), "secret_key": []byte("secret")}))
+				mock.EXPECT().GetObjectStorageBucket(gomock.Any(), "us-ord", "logs").
+					Return(&linodego.ObjectStorageBucket{Label: "logs", Hostname: "logs.us-ord-1.linodeobjects.com"}, nil)
+				mock.EXPECT().GetObjectStorageBucket(gomock.Any(), "us-ord", "assets").
+					Return(&linodego.ObjectStorageBucket{Label: "assets", Hostname: "assets.us-ord-1.linodeobjects.com"}, nil)
+			},
+			wantParts: [][2]string{
+				{"text/cloud-config", "#cloud-config\n"},
+				{"text/cloud-config", "#cloud-config\n" +
+					"mounts:\n" +
+					"- - logs\n  - /mnt/logs\n  - fuse.s3fs\n" +
+					"  - _netdev,nofail,allow_other,use_path_request_style,passwd_file=/etc/passwd-s3fs,url=https://us-ord-1.linodeobjects.com\n" +
+					"  - \"0\"\n  - \"0\"\n" +
+					"- - assets\n  - /srv/assets\n  - fuse.s3fs\n" +
+					"  - _netdev,nofail,allow_other,use_path_request_style,passwd_file=/etc/passwd-s3fs,url=https://us-ord-1.linodeobjects.com,ro\n" +
+					"  - \"0\"\n  - \"0\"\n" +
+					"packages:\n- s3fs\n" +
+					"runcmd:\n- - mount\n  - /mnt/logs\n- - mount\n  - /srv/assets\n" +
+					"write_files:\n- content: |\n    access:secret\n  owner: root:root\n  path: /etc/passwd-s3fs\n  permissions: \"0600\"\n"},
+			},
+		},
+		{
+			name:          "Error - relative mount point",
+			mounts:        []BucketMount{{BucketName: "logs", Region: "us-ord", MountPoint: "mnt/logs"}},
+			expects:       func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {},
+			expectedError: `invalid mount point "mnt/logs" for bucket logs`,
+		},
+		{
+			name: "Error - duplicate mount point",
+			mounts: []BucketMount{
+				{BucketName: "logs", Region: "us-ord", MountPoint: "/mnt/data"},
+				{BucketName: "assets", Region: "us-ord", MountPoint: "/mnt/data"},
+			},
+			expects:       func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {},
+			expectedError: "mount point /mnt/data is used by more than one bucket",
+		},
+		{
+			name:          "Error - missing region",
+			mounts:        []BucketMount{{BucketName: "logs", MountPoint: "/mnt/logs"}},
+			expects:       func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {},
+			expectedError: `bucket mount at "/mnt/logs" requires a bucket name and region`,
+		},
+		{
+			name:   "Error - key secret without credentials",
+			mounts: mounts,
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				existingKey(mock, k8s)
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(keySecret(map[string][]byte{"access_key": []byte("access")}))
+			},
+			expectedError: "object storage key secret test-machine-obj-key is missing the access_key or secret_key",
+		},
+		{
+			name:   "Error - get bucket",
+			mounts: mounts,
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				existingKey(mock, k8s)
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(keySecret(map[string][]byte{"access_key": []byte("access"), "secret_key": []byte("secret")}))
+				mock.EXPECT().GetObjectStorageBucket(gomock.Any(), "us-ord", "logs").Return(nil, errors.New("not found"))
+			},
+			expectedError: "get object storage bucket logs: not found",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			mockK8sClient := mock.NewMockK8sClient(ctrl)
+			testcase.expects(mockLinodeClient, mockK8sClient)
+
+			mScope := &MachineScope{
+				Client:       mockK8sClient,
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: "default"},
+					Status:     infrav1alpha2.LinodeMachineStatus{ObjectStorageKeyID: ptr.To(10)},
+				},
+				bootstrapData: []byte("#cloud-config\n"),
+			}
+
+			data, err := mScope.BootstrapDataWithBucketMount(context.Background(), testcase.mounts)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				assert.NotContains(t, err.Error(), "access:secret")
+				return
+			}
+			require.NoError(t, err)
+			if testcase.wantParts != nil {
+				assert.Equal(t, testcase.wantParts, parseMultipartUserData(t, data))
+			} else {
+				assert.Equal(t, testcase.wantData, data)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package scope
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/linode/linodego"
+
+	. "github.com/linode/cluster-api-provider-linode/clients"
+)
+
+// childAccountTokenMargin is how long before its expiry a cached child account token is replaced,
+// so it does not expire during a reconcile.
+const childAccountTokenMargin = 5 * time.Minute
+
+type childAccountToken struct {
+	token  string
+	expiry time.Time
+}
+
+// childAccountTokens holds a childAccountToken per parent token and child account, keyed by
+// their hash so the parent token itself is never retained.
+var childAccountTokens sync.Map
+
+// childAccountAPIKey returns a token of the child account with the EUUID, created with the
+// parent account's client. Tokens are cached until shortly before they expire, since every
+// request for a new one creates a token on the child account.
+func childAccountAPIKey(ctx context.Context, parent LinodeClient, parentKey, euuid string) (string, error) {
+	sum := sha256.Sum256([]byte(parentKey + "/" + euuid))
+	key := hex.EncodeToString(sum[:])
+	if cached, ok := childAccountTokens.Load(key); ok {
+		if token := cached.(childAccountToken); time.Now().Add(childAccountTokenMargin).Before(token.expiry) {
+			return token.token, nil
+		}
+	}
+
+	token, err := parent.CreateChildAccountToken(ctx, euuid)
+	if err != nil {
+		if linodego.ErrHasStatus(err, http.StatusUnauthorized, http.StatusForbidden) {
+			return "", fmt.Errorf("credentials are not allowed to act on child account %s, they must belong to a parent account user with child account access: %w", euuid, err)
+		}
+		if linodego.ErrHasStatus(err, http.StatusNotFound) {
+			return "", fmt.Errorf("child account %s does not exist under the parent account: %w", euuid, err)
+		}
+		return "", fmt.Errorf("create token for child account %s: %w", euuid, err)
+	}
+	if token.Token == "" {
+		return "", fmt.Errorf("no token returned for child account %s", euuid)
+	}
+	if token.Expiry != nil {
+		childAccountTokens.Store(key, childAccountToken{token: token.Token, expiry: *token.Expiry})
+	}
+
+	return token.Token, nil
+}
@@ -0,0 +1,103 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"k8s.io/utils/ptr"
+
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestChildAccountAPIKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		expects       func(mock *mock.MockLinodeClient)
+		wantToken     string
+		wantCalls     int
+		expectedError string
+	}{
+		{
+			name: "Token is created and cached",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().CreateChildAccountToken(gomock.Any(), "child-euuid").
+					Return(&linodego.ChildAccountToken{Token: "child-token", Expiry: ptr.To(time.Now().Add(15 * time.Minute))}, nil)
+			},
+			wantToken: "child-token",
+			wantCalls: 2,
+		},
+		{
+			name: "Expiring token is replaced",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().CreateChildAccountToken(gomock.Any(), "child-euuid").
+					Return(&linodego.ChildAccountToken{Token: "child-token", Expiry: ptr.To(time.Now().Add(time.Minute))}, nil).Times(2)
+			},
+			wantToken: "child-token",
+			wantCalls: 2,
+		},
+		{
+			name: "Error - not allowed to act on the child account",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().CreateChildAccountToken(gomock.Any(), "child-euuid").
+					Return(nil, &linodego.Error{Code: http.StatusUnauthorized, Message: "Invalid Token"})
+			},
+			expectedError: "credentials are not allowed to act on child account child-euuid",
+		},
+		{
+			name: "Error - child account does not exist",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().CreateChildAccountToken(gomock.Any(), "child-euuid").
+					Return(nil, &linodego.Error{Code: http.StatusNotFound, Message: "Not found"})
+			},
+			expectedError: "child account child-euuid does not exist under the parent account",
+		},
+		{
+			name: "Error - token creation fails",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().CreateChildAccountToken(gomock.Any(), "child-euuid").Return(nil, errors.New("api error"))
+			},
+			expectedError: "create token for child account child-euuid: api error",
+		},
+		{
+			name: "Error - empty token",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().CreateChildAccountToken(gomock.Any(), "child-euuid").Return(&linodego.ChildAccountToken{}, nil)
+			},
+			expectedError: "no token returned for child account child-euuid",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			// The parent key is unique per test case, as tokens are cached per parent key.
+			parentKey := "parent-" + testcase.name
+			if testcase.expectedError != "" {
+				_, err := childAccountAPIKey(context.Background(), mockLinodeClient, parentKey, "child-euuid")
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			for range testcase.wantCalls {
+				token, err := childAccountAPIKey(context.Background(), mockLinodeClient, parentKey, "child-euuid")
+				require.NoError(t, err)
+				assert.Equal(t, testcase.wantToken, token)
+			}
+		})
+	}
+}
@@ -0,0 +1,380 @@
+package scope
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/linode/linodego"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+)
+
+// ReconcileBootConfig ensures the config profile with the given label is the one the
+// instance boots by default. The profile ID is recorded on the LinodeMachine, so that
+// ScheduleReboot and ApplyConfigChangesWithReboot reboot the instance into it. An offline
+// instance is booted into the profile once it is recorded. A running instance is checked
+// against the profile its most recent boot used, and is only rebooted into the named
+// profile through ScheduleReboot, so the reboot waits for the maintenance window.
+func (s *MachineScope) ReconcileBootConfig(ctx context.Context, instanceID int, configLabel string) error {
+	configs, err := s.LinodeClient.ListInstanceConfigs(ctx, instanceID, &linodego.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("list instance configs: %w", err)
+	}
+
+	var config *linodego.InstanceConfig
+	for i := range configs {
+		if configs[i].Label == configLabel {
+			config = &configs[i]
+			break
+		}
+	}
+	if config == nil {
+		return fmt.Errorf("config profile %q does not exist on instance %d", configLabel, instanceID)
+	}
+
+	configID := strconv.Itoa(config.ID)
+	recorded := s.LinodeMachine.Annotations[infrav1alpha2.BootConfigAnnotation] == configID
+	if !recorded {
+		if s.LinodeMachine.Annotations == nil {
+			s.LinodeMachine.Annotations = map[string]string{}
+		}
+		s.LinodeMachine.Annotations[infrav1alpha2.BootConfigAnnotation] = configID
+	}
+
+	instance, err := s.LinodeClient.GetInstance(ctx, instanceID)
+	if err != nil {
+		return fmt.Errorf("get instance %d: %w", instanceID, err)
+	}
+	switch instance.Status {
+	case linodego.InstanceRunning:
+		runningConfigID, err := s.runningConfigID(ctx, instanceID)
+		if err != nil {
+			return err
+		}
+		if runningConfigID == config.ID || (runningConfigID == 0 && recorded && s.LinodeMachine.Status.RebootPendingSince == nil) {
+			return nil
+		}
+		if err := s.ScheduleReboot(ctx); err != nil {
+			return fmt.Errorf("reboot instance %d into config profile %q: %w", instanceID, configLabel, err)
+		}
+	case linodego.InstanceOffline:
+		if recorded {
+			return nil
+		}
+		if err := s.LinodeClient.BootInstance(ctx, instanceID, config.ID); err != nil {
+			return fmt.Errorf("boot instance %d into config profile %q: %w", instanceID, configLabel, err)
+		}
+	default:
+		return fmt.Errorf("instance %d is %s, cannot boot into config profile %q yet", instanceID, instance.Status, configLabel)
+	}
+
+	return nil
+}
+
+// runningConfigID returns the ID of the config profile the instance was last booted into,
+// which Linode records as the secondary entity of boot and reboot events. It returns 0 when
+// the instance has no such recent event.
+func (s *MachineScope) runningConfigID(ctx context.Context, instanceID int) (int, error) {
+	events, err := s.bootEvents(ctx, instanceID)
+	if err != nil {
+		return 0, err
+	}
+	for _, event := range events {
+		if event.SecondaryEntity == nil {
+			continue
+		}
+		switch id := event.SecondaryEntity.ID.(type) {
+		case float64:
+			return int(id), nil
+		case int:
+			return id, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// bootedSince reports whether the instance was booted or rebooted after the given time.
+func (s *MachineScope) bootedSince(ctx context.Context, instanceID int, since time.Time) (bool, error) {
+	events, err := s.bootEvents(ctx, instanceID)
+	if err != nil {
+		return false, err
+	}
+
+	return len(events) > 0 && events[0].Created != nil && events[0].Created.After(since), nil
+}
+
+// bootEvents returns the instance's most recent boot and reboot events, newest first.
+func (s *MachineScope) bootEvents(ctx context.Context, instanceID int) ([]linodego.Event, error) {
+	filter, err := json.Marshal(map[string]any{
+		"entity.id":   instanceID,
+		"entity.type": "linode",
+		"+or":         []map[string]any{{"action": linodego.ActionLinodeBoot}, {"action": linodego.ActionLinodeReboot}},
+		"+order_by":   "created",
+		"+order":      "desc",
+	})
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.LinodeClient.ListEvents(ctx, &linodego.ListOptions{
+		PageOptions: &linodego.PageOptions{Page: 1},
+		Filter:      string(filter),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list instance %d boot events: %w", instanceID, err)
+	}
+
+	return events, nil
+}
+
+// bootConfigID returns the ID of the config profile recorded by ReconcileBootConfig, or 0 to
+// boot the instance into the profile it was last booted into.
+func (s *MachineScope) bootConfigID() int {
+	configID, err := strconv.Atoi(s.LinodeMachine.Annotations[infrav1alpha2.BootConfigAnnotation])
+	if err != nil {
+		return 0
+	}
+
+	return configID
+}
+
+// ReconcileKernel sets the kernel of the instance's boot config profile, e.g. to
+// linode/direct-disk for images which boot with their own bootloader. The boot config
+// profile is the one recorded by ReconcileBootConfig, or the first profile otherwise.
+// The kernel only takes effect the next time the instance boots. It reports whether the
+// kernel was changed.
+func (s *MachineScope) ReconcileKernel(ctx context.Context, instanceID int, kernel string) (bool, error) {
+	if kernel == "" {
+		return false, nil
+	}
+
+	configs, err := s.LinodeClient.ListInstanceConfigs(ctx, instanceID, &linodego.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("list instance configs: %w", err)
+	}
+
+	config, err := s.bootConfig(configs, instanceID)
+	if err != nil {
+		return false, err
+	}
+	if config.Kernel == kernel {
+		return false, nil
+	}
+
+	if _, err := s.LinodeClient.GetKernel(ctx, kernel); err != nil {
+		return false, fmt.Errorf("get kernel %s: %w", kernel, err)
+	}
+
+	if _, err := s.LinodeClient.UpdateInstanceConfig(ctx, instanceID, config.ID, linodego.InstanceConfigUpdateOptions{Kernel: kernel}); err != nil {
+		return false, fmt.Errorf("update instance config %d kernel: %w", config.ID, err)
+	}
+
+	return true, nil
+}
+
+// HelpersSpec is the desired state of the helpers of a config profile. Unset helpers are
+// left as they are.
+type HelpersSpec struct {
+	// UpdateDBDisabled disables updatedb cron jobs, which index the filesystem.
+	UpdateDBDisabled *bool
+	// Distro enables the distribution specific boot helper.
+	Distro *bool
+	// ModulesDep creates a modules dependency file for the kernel.
+	ModulesDep *bool
+	// Network configures networking automatically on boot.
+	Network *bool
+	// DevTmpFsAutomount mounts devtmpfs on boot.
+	DevTmpFsAutomount *bool
+}
+
+// apply sets the helpers of the spec on the config profile helpers.
+func (h HelpersSpec) apply(helpers *linodego.InstanceConfigHelpers) {
+	for _, flag := range []struct {
+		desired *bool
+		current *bool
+	}{
+		{h.UpdateDBDisabled, &helpers.UpdateDBDisabled},
+		{h.Distro, &helpers.Distro},
+		{h.ModulesDep, &helpers.ModulesDep},
+		{h.Network, &helpers.Network},
+		{h.DevTmpFsAutomount, &helpers.DevTmpFsAutomount},
+	} {
+		if flag.desired != nil {
+			*flag.current = *flag.desired
+		}
+	}
+}
+
+// ReconcileConfigHelpers sets the helpers of the config profile to the helpers spec, e.g. to
+// disable the network helper on nodes which configure networking themselves. A config ID of
+// 0 selects the instance's boot config profile. The config profile is only updated when its
+// helpers have drifted from the spec, and it reports whether it was. Helpers only take effect
+// the next time the instance boots.
+func (s *MachineScope) ReconcileConfigHelpers(ctx context.Context, instanceID, configID int, helpers HelpersSpec) (bool, error) {
+	config, err := s.configProfile(ctx, instanceID, configID)
+	if err != nil {
+		return false, err
+	}
+
+	current := linodego.InstanceConfigHelpers{}
+	if config.Helpers != nil {
+		current = *config.Helpers
+	}
+	desired := current
+	helpers.apply(&desired)
+	if desired == current {
+		return false, nil
+	}
+
+	if _, err := s.LinodeClient.UpdateInstanceConfig(ctx, instanceID, config.ID, linodego.InstanceConfigUpdateOptions{Helpers: &desired}); err != nil {
+		return false, fmt.Errorf("update instance config %d helpers: %w", config.ID, err)
+	}
+
+	return true, nil
+}
+
+// DeviceMap maps device names, sda to sdh, to the disk or volume of the device. A device
+// with neither a disk nor a volume is cleared.
+type DeviceMap map[string]linodego.InstanceConfigDevice
+
+// DeviceMapFromSpec returns the device map of the devices set in the spec.
+func DeviceMapFromSpec(devices *infrav1alpha2.InstanceConfigDevices) DeviceMap {
+	if devices == nil {
+		return nil
+	}
+
+	deviceMap := DeviceMap{}
+	for name, device := range map[string]*infrav1alpha2.InstanceConfigDevice{
+		"sda": devices.SDA, "sdb": devices.SDB, "sdc": devices.SDC, "sdd": devices.SDD,
+		"sde": devices.SDE, "sdf": devices.SDF, "sdg": devices.SDG, "sdh": devices.SDH,
+	} {
+		if device != nil {
+			deviceMap[name] = linodego.InstanceConfigDevice{DiskID: device.DiskID, VolumeID: device.VolumeID}
+		}
+	}
+
+	return deviceMap
+}
+
+// ReconcileConfigDevices sets the devices of the config profile to the disks and volumes of
+// the device map, leaving the devices not in the map as they are. A config ID of 0 selects
+// the instance's boot config profile. When the devices have drifted, the referenced disks
+// must exist on the instance and the referenced volumes must not be attached to another
+// instance before the config profile is updated. It reports whether it was updated.
+func (s *MachineScope) ReconcileConfigDevices(ctx context.Context, instanceID, configID int, devices DeviceMap) (bool, error) {
+	if len(devices) == 0 {
+		return false, nil
+	}
+
+	names := make([]string, 0, len(devices))
+	for name := range devices {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for i, name := range names {
+		device := devices[name]
+		if configDevice(&linodego.InstanceConfigDeviceMap{}, name) == nil {
+			return false, fmt.Errorf("unknown device %q", name)
+		}
+		if device.DiskID != 0 && device.VolumeID != 0 {
+			return false, fmt.Errorf("device %s must reference either a disk or a volume", name)
+		}
+		for _, other := range names[:i] {
+			if (device.DiskID != 0 && devices[other].DiskID == device.DiskID) || (device.VolumeID != 0 && devices[other].VolumeID == device.VolumeID) {
+				return false, fmt.Errorf("devices %s and %s reference the same disk or volume", other, name)
+			}
+		}
+	}
+
+	config, err := s.configProfile(ctx, instanceID, configID)
+	if err != nil {
+		return false, err
+	}
+	current := linodego.InstanceConfigDeviceMap{}
+	if config.Devices != nil {
+		current = *config.Devices
+	}
+	desired := current
+	for _, name := range names {
+		device := devices[name]
+		slot := configDevice(&desired, name)
+		if device.DiskID == 0 && device.VolumeID == 0 {
+			*slot = nil
+		} else {
+			*slot = &device
+		}
+	}
+	if reflect.DeepEqual(desired, current) {
+		return false, nil
+	}
+
+	var disks []linodego.InstanceDisk
+	for _, name := range names {
+		device := devices[name]
+		switch {
+		case device.DiskID != 0:
+			if disks == nil {
+				if disks, err = s.LinodeClient.ListInstanceDisks(ctx, instanceID, &linodego.ListOptions{}); err != nil {
+					return false, fmt.Errorf("list instance disks: %w", err)
+				}
+			}
+			if !slices.ContainsFunc(disks, func(disk linodego.InstanceDisk) bool { return disk.ID == device.DiskID }) {
+				return false, fmt.Errorf("disk %d of device %s does not exist on instance %d", device.DiskID, name, instanceID)
+			}
+		case device.VolumeID != 0:
+			volume, err := s.LinodeClient.GetVolume(ctx, device.VolumeID)
+			if err != nil {
+				return false, fmt.Errorf("get volume %d of device %s: %w", device.VolumeID, name, err)
+			}
+			if volume.LinodeID != nil && *volume.LinodeID != instanceID {
+				return false, fmt.Errorf("volume %d of device %s is attached to instance %d", device.VolumeID, name, *volume.LinodeID)
+			}
+		}
+	}
+
+	if _, err := s.LinodeClient.UpdateInstanceConfig(ctx, instanceID, config.ID, linodego.InstanceConfigUpdateOptions{Devices: &desired}); err != nil {
+		return false, fmt.Errorf("update instance config %d devices: %w", config.ID, err)
+	}
+
+	return true, nil
+}
+
+// configProfile returns the config profile with the ID, or the boot config profile if the ID is 0.
+func (s *MachineScope) configProfile(ctx context.Context, instanceID, configID int) (*linodego.InstanceConfig, error) {
+	configs, err := s.LinodeClient.ListInstanceConfigs(ctx, instanceID, &linodego.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list instance configs: %w", err)
+	}
+	if configID == 0 {
+		return s.bootConfig(configs, instanceID)
+	}
+	idx := slices.IndexFunc(configs, func(c linodego.InstanceConfig) bool { return c.ID == configID })
+	if idx < 0 {
+		return nil, fmt.Errorf("config profile %d does not exist on instance %d", configID, instanceID)
+	}
+
+	return &configs[idx], nil
+}
+
+// bootConfig returns the config profile recorded by ReconcileBootConfig, or the first
+// config profile otherwise.
+func (s *MachineScope) bootConfig(configs []linodego.InstanceConfig, instanceID int) (*linodego.InstanceConfig, error) {
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("instance %d has no config profiles", instanceID)
+	}
+	bootConfigID, ok := s.LinodeMachine.Annotations[infrav1alpha2.BootConfigAnnotation]
+	if !ok {
+		return &configs[0], nil
+	}
+	idx := slices.IndexFunc(configs, func(c linodego.InstanceConfig) bool { return strconv.Itoa(c.ID) == bootConfigID })
+	if idx < 0 {
+		return nil, fmt.Errorf("boot config profile %s does not exist on instance %d", bootConfigID, instanceID)
+	}
+
+	return &configs[idx], nil
+}
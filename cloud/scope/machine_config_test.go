@@ -0,0 +1,499 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeReconcileBootConfig(t *testing.T) {
+	t.Parallel()
+
+	configs := []linodego.InstanceConfig{{ID: 1, Label: "normal"}, {ID: 2, Label: "rescue"}}
+	bootedInto := func(configID int) []linodego.Event {
+		return []linodego.Event{
+			{Action: linodego.ActionLinodeBoot, SecondaryEntity: &linodego.EventEntity{ID: float64(configID), Label: "config"}},
+		}
+	}
+	running := &linodego.Instance{ID: 123, Status: linodego.InstanceRunning}
+	closedWindow := &infrav1alpha2.TimeWindow{
+		Start:    time.Now().UTC().Add(12 * time.Hour).Format("15:04"),
+		Duration: metav1.Duration{Duration: time.Minute},
+	}
+
+	tests := []struct {
+		name              string
+		annotations       map[string]string
+		maintenanceWindow *infrav1alpha2.TimeWindow
+		expects           func(mock *mock.MockLinodeClient)
+		wantAnnotation    string
+		wantRebootPending bool
+		expectedError     string
+	}{
+		{
+			name: "Reboot running instance into the named config",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(running, nil)
+				mock.EXPECT().ListEvents(gomock.Any(), &linodego.ListOptions{
+					PageOptions: &linodego.PageOptions{Page: 1},
+					Filter:      `{"+or":[{"action":"linode_boot"},{"action":"linode_reboot"}],"+order":"desc","+order_by":"created","entity.id":123,"entity.type":"linode"}`,
+				}).Return(bootedInto(1), nil)
+				mock.EXPECT().RebootInstance(gomock.Any(), 123, 2).Return(nil)
+			},
+			wantAnnotation: "2",
+		},
+		{
+			name:              "Defer reboot into the named config to the maintenance window",
+			maintenanceWindow: closedWindow,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(running, nil)
+				mock.EXPECT().ListEvents(gomock.Any(), gomock.Any()).Return(bootedInto(1), nil)
+			},
+			wantAnnotation:    "2",
+			wantRebootPending: true,
+		},
+		{
+			name:        "Running instance already booted into the named config",
+			annotations: map[string]string{infrav1alpha2.BootConfigAnnotation: "1"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(running, nil)
+				mock.EXPECT().ListEvents(gomock.Any(), gomock.Any()).Return(bootedInto(2), nil)
+			},
+			wantAnnotation: "2",
+		},
+		{
+			name:        "Reboot running instance booted into another config since it was recorded",
+			annotations: map[string]string{infrav1alpha2.BootConfigAnnotation: "2"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(running, nil)
+				mock.EXPECT().ListEvents(gomock.Any(), gomock.Any()).Return(bootedInto(1), nil)
+				mock.EXPECT().RebootInstance(gomock.Any(), 123, 2).Return(nil)
+			},
+			wantAnnotation: "2",
+		},
+		{
+			name:        "Recorded config of a running instance without boot events",
+			annotations: map[string]string{infrav1alpha2.BootConfigAnnotation: "2"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(running, nil)
+				mock.EXPECT().ListEvents(gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+			wantAnnotation: "2",
+		},
+		{
+			name:        "Boot offline instance into the named config",
+			annotations: map[string]string{infrav1alpha2.BootConfigAnnotation: "1"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceOffline}, nil)
+				mock.EXPECT().BootInstance(gomock.Any(), 123, 2).Return(nil)
+			},
+			wantAnnotation: "2",
+		},
+		{
+			name:        "Recorded config of an offline instance",
+			annotations: map[string]string{infrav1alpha2.BootConfigAnnotation: "2"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceOffline}, nil)
+			},
+			wantAnnotation: "2",
+		},
+		{
+			name: "Error - named config does not exist",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs[:1], nil)
+			},
+			expectedError: "config profile \"rescue\" does not exist on instance 123",
+		},
+		{
+			name: "Error - instance is busy",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceRebooting}, nil)
+			},
+			expectedError: "instance 123 is rebooting",
+		},
+		{
+			name: "Error - list boot events fails",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(running, nil)
+				mock.EXPECT().ListEvents(gomock.Any(), gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "list instance 123 boot events: api error",
+		},
+		{
+			name: "Error - reboot fails",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(running, nil)
+				mock.EXPECT().ListEvents(gomock.Any(), gomock.Any()).Return(bootedInto(1), nil)
+				mock.EXPECT().RebootInstance(gomock.Any(), 123, 2).Return(errors.New("api error"))
+			},
+			expectedError: "api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{Annotations: testcase.annotations},
+					Spec: infrav1alpha2.LinodeMachineSpec{
+						InstanceID:        ptr.To(123),
+						MaintenanceWindow: testcase.maintenanceWindow,
+					},
+				},
+			}
+
+			err := mScope.ReconcileBootConfig(context.Background(), 123, "rescue")
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantAnnotation, mScope.LinodeMachine.Annotations[infrav1alpha2.BootConfigAnnotation])
+			assert.Equal(t, testcase.wantRebootPending, mScope.LinodeMachine.Status.RebootPendingSince != nil)
+		})
+	}
+}
+
+func TestMachineScopeReconcileKernel(t *testing.T) {
+	t.Parallel()
+
+	configs := []linodego.InstanceConfig{{ID: 1, Kernel: "linode/grub2"}, {ID: 2, Kernel: "linode/direct-disk"}}
+
+	tests := []struct {
+		name          string
+		kernel        string
+		annotations   map[string]string
+		expects       func(mock *mock.MockLinodeClient)
+		wantChanged   bool
+		expectedError string
+	}{
+		{
+			name:    "No kernel requested",
+			kernel:  "",
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:   "Update kernel of the first config",
+			kernel: "linode/direct-disk",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().GetKernel(gomock.Any(), "linode/direct-disk").Return(&linodego.LinodeKernel{ID: "linode/direct-disk"}, nil)
+				mock.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 1, linodego.InstanceConfigUpdateOptions{Kernel: "linode/direct-disk"}).Return(&linodego.InstanceConfig{}, nil)
+			},
+			wantChanged: true,
+		},
+		{
+			name:        "Boot config already uses the kernel",
+			kernel:      "linode/direct-disk",
+			annotations: map[string]string{infrav1alpha2.BootConfigAnnotation: "2"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+			},
+		},
+		{
+			name:        "Error - boot config does not exist",
+			kernel:      "linode/direct-disk",
+			annotations: map[string]string{infrav1alpha2.BootConfigAnnotation: "3"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+			},
+			expectedError: "boot config profile 3 does not exist on instance 123",
+		},
+		{
+			name:   "Error - no config profiles",
+			kernel: "linode/direct-disk",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(nil, nil)
+			},
+			expectedError: "instance 123 has no config profiles",
+		},
+		{
+			name:   "Error - kernel does not exist",
+			kernel: "linode/bogus",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().GetKernel(gomock.Any(), "linode/bogus").Return(nil, &linodego.Error{Code: 404})
+			},
+			expectedError: "get kernel linode/bogus",
+		},
+		{
+			name:   "Error - update fails",
+			kernel: "linode/direct-disk",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().GetKernel(gomock.Any(), "linode/direct-disk").Return(&linodego.LinodeKernel{ID: "linode/direct-disk"}, nil)
+				mock.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 1, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{Annotations: testcase.annotations},
+				},
+			}
+
+			changed, err := mScope.ReconcileKernel(context.Background(), 123, testcase.kernel)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantChanged, changed)
+		})
+	}
+}
+
+func TestMachineScopeReconcileConfigHelpers(t *testing.T) {
+	t.Parallel()
+
+	helpers := &linodego.InstanceConfigHelpers{Distro: true, ModulesDep: true, Network: true, DevTmpFsAutomount: true}
+	configs := []linodego.InstanceConfig{{ID: 1, Helpers: helpers}, {ID: 2, Helpers: helpers}}
+
+	tests := []struct {
+		name          string
+		configID      int
+		helpers       HelpersSpec
+		expects       func(mock *mock.MockLinodeClient)
+		wantChanged   bool
+		expectedError string
+	}{
+		{
+			name:    "Helpers already match",
+			helpers: HelpersSpec{Network: ptr.To(true), UpdateDBDisabled: ptr.To(false)},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+			},
+		},
+		{
+			name:     "Update drifted helpers of the config",
+			configID: 2,
+			helpers:  HelpersSpec{Network: ptr.To(false), UpdateDBDisabled: ptr.To(true)},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 2, linodego.InstanceConfigUpdateOptions{
+					Helpers: &linodego.InstanceConfigHelpers{UpdateDBDisabled: true, Distro: true, ModulesDep: true, DevTmpFsAutomount: true},
+				}).Return(&linodego.InstanceConfig{}, nil)
+			},
+			wantChanged: true,
+		},
+		{
+			name:    "Boot config without helpers",
+			helpers: HelpersSpec{DevTmpFsAutomount: ptr.To(true)},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceConfig{{ID: 1}}, nil)
+				mock.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 1, linodego.InstanceConfigUpdateOptions{
+					Helpers: &linodego.InstanceConfigHelpers{DevTmpFsAutomount: true},
+				}).Return(&linodego.InstanceConfig{}, nil)
+			},
+			wantChanged: true,
+		},
+		{
+			name:     "Error - config does not exist",
+			configID: 3,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+			},
+			expectedError: "config profile 3 does not exist on instance 123",
+		},
+		{
+			name:    "Error - update fails",
+			helpers: HelpersSpec{Distro: ptr.To(false)},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 1, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "update instance config 1 helpers: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient:  mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{},
+			}
+
+			changed, err := mScope.ReconcileConfigHelpers(context.Background(), 123, testcase.configID, testcase.helpers)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantChanged, changed)
+		})
+	}
+}
+
+func TestMachineScopeReconcileConfigDevices(t *testing.T) {
+	t.Parallel()
+
+	configs := func(devices linodego.InstanceConfigDeviceMap) []linodego.InstanceConfig {
+		return []linodego.InstanceConfig{{ID: 9, Devices: &devices}}
+	}
+	rootOnly := linodego.InstanceConfigDeviceMap{SDA: &linodego.InstanceConfigDevice{DiskID: 1}, SDB: &linodego.InstanceConfigDevice{DiskID: 2}}
+
+	tests := []struct {
+		name          string
+		devices       DeviceMap
+		expects       func(mock *mock.MockLinodeClient)
+		wantChanged   bool
+		expectedError string
+	}{
+		{
+			name:    "No devices",
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:    "Map a disk and a volume and clear a device",
+			devices: DeviceMap{"sdb": {}, "sdc": {DiskID: 3}, "sdd": {VolumeID: 7}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs(rootOnly), nil)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceDisk{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+				mock.EXPECT().GetVolume(gomock.Any(), 7).Return(&linodego.Volume{ID: 7}, nil)
+				mock.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 9, linodego.InstanceConfigUpdateOptions{Devices: &linodego.InstanceConfigDeviceMap{
+					SDA: &linodego.InstanceConfigDevice{DiskID: 1},
+					SDC: &linodego.InstanceConfigDevice{DiskID: 3},
+					SDD: &linodego.InstanceConfigDevice{VolumeID: 7},
+				}}).Return(&linodego.InstanceConfig{}, nil)
+			},
+			wantChanged: true,
+		},
+		{
+			name:    "Devices are up to date",
+			devices: DeviceMap{"sda": {DiskID: 1}, "sdb": {DiskID: 2}, "sdc": {}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs(rootOnly), nil)
+			},
+		},
+		{
+			name:          "Error - unknown device",
+			devices:       DeviceMap{"sdz": {DiskID: 1}},
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expectedError: "unknown device \"sdz\"",
+		},
+		{
+			name:          "Error - disk and volume",
+			devices:       DeviceMap{"sdc": {DiskID: 3, VolumeID: 7}},
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expectedError: "device sdc must reference either a disk or a volume",
+		},
+		{
+			name:          "Error - disk mapped twice",
+			devices:       DeviceMap{"sdb": {DiskID: 3}, "sdc": {DiskID: 3}},
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expectedError: "devices sdb and sdc reference the same disk or volume",
+		},
+		{
+			name:    "Error - disk does not exist",
+			devices: DeviceMap{"sdc": {DiskID: 4}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs(rootOnly), nil)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceDisk{{ID: 1}, {ID: 2}}, nil)
+			},
+			expectedError: "disk 4 of device sdc does not exist on instance 123",
+		},
+		{
+			name:    "Error - volume attached to another instance",
+			devices: DeviceMap{"sdc": {VolumeID: 7}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs(rootOnly), nil)
+				mock.EXPECT().GetVolume(gomock.Any(), 7).Return(&linodego.Volume{ID: 7, LinodeID: ptr.To(456)}, nil)
+			},
+			expectedError: "volume 7 of device sdc is attached to instance 456",
+		},
+		{
+			name:    "Error - config profile does not exist",
+			devices: DeviceMap{"sdc": {DiskID: 3}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(nil, nil)
+			},
+			expectedError: "instance 123 has no config profiles",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient:  mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{},
+			}
+
+			changed, err := mScope.ReconcileConfigDevices(context.Background(), 123, 0, testcase.devices)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantChanged, changed)
+		})
+	}
+}
+
+func TestDeviceMapFromSpec(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, DeviceMapFromSpec(nil))
+	assert.Equal(t, DeviceMap{"sda": {DiskID: 1}, "sdc": {VolumeID: 7}}, DeviceMapFromSpec(&infrav1alpha2.InstanceConfigDevices{
+		SDA: &infrav1alpha2.InstanceConfigDevice{DiskID: 1},
+		SDC: &infrav1alpha2.InstanceConfigDevice{VolumeID: 7},
+	}))
+}
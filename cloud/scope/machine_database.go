@@ -0,0 +1,84 @@
+package scope
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+
+	"github.com/linode/linodego"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// linodePrivateIPv4Prefix is the range Linode assigns private IPv4 addresses from.
+var linodePrivateIPv4Prefix = netip.MustParsePrefix("192.168.128.0/17")
+
+// ReconcileDatabaseAllowlist ensures the allow list of the Managed Database contains the
+// machine's public addresses and Linode private IPv4 address, so the machine can connect to
+// it as its addresses change. The entries added are recorded in the status, and only those
+// are removed when the addresses change or the LinodeMachine is being deleted, so entries
+// added by other means are left alone. Nothing is left to remove when the database no longer
+// exists.
+func (s *MachineScope) ReconcileDatabaseAllowlist(ctx context.Context, dbID int) error {
+	var desired []string
+	if s.LinodeMachine.DeletionTimestamp.IsZero() {
+		for _, address := range s.LinodeMachine.Status.Addresses {
+			addr, err := netip.ParseAddr(address.Address)
+			if err != nil {
+				continue
+			}
+			if address.Type == clusterv1.MachineExternalIP || linodePrivateIPv4Prefix.Contains(addr) {
+				desired = append(desired, netip.PrefixFrom(addr, addr.BitLen()).String())
+			}
+		}
+	}
+	managed := s.LinodeMachine.Status.ManagedDatabaseAllowList
+	if len(desired) == 0 && len(managed) == 0 {
+		return nil
+	}
+
+	databases, err := s.LinodeClient.ListDatabases(ctx, &linodego.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("list databases: %w", err)
+	}
+	idx := slices.IndexFunc(databases, func(database linodego.Database) bool { return database.ID == dbID })
+	switch {
+	case idx < 0 && len(desired) == 0:
+		// The managed entries were removed with the database.
+		s.LinodeMachine.Status.ManagedDatabaseAllowList = nil
+		return nil
+	case idx < 0:
+		return fmt.Errorf("database %d does not exist", dbID)
+	}
+	database := databases[idx]
+
+	allowList := make([]string, 0, len(database.AllowList)+len(desired))
+	for _, entry := range database.AllowList {
+		if slices.Contains(managed, entry) && !slices.Contains(desired, entry) {
+			continue
+		}
+		allowList = append(allowList, entry)
+	}
+	for _, entry := range desired {
+		if !slices.Contains(allowList, entry) {
+			allowList = append(allowList, entry)
+		}
+	}
+
+	if !slices.Equal(allowList, database.AllowList) {
+		switch database.Engine {
+		case "mysql":
+			_, err = s.LinodeClient.UpdateMySQLDatabase(ctx, dbID, linodego.MySQLUpdateOptions{AllowList: &allowList})
+		case "postgresql":
+			_, err = s.LinodeClient.UpdatePostgresDatabase(ctx, dbID, linodego.PostgresUpdateOptions{AllowList: &allowList})
+		default:
+			return fmt.Errorf("database %d has unsupported engine %s", dbID, database.Engine)
+		}
+		if err != nil {
+			return fmt.Errorf("update database %d allow list: %w", dbID, err)
+		}
+	}
+	s.LinodeMachine.Status.ManagedDatabaseAllowList = desired
+
+	return nil
+}
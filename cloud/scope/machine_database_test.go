@@ -0,0 +1,153 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeReconcileDatabaseAllowlist(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+	addresses := []clusterv1.MachineAddress{
+		{Type: clusterv1.MachineExternalIP, Address: "172.0.0.10"},
+		{Type: clusterv1.MachineExternalIP, Address: "2600:3c06::1"},
+		{Type: clusterv1.MachineInternalIP, Address: "10.0.0.10"},
+		{Type: clusterv1.MachineInternalIP, Address: "192.168.130.10"},
+	}
+	desired := []string{"172.0.0.10/32", "2600:3c06::1/128", "192.168.130.10/32"}
+
+	tests := []struct {
+		name          string
+		deleting      bool
+		addresses     []clusterv1.MachineAddress
+		managed       []string
+		expects       func(mock *mock.MockLinodeClient)
+		wantManaged   []string
+		expectedError string
+	}{
+		{
+			name:      "Add the machine's addresses",
+			addresses: addresses,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListDatabases(gomock.Any(), gomock.Any()).
+					Return([]linodego.Database{{ID: 5, Engine: "mysql", AllowList: []string{"203.0.113.0/24"}}}, nil)
+				mock.EXPECT().UpdateMySQLDatabase(gomock.Any(), 5, linodego.MySQLUpdateOptions{
+					AllowList: &[]string{"203.0.113.0/24", "172.0.0.10/32", "2600:3c06::1/128", "192.168.130.10/32"},
+				}).Return(&linodego.MySQLDatabase{}, nil)
+			},
+			wantManaged: desired,
+		},
+		{
+			name:      "Replace a stale address",
+			addresses: addresses,
+			managed:   []string{"172.0.0.9/32", "2600:3c06::1/128", "192.168.130.10/32"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListDatabases(gomock.Any(), gomock.Any()).
+					Return([]linodego.Database{{ID: 5, Engine: "postgresql", AllowList: []string{"172.0.0.9/32", "2600:3c06::1/128", "192.168.130.10/32"}}}, nil)
+				mock.EXPECT().UpdatePostgresDatabase(gomock.Any(), 5, linodego.PostgresUpdateOptions{
+					AllowList: &[]string{"2600:3c06::1/128", "192.168.130.10/32", "172.0.0.10/32"},
+				}).Return(&linodego.PostgresDatabase{}, nil)
+			},
+			wantManaged: desired,
+		},
+		{
+			name:      "Allow list is up to date",
+			addresses: addresses,
+			managed:   desired,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListDatabases(gomock.Any(), gomock.Any()).
+					Return([]linodego.Database{{ID: 5, Engine: "mysql", AllowList: desired}}, nil)
+			},
+			wantManaged: desired,
+		},
+		{
+			name:      "Remove the machine's addresses on deletion",
+			deleting:  true,
+			addresses: addresses,
+			managed:   desired,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListDatabases(gomock.Any(), gomock.Any()).
+					Return([]linodego.Database{{ID: 5, Engine: "mysql", AllowList: append([]string{"203.0.113.0/24"}, desired...)}}, nil)
+				mock.EXPECT().UpdateMySQLDatabase(gomock.Any(), 5, linodego.MySQLUpdateOptions{
+					AllowList: &[]string{"203.0.113.0/24"},
+				}).Return(&linodego.MySQLDatabase{}, nil)
+			},
+		},
+		{
+			name:      "Deleted database has nothing to remove",
+			deleting:  true,
+			addresses: addresses,
+			managed:   desired,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListDatabases(gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+		},
+		{
+			name:    "No addresses",
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:      "Error - database does not exist",
+			addresses: addresses,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListDatabases(gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+			expectedError: "database 5 does not exist",
+		},
+		{
+			name:      "Error - update fails",
+			addresses: addresses,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListDatabases(gomock.Any(), gomock.Any()).Return([]linodego.Database{{ID: 5, Engine: "mysql"}}, nil)
+				mock.EXPECT().UpdateMySQLDatabase(gomock.Any(), 5, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "update database 5 allow list: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			linodeMachine := &infrav1alpha2.LinodeMachine{
+				Status: infrav1alpha2.LinodeMachineStatus{
+					Addresses:                testcase.addresses,
+					ManagedDatabaseAllowList: testcase.managed,
+				},
+			}
+			if testcase.deleting {
+				linodeMachine.DeletionTimestamp = &now
+			}
+			mScope := &MachineScope{
+				LinodeClient:  mockLinodeClient,
+				LinodeMachine: linodeMachine,
+			}
+
+			err := mScope.ReconcileDatabaseAllowlist(context.Background(), 5)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantManaged, mScope.LinodeMachine.Status.ManagedDatabaseAllowList)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package scope
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/linode/linodego"
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// bootDiagnosticsEventCount is the number of recent instance events captured as boot diagnostics.
+	bootDiagnosticsEventCount = 25
+
+	// ConditionBootDiagnosticsCaptured reports that boot diagnostics were captured after the
+	// instance exceeded its boot timeout.
+	ConditionBootDiagnosticsCaptured clusterv1.ConditionType = "BootDiagnosticsCaptured"
+)
+
+// BootDiagnostics is the state of an instance which did not join the cluster within its boot timeout.
+type BootDiagnostics struct {
+	// InstanceStatus is the status of the instance when the diagnostics were captured.
+	InstanceStatus linodego.InstanceStatus
+	// Events are the instance's most recent events, newest first.
+	Events []linodego.Event
+	// Config is the instance's boot config profile, if it has one.
+	Config *linodego.InstanceConfig
+	// ConfigMapName is the name of the ConfigMap the diagnostics are stored in.
+	ConfigMapName string
+}
+
+// BootTimedOut reports whether the instance has not joined the cluster as a Node within the
+// spec's BootTimeout of being created, and its boot diagnostics have not been captured yet.
+func (s *MachineScope) BootTimedOut(instance *linodego.Instance, now time.Time) bool {
+	timeout := s.LinodeMachine.Spec.BootTimeout
+	if timeout == nil || instance.Created == nil || conditions.IsTrue(s.LinodeMachine, ConditionBootDiagnosticsCaptured) {
+		return false
+	}
+	if s.Machine != nil && s.Machine.Status.NodeRef != nil {
+		return false
+	}
+
+	return now.After(instance.Created.Add(timeout.Duration))
+}
+
+// CaptureBootDiagnostics gathers the status, most recent events and boot config profile of an
+// instance which did not join the cluster in time, and stores them in the
+// <name>-boot-diagnostics ConfigMap owned by the LinodeMachine, so the failed boot can be
+// root-caused before the machine is remediated. The serial console is only reachable through
+// Lish and not the Linode API, so its output is not captured. ConditionBootDiagnosticsCaptured
+// is marked true, so the diagnostics are only captured once.
+func (s *MachineScope) CaptureBootDiagnostics(ctx context.Context, instanceID int) (BootDiagnostics, error) {
+	diagnostics := BootDiagnostics{ConfigMapName: s.LinodeMachine.Name + "-boot-diagnostics"}
+
+	instance, err := s.LinodeClient.GetInstance(ctx, instanceID)
+	if err != nil {
+		return diagnostics, fmt.Errorf("get instance %d: %w", instanceID, err)
+	}
+	diagnostics.InstanceStatus = instance.Status
+
+	filter, err := json.Marshal(map[string]any{
+		"entity.id":   instanceID,
+		"entity.type": "linode",
+		"+order_by":   "created",
+		"+order":      "desc",
+	})
+	if err != nil {
+		return diagnostics, err
+	}
+	events, err := s.LinodeClient.ListEvents(ctx, &linodego.ListOptions{
+		PageOptions: &linodego.PageOptions{Page: 1},
+		PageSize:    bootDiagnosticsEventCount,
+		Filter:      string(filter),
+	})
+	if err != nil {
+		return diagnostics, fmt.Errorf("list instance %d events: %w", instanceID, err)
+	}
+	diagnostics.Events = events
+
+	configs, err := s.LinodeClient.ListInstanceConfigs(ctx, instanceID, &linodego.ListOptions{})
+	if err != nil {
+		return diagnostics, fmt.Errorf("list instance configs: %w", err)
+	}
+	if len(configs) > 0 {
+		if diagnostics.Config, err = s.bootConfig(configs, instanceID); err != nil {
+			return diagnostics, err
+		}
+	}
+	config, err := json.MarshalIndent(diagnostics.Config, "", "  ")
+	if err != nil {
+		return diagnostics, fmt.Errorf("marshal boot config profile: %w", err)
+	}
+
+	eventLines := make([]string, 0, len(events))
+	for _, event := range events {
+		created := ""
+		if event.Created != nil {
+			created = event.Created.UTC().Format(time.RFC3339)
+		}
+		eventLines = append(eventLines, fmt.Sprintf("%s %s %s", created, event.Action, event.Status))
+	}
+
+	configMap := &corev1.ConfigMap{}
+	configMap.Name = diagnostics.ConfigMapName
+	configMap.Namespace = s.LinodeMachine.Namespace
+	if _, err := controllerutil.CreateOrUpdate(ctx, s.Client, configMap, func() error {
+		configMap.Data = map[string]string{
+			"status":      string(instance.Status),
+			"events":      strings.Join(eventLines, "\n"),
+			"config.json": string(config),
+		}
+
+		return controllerutil.SetControllerReference(s.LinodeMachine, configMap, s.Client.Scheme())
+	}); err != nil {
+		return diagnostics, fmt.Errorf("write boot diagnostics configmap %s: %w", diagnostics.ConfigMapName, err)
+	}
+	conditions.MarkTrue(s.LinodeMachine, ConditionBootDiagnosticsCaptured)
+
+	return diagnostics, nil
+}
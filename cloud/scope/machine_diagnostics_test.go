@@ -0,0 +1,205 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeBootTimedOut(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	timeout := &metav1.Duration{Duration: 10 * time.Minute}
+
+	tests := []struct {
+		name     string
+		timeout  *metav1.Duration
+		nodeRef  *corev1.ObjectReference
+		captured bool
+		now      time.Time
+		want     bool
+	}{
+		{
+			name: "No boot timeout",
+			now:  created.Add(time.Hour),
+		},
+		{
+			name:    "Within boot timeout",
+			timeout: timeout,
+			now:     created.Add(5 * time.Minute),
+		},
+		{
+			name:    "Boot timeout exceeded",
+			timeout: timeout,
+			now:     created.Add(11 * time.Minute),
+			want:    true,
+		},
+		{
+			name:    "Node joined the cluster",
+			timeout: timeout,
+			nodeRef: &corev1.ObjectReference{Name: "test-node"},
+			now:     created.Add(11 * time.Minute),
+		},
+		{
+			name:     "Diagnostics already captured",
+			timeout:  timeout,
+			captured: true,
+			now:      created.Add(11 * time.Minute),
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mScope := &MachineScope{
+				Machine: &clusterv1.Machine{Status: clusterv1.MachineStatus{NodeRef: testcase.nodeRef}},
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{BootTimeout: testcase.timeout},
+				},
+			}
+			if testcase.captured {
+				conditions.MarkTrue(mScope.LinodeMachine, ConditionBootDiagnosticsCaptured)
+			}
+
+			assert.Equal(t, testcase.want, mScope.BootTimedOut(&linodego.Instance{ID: 123, Created: &created}, testcase.now))
+		})
+	}
+}
+
+func TestMachineScopeCaptureBootDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	scheme := func() *runtime.Scheme {
+		s := runtime.NewScheme()
+		_ = infrav1alpha2.AddToScheme(s)
+		return s
+	}
+	created := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	events := []linodego.Event{
+		{ID: 2, Action: linodego.ActionLinodeBoot, Status: linodego.EventFailed, Created: ptr.To(created.Add(time.Minute))},
+		{ID: 1, Action: linodego.ActionLinodeCreate, Status: linodego.EventFinished, Created: &created},
+	}
+	configs := []linodego.InstanceConfig{{ID: 100, Label: "default", Kernel: "linode/grub2"}}
+
+	tests := []struct {
+		name          string
+		expects       func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient)
+		wantEvents    []linodego.Event
+		wantConfig    *linodego.InstanceConfig
+		expectedError string
+	}{
+		{
+			name: "Capture diagnostics into a configmap",
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().ListEvents(gomock.Any(), &linodego.ListOptions{
+					PageOptions: &linodego.PageOptions{Page: 1},
+					PageSize:    25,
+					Filter:      `{"+order":"desc","+order_by":"created","entity.id":123,"entity.type":"linode"}`,
+				}).Return(events, nil)
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				k8s.EXPECT().Get(gomock.Any(), client.ObjectKey{Namespace: "default", Name: "test-machine-boot-diagnostics"}, gomock.Any()).
+					Return(apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "test-machine-boot-diagnostics"))
+				k8s.EXPECT().Scheme().DoAndReturn(scheme)
+				k8s.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+						configMap := obj.(*corev1.ConfigMap)
+						assert.Equal(t, "running", configMap.Data["status"])
+						assert.Equal(t, "2024-06-01T12:01:00Z linode_boot failed\n2024-06-01T12:00:00Z linode_create finished", configMap.Data["events"])
+						assert.Contains(t, configMap.Data["config.json"], `"kernel": "linode/grub2"`)
+						assert.Len(t, configMap.OwnerReferences, 1)
+						return nil
+					})
+			},
+			wantEvents: events,
+			wantConfig: &configs[0],
+		},
+		{
+			name: "Capture diagnostics of an instance without config profiles",
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceOffline}, nil)
+				mock.EXPECT().ListEvents(gomock.Any(), gomock.Any()).Return(nil, nil)
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(nil, nil)
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				k8s.EXPECT().Scheme().DoAndReturn(scheme)
+				k8s.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+						configMap := obj.(*corev1.ConfigMap)
+						assert.Equal(t, map[string]string{"status": "offline", "events": "", "config.json": "null"}, configMap.Data)
+						return nil
+					})
+			},
+		},
+		{
+			name: "Error - list events",
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().ListEvents(gomock.Any(), gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "list instance 123 events: api error",
+		},
+		{
+			name: "Error - write configmap",
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().ListEvents(gomock.Any(), gomock.Any()).Return(events, nil)
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("api error"))
+			},
+			expectedError: "write boot diagnostics configmap test-machine-boot-diagnostics: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			mockK8sClient := mock.NewMockK8sClient(ctrl)
+			testcase.expects(mockLinodeClient, mockK8sClient)
+
+			mScope := &MachineScope{
+				Client:       mockK8sClient,
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: "default"},
+				},
+			}
+
+			diagnostics, err := mScope.CaptureBootDiagnostics(context.Background(), 123)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				assert.False(t, conditions.IsTrue(mScope.LinodeMachine, ConditionBootDiagnosticsCaptured))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "test-machine-boot-diagnostics", diagnostics.ConfigMapName)
+			assert.Equal(t, testcase.wantEvents, diagnostics.Events)
+			assert.Equal(t, testcase.wantConfig, diagnostics.Config)
+			assert.True(t, conditions.IsTrue(mScope.LinodeMachine, ConditionBootDiagnosticsCaptured))
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package scope
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/linode/linodego"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+)
+
+const (
+	// minDiskLabelLength and maxDiskLabelLength are the disk label lengths accepted by the Linode API.
+	minDiskLabelLength = 1
+	maxDiskLabelLength = 48
+)
+
+// ReconcileDiskLabel sets the label of the instance disk, leaving the disk untouched if it
+// already has the label.
+func (s *MachineScope) ReconcileDiskLabel(ctx context.Context, instanceID, diskID int, label string) error {
+	if len(label) < minDiskLabelLength || len(label) > maxDiskLabelLength {
+		return fmt.Errorf("disk label %q must be between %d and %d characters", label, minDiskLabelLength, maxDiskLabelLength)
+	}
+
+	disk, err := s.LinodeClient.GetInstanceDisk(ctx, instanceID, diskID)
+	if err != nil {
+		return fmt.Errorf("get instance disk %d: %w", diskID, err)
+	}
+	if disk.Label == label {
+		return nil
+	}
+
+	if _, err := s.LinodeClient.UpdateInstanceDisk(ctx, instanceID, diskID, linodego.InstanceDiskUpdateOptions{Label: label}); err != nil {
+		return fmt.Errorf("update instance disk %d label: %w", diskID, err)
+	}
+
+	return nil
+}
+
+// ResizeRootDisk grows an instance disk to the target size in MB. Linode only resizes the
+// disks of an offline instance, so a running instance is shut down first and booted again
+// once the resize has finished. Shrinking is refused since the used space on the disk is
+// not known, as is growing past the space left in the instance's plan. It reports done
+// once the disk has the target size and the instance is running, so callers should
+// requeue until done is true.
+func (s *MachineScope) ResizeRootDisk(ctx context.Context, instanceID, diskID, targetSize int) (bool, error) {
+	disk, err := s.LinodeClient.GetInstanceDisk(ctx, instanceID, diskID)
+	if err != nil {
+		return false, fmt.Errorf("get instance disk %d: %w", diskID, err)
+	}
+	if disk.Status != linodego.DiskReady {
+		return false, nil
+	}
+	if targetSize < disk.Size {
+		return false, fmt.Errorf("cannot shrink disk %d from %d MB to %d MB", diskID, disk.Size, targetSize)
+	}
+
+	instance, err := s.LinodeClient.GetInstance(ctx, instanceID)
+	if err != nil {
+		return false, fmt.Errorf("get instance %d: %w", instanceID, err)
+	}
+
+	if disk.Size == targetSize {
+		switch instance.Status {
+		case linodego.InstanceRunning:
+			return true, nil
+		case linodego.InstanceOffline:
+			if err := s.LinodeClient.BootInstance(ctx, instanceID, 0); err != nil {
+				return false, fmt.Errorf("boot instance %d after disk resize: %w", instanceID, err)
+			}
+		}
+		return false, nil
+	}
+
+	linodeType, err := s.LinodeClient.GetType(ctx, instance.Type)
+	if err != nil {
+		return false, fmt.Errorf("get type %s: %w", instance.Type, err)
+	}
+	disks, err := s.LinodeClient.ListInstanceDisks(ctx, instanceID, &linodego.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("list instance disks: %w", err)
+	}
+	used := targetSize - disk.Size
+	for _, d := range disks {
+		used += d.Size
+	}
+	if used > linodeType.Disk {
+		return false, fmt.Errorf("cannot grow disk %d to %d MB: the disks would use %d MB but plan %s only has %d MB", diskID, targetSize, used, instance.Type, linodeType.Disk)
+	}
+
+	switch instance.Status {
+	case linodego.InstanceRunning:
+		if err := s.LinodeClient.ShutdownInstance(ctx, instanceID); err != nil {
+			return false, fmt.Errorf("shut down instance %d: %w", instanceID, err)
+		}
+	case linodego.InstanceOffline:
+		if err := s.LinodeClient.ResizeInstanceDisk(ctx, instanceID, diskID, targetSize); err != nil {
+			return false, fmt.Errorf("resize instance disk %d: %w", diskID, err)
+		}
+	}
+
+	return false, nil
+}
+
+// ReconcileAdditionalDisks creates the spec's data disks which do not exist on the instance
+// yet and attaches every data disk as its device in the boot config profile, so nodes can
+// keep e.g. container storage on a dedicated disk. Disks are matched by ID, or by label
+// when their ID has not been recorded. Nothing is created if the new disks do not fit in
+// the space left in the instance's plan. The created disks' IDs are recorded in the spec,
+// and the IDs of all data disks are returned in device order.
+func (s *MachineScope) ReconcileAdditionalDisks(ctx context.Context, instanceID int) ([]int, error) {
+	dataDisks := s.LinodeMachine.Spec.DataDisks
+	if len(dataDisks) == 0 {
+		return nil, nil
+	}
+
+	devices := make([]string, 0, len(dataDisks))
+	for device, disk := range dataDisks {
+		if disk == nil {
+			return nil, fmt.Errorf("data disk %s has no configuration", device)
+		}
+		if device == "sda" || configDevice(&linodego.InstanceConfigDeviceMap{}, device) == nil {
+			return nil, fmt.Errorf("data disk has unknown device %q", device)
+		}
+		devices = append(devices, device)
+	}
+	slices.Sort(devices)
+
+	disks, err := s.LinodeClient.ListInstanceDisks(ctx, instanceID, &linodego.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list instance disks: %w", err)
+	}
+
+	var missing []string
+	used := 0
+	for _, disk := range disks {
+		used += disk.Size
+	}
+	for _, device := range devices {
+		dataDisk := dataDisks[device]
+		label := dataDiskLabel(device, dataDisk)
+		idx := slices.IndexFunc(disks, func(d linodego.InstanceDisk) bool {
+			return (dataDisk.DiskID != 0 && d.ID == dataDisk.DiskID) || (dataDisk.DiskID == 0 && d.Label == label)
+		})
+		if idx < 0 {
+			missing = append(missing, device)
+			used += int(dataDisk.Size.ScaledValue(resource.Mega))
+			continue
+		}
+		dataDisk.DiskID = disks[idx].ID
+	}
+
+	if len(missing) > 0 {
+		instance, err := s.LinodeClient.GetInstance(ctx, instanceID)
+		if err != nil {
+			return nil, fmt.Errorf("get instance %d: %w", instanceID, err)
+		}
+		linodeType, err := s.LinodeClient.GetType(ctx, instance.Type)
+		if err != nil {
+			return nil, fmt.Errorf("get type %s: %w", instance.Type, err)
+		}
+		if used > linodeType.Disk {
+			return nil, fmt.Errorf("cannot create data disks %s: the disks would use %d MB but plan %s only has %d MB", strings.Join(missing, ", "), used, instance.Type, linodeType.Disk)
+		}
+
+		for _, device := range missing {
+			dataDisk := dataDisks[device]
+			filesystem := dataDisk.Filesystem
+			if filesystem == "" {
+				filesystem = string(linodego.FilesystemExt4)
+			}
+			disk, err := s.LinodeClient.CreateInstanceDisk(ctx, instanceID, linodego.InstanceDiskCreateOptions{
+				Label:      dataDiskLabel(device, dataDisk),
+				Size:       int(dataDisk.Size.ScaledValue(resource.Mega)),
+				Filesystem: filesystem,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("create data disk %s: %w", device, err)
+			}
+			dataDisk.DiskID = disk.ID
+		}
+	}
+
+	configs, err := s.LinodeClient.ListInstanceConfigs(ctx, instanceID, &linodego.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list instance configs: %w", err)
+	}
+	config, err := s.bootConfig(configs, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	configDevices := linodego.InstanceConfigDeviceMap{}
+	if config.Devices != nil {
+		configDevices = *config.Devices
+	}
+
+	ids := make([]int, 0, len(devices))
+	changed := false
+	for _, device := range devices {
+		diskID := dataDisks[device].DiskID
+		ids = append(ids, diskID)
+		if slot := configDevice(&configDevices, device); *slot == nil || (*slot).DiskID != diskID {
+			*slot = &linodego.InstanceConfigDevice{DiskID: diskID}
+			changed = true
+		}
+	}
+	if changed {
+		if _, err := s.LinodeClient.UpdateInstanceConfig(ctx, instanceID, config.ID, linodego.InstanceConfigUpdateOptions{Devices: &configDevices}); err != nil {
+			return nil, fmt.Errorf("update instance config %d devices: %w", config.ID, err)
+		}
+	}
+
+	return ids, nil
+}
+
+// dataDiskLabel returns the label of the data disk, which defaults to its device name.
+func dataDiskLabel(device string, disk *infrav1alpha2.InstanceDisk) string {
+	if disk.Label != "" {
+		return disk.Label
+	}
+	return device
+}
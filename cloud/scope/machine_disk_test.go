@@ -0,0 +1,346 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeReconcileDiskLabel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		label         string
+		expects       func(mock *mock.MockLinodeClient)
+		expectedError string
+	}{
+		{
+			name:  "Disk already has the label",
+			label: "cloudimg-rootfs",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceDisk(gomock.Any(), 123, 456).Return(&linodego.InstanceDisk{ID: 456, Label: "cloudimg-rootfs"}, nil)
+			},
+		},
+		{
+			name:  "Relabel disk",
+			label: "cloudimg-rootfs",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceDisk(gomock.Any(), 123, 456).Return(&linodego.InstanceDisk{ID: 456, Label: "Ubuntu 22.04 Disk"}, nil)
+				mock.EXPECT().UpdateInstanceDisk(gomock.Any(), 123, 456, linodego.InstanceDiskUpdateOptions{Label: "cloudimg-rootfs"}).Return(&linodego.InstanceDisk{}, nil)
+			},
+		},
+		{
+			name:          "Error - empty label",
+			label:         "",
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expectedError: "must be between 1 and 48 characters",
+		},
+		{
+			name:          "Error - label too long",
+			label:         strings.Repeat("a", 49),
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expectedError: "must be between 1 and 48 characters",
+		},
+		{
+			name:  "Error - get disk fails",
+			label: "cloudimg-rootfs",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceDisk(gomock.Any(), 123, 456).Return(nil, errors.New("api error"))
+			},
+			expectedError: "get instance disk 456: api error",
+		},
+		{
+			name:  "Error - update fails",
+			label: "cloudimg-rootfs",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceDisk(gomock.Any(), 123, 456).Return(&linodego.InstanceDisk{ID: 456}, nil)
+				mock.EXPECT().UpdateInstanceDisk(gomock.Any(), 123, 456, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "update instance disk 456 label: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient:  mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{},
+			}
+
+			err := mScope.ReconcileDiskLabel(context.Background(), 123, 456, testcase.label)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMachineScopeResizeRootDisk(t *testing.T) {
+	t.Parallel()
+
+	plan := &linodego.LinodeType{ID: "g6-standard-2", Disk: 81920}
+
+	tests := []struct {
+		name          string
+		expects       func(mock *mock.MockLinodeClient)
+		wantDone      bool
+		expectedError string
+	}{
+		{
+			name: "Done - disk has the target size",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceDisk(gomock.Any(), 123, 1).Return(&linodego.InstanceDisk{ID: 1, Size: 40960, Status: linodego.DiskReady}, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-2", Status: linodego.InstanceRunning}, nil)
+			},
+			wantDone: true,
+		},
+		{
+			name: "In progress - disk is resizing",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceDisk(gomock.Any(), 123, 1).Return(&linodego.InstanceDisk{ID: 1, Size: 20480, Status: linodego.DiskNotReady}, nil)
+			},
+		},
+		{
+			name: "In progress - shut down running instance",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceDisk(gomock.Any(), 123, 1).Return(&linodego.InstanceDisk{ID: 1, Size: 20480, Status: linodego.DiskReady}, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-2", Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-2").Return(plan, nil)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceDisk{{ID: 1, Size: 20480}, {ID: 2, Size: 512}}, nil)
+				mock.EXPECT().ShutdownInstance(gomock.Any(), 123).Return(nil)
+			},
+		},
+		{
+			name: "In progress - resize disk of offline instance",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceDisk(gomock.Any(), 123, 1).Return(&linodego.InstanceDisk{ID: 1, Size: 20480, Status: linodego.DiskReady}, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-2", Status: linodego.InstanceOffline}, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-2").Return(plan, nil)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceDisk{{ID: 1, Size: 20480}}, nil)
+				mock.EXPECT().ResizeInstanceDisk(gomock.Any(), 123, 1, 40960).Return(nil)
+			},
+		},
+		{
+			name: "In progress - boot instance after resize",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceDisk(gomock.Any(), 123, 1).Return(&linodego.InstanceDisk{ID: 1, Size: 40960, Status: linodego.DiskReady}, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-2", Status: linodego.InstanceOffline}, nil)
+				mock.EXPECT().BootInstance(gomock.Any(), 123, 0).Return(nil)
+			},
+		},
+		{
+			name: "Error - shrinking",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceDisk(gomock.Any(), 123, 1).Return(&linodego.InstanceDisk{ID: 1, Size: 61440, Status: linodego.DiskReady}, nil)
+			},
+			expectedError: "cannot shrink disk 1 from 61440 MB to 40960 MB",
+		},
+		{
+			name: "Error - plan has no space left",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceDisk(gomock.Any(), 123, 1).Return(&linodego.InstanceDisk{ID: 1, Size: 20480, Status: linodego.DiskReady}, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-2", Status: linodego.InstanceOffline}, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-2").Return(plan, nil)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceDisk{{ID: 1, Size: 20480}, {ID: 2, Size: 45056}}, nil)
+			},
+			expectedError: "cannot grow disk 1 to 40960 MB: the disks would use 86016 MB",
+		},
+		{
+			name: "Error - resize fails",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceDisk(gomock.Any(), 123, 1).Return(&linodego.InstanceDisk{ID: 1, Size: 20480, Status: linodego.DiskReady}, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-2", Status: linodego.InstanceOffline}, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-2").Return(plan, nil)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return(nil, nil)
+				mock.EXPECT().ResizeInstanceDisk(gomock.Any(), 123, 1, 40960).Return(errors.New("api error"))
+			},
+			expectedError: "resize instance disk 1: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{LinodeClient: mockLinodeClient}
+
+			done, err := mScope.ResizeRootDisk(context.Background(), 123, 1, 40960)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantDone, done)
+		})
+	}
+}
+
+func TestMachineScopeReconcileAdditionalDisks(t *testing.T) {
+	t.Parallel()
+
+	rootDisk := linodego.InstanceDisk{ID: 1, Label: "root", Size: 20000}
+	bootConfig := func(devices linodego.InstanceConfigDeviceMap) []linodego.InstanceConfig {
+		return []linodego.InstanceConfig{{ID: 9, Devices: &devices}}
+	}
+
+	tests := []struct {
+		name          string
+		dataDisks     map[string]*infrav1alpha2.InstanceDisk
+		expects       func(mock *mock.MockLinodeClient)
+		want          []int
+		expectedError string
+	}{
+		{
+			name:    "No data disks",
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name: "Create missing disk and attach it",
+			dataDisks: map[string]*infrav1alpha2.InstanceDisk{
+				"sdc": {Size: resource.MustParse("10G"), Label: "containerd"},
+				"sdb": {DiskID: 2, Size: resource.MustParse("5G")},
+			},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceDisk{rootDisk, {ID: 2, Label: "sdb", Size: 5000}}, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-2"}, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-2").Return(&linodego.LinodeType{Disk: 81920}, nil)
+				mock.EXPECT().CreateInstanceDisk(gomock.Any(), 123, linodego.InstanceDiskCreateOptions{
+					Label:      "containerd",
+					Size:       10000,
+					Filesystem: "ext4",
+				}).Return(&linodego.InstanceDisk{ID: 3}, nil)
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(bootConfig(linodego.InstanceConfigDeviceMap{
+					SDA: &linodego.InstanceConfigDevice{DiskID: 1},
+					SDB: &linodego.InstanceConfigDevice{DiskID: 2},
+				}), nil)
+				mock.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 9, linodego.InstanceConfigUpdateOptions{Devices: &linodego.InstanceConfigDeviceMap{
+					SDA: &linodego.InstanceConfigDevice{DiskID: 1},
+					SDB: &linodego.InstanceConfigDevice{DiskID: 2},
+					SDC: &linodego.InstanceConfigDevice{DiskID: 3},
+				}}).Return(&linodego.InstanceConfig{}, nil)
+			},
+			want: []int{2, 3},
+		},
+		{
+			name: "Disk found by label is only attached",
+			dataDisks: map[string]*infrav1alpha2.InstanceDisk{
+				"sdb": {Size: resource.MustParse("5G")},
+			},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceDisk{rootDisk, {ID: 2, Label: "sdb", Size: 5000}}, nil)
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(bootConfig(linodego.InstanceConfigDeviceMap{
+					SDA: &linodego.InstanceConfigDevice{DiskID: 1},
+				}), nil)
+				mock.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 9, linodego.InstanceConfigUpdateOptions{Devices: &linodego.InstanceConfigDeviceMap{
+					SDA: &linodego.InstanceConfigDevice{DiskID: 1},
+					SDB: &linodego.InstanceConfigDevice{DiskID: 2},
+				}}).Return(&linodego.InstanceConfig{}, nil)
+			},
+			want: []int{2},
+		},
+		{
+			name: "Disks are up to date",
+			dataDisks: map[string]*infrav1alpha2.InstanceDisk{
+				"sdb": {DiskID: 2, Size: resource.MustParse("5G")},
+			},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceDisk{rootDisk, {ID: 2, Label: "sdb", Size: 5000}}, nil)
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(bootConfig(linodego.InstanceConfigDeviceMap{
+					SDA: &linodego.InstanceConfigDevice{DiskID: 1},
+					SDB: &linodego.InstanceConfigDevice{DiskID: 2},
+				}), nil)
+			},
+			want: []int{2},
+		},
+		{
+			name: "Error - disks exceed the plan",
+			dataDisks: map[string]*infrav1alpha2.InstanceDisk{
+				"sdb": {Size: resource.MustParse("70G")},
+			},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceDisk{rootDisk}, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-2"}, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-2").Return(&linodego.LinodeType{Disk: 81920}, nil)
+			},
+			expectedError: "cannot create data disks sdb: the disks would use 90000 MB but plan g6-standard-2 only has 81920 MB",
+		},
+		{
+			name: "Error - unknown device",
+			dataDisks: map[string]*infrav1alpha2.InstanceDisk{
+				"sda": {Size: resource.MustParse("5G")},
+			},
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expectedError: "data disk has unknown device \"sda\"",
+		},
+		{
+			name: "Error - create disk fails",
+			dataDisks: map[string]*infrav1alpha2.InstanceDisk{
+				"sdb": {Size: resource.MustParse("5G")},
+			},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceDisk{rootDisk}, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-2"}, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-2").Return(&linodego.LinodeType{Disk: 81920}, nil)
+				mock.EXPECT().CreateInstanceDisk(gomock.Any(), 123, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "create data disk sdb: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{DataDisks: testcase.dataDisks},
+				},
+			}
+
+			ids, err := mScope.ReconcileAdditionalDisks(context.Background(), 123)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.want, ids)
+			for _, disk := range testcase.dataDisks {
+				assert.Contains(t, testcase.want, disk.DiskID)
+			}
+		})
+	}
+}
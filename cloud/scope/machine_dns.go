@@ -0,0 +1,468 @@
+package scope
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/linode/linodego"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	rutil "github.com/linode/cluster-api-provider-linode/util/reconciler"
+
+	. "github.com/linode/cluster-api-provider-linode/clients"
+)
+
+// ControlPlaneHostname returns the record name of the control plane endpoint within the root domain,
+// which is the cluster name followed by the DNS unique identifier, when one is set.
+func ControlPlaneHostname(linodeCluster *infrav1alpha2.LinodeCluster) string {
+	if linodeCluster.Spec.Network.DNSUniqueIdentifier == "" {
+		return linodeCluster.Name
+	}
+
+	return linodeCluster.Name + "-" + linodeCluster.Spec.Network.DNSUniqueIdentifier
+}
+
+// dnsTTLSec returns the TTL configured for the cluster's DNS records.
+func dnsTTLSec(linodeCluster *infrav1alpha2.LinodeCluster) int {
+	if linodeCluster.Spec.Network.DNSTTLSec != 0 {
+		return linodeCluster.Spec.Network.DNSTTLSec
+	}
+
+	return rutil.DefaultDNSTTLSec
+}
+
+// Weighted control plane DNS is published as SRV records of the kube-apiserver service, since
+// Linode DNS only supports weights and priorities on SRV records.
+const (
+	apiserverSRVService  = "kube-apiserver"
+	apiserverSRVProtocol = "tcp"
+	maxSRVValue          = 65535
+	// defaultApiserverPort is the apiserver port when the LinodeCluster does not set one.
+	defaultApiserverPort = 6443
+)
+
+// ReconcileWeightedDNS manages the machine's entry in the weighted control plane record set, so
+// traffic can be shifted gradually between machines during rollouts. The entry is an SRV record
+// of the kube-apiserver service with the weight and the spec's DNS priority, see
+// ReconcileSRVRecord. Clients resolving the SRV record prefer the lowest priority, and pick
+// between equal priorities in proportion to their weight.
+func (s *MachineScope) ReconcileWeightedDNS(ctx context.Context, weight int) error {
+	port := s.LinodeCluster.Spec.Network.ApiserverLoadBalancerPort
+	if port == 0 {
+		port = defaultApiserverPort
+	}
+
+	return s.ReconcileSRVRecord(ctx, apiserverSRVService, apiserverSRVProtocol, port, weight, s.LinodeMachine.Spec.DNSPriority)
+}
+
+// ReconcileSRVRecord manages an SRV record of the service on the control plane hostname, targeting
+// address records of the machine's external IPs under its own hostname, so clients can discover
+// the control plane machines through the service. The record and the machine's address records
+// are removed when the LinodeMachine is being deleted. Only the Linode DNS provider is supported.
+func (s *MachineScope) ReconcileSRVRecord(ctx context.Context, service, proto string, port, weight, priority int) error {
+	if service == "" || proto == "" {
+		return errors.New("srv record service and protocol are required")
+	}
+	if port < 1 || port > maxSRVValue {
+		return fmt.Errorf("invalid srv port %d, must be between 1 and %d", port, maxSRVValue)
+	}
+	if weight < 0 || weight > maxSRVValue {
+		return fmt.Errorf("invalid dns weight %d, must be between 0 and %d", weight, maxSRVValue)
+	}
+	if priority < 0 || priority > maxSRVValue {
+		return fmt.Errorf("invalid dns priority %d, must be between 0 and %d", priority, maxSRVValue)
+	}
+	rootDomain := s.LinodeCluster.Spec.Network.DNSRootDomain
+	if rootDomain == "" {
+		return errors.New("dns root domain is not configured on the LinodeCluster")
+	}
+	if s.LinodeCluster.Spec.Network.DNSProvider == "akamai" {
+		return errors.New("srv records are only supported by the linode dns provider")
+	}
+	domainID, err := s.ValidateDNSZone(ctx)
+	if err != nil {
+		return err
+	}
+	remove := !s.LinodeMachine.DeletionTimestamp.IsZero()
+
+	hostname := s.LinodeMachine.Name + "." + ControlPlaneHostname(s.LinodeCluster)
+	var addrs []string
+	if !remove {
+		for _, address := range s.LinodeMachine.Status.Addresses {
+			if address.Type == clusterv1.MachineExternalIP {
+				addrs = append(addrs, address.Address)
+			}
+		}
+		if len(addrs) == 0 {
+			return errors.New("no external addresses available on the LinodeMachine resource")
+		}
+	}
+
+	target := hostname + "." + rootDomain
+	filter, err := json.Marshal(map[string]interface{}{"type": linodego.RecordTypeSRV, "target": target})
+	if err != nil {
+		return err
+	}
+	records, err := s.LinodeDomainsClient.ListDomainRecords(ctx, domainID, linodego.NewListOptions(0, string(filter)))
+	if err != nil {
+		return fmt.Errorf("list domain records: %w", err)
+	}
+	// The API prefixes the service and protocol with an underscore.
+	records = slices.DeleteFunc(records, func(record linodego.DomainRecord) bool {
+		return record.Service == nil || strings.TrimPrefix(*record.Service, "_") != service ||
+			record.Protocol == nil || strings.TrimPrefix(*record.Protocol, "_") != proto
+	})
+
+	switch {
+	case remove:
+		for _, record := range records {
+			if err := s.LinodeDomainsClient.DeleteDomainRecord(ctx, domainID, record.ID); err != nil {
+				return fmt.Errorf("delete domain record %d: %w", record.ID, err)
+			}
+		}
+	case len(records) == 0:
+		if _, err := s.LinodeDomainsClient.CreateDomainRecord(ctx, domainID, linodego.DomainRecordCreateOptions{
+			Type:     linodego.RecordTypeSRV,
+			Name:     ControlPlaneHostname(s.LinodeCluster),
+			Target:   target,
+			Priority: &priority,
+			Weight:   &weight,
+			Port:     &port,
+			Service:  &service,
+			Protocol: &proto,
+			TTLSec:   dnsTTLSec(s.LinodeCluster),
+		}); err != nil {
+			return fmt.Errorf("create domain record: %w", err)
+		}
+	case records[0].Weight != weight || records[0].Priority != priority || records[0].Port != port:
+		if _, err := s.LinodeDomainsClient.UpdateDomainRecord(ctx, domainID, records[0].ID, linodego.DomainRecordUpdateOptions{
+			Priority: &priority,
+			Weight:   &weight,
+			Port:     &port,
+			TTLSec:   dnsTTLSec(s.LinodeCluster),
+		}); err != nil {
+			return fmt.Errorf("update domain record %d: %w", records[0].ID, err)
+		}
+	}
+
+	return s.reconcileMachineAddressRecords(ctx, domainID, hostname, addrs)
+}
+
+// ReconcileTXTRecords ensures the TXT records of the spec exist, see ReconcileTXTRecord, and
+// removes the records CAPL created for an earlier spec. The created records are tracked in the
+// status, and every one of them is removed when the LinodeMachine is being deleted.
+func (s *MachineScope) ReconcileTXTRecords(ctx context.Context) error {
+	desired := s.LinodeMachine.Spec.TXTRecords
+	if !s.LinodeMachine.DeletionTimestamp.IsZero() {
+		desired = nil
+	}
+
+	managed := s.LinodeMachine.Status.ManagedTXTRecords
+	kept := make([]infrav1alpha2.TXTRecord, 0, len(managed))
+	for i, record := range managed {
+		if slices.Contains(desired, record) {
+			kept = append(kept, record)
+			continue
+		}
+		if err := s.reconcileTXTRecord(ctx, record.Name, record.Value, true); err != nil {
+			s.LinodeMachine.Status.ManagedTXTRecords = append(kept, managed[i:]...)
+			return err
+		}
+	}
+	s.LinodeMachine.Status.ManagedTXTRecords = kept
+
+	for _, record := range desired {
+		if err := s.reconcileTXTRecord(ctx, record.Name, record.Value, false); err != nil {
+			return err
+		}
+		if !slices.Contains(s.LinodeMachine.Status.ManagedTXTRecords, record) {
+			s.LinodeMachine.Status.ManagedTXTRecords = append(s.LinodeMachine.Status.ManagedTXTRecords, record)
+		}
+	}
+
+	return nil
+}
+
+// ReconcileTXTRecord ensures the LinodeCluster's DNS root domain has a TXT record with the value
+// under the name, which is relative to the root domain, e.g. for DNS-01 challenges of the
+// machine. Other values of the name are left alone, so it may hold several values. The record
+// is removed when the LinodeMachine is being deleted. Only the Linode DNS provider is supported.
+func (s *MachineScope) ReconcileTXTRecord(ctx context.Context, name, value string) error {
+	return s.reconcileTXTRecord(ctx, name, value, !s.LinodeMachine.DeletionTimestamp.IsZero())
+}
+
+func (s *MachineScope) reconcileTXTRecord(ctx context.Context, name, value string, remove bool) error {
+	if name == "" || value == "" {
+		return errors.New("txt record name and value are required")
+	}
+	if s.LinodeCluster.Spec.Network.DNSRootDomain == "" {
+		return errors.New("dns root domain is not configured on the LinodeCluster")
+	}
+	if s.LinodeCluster.Spec.Network.DNSProvider == "akamai" {
+		return errors.New("txt records are only supported by the linode dns provider")
+	}
+	domainID, err := s.ValidateDNSZone(ctx)
+	if err != nil {
+		return err
+	}
+
+	filter, err := json.Marshal(map[string]interface{}{"type": linodego.RecordTypeTXT, "name": name})
+	if err != nil {
+		return err
+	}
+	records, err := s.LinodeDomainsClient.ListDomainRecords(ctx, domainID, linodego.NewListOptions(0, string(filter)))
+	if err != nil {
+		return fmt.Errorf("list domain records: %w", err)
+	}
+	records = slices.DeleteFunc(records, func(record linodego.DomainRecord) bool {
+		return record.Type != linodego.RecordTypeTXT || record.Name != name || record.Target != value
+	})
+
+	switch {
+	case remove:
+		for _, record := range records {
+			if err := s.LinodeDomainsClient.DeleteDomainRecord(ctx, domainID, record.ID); err != nil {
+				return fmt.Errorf("delete domain record %d: %w", record.ID, err)
+			}
+		}
+	case len(records) == 0:
+		if _, err := s.LinodeDomainsClient.CreateDomainRecord(ctx, domainID, linodego.DomainRecordCreateOptions{
+			Type:   linodego.RecordTypeTXT,
+			Name:   name,
+			Target: value,
+			TTLSec: dnsTTLSec(s.LinodeCluster),
+		}); err != nil {
+			return fmt.Errorf("create domain record: %w", err)
+		}
+	case records[0].TTLSec != dnsTTLSec(s.LinodeCluster):
+		if _, err := s.LinodeDomainsClient.UpdateDomainRecord(ctx, domainID, records[0].ID, linodego.DomainRecordUpdateOptions{
+			TTLSec: dnsTTLSec(s.LinodeCluster),
+		}); err != nil {
+			return fmt.Errorf("update domain record %d: %w", records[0].ID, err)
+		}
+	}
+
+	return nil
+}
+
+// ReconcileSplitHorizonDNS manages the machine's address records in the internal and
+// external zones of the spec's split-horizon DNS, so clients inside the network resolve the
+// machine to its private addresses and clients outside to its public ones. Control plane
+// machines are registered in the external zone behind the NodeBalancer instead, when its IP is
+// given, so external clients reach the API server through the load balancer. The records are
+// named after the LinodeMachine, and are removed when it is being deleted. Both zones must be
+// Linode domains owned by the account.
+func (s *MachineScope) ReconcileSplitHorizonDNS(ctx context.Context, nbIP string) error {
+	zones := s.LinodeMachine.Spec.SplitHorizonDNS
+	if zones == nil {
+		return nil
+	}
+	if zones.InternalDomain == "" {
+		return errors.New("split-horizon dns internal domain is not configured on the LinodeMachine")
+	}
+	if zones.ExternalDomain == "" {
+		return errors.New("split-horizon dns external domain is not configured on the LinodeMachine")
+	}
+
+	var internal, external []string
+	if s.LinodeMachine.DeletionTimestamp.IsZero() {
+		for _, address := range s.LinodeMachine.Status.Addresses {
+			switch address.Type {
+			case clusterv1.MachineInternalIP:
+				internal = append(internal, address.Address)
+			case clusterv1.MachineExternalIP:
+				external = append(external, address.Address)
+			}
+		}
+		if len(internal) == 0 {
+			return errors.New("no internal addresses available on the LinodeMachine resource")
+		}
+		if s.IsControlPlane() && nbIP != "" {
+			external = []string{nbIP}
+		}
+		if len(external) == 0 {
+			return errors.New("no external addresses available on the LinodeMachine resource")
+		}
+	}
+
+	for _, zone := range []struct {
+		domain string
+		ips    []string
+	}{
+		{zones.InternalDomain, internal},
+		{zones.ExternalDomain, external},
+	} {
+		domainID, err := lookupDomainID(ctx, s.LinodeDomainsClient, zone.domain)
+		if err != nil {
+			return err
+		}
+		if err := s.reconcileMachineAddressRecords(ctx, domainID, s.LinodeMachine.Name, zone.ips); err != nil {
+			return fmt.Errorf("reconcile %s records: %w", zone.domain, err)
+		}
+	}
+
+	return nil
+}
+
+// reconcileMachineAddressRecords ensures the hostname has exactly one address record per IP,
+// removing records of other IPs.
+func (s *MachineScope) reconcileMachineAddressRecords(ctx context.Context, domainID int, hostname string, ips []string) error {
+	filter, err := json.Marshal(map[string]interface{}{"name": hostname})
+	if err != nil {
+		return err
+	}
+	records, err := s.LinodeDomainsClient.ListDomainRecords(ctx, domainID, linodego.NewListOptions(0, string(filter)))
+	if err != nil {
+		return fmt.Errorf("list domain records: %w", err)
+	}
+
+	for _, record := range records {
+		if (record.Type != linodego.RecordTypeA && record.Type != linodego.RecordTypeAAAA) || slices.Contains(ips, record.Target) {
+			continue
+		}
+		if err := s.LinodeDomainsClient.DeleteDomainRecord(ctx, domainID, record.ID); err != nil {
+			return fmt.Errorf("delete domain record %d: %w", record.ID, err)
+		}
+	}
+	for _, ip := range ips {
+		if slices.ContainsFunc(records, func(record linodego.DomainRecord) bool { return record.Target == ip }) {
+			continue
+		}
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			return fmt.Errorf("not a valid IP %w", err)
+		}
+		recordType := linodego.RecordTypeA
+		if !addr.Is4() {
+			recordType = linodego.RecordTypeAAAA
+		}
+		if _, err := s.LinodeDomainsClient.CreateDomainRecord(ctx, domainID, linodego.DomainRecordCreateOptions{
+			Type:   recordType,
+			Name:   hostname,
+			Target: ip,
+			TTLSec: dnsTTLSec(s.LinodeCluster),
+		}); err != nil {
+			return fmt.Errorf("create domain record: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateDNSZone resolves the LinodeCluster's DNS root domain to its Linode domain ID,
+// returning an error if the account does not own the domain. The domain ID is cached for
+// the lifetime of the scope. It returns 0 without error when DNS is not configured or is
+// served by Akamai Edge DNS, which has no Linode domain.
+func (s *MachineScope) ValidateDNSZone(ctx context.Context) (int, error) {
+	rootDomain := s.LinodeCluster.Spec.Network.DNSRootDomain
+	if rootDomain == "" || s.LinodeCluster.Spec.Network.DNSProvider == "akamai" {
+		return 0, nil
+	}
+	if s.dnsZone == rootDomain {
+		return s.dnsZoneID, nil
+	}
+
+	domainID, err := lookupDomainID(ctx, s.LinodeDomainsClient, rootDomain)
+	if err != nil {
+		return 0, err
+	}
+
+	s.dnsZone = rootDomain
+	s.dnsZoneID = domainID
+
+	return s.dnsZoneID, nil
+}
+
+// lookupDomainID returns the ID of the Linode domain, returning an error if the account
+// does not own it.
+func lookupDomainID(ctx context.Context, client LinodeClient, domain string) (int, error) {
+	filter, err := json.Marshal(map[string]string{"domain": domain})
+	if err != nil {
+		return 0, err
+	}
+	domains, err := client.ListDomains(ctx, linodego.NewListOptions(0, string(filter)))
+	if err != nil {
+		return 0, fmt.Errorf("list domains: %w", err)
+	}
+	if len(domains) != 1 || domains[0].Domain != domain {
+		return 0, fmt.Errorf("domain %s not found in list of domains owned by this account", domain)
+	}
+
+	return domains[0].ID, nil
+}
+
+// linodeNameservers are the authoritative nameservers of domains hosted by Linode DNS.
+var linodeNameservers = []string{"ns1.linode.com", "ns2.linode.com", "ns3.linode.com", "ns4.linode.com", "ns5.linode.com"}
+
+// dnsResolver is the subset of net.Resolver used to check DNS propagation.
+type dnsResolver interface {
+	LookupNS(ctx context.Context, name string) ([]*net.NS, error)
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// systemResolver resolves the nameservers of Akamai Edge DNS zones.
+var systemResolver dnsResolver = net.DefaultResolver
+
+// nameserverResolver returns a resolver that sends its queries to the nameserver.
+var nameserverResolver = func(nameserver string) dnsResolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, net.JoinHostPort(nameserver, "53"))
+		},
+	}
+}
+
+// DNSRecordPropagated reports whether every authoritative nameserver of the LinodeCluster's
+// DNS root domain resolves the FQDN to the IP. The nameservers are Linode's, or those of the
+// zone when it is served by Akamai Edge DNS. A nameserver which does not know the record yet
+// is not an error, so callers should requeue until the record has propagated.
+func (s *MachineScope) DNSRecordPropagated(ctx context.Context, fqdn, ip string) (bool, error) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false, fmt.Errorf("not a valid IP %w", err)
+	}
+	network := "ip4"
+	if !addr.Is4() {
+		network = "ip6"
+	}
+
+	nameservers := linodeNameservers
+	if s.LinodeCluster.Spec.Network.DNSProvider == "akamai" {
+		records, err := systemResolver.LookupNS(ctx, s.LinodeCluster.Spec.Network.DNSRootDomain)
+		if err != nil {
+			return false, fmt.Errorf("lookup nameservers of %s: %w", s.LinodeCluster.Spec.Network.DNSRootDomain, err)
+		}
+		nameservers = make([]string, 0, len(records))
+		for _, record := range records {
+			nameservers = append(nameservers, strings.TrimSuffix(record.Host, "."))
+		}
+	}
+
+	for _, nameserver := range nameservers {
+		addrs, err := nameserverResolver(nameserver).LookupNetIP(ctx, network, fqdn)
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("lookup %s on %s: %w", fqdn, nameserver, err)
+		}
+		if !slices.Contains(addrs, addr) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,964 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeReconcileWeightedDNS(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+	linodeCluster := func(provider string) *infrav1alpha2.LinodeCluster {
+		return &infrav1alpha2.LinodeCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+			Spec: infrav1alpha2.LinodeClusterSpec{
+				Network: infrav1alpha2.NetworkSpec{
+					DNSProvider:         provider,
+					DNSRootDomain:       "lkedevs.net",
+					DNSUniqueIdentifier: "abc123",
+				},
+			},
+		}
+	}
+	linodeMachine := func(deleting bool) *infrav1alpha2.LinodeMachine {
+		machine := &infrav1alpha2.LinodeMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "cp-0"},
+			Spec:       infrav1alpha2.LinodeMachineSpec{DNSPriority: 10},
+			Status: infrav1alpha2.LinodeMachineStatus{Addresses: []clusterv1.MachineAddress{
+				{Type: clusterv1.MachineExternalIP, Address: "172.0.0.10"},
+				{Type: clusterv1.MachineInternalIP, Address: "10.0.0.10"},
+			}},
+		}
+		if deleting {
+			machine.DeletionTimestamp = &now
+			machine.Finalizers = []string{infrav1alpha2.MachineFinalizer}
+		}
+		return machine
+	}
+	domains := []linodego.Domain{{ID: 1, Domain: "lkedevs.net"}}
+
+	tests := []struct {
+		name          string
+		linodeCluster *infrav1alpha2.LinodeCluster
+		linodeMachine *infrav1alpha2.LinodeMachine
+		weight        int
+		expects       func(linode *mock.MockLinodeClient)
+		expectedError string
+	}{
+		{
+			name:          "Create weighted and address records",
+			linodeCluster: linodeCluster("linode"),
+			linodeMachine: linodeMachine(false),
+			weight:        50,
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).Return(nil, nil)
+				linode.EXPECT().CreateDomainRecord(gomock.Any(), 1, linodego.DomainRecordCreateOptions{
+					Type:     linodego.RecordTypeSRV,
+					Name:     "test-cluster-abc123",
+					Target:   "cp-0.test-cluster-abc123.lkedevs.net",
+					Priority: ptr.To(10),
+					Weight:   ptr.To(50),
+					Port:     ptr.To(6443),
+					Service:  ptr.To("kube-apiserver"),
+					Protocol: ptr.To("tcp"),
+					TTLSec:   30,
+				}).Return(&linodego.DomainRecord{}, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).Return(nil, nil)
+				linode.EXPECT().CreateDomainRecord(gomock.Any(), 1, linodego.DomainRecordCreateOptions{
+					Type:   linodego.RecordTypeA,
+					Name:   "cp-0.test-cluster-abc123",
+					Target: "172.0.0.10",
+					TTLSec: 30,
+				}).Return(&linodego.DomainRecord{}, nil)
+			},
+		},
+		{
+			name:          "Update weight and replace stale address record",
+			linodeCluster: linodeCluster("linode"),
+			linodeMachine: linodeMachine(false),
+			weight:        10,
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{{ID: 5, Type: linodego.RecordTypeSRV, Service: ptr.To("_kube-apiserver"), Protocol: ptr.To("_tcp"), Priority: 10, Weight: 50, Port: 6443}}, nil)
+				linode.EXPECT().UpdateDomainRecord(gomock.Any(), 1, 5, linodego.DomainRecordUpdateOptions{
+					Priority: ptr.To(10),
+					Weight:   ptr.To(10),
+					Port:     ptr.To(6443),
+					TTLSec:   30,
+				}).Return(&linodego.DomainRecord{}, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{{ID: 6, Type: linodego.RecordTypeA, Target: "172.0.0.9"}}, nil)
+				linode.EXPECT().DeleteDomainRecord(gomock.Any(), 1, 6).Return(nil)
+				linode.EXPECT().CreateDomainRecord(gomock.Any(), 1, gomock.Any()).Return(&linodego.DomainRecord{}, nil)
+			},
+		},
+		{
+			name:          "Records are up to date",
+			linodeCluster: linodeCluster("linode"),
+			linodeMachine: linodeMachine(false),
+			weight:        50,
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{{ID: 5, Type: linodego.RecordTypeSRV, Service: ptr.To("_kube-apiserver"), Protocol: ptr.To("_tcp"), Priority: 10, Weight: 50, Port: 6443}}, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{{ID: 6, Type: linodego.RecordTypeA, Target: "172.0.0.10"}}, nil)
+			},
+		},
+		{
+			name:          "Records are removed on machine deletion",
+			linodeCluster: linodeCluster("linode"),
+			linodeMachine: linodeMachine(true),
+			weight:        50,
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{{ID: 5, Type: linodego.RecordTypeSRV, Service: ptr.To("_kube-apiserver"), Protocol: ptr.To("_tcp")}}, nil)
+				linode.EXPECT().DeleteDomainRecord(gomock.Any(), 1, 5).Return(nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{{ID: 6, Type: linodego.RecordTypeA, Target: "172.0.0.10"}}, nil)
+				linode.EXPECT().DeleteDomainRecord(gomock.Any(), 1, 6).Return(nil)
+			},
+		},
+		{
+			name:          "Error - invalid weight",
+			linodeCluster: linodeCluster("linode"),
+			linodeMachine: linodeMachine(false),
+			weight:        70000,
+			expects:       func(linode *mock.MockLinodeClient) {},
+			expectedError: "invalid dns weight 70000, must be between 0 and 65535",
+		},
+		{
+			name:          "Error - akamai provider",
+			linodeCluster: linodeCluster("akamai"),
+			linodeMachine: linodeMachine(false),
+			weight:        50,
+			expects:       func(linode *mock.MockLinodeClient) {},
+			expectedError: "srv records are only supported by the linode dns provider",
+		},
+		{
+			name:          "Error - no external addresses",
+			linodeCluster: linodeCluster("linode"),
+			linodeMachine: &infrav1alpha2.LinodeMachine{ObjectMeta: metav1.ObjectMeta{Name: "cp-0"}},
+			weight:        50,
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+			},
+			expectedError: "no external addresses available on the LinodeMachine resource",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeDomainsClient: mockLinodeClient,
+				LinodeCluster:       testcase.linodeCluster,
+				LinodeMachine:       testcase.linodeMachine,
+			}
+
+			err := mScope.ReconcileWeightedDNS(context.Background(), testcase.weight)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMachineScopeReconcileSRVRecord(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+	linodeCluster := &infrav1alpha2.LinodeCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		Spec: infrav1alpha2.LinodeClusterSpec{
+			Network: infrav1alpha2.NetworkSpec{DNSRootDomain: "lkedevs.net"},
+		},
+	}
+	linodeMachine := func(deleting bool) *infrav1alpha2.LinodeMachine {
+		machine := &infrav1alpha2.LinodeMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "cp-0"},
+			Status: infrav1alpha2.LinodeMachineStatus{Addresses: []clusterv1.MachineAddress{
+				{Type: clusterv1.MachineExternalIP, Address: "172.0.0.10"},
+			}},
+		}
+		if deleting {
+			machine.DeletionTimestamp = &now
+			machine.Finalizers = []string{infrav1alpha2.MachineFinalizer}
+		}
+		return machine
+	}
+	domains := []linodego.Domain{{ID: 1, Domain: "lkedevs.net"}}
+	srvRecord := func(service string) linodego.DomainRecord {
+		return linodego.DomainRecord{
+			ID: 5, Type: linodego.RecordTypeSRV, Service: ptr.To("_" + service), Protocol: ptr.To("_tcp"),
+			Priority: 10, Weight: 5, Port: 443, Target: "cp-0.test-cluster.lkedevs.net",
+		}
+	}
+	addressRecord := linodego.DomainRecord{ID: 6, Type: linodego.RecordTypeA, Target: "172.0.0.10"}
+
+	tests := []struct {
+		name          string
+		linodeMachine *infrav1alpha2.LinodeMachine
+		port          int
+		expects       func(linode *mock.MockLinodeClient)
+		expectedError string
+	}{
+		{
+			name:          "Create srv record next to another service's",
+			linodeMachine: linodeMachine(false),
+			port:          443,
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{srvRecord("kube-apiserver")}, nil)
+				linode.EXPECT().CreateDomainRecord(gomock.Any(), 1, linodego.DomainRecordCreateOptions{
+					Type:     linodego.RecordTypeSRV,
+					Name:     "test-cluster",
+					Target:   "cp-0.test-cluster.lkedevs.net",
+					Priority: ptr.To(10),
+					Weight:   ptr.To(5),
+					Port:     ptr.To(443),
+					Service:  ptr.To("https"),
+					Protocol: ptr.To("tcp"),
+					TTLSec:   30,
+				}).Return(&linodego.DomainRecord{}, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{addressRecord}, nil)
+			},
+		},
+		{
+			name:          "Update port of srv record",
+			linodeMachine: linodeMachine(false),
+			port:          8443,
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{srvRecord("https")}, nil)
+				linode.EXPECT().UpdateDomainRecord(gomock.Any(), 1, 5, linodego.DomainRecordUpdateOptions{
+					Priority: ptr.To(10),
+					Weight:   ptr.To(5),
+					Port:     ptr.To(8443),
+					TTLSec:   30,
+				}).Return(&linodego.DomainRecord{}, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{addressRecord}, nil)
+			},
+		},
+		{
+			name:          "Records are removed on machine deletion",
+			linodeMachine: linodeMachine(true),
+			port:          443,
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{srvRecord("https")}, nil)
+				linode.EXPECT().DeleteDomainRecord(gomock.Any(), 1, 5).Return(nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{addressRecord}, nil)
+				linode.EXPECT().DeleteDomainRecord(gomock.Any(), 1, 6).Return(nil)
+			},
+		},
+		{
+			name:          "Error - invalid port",
+			linodeMachine: linodeMachine(false),
+			expects:       func(linode *mock.MockLinodeClient) {},
+			expectedError: "invalid srv port 0, must be between 1 and 65535",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeDomainsClient: mockLinodeClient,
+				LinodeCluster:       linodeCluster,
+				LinodeMachine:       testcase.linodeMachine,
+			}
+
+			err := mScope.ReconcileSRVRecord(context.Background(), "https", "tcp", testcase.port, 5, 10)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMachineScopeReconcileTXTRecords(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+	linodeCluster := &infrav1alpha2.LinodeCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		Spec: infrav1alpha2.LinodeClusterSpec{
+			Network: infrav1alpha2.NetworkSpec{DNSRootDomain: "lkedevs.net"},
+		},
+	}
+	tokenA := infrav1alpha2.TXTRecord{Name: "_acme-challenge.cp-0", Value: "token-a"}
+	tokenB := infrav1alpha2.TXTRecord{Name: "_acme-challenge.cp-0", Value: "token-b"}
+	domains := []linodego.Domain{{ID: 1, Domain: "lkedevs.net"}}
+	txtRecord := func(id int, value string) linodego.DomainRecord {
+		return linodego.DomainRecord{ID: id, Type: linodego.RecordTypeTXT, Name: "_acme-challenge.cp-0", Target: value, TTLSec: 30}
+	}
+
+	tests := []struct {
+		name            string
+		spec            []infrav1alpha2.TXTRecord
+		managed         []infrav1alpha2.TXTRecord
+		deleting        bool
+		expects         func(linode *mock.MockLinodeClient)
+		expectedManaged []infrav1alpha2.TXTRecord
+		expectedError   string
+	}{
+		{
+			name:    "Nothing to do without records",
+			expects: func(linode *mock.MockLinodeClient) {},
+		},
+		{
+			name: "Create records of the spec",
+			spec: []infrav1alpha2.TXTRecord{tokenA},
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).Return(nil, nil)
+				linode.EXPECT().CreateDomainRecord(gomock.Any(), 1, linodego.DomainRecordCreateOptions{
+					Type:   linodego.RecordTypeTXT,
+					Name:   "_acme-challenge.cp-0",
+					Target: "token-a",
+					TTLSec: 30,
+				}).Return(&linodego.DomainRecord{}, nil)
+			},
+			expectedManaged: []infrav1alpha2.TXTRecord{tokenA},
+		},
+		{
+			name:    "Remove records dropped from the spec",
+			spec:    []infrav1alpha2.TXTRecord{tokenB},
+			managed: []infrav1alpha2.TXTRecord{tokenA, tokenB},
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{txtRecord(5, "token-a"), txtRecord(6, "token-b")}, nil)
+				linode.EXPECT().DeleteDomainRecord(gomock.Any(), 1, 5).Return(nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{txtRecord(6, "token-b")}, nil)
+			},
+			expectedManaged: []infrav1alpha2.TXTRecord{tokenB},
+		},
+		{
+			name:     "Remove every managed record on machine deletion",
+			spec:     []infrav1alpha2.TXTRecord{tokenA},
+			managed:  []infrav1alpha2.TXTRecord{tokenA},
+			deleting: true,
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{txtRecord(5, "token-a")}, nil)
+				linode.EXPECT().DeleteDomainRecord(gomock.Any(), 1, 5).Return(nil)
+			},
+			expectedManaged: []infrav1alpha2.TXTRecord{},
+		},
+		{
+			name:     "Error - failed removal keeps the record managed",
+			managed:  []infrav1alpha2.TXTRecord{tokenA, tokenB},
+			deleting: true,
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{txtRecord(5, "token-a")}, nil)
+				linode.EXPECT().DeleteDomainRecord(gomock.Any(), 1, 5).Return(errors.New("api error"))
+			},
+			expectedManaged: []infrav1alpha2.TXTRecord{tokenA, tokenB},
+			expectedError:   "delete domain record 5: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			linodeMachine := &infrav1alpha2.LinodeMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "cp-0"},
+				Spec:       infrav1alpha2.LinodeMachineSpec{TXTRecords: testcase.spec},
+				Status:     infrav1alpha2.LinodeMachineStatus{ManagedTXTRecords: testcase.managed},
+			}
+			if testcase.deleting {
+				linodeMachine.DeletionTimestamp = &now
+				linodeMachine.Finalizers = []string{infrav1alpha2.MachineFinalizer}
+			}
+			mScope := &MachineScope{
+				LinodeDomainsClient: mockLinodeClient,
+				LinodeCluster:       linodeCluster,
+				LinodeMachine:       linodeMachine,
+			}
+
+			err := mScope.ReconcileTXTRecords(context.Background())
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			if testcase.expectedManaged != nil {
+				require.Equal(t, testcase.expectedManaged, linodeMachine.Status.ManagedTXTRecords)
+			}
+		})
+	}
+}
+
+func TestMachineScopeReconcileTXTRecord(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+	linodeCluster := func(provider string) *infrav1alpha2.LinodeCluster {
+		return &infrav1alpha2.LinodeCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+			Spec: infrav1alpha2.LinodeClusterSpec{
+				Network: infrav1alpha2.NetworkSpec{DNSRootDomain: "lkedevs.net", DNSProvider: provider},
+			},
+		}
+	}
+	linodeMachine := func(deleting bool) *infrav1alpha2.LinodeMachine {
+		machine := &infrav1alpha2.LinodeMachine{ObjectMeta: metav1.ObjectMeta{Name: "cp-0"}}
+		if deleting {
+			machine.DeletionTimestamp = &now
+			machine.Finalizers = []string{infrav1alpha2.MachineFinalizer}
+		}
+		return machine
+	}
+	domains := []linodego.Domain{{ID: 1, Domain: "lkedevs.net"}}
+	txtRecord := func(id int, value string, ttl int) linodego.DomainRecord {
+		return linodego.DomainRecord{ID: id, Type: linodego.RecordTypeTXT, Name: "_acme-challenge.cp-0", Target: value, TTLSec: ttl}
+	}
+
+	tests := []struct {
+		name          string
+		linodeCluster *infrav1alpha2.LinodeCluster
+		linodeMachine *infrav1alpha2.LinodeMachine
+		value         string
+		expects       func(linode *mock.MockLinodeClient)
+		expectedError string
+	}{
+		{
+			name:          "Create txt record next to another value",
+			linodeCluster: linodeCluster(""),
+			linodeMachine: linodeMachine(false),
+			value:         "token-b",
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{txtRecord(5, "token-a", 30)}, nil)
+				linode.EXPECT().CreateDomainRecord(gomock.Any(), 1, linodego.DomainRecordCreateOptions{
+					Type:   linodego.RecordTypeTXT,
+					Name:   "_acme-challenge.cp-0",
+					Target: "token-b",
+					TTLSec: 30,
+				}).Return(&linodego.DomainRecord{}, nil)
+			},
+		},
+		{
+			name:          "Existing txt record is left alone",
+			linodeCluster: linodeCluster(""),
+			linodeMachine: linodeMachine(false),
+			value:         "token-a",
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{txtRecord(5, "token-a", 30), txtRecord(6, "token-b", 30)}, nil)
+			},
+		},
+		{
+			name:          "Update ttl of txt record",
+			linodeCluster: linodeCluster(""),
+			linodeMachine: linodeMachine(false),
+			value:         "token-a",
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{txtRecord(5, "token-a", 300)}, nil)
+				linode.EXPECT().UpdateDomainRecord(gomock.Any(), 1, 5, linodego.DomainRecordUpdateOptions{TTLSec: 30}).
+					Return(&linodego.DomainRecord{}, nil)
+			},
+		},
+		{
+			name:          "Only the value is removed on machine deletion",
+			linodeCluster: linodeCluster(""),
+			linodeMachine: linodeMachine(true),
+			value:         "token-a",
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{txtRecord(5, "token-a", 30), txtRecord(6, "token-b", 30)}, nil)
+				linode.EXPECT().DeleteDomainRecord(gomock.Any(), 1, 5).Return(nil)
+			},
+		},
+		{
+			name:          "Error - missing value",
+			linodeCluster: linodeCluster(""),
+			linodeMachine: linodeMachine(false),
+			expects:       func(linode *mock.MockLinodeClient) {},
+			expectedError: "txt record name and value are required",
+		},
+		{
+			name:          "Error - akamai dns provider",
+			linodeCluster: linodeCluster("akamai"),
+			linodeMachine: linodeMachine(false),
+			value:         "token-a",
+			expects:       func(linode *mock.MockLinodeClient) {},
+			expectedError: "txt records are only supported by the linode dns provider",
+		},
+		{
+			name:          "Error - create txt record",
+			linodeCluster: linodeCluster(""),
+			linodeMachine: linodeMachine(false),
+			value:         "token-a",
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).Return(nil, nil)
+				linode.EXPECT().CreateDomainRecord(gomock.Any(), 1, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "create domain record: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeDomainsClient: mockLinodeClient,
+				LinodeCluster:       testcase.linodeCluster,
+				LinodeMachine:       testcase.linodeMachine,
+			}
+
+			err := mScope.ReconcileTXTRecord(context.Background(), "_acme-challenge.cp-0", testcase.value)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMachineScopeReconcileSplitHorizonDNS(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+	zones := &infrav1alpha2.SplitHorizonDNS{InternalDomain: "internal.example.com", ExternalDomain: "example.com"}
+	linodeMachine := func(zones *infrav1alpha2.SplitHorizonDNS, deleting bool) *infrav1alpha2.LinodeMachine {
+		machine := &infrav1alpha2.LinodeMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "cp-0"},
+			Spec:       infrav1alpha2.LinodeMachineSpec{SplitHorizonDNS: zones},
+			Status: infrav1alpha2.LinodeMachineStatus{Addresses: []clusterv1.MachineAddress{
+				{Type: clusterv1.MachineExternalIP, Address: "172.0.0.10"},
+				{Type: clusterv1.MachineInternalIP, Address: "192.168.128.10"},
+			}},
+		}
+		if deleting {
+			machine.DeletionTimestamp = &now
+			machine.Finalizers = []string{infrav1alpha2.MachineFinalizer}
+		}
+		return machine
+	}
+	expectDomains := func(linode *mock.MockLinodeClient) {
+		gomock.InOrder(
+			linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return([]linodego.Domain{{ID: 1, Domain: "internal.example.com"}}, nil),
+			linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return([]linodego.Domain{{ID: 2, Domain: "example.com"}}, nil),
+		)
+	}
+
+	controlPlane := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{clusterv1.MachineControlPlaneLabel: "true"}}}
+
+	tests := []struct {
+		name          string
+		linodeMachine *infrav1alpha2.LinodeMachine
+		machine       *clusterv1.Machine
+		nbIP          string
+		expects       func(linode *mock.MockLinodeClient)
+		expectedError string
+	}{
+		{
+			name:          "Split-horizon DNS not configured",
+			linodeMachine: linodeMachine(nil, false),
+			expects:       func(linode *mock.MockLinodeClient) {},
+		},
+		{
+			name:          "Create records with the address of each zone",
+			linodeMachine: linodeMachine(zones, false),
+			expects: func(linode *mock.MockLinodeClient) {
+				expectDomains(linode)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).Return(nil, nil)
+				linode.EXPECT().CreateDomainRecord(gomock.Any(), 1, linodego.DomainRecordCreateOptions{
+					Type:   linodego.RecordTypeA,
+					Name:   "cp-0",
+					Target: "192.168.128.10",
+					TTLSec: 30,
+				}).Return(&linodego.DomainRecord{}, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 2, gomock.Any()).Return(nil, nil)
+				linode.EXPECT().CreateDomainRecord(gomock.Any(), 2, linodego.DomainRecordCreateOptions{
+					Type:   linodego.RecordTypeA,
+					Name:   "cp-0",
+					Target: "172.0.0.10",
+					TTLSec: 30,
+				}).Return(&linodego.DomainRecord{}, nil)
+			},
+		},
+		{
+			name:          "Control plane machine is registered behind the NodeBalancer",
+			linodeMachine: linodeMachine(zones, false),
+			machine:       controlPlane,
+			nbIP:          "172.0.0.100",
+			expects: func(linode *mock.MockLinodeClient) {
+				expectDomains(linode)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{{ID: 5, Type: linodego.RecordTypeA, Target: "192.168.128.10"}}, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 2, gomock.Any()).
+					Return([]linodego.DomainRecord{{ID: 6, Type: linodego.RecordTypeA, Target: "172.0.0.10"}}, nil)
+				linode.EXPECT().DeleteDomainRecord(gomock.Any(), 2, 6).Return(nil)
+				linode.EXPECT().CreateDomainRecord(gomock.Any(), 2, linodego.DomainRecordCreateOptions{
+					Type:   linodego.RecordTypeA,
+					Name:   "cp-0",
+					Target: "172.0.0.100",
+					TTLSec: 30,
+				}).Return(&linodego.DomainRecord{}, nil)
+			},
+		},
+		{
+			name:          "Worker machine uses its instance IP",
+			linodeMachine: linodeMachine(zones, false),
+			machine:       &clusterv1.Machine{},
+			nbIP:          "172.0.0.100",
+			expects: func(linode *mock.MockLinodeClient) {
+				expectDomains(linode)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{{ID: 5, Type: linodego.RecordTypeA, Target: "192.168.128.10"}}, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 2, gomock.Any()).
+					Return([]linodego.DomainRecord{{ID: 6, Type: linodego.RecordTypeA, Target: "172.0.0.10"}}, nil)
+			},
+		},
+		{
+			name:          "Records are up to date",
+			linodeMachine: linodeMachine(zones, false),
+			expects: func(linode *mock.MockLinodeClient) {
+				expectDomains(linode)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{{ID: 5, Type: linodego.RecordTypeA, Target: "192.168.128.10"}}, nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 2, gomock.Any()).
+					Return([]linodego.DomainRecord{{ID: 6, Type: linodego.RecordTypeA, Target: "172.0.0.10"}}, nil)
+			},
+		},
+		{
+			name:          "Records are removed on machine deletion",
+			linodeMachine: linodeMachine(zones, true),
+			expects: func(linode *mock.MockLinodeClient) {
+				expectDomains(linode)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{{ID: 5, Type: linodego.RecordTypeA, Target: "192.168.128.10"}}, nil)
+				linode.EXPECT().DeleteDomainRecord(gomock.Any(), 1, 5).Return(nil)
+				linode.EXPECT().ListDomainRecords(gomock.Any(), 2, gomock.Any()).
+					Return([]linodego.DomainRecord{{ID: 6, Type: linodego.RecordTypeA, Target: "172.0.0.10"}}, nil)
+				linode.EXPECT().DeleteDomainRecord(gomock.Any(), 2, 6).Return(nil)
+			},
+		},
+		{
+			name:          "Error - internal domain missing",
+			linodeMachine: linodeMachine(&infrav1alpha2.SplitHorizonDNS{ExternalDomain: "example.com"}, false),
+			expects:       func(linode *mock.MockLinodeClient) {},
+			expectedError: "split-horizon dns internal domain is not configured on the LinodeMachine",
+		},
+		{
+			name:          "Error - external domain missing",
+			linodeMachine: linodeMachine(&infrav1alpha2.SplitHorizonDNS{InternalDomain: "internal.example.com"}, false),
+			expects:       func(linode *mock.MockLinodeClient) {},
+			expectedError: "split-horizon dns external domain is not configured on the LinodeMachine",
+		},
+		{
+			name:          "Error - domain not owned by the account",
+			linodeMachine: linodeMachine(zones, false),
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+			expectedError: "domain internal.example.com not found in list of domains owned by this account",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeDomainsClient: mockLinodeClient,
+				Machine:             testcase.machine,
+				LinodeCluster:       &infrav1alpha2.LinodeCluster{},
+				LinodeMachine:       testcase.linodeMachine,
+			}
+
+			err := mScope.ReconcileSplitHorizonDNS(context.Background(), testcase.nbIP)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMachineScopeValidateDNSZone(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		network       infrav1alpha2.NetworkSpec
+		expects       func(linode *mock.MockLinodeClient)
+		expectedID    int
+		expectedError string
+	}{
+		{
+			name:    "Success - DNS is not configured",
+			network: infrav1alpha2.NetworkSpec{},
+			expects: func(linode *mock.MockLinodeClient) {},
+		},
+		{
+			name:    "Success - Akamai DNS has no Linode domain",
+			network: infrav1alpha2.NetworkSpec{DNSProvider: "akamai", DNSRootDomain: "lkedevs.net"},
+			expects: func(linode *mock.MockLinodeClient) {},
+		},
+		{
+			name:    "Success - Domain is resolved once",
+			network: infrav1alpha2.NetworkSpec{DNSRootDomain: "lkedevs.net"},
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return([]linodego.Domain{{ID: 7, Domain: "lkedevs.net"}}, nil).Times(1)
+			},
+			expectedID: 7,
+		},
+		{
+			name:    "Error - Domain is not owned by the account",
+			network: infrav1alpha2.NetworkSpec{DNSRootDomain: "lkedevs.net"},
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return([]linodego.Domain{{ID: 7, Domain: "other.net"}}, nil)
+			},
+			expectedError: "domain lkedevs.net not found in list of domains owned by this account",
+		},
+		{
+			name:    "Error - Listing domains fails",
+			network: infrav1alpha2.NetworkSpec{DNSRootDomain: "lkedevs.net"},
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "list domains: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeDomainsClient: mockLinodeClient,
+				LinodeCluster: &infrav1alpha2.LinodeCluster{
+					Spec: infrav1alpha2.LinodeClusterSpec{Network: testcase.network},
+				},
+			}
+
+			domainID, err := mScope.ValidateDNSZone(context.Background())
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testcase.expectedID, domainID)
+
+			// The resolved domain is cached for the scope lifetime
+			domainID, err = mScope.ValidateDNSZone(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, testcase.expectedID, domainID)
+		})
+	}
+}
+
+// fakeResolver answers lookups from fixed records, keyed by host.
+type fakeResolver struct {
+	ns    map[string][]*net.NS
+	addrs map[string][]netip.Addr
+	err   error
+}
+
+func (r fakeResolver) LookupNS(ctx context.Context, name string) ([]*net.NS, error) {
+	return r.ns[name], r.err
+}
+
+func (r fakeResolver) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	addrs, ok := r.addrs[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return addrs, nil
+}
+
+//nolint:paralleltest // the package resolvers are replaced for each nested t.Run
+func TestMachineScopeDNSRecordPropagated(t *testing.T) {
+	resolverFor, system := nameserverResolver, systemResolver
+	t.Cleanup(func() { nameserverResolver, systemResolver = resolverFor, system })
+
+	fqdn := "test-cluster-abc.example.com"
+	propagated := fakeResolver{addrs: map[string][]netip.Addr{fqdn: {netip.MustParseAddr("10.0.0.1")}}}
+
+	tests := []struct {
+		name          string
+		provider      string
+		ip            string
+		nameservers   map[string]fakeResolver
+		system        fakeResolver
+		ctx           func() context.Context
+		want          bool
+		expectedError string
+	}{
+		{
+			name: "Propagated to all Linode nameservers",
+			ip:   "10.0.0.1",
+			nameservers: map[string]fakeResolver{
+				"ns1.linode.com": propagated, "ns2.linode.com": propagated, "ns3.linode.com": propagated,
+				"ns4.linode.com": propagated, "ns5.linode.com": propagated,
+			},
+			want: true,
+		},
+		{
+			name: "Not yet known to a nameserver",
+			ip:   "10.0.0.1",
+			nameservers: map[string]fakeResolver{
+				"ns1.linode.com": propagated, "ns2.linode.com": {},
+			},
+			want: false,
+		},
+		{
+			name: "Nameserver resolves a stale address",
+			ip:   "10.0.0.2",
+			nameservers: map[string]fakeResolver{
+				"ns1.linode.com": propagated,
+			},
+			want: false,
+		},
+		{
+			name:     "Propagated to the Akamai zone nameservers",
+			provider: "akamai",
+			ip:       "10.0.0.1",
+			system:   fakeResolver{ns: map[string][]*net.NS{"example.com": {{Host: "a1-1.akam.net."}, {Host: "a2-2.akam.net."}}}},
+			nameservers: map[string]fakeResolver{
+				"a1-1.akam.net": propagated, "a2-2.akam.net": propagated,
+			},
+			want: true,
+		},
+		{
+			name: "Error - lookup fails",
+			ip:   "10.0.0.1",
+			nameservers: map[string]fakeResolver{
+				"ns1.linode.com": {err: &net.DNSError{Err: "i/o timeout", IsTimeout: true}},
+			},
+			expectedError: "lookup test-cluster-abc.example.com on ns1.linode.com",
+		},
+		{
+			name: "Error - context canceled",
+			ip:   "10.0.0.1",
+			nameservers: map[string]fakeResolver{
+				"ns1.linode.com": {err: context.Canceled},
+			},
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+			expectedError: "context canceled",
+		},
+		{
+			name:          "Error - invalid IP",
+			ip:            "not-an-ip",
+			expectedError: "not a valid IP",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			nameserverResolver = func(nameserver string) dnsResolver { return testcase.nameservers[nameserver] }
+			systemResolver = testcase.system
+
+			ctx := context.Background()
+			if testcase.ctx != nil {
+				ctx = testcase.ctx()
+			}
+			mScope := &MachineScope{
+				LinodeCluster: &infrav1alpha2.LinodeCluster{
+					Spec: infrav1alpha2.LinodeClusterSpec{
+						Network: infrav1alpha2.NetworkSpec{DNSRootDomain: "example.com", DNSProvider: testcase.provider},
+					},
+				},
+			}
+
+			got, err := mScope.DNSRecordPropagated(ctx, fqdn, testcase.ip)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testcase.want, got)
+		})
+	}
+}
@@ -0,0 +1,526 @@
+package scope
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/linode/linodego"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/util"
+)
+
+// Label prefixes of the firewall rules managed by CAPL, so they can be told apart from rules
+// managed by other means.
+const (
+	// clusterFirewallRuleLabelPrefix prefixes the rules derived from the Cluster's CIDRs.
+	clusterFirewallRuleLabelPrefix = "capl-cluster-"
+	// managementFirewallRuleLabelPrefix prefixes the rules admitting the management cluster.
+	managementFirewallRuleLabelPrefix = "capl-management-"
+)
+
+// nodeBalancerFirewallTagPrefix is the prefix of the NodeBalancer tag recording the ID of the
+// firewall CAPL attached the NodeBalancer to.
+const nodeBalancerFirewallTagPrefix = "capl-firewall:"
+
+// ReconcileFirewallByLabel attaches the instance to the account firewalls with the given
+// labels, and detaches it from the firewalls it was previously attached to by label that
+// are no longer desired. The IDs of the attached firewalls are recorded in the status, so
+// firewalls attached by other means, such as the spec's firewall ID, are left alone. An
+// error naming the missing labels is returned if any label does not match a firewall.
+func (s *MachineScope) ReconcileFirewallByLabel(ctx context.Context, instanceID int, labels []string) error {
+	if len(labels) == 0 && len(s.LinodeMachine.Status.ManagedFirewallIDs) == 0 {
+		return nil
+	}
+
+	firewalls, err := s.LinodeClient.ListFirewalls(ctx, &linodego.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("list firewalls: %w", err)
+	}
+	var (
+		desired []int
+		missing []string
+	)
+	for _, label := range labels {
+		idx := slices.IndexFunc(firewalls, func(firewall linodego.Firewall) bool { return firewall.Label == label })
+		if idx < 0 {
+			missing = append(missing, label)
+			continue
+		}
+		if !slices.Contains(desired, firewalls[idx].ID) {
+			desired = append(desired, firewalls[idx].ID)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("firewalls %s do not exist", strings.Join(missing, ", "))
+	}
+
+	attached, err := s.LinodeClient.ListInstanceFirewalls(ctx, instanceID, &linodego.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("list instance %d firewalls: %w", instanceID, err)
+	}
+	isAttached := func(firewallID int) bool {
+		return slices.ContainsFunc(attached, func(firewall linodego.Firewall) bool { return firewall.ID == firewallID })
+	}
+
+	for _, firewallID := range desired {
+		if isAttached(firewallID) {
+			continue
+		}
+		if _, err := s.LinodeClient.CreateFirewallDevice(ctx, firewallID, linodego.FirewallDeviceCreateOptions{
+			ID:   instanceID,
+			Type: linodego.FirewallDeviceLinode,
+		}); err != nil {
+			return fmt.Errorf("attach instance %d to firewall %d: %w", instanceID, firewallID, err)
+		}
+	}
+
+	for _, firewallID := range s.LinodeMachine.Status.ManagedFirewallIDs {
+		if slices.Contains(desired, firewallID) || !isAttached(firewallID) {
+			continue
+		}
+		if err := s.detachFirewall(ctx, instanceID, firewallID); err != nil {
+			return err
+		}
+	}
+	s.LinodeMachine.Status.ManagedFirewallIDs = desired
+
+	return nil
+}
+
+// ReconcileNodeBalancerFirewall attaches the NodeBalancer to the firewall, and detaches it from
+// the firewalls CAPL previously attached it to. The attached firewall is recorded in a tag of the
+// NodeBalancer, so firewalls attached by other means are left alone. A firewall ID of 0 only
+// detaches the previously attached firewalls. A missing NodeBalancer or firewall is not an error.
+func (s *MachineScope) ReconcileNodeBalancerFirewall(ctx context.Context, nbID, firewallID int) error {
+	nodeBalancer, err := s.LinodeClient.GetNodeBalancer(ctx, nbID)
+	if err != nil {
+		return util.IgnoreLinodeAPIError(err, http.StatusNotFound)
+	}
+	attached, err := s.LinodeClient.ListNodeBalancerFirewalls(ctx, nbID, &linodego.ListOptions{})
+	if err != nil {
+		return util.IgnoreLinodeAPIError(fmt.Errorf("list nodebalancer %d firewalls: %w", nbID, err), http.StatusNotFound)
+	}
+	isAttached := func(id int) bool {
+		return slices.ContainsFunc(attached, func(firewall linodego.Firewall) bool { return firewall.ID == id })
+	}
+
+	var desiredTags []string
+	if firewallID != 0 {
+		desiredTags = append(desiredTags, nodeBalancerFirewallTagPrefix+strconv.Itoa(firewallID))
+		if !isAttached(firewallID) {
+			_, err := s.LinodeClient.CreateFirewallDevice(ctx, firewallID, linodego.FirewallDeviceCreateOptions{
+				ID:   nbID,
+				Type: linodego.FirewallDeviceNodeBalancer,
+			})
+			if linodego.ErrHasStatus(err, http.StatusNotFound) {
+				desiredTags = nil
+			} else if err != nil {
+				return fmt.Errorf("attach nodebalancer %d to firewall %d: %w", nbID, firewallID, err)
+			}
+		}
+	}
+
+	var staleTags []string
+	for _, tag := range nodeBalancer.Tags {
+		if !strings.HasPrefix(tag, nodeBalancerFirewallTagPrefix) || slices.Contains(desiredTags, tag) {
+			continue
+		}
+		staleTags = append(staleTags, tag)
+		id, err := strconv.Atoi(strings.TrimPrefix(tag, nodeBalancerFirewallTagPrefix))
+		if err != nil || id == firewallID || !isAttached(id) {
+			continue
+		}
+		devices, err := s.LinodeClient.ListFirewallDevices(ctx, id, &linodego.ListOptions{})
+		if err != nil {
+			return util.IgnoreLinodeAPIError(fmt.Errorf("list firewall %d devices: %w", id, err), http.StatusNotFound)
+		}
+		for _, device := range devices {
+			if device.Entity.Type != linodego.FirewallDeviceNodeBalancer || device.Entity.ID != nbID {
+				continue
+			}
+			if err := s.LinodeClient.DeleteFirewallDevice(ctx, id, device.ID); util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
+				return fmt.Errorf("detach nodebalancer %d from firewall %d: %w", nbID, id, err)
+			}
+		}
+	}
+
+	tags := mergeTags(nodeBalancer.Tags, desiredTags, staleTags)
+	if slices.Equal(tags, nodeBalancer.Tags) {
+		return nil
+	}
+	if _, err := s.LinodeClient.UpdateNodeBalancer(ctx, nbID, linodego.NodeBalancerUpdateOptions{Tags: &tags}); err != nil {
+		return fmt.Errorf("update nodebalancer %d tags: %w", nbID, err)
+	}
+
+	return nil
+}
+
+// FirewallChanges reports which parts of the spec's firewall were changed by ReconcileFirewall.
+type FirewallChanges struct {
+	// Policy is whether the default inbound or outbound policy was changed.
+	Policy bool
+	// ClusterRules is whether the rules derived from the Cluster's CIDRs were changed.
+	ClusterRules bool
+	// SpecRules is whether the rules from the spec's firewall rules were changed.
+	SpecRules bool
+	// ManagementRules is whether the rules admitting the management cluster were changed.
+	ManagementRules bool
+}
+
+// Changed reports whether any part of the firewall was changed.
+func (c FirewallChanges) Changed() bool {
+	return c.Policy || c.ClusterRules || c.SpecRules || c.ManagementRules
+}
+
+// ReconcileFirewall applies the spec's firewall policy, the rules derived from the Cluster's
+// CIDRs when the spec asks for them, the spec's firewall rules, and the rules admitting the
+// management cluster to the spec's firewall. The firewall's rules are read once, every part
+// is merged in memory, and the result is written once if it differs, so the parts neither
+// overwrite each other's changes nor cost an API round trip each. Rules managed by other means
+// are left untouched. Unset policies are left as they are.
+func (s *MachineScope) ReconcileFirewall(ctx context.Context) (FirewallChanges, error) {
+	var changes FirewallChanges
+	spec := s.LinodeMachine.Spec
+	firewallID := spec.FirewallID
+	specRules := len(spec.FirewallRules) > 0 || len(s.LinodeMachine.Status.ManagedFirewallRules) > 0
+	if firewallID == 0 || (spec.FirewallPolicy == nil && !spec.ClusterFirewallRules && !specRules && len(s.ManagementCIDRs) == 0) {
+		return changes, nil
+	}
+
+	var clusterRules, managementRules []linodego.FirewallRule
+	if spec.ClusterFirewallRules {
+		derived, err := s.DeriveClusterFirewallRules(ctx)
+		if err != nil {
+			return changes, err
+		}
+		clusterRules = derived
+	}
+	desiredSpecRules, labels, err := s.desiredFirewallRules()
+	if err != nil {
+		return changes, err
+	}
+	if len(s.ManagementCIDRs) > 0 {
+		if managementRules, err = managementFirewallRules(s.ManagementCIDRs); err != nil {
+			return changes, err
+		}
+	}
+
+	rules, err := s.LinodeClient.GetFirewallRules(ctx, firewallID)
+	if err != nil {
+		return changes, fmt.Errorf("get firewall %d rules: %w", firewallID, err)
+	}
+	desired := *rules
+	if policy := spec.FirewallPolicy; policy != nil {
+		if policy.Inbound != "" {
+			desired.InboundPolicy = policy.Inbound
+		}
+		if policy.Outbound != "" {
+			desired.OutboundPolicy = policy.Outbound
+		}
+		changes.Policy = desired.InboundPolicy != rules.InboundPolicy || desired.OutboundPolicy != rules.OutboundPolicy
+	}
+	if spec.ClusterFirewallRules {
+		changes.ClusterRules = replaceInboundRules(&desired, clusterFirewallRuleLabelPrefix, clusterRules)
+	}
+	if specRules {
+		changes.SpecRules = s.applyFirewallRules(&desired, desiredSpecRules)
+	}
+	if len(s.ManagementCIDRs) > 0 {
+		changes.ManagementRules = replaceInboundRules(&desired, managementFirewallRuleLabelPrefix, managementRules)
+	}
+
+	if changes.Changed() {
+		if _, err := s.LinodeClient.UpdateFirewallRules(ctx, firewallID, desired); err != nil {
+			return FirewallChanges{}, fmt.Errorf("update firewall %d rules: %w", firewallID, err)
+		}
+	}
+	if specRules {
+		s.LinodeMachine.Status.ManagedFirewallRules = labels
+	}
+
+	return changes, nil
+}
+
+// DeriveClusterFirewallRules builds inbound rules accepting TCP, UDP and ICMP traffic from the
+// pod and service CIDRs of the Cluster's cluster network, and from the IPv4 ranges of the
+// subnets of the LinodeCluster's VPC the nodes are in. Sources without CIDRs get no rules.
+func (s *MachineScope) DeriveClusterFirewallRules(ctx context.Context) ([]linodego.FirewallRule, error) {
+	var pods, services, nodes []string
+	if network := s.Cluster.Spec.ClusterNetwork; network != nil {
+		if network.Pods != nil {
+			pods = network.Pods.CIDRBlocks
+		}
+		if network.Services != nil {
+			services = network.Services.CIDRBlocks
+		}
+	}
+	if vpcRef := s.LinodeCluster.Spec.VPCRef; vpcRef != nil {
+		linodeVPC := &infrav1alpha2.LinodeVPC{}
+		key := client.ObjectKey{Namespace: vpcRef.Namespace, Name: vpcRef.Name}
+		if key.Namespace == "" {
+			key.Namespace = s.LinodeCluster.Namespace
+		}
+		if err := s.Client.Get(ctx, key, linodeVPC); err != nil {
+			return nil, fmt.Errorf("get linodevpc %s: %w", key, err)
+		}
+		for _, subnet := range linodeVPC.Spec.Subnets {
+			if subnet.IPv4 != "" {
+				nodes = append(nodes, subnet.IPv4)
+			}
+		}
+	}
+
+	var rules []linodego.FirewallRule
+	for _, source := range []struct {
+		name  string
+		cidrs []string
+	}{
+		{"pods", pods},
+		{"services", services},
+		{"nodes", nodes},
+	} {
+		if len(source.cidrs) == 0 {
+			continue
+		}
+		addresses, err := firewallAddresses(source.cidrs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s cidr %w", source.name, err)
+		}
+		for _, protocol := range []linodego.NetworkProtocol{linodego.TCP, linodego.UDP, linodego.ICMP} {
+			rules = append(rules, linodego.FirewallRule{
+				Action:      "ACCEPT",
+				Label:       clusterFirewallRuleLabelPrefix + source.name + "-" + strings.ToLower(string(protocol)),
+				Description: fmt.Sprintf("Allow %s traffic from the %s of cluster %s", protocol, source.name, s.Cluster.Name),
+				Protocol:    protocol,
+				Addresses:   addresses,
+			})
+		}
+	}
+
+	return rules, nil
+}
+
+// desiredFirewallRules validates the spec's firewall rules, and returns them by direction and
+// label along with their labels.
+func (s *MachineScope) desiredFirewallRules() (map[string]map[string]linodego.FirewallRule, []string, error) {
+	desired := map[string]map[string]linodego.FirewallRule{"inbound": {}, "outbound": {}}
+	labels := make([]string, 0, len(s.LinodeMachine.Spec.FirewallRules))
+	for _, rule := range s.LinodeMachine.Spec.FirewallRules {
+		if strings.HasPrefix(rule.Label, clusterFirewallRuleLabelPrefix) || strings.HasPrefix(rule.Label, managementFirewallRuleLabelPrefix) {
+			return nil, nil, fmt.Errorf("firewall rule label %q uses a prefix reserved for rules managed by CAPL", rule.Label)
+		}
+		if slices.Contains(labels, rule.Label) {
+			return nil, nil, fmt.Errorf("firewall rule label %q is not unique", rule.Label)
+		}
+		if _, ok := desired[rule.Direction]; !ok {
+			return nil, nil, fmt.Errorf("firewall rule %q has unknown direction %q", rule.Label, rule.Direction)
+		}
+		addresses, err := firewallAddresses(rule.Addresses)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid firewall rule %q address %w", rule.Label, err)
+		}
+		labels = append(labels, rule.Label)
+		desired[rule.Direction][rule.Label] = linodego.FirewallRule{
+			Action:      rule.Action,
+			Label:       rule.Label,
+			Description: rule.Description,
+			Ports:       rule.Ports,
+			Protocol:    linodego.NetworkProtocol(rule.Protocol),
+			Addresses:   addresses,
+		}
+	}
+
+	return desired, labels, nil
+}
+
+// applyFirewallRules applies the spec's firewall rules to the rule set. Rules are matched by
+// label, so a rule whose other fields drifted is replaced in place and the order of the
+// firewall's rules is kept stable. Rules removed from the spec are removed from the firewall
+// only if CAPL set them, as recorded in the status, so rules managed by other means are left
+// alone. It reports whether the rules were changed.
+func (s *MachineScope) applyFirewallRules(rules *linodego.FirewallRuleSet, desired map[string]map[string]linodego.FirewallRule) bool {
+	managed := s.LinodeMachine.Status.ManagedFirewallRules
+
+	// apply replaces the rules with a desired label in place, drops the managed rules which
+	// are no longer desired, and appends the desired rules the firewall does not have yet.
+	apply := func(current []linodego.FirewallRule, direction string) []linodego.FirewallRule {
+		applied := make([]linodego.FirewallRule, 0, len(current)+len(desired[direction]))
+		var seen []string
+		for _, rule := range current {
+			want, ok := desired[direction][rule.Label]
+			switch {
+			case ok && !slices.Contains(seen, rule.Label):
+				applied = append(applied, want)
+				seen = append(seen, rule.Label)
+			case ok, slices.Contains(managed, rule.Label):
+				// A duplicate of a desired rule, or a managed rule which is no longer desired
+			default:
+				applied = append(applied, rule)
+			}
+		}
+		for _, rule := range s.LinodeMachine.Spec.FirewallRules {
+			if rule.Direction == direction && !slices.Contains(seen, rule.Label) {
+				applied = append(applied, desired[direction][rule.Label])
+			}
+		}
+		return applied
+	}
+	inbound := apply(rules.Inbound, "inbound")
+	outbound := apply(rules.Outbound, "outbound")
+	if firewallRulesEqual(inbound, rules.Inbound) && firewallRulesEqual(outbound, rules.Outbound) {
+		return false
+	}
+	rules.Inbound = inbound
+	rules.Outbound = outbound
+
+	return true
+}
+
+// AllowManagementAccess ensures the firewall has inbound rules accepting TCP and ICMP traffic
+// from the management cluster's egress CIDRs, so the controller is not locked out of the nodes
+// it manages by a default-deny policy. When the LinodeMachine is being deleted the rules are
+// removed, unless the firewall still protects other devices which may need them. Without
+// management CIDRs the firewall is left untouched.
+func (s *MachineScope) AllowManagementAccess(ctx context.Context, firewallID int, mgmtCIDRs []string) error {
+	deleting := !s.LinodeMachine.DeletionTimestamp.IsZero()
+	if firewallID == 0 || (len(mgmtCIDRs) == 0 && !deleting) {
+		return nil
+	}
+
+	var desired []linodego.FirewallRule
+	if !deleting {
+		var err error
+		if desired, err = managementFirewallRules(mgmtCIDRs); err != nil {
+			return err
+		}
+	} else {
+		devices, err := s.LinodeClient.ListFirewallDevices(ctx, firewallID, &linodego.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("list firewall %d devices: %w", firewallID, err)
+		}
+		for _, device := range devices {
+			instanceID := s.LinodeMachine.Spec.InstanceID
+			if device.Entity.Type != linodego.FirewallDeviceLinode || instanceID == nil || device.Entity.ID != *instanceID {
+				return nil
+			}
+		}
+	}
+
+	rules, err := s.LinodeClient.GetFirewallRules(ctx, firewallID)
+	if err != nil {
+		return fmt.Errorf("get firewall %d rules: %w", firewallID, err)
+	}
+	updated := *rules
+	if !replaceInboundRules(&updated, managementFirewallRuleLabelPrefix, desired) {
+		return nil
+	}
+	if _, err := s.LinodeClient.UpdateFirewallRules(ctx, firewallID, updated); err != nil {
+		return fmt.Errorf("update firewall %d rules: %w", firewallID, err)
+	}
+
+	return nil
+}
+
+// managementFirewallRules builds inbound rules accepting TCP and ICMP traffic from the
+// management cluster's egress CIDRs.
+func managementFirewallRules(mgmtCIDRs []string) ([]linodego.FirewallRule, error) {
+	addresses, err := firewallAddresses(mgmtCIDRs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid management cidr %w", err)
+	}
+	rules := make([]linodego.FirewallRule, 0, 2)
+	for _, protocol := range []linodego.NetworkProtocol{linodego.TCP, linodego.ICMP} {
+		rules = append(rules, linodego.FirewallRule{
+			Action:      "ACCEPT",
+			Label:       managementFirewallRuleLabelPrefix + strings.ToLower(string(protocol)),
+			Description: fmt.Sprintf("Allow %s traffic from the management cluster", protocol),
+			Protocol:    protocol,
+			Addresses:   addresses,
+		})
+	}
+
+	return rules, nil
+}
+
+// replaceInboundRules replaces the inbound rules of the rule set whose label has the prefix with
+// the given rules, leaving all other rules untouched. The replacement takes the place of the
+// first replaced rule, or is appended, so the order of the rules is kept stable when several
+// groups of rules are replaced. It reports whether the rules were changed.
+func replaceInboundRules(rules *linodego.FirewallRuleSet, labelPrefix string, replacement []linodego.FirewallRule) bool {
+	inbound := make([]linodego.FirewallRule, 0, len(rules.Inbound)+len(replacement))
+	replaced := false
+	for _, rule := range rules.Inbound {
+		switch {
+		case !strings.HasPrefix(rule.Label, labelPrefix):
+			inbound = append(inbound, rule)
+		case !replaced:
+			inbound = append(inbound, replacement...)
+			replaced = true
+		}
+	}
+	if !replaced {
+		inbound = append(inbound, replacement...)
+	}
+	if firewallRulesEqual(inbound, rules.Inbound) {
+		return false
+	}
+	rules.Inbound = inbound
+
+	return true
+}
+
+// firewallRulesEqual reports whether the rules are the same, treating nil and empty alike.
+func firewallRulesEqual(a, b []linodego.FirewallRule) bool {
+	return reflect.DeepEqual(a, b) || (len(a) == 0 && len(b) == 0)
+}
+
+// firewallAddresses splits the CIDRs into the IPv4 and IPv6 addresses of a firewall rule.
+func firewallAddresses(cidrs []string) (linodego.NetworkAddresses, error) {
+	var ipv4, ipv6 []string
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return linodego.NetworkAddresses{}, fmt.Errorf("%q: %w", cidr, err)
+		}
+		if prefix.Addr().Is4() {
+			ipv4 = append(ipv4, prefix.Masked().String())
+		} else {
+			ipv6 = append(ipv6, prefix.Masked().String())
+		}
+	}
+	var addresses linodego.NetworkAddresses
+	if len(ipv4) > 0 {
+		addresses.IPv4 = &ipv4
+	}
+	if len(ipv6) > 0 {
+		addresses.IPv6 = &ipv6
+	}
+
+	return addresses, nil
+}
+
+// detachFirewall removes the instance from the devices of the firewall.
+func (s *MachineScope) detachFirewall(ctx context.Context, instanceID, firewallID int) error {
+	devices, err := s.LinodeClient.ListFirewallDevices(ctx, firewallID, &linodego.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("list firewall %d devices: %w", firewallID, err)
+	}
+	for _, device := range devices {
+		if device.Entity.Type != linodego.FirewallDeviceLinode || device.Entity.ID != instanceID {
+			continue
+		}
+		if err := s.LinodeClient.DeleteFirewallDevice(ctx, firewallID, device.ID); util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
+			return fmt.Errorf("detach instance %d from firewall %d: %w", instanceID, firewallID, err)
+		}
+	}
+
+	return nil
+}
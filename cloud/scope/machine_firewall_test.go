@@ -0,0 +1,947 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeReconcileFirewallByLabel(t *testing.T) {
+	t.Parallel()
+
+	firewalls := []linodego.Firewall{{ID: 1, Label: "ssh"}, {ID: 2, Label: "web"}, {ID: 3, Label: "legacy"}}
+
+	tests := []struct {
+		name           string
+		labels         []string
+		managed        []int
+		expects        func(mock *mock.MockLinodeClient)
+		wantManagedIDs []int
+		expectedError  string
+	}{
+		{
+			name:    "No labels and nothing managed",
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:   "Attach to firewalls by label",
+			labels: []string{"ssh", "web"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListFirewalls(gomock.Any(), gomock.Any()).Return(firewalls, nil)
+				mock.EXPECT().ListInstanceFirewalls(gomock.Any(), 123, gomock.Any()).Return([]linodego.Firewall{{ID: 1}}, nil)
+				mock.EXPECT().CreateFirewallDevice(gomock.Any(), 2, linodego.FirewallDeviceCreateOptions{ID: 123, Type: linodego.FirewallDeviceLinode}).
+					Return(&linodego.FirewallDevice{}, nil)
+			},
+			wantManagedIDs: []int{1, 2},
+		},
+		{
+			name:    "Detach managed firewall no longer desired and keep others",
+			labels:  []string{"ssh"},
+			managed: []int{1, 3},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListFirewalls(gomock.Any(), gomock.Any()).Return(firewalls, nil)
+				mock.EXPECT().ListInstanceFirewalls(gomock.Any(), 123, gomock.Any()).Return([]linodego.Firewall{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+				mock.EXPECT().ListFirewallDevices(gomock.Any(), 3, gomock.Any()).Return([]linodego.FirewallDevice{
+					{ID: 30, Entity: linodego.FirewallDeviceEntity{ID: 456, Type: linodego.FirewallDeviceLinode}},
+					{ID: 31, Entity: linodego.FirewallDeviceEntity{ID: 123, Type: linodego.FirewallDeviceLinode}},
+				}, nil)
+				mock.EXPECT().DeleteFirewallDevice(gomock.Any(), 3, 31).Return(nil)
+			},
+			wantManagedIDs: []int{1},
+		},
+		{
+			name:   "Error - unknown labels",
+			labels: []string{"ssh", "db", "cache"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListFirewalls(gomock.Any(), gomock.Any()).Return(firewalls, nil)
+			},
+			expectedError: "firewalls db, cache do not exist",
+		},
+		{
+			name:   "Error - attach fails",
+			labels: []string{"web"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListFirewalls(gomock.Any(), gomock.Any()).Return(firewalls, nil)
+				mock.EXPECT().ListInstanceFirewalls(gomock.Any(), 123, gomock.Any()).Return(nil, nil)
+				mock.EXPECT().CreateFirewallDevice(gomock.Any(), 2, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "attach instance 123 to firewall 2: api error",
+		},
+		{
+			name:    "Error - detach fails",
+			managed: []int{3},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListFirewalls(gomock.Any(), gomock.Any()).Return(firewalls, nil)
+				mock.EXPECT().ListInstanceFirewalls(gomock.Any(), 123, gomock.Any()).Return([]linodego.Firewall{{ID: 3}}, nil)
+				mock.EXPECT().ListFirewallDevices(gomock.Any(), 3, gomock.Any()).Return([]linodego.FirewallDevice{
+					{ID: 31, Entity: linodego.FirewallDeviceEntity{ID: 123, Type: linodego.FirewallDeviceLinode}},
+				}, nil)
+				mock.EXPECT().DeleteFirewallDevice(gomock.Any(), 3, 31).Return(errors.New("api error"))
+			},
+			wantManagedIDs: []int{3},
+			expectedError:  "detach instance 123 from firewall 3: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Status: infrav1alpha2.LinodeMachineStatus{ManagedFirewallIDs: testcase.managed},
+				},
+			}
+
+			err := mScope.ReconcileFirewallByLabel(context.Background(), 123, testcase.labels)
+			assert.Equal(t, testcase.wantManagedIDs, mScope.LinodeMachine.Status.ManagedFirewallIDs)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMachineScopeReconcileFirewall(t *testing.T) {
+	t.Parallel()
+
+	userRule := linodego.FirewallRule{Action: "ACCEPT", Label: "ssh", Protocol: linodego.TCP, Ports: "22"}
+	clusterRules := []linodego.FirewallRule{
+		{
+			Action:      "ACCEPT",
+			Label:       "capl-cluster-pods-tcp",
+			Description: "Allow TCP traffic from the pods of cluster test-cluster",
+			Protocol:    linodego.TCP,
+			Addresses:   linodego.NetworkAddresses{IPv4: &[]string{"10.192.0.0/10"}},
+		},
+		{
+			Action:      "ACCEPT",
+			Label:       "capl-cluster-pods-udp",
+			Description: "Allow UDP traffic from the pods of cluster test-cluster",
+			Protocol:    linodego.UDP,
+			Addresses:   linodego.NetworkAddresses{IPv4: &[]string{"10.192.0.0/10"}},
+		},
+		{
+			Action:      "ACCEPT",
+			Label:       "capl-cluster-pods-icmp",
+			Description: "Allow ICMP traffic from the pods of cluster test-cluster",
+			Protocol:    linodego.ICMP,
+			Addresses:   linodego.NetworkAddresses{IPv4: &[]string{"10.192.0.0/10"}},
+		},
+	}
+	httpsRule := linodego.FirewallRule{Action: "ACCEPT", Label: "pol-1234-https", Protocol: linodego.TCP, Ports: "443"}
+	mgmtRules := []linodego.FirewallRule{
+		{
+			Action:      "ACCEPT",
+			Label:       "capl-management-tcp",
+			Description: "Allow TCP traffic from the management cluster",
+			Protocol:    linodego.TCP,
+			Addresses:   linodego.NetworkAddresses{IPv4: &[]string{"192.0.2.0/24"}},
+		},
+		{
+			Action:      "ACCEPT",
+			Label:       "capl-management-icmp",
+			Description: "Allow ICMP traffic from the management cluster",
+			Protocol:    linodego.ICMP,
+			Addresses:   linodego.NetworkAddresses{IPv4: &[]string{"192.0.2.0/24"}},
+		},
+	}
+	allRules := append(append(append([]linodego.FirewallRule{userRule}, clusterRules...), httpsRule), mgmtRules...)
+
+	tests := []struct {
+		name          string
+		expects       func(mock *mock.MockLinodeClient)
+		wantChanges   FirewallChanges
+		wantManaged   []string
+		expectedError string
+	}{
+		{
+			name: "Merge all parts into a single update",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetFirewallRules(gomock.Any(), 1).Return(&linodego.FirewallRuleSet{
+					Inbound:        []linodego.FirewallRule{userRule},
+					InboundPolicy:  "ACCEPT",
+					OutboundPolicy: "ACCEPT",
+				}, nil)
+				mock.EXPECT().UpdateFirewallRules(gomock.Any(), 1, linodego.FirewallRuleSet{
+					Inbound:        allRules,
+					Outbound:       []linodego.FirewallRule{},
+					InboundPolicy:  "DROP",
+					OutboundPolicy: "ACCEPT",
+				}).Return(&linodego.FirewallRuleSet{}, nil)
+			},
+			wantChanges: FirewallChanges{Policy: true, ClusterRules: true, SpecRules: true, ManagementRules: true},
+			wantManaged: []string{"pol-1234-https"},
+		},
+		{
+			name: "Firewall is up to date",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetFirewallRules(gomock.Any(), 1).Return(&linodego.FirewallRuleSet{
+					Inbound:        allRules,
+					InboundPolicy:  "DROP",
+					OutboundPolicy: "ACCEPT",
+				}, nil)
+			},
+			wantManaged: []string{"pol-1234-https"},
+		},
+		{
+			name: "Error - get rules fails",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetFirewallRules(gomock.Any(), 1).Return(nil, errors.New("api error"))
+			},
+			expectedError: "get firewall 1 rules: api error",
+		},
+		{
+			name: "Error - update fails",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetFirewallRules(gomock.Any(), 1).Return(&linodego.FirewallRuleSet{}, nil)
+				mock.EXPECT().UpdateFirewallRules(gomock.Any(), 1, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "update firewall 1 rules: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient:    mockLinodeClient,
+				ManagementCIDRs: []string{"192.0.2.0/24"},
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+					Spec: clusterv1.ClusterSpec{ClusterNetwork: &clusterv1.ClusterNetwork{
+						Pods: &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.192.0.0/10"}},
+					}},
+				},
+				LinodeCluster: &infrav1alpha2.LinodeCluster{},
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{
+						FirewallID:           1,
+						FirewallPolicy:       &infrav1alpha2.FirewallPolicy{Inbound: "DROP"},
+						ClusterFirewallRules: true,
+						FirewallRules: []infrav1alpha2.FirewallRule{
+							{Label: "pol-1234-https", Direction: "inbound", Action: "ACCEPT", Protocol: "TCP", Ports: "443"},
+						},
+					},
+				},
+			}
+
+			changes, err := mScope.ReconcileFirewall(context.Background())
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				assert.Empty(t, mScope.LinodeMachine.Status.ManagedFirewallRules)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantChanges, changes)
+			assert.Equal(t, testcase.wantManaged, mScope.LinodeMachine.Status.ManagedFirewallRules)
+		})
+	}
+}
+
+func TestMachineScopeReconcileFirewallPolicy(t *testing.T) {
+	t.Parallel()
+
+	rules := &linodego.FirewallRuleSet{
+		Inbound:        []linodego.FirewallRule{{Label: "ssh", Action: "ACCEPT", Protocol: linodego.TCP, Ports: "22"}},
+		InboundPolicy:  "ACCEPT",
+		OutboundPolicy: "ACCEPT",
+	}
+
+	tests := []struct {
+		name          string
+		firewallID    int
+		policy        *infrav1alpha2.FirewallPolicy
+		expects       func(mock *mock.MockLinodeClient)
+		wantChanged   bool
+		expectedError string
+	}{
+		{
+			name:       "No policy",
+			firewallID: 1,
+			expects:    func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:    "No firewall",
+			policy:  &infrav1alpha2.FirewallPolicy{Inbound: "DROP"},
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:       "Policy already set",
+			firewallID: 1,
+			policy:     &infrav1alpha2.FirewallPolicy{Inbound: "ACCEPT", Outbound: "ACCEPT"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetFirewallRules(gomock.Any(), 1).Return(rules, nil)
+			},
+		},
+		{
+			name:       "Change inbound policy and keep rules",
+			firewallID: 1,
+			policy:     &infrav1alpha2.FirewallPolicy{Inbound: "DROP"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetFirewallRules(gomock.Any(), 1).Return(rules, nil)
+				mock.EXPECT().UpdateFirewallRules(gomock.Any(), 1, linodego.FirewallRuleSet{
+					Inbound:        rules.Inbound,
+					InboundPolicy:  "DROP",
+					OutboundPolicy: "ACCEPT",
+				}).Return(&linodego.FirewallRuleSet{}, nil)
+			},
+			wantChanged: true,
+		},
+		{
+			name:       "Error - update fails",
+			firewallID: 1,
+			policy:     &infrav1alpha2.FirewallPolicy{Outbound: "DROP"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetFirewallRules(gomock.Any(), 1).Return(rules, nil)
+				mock.EXPECT().UpdateFirewallRules(gomock.Any(), 1, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "update firewall 1 rules: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{FirewallID: testcase.firewallID, FirewallPolicy: testcase.policy},
+				},
+			}
+
+			changes, err := mScope.ReconcileFirewall(context.Background())
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, FirewallChanges{Policy: testcase.wantChanged}, changes)
+		})
+	}
+}
+
+func TestMachineScopeDeriveClusterFirewallRules(t *testing.T) {
+	t.Parallel()
+
+	rules := func(source string, addresses linodego.NetworkAddresses) []linodego.FirewallRule {
+		var rules []linodego.FirewallRule
+		for _, protocol := range []linodego.NetworkProtocol{linodego.TCP, linodego.UDP, linodego.ICMP} {
+			rules = append(rules, linodego.FirewallRule{
+				Action:      "ACCEPT",
+				Label:       "capl-cluster-" + source + "-" + strings.ToLower(string(protocol)),
+				Description: "Allow " + string(protocol) + " traffic from the " + source + " of cluster test-cluster",
+				Protocol:    protocol,
+				Addresses:   addresses,
+			})
+		}
+		return rules
+	}
+
+	tests := []struct {
+		name          string
+		network       *clusterv1.ClusterNetwork
+		vpcRef        *corev1.ObjectReference
+		expects       func(k8sClient *mock.MockK8sClient)
+		want          []linodego.FirewallRule
+		expectedError string
+	}{
+		{
+			name:    "No cluster CIDRs",
+			expects: func(k8sClient *mock.MockK8sClient) {},
+		},
+		{
+			name: "Rules for pod, service and node CIDRs",
+			network: &clusterv1.ClusterNetwork{
+				Pods:     &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.192.0.0/10", "fd00::/108"}},
+				Services: &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.96.0.0/12"}},
+			},
+			vpcRef: &corev1.ObjectReference{Name: "test-vpc"},
+			expects: func(k8sClient *mock.MockK8sClient) {
+				k8sClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Namespace: "default", Name: "test-vpc"}, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, key client.ObjectKey, obj *infrav1alpha2.LinodeVPC, opts ...client.GetOption) error {
+						obj.Spec.Subnets = []infrav1alpha2.VPCSubnetCreateOptions{{Label: "primary", IPv4: "10.0.0.0/8"}}
+						return nil
+					})
+			},
+			want: slices.Concat(
+				rules("pods", linodego.NetworkAddresses{IPv4: &[]string{"10.192.0.0/10"}, IPv6: &[]string{"fd00::/108"}}),
+				rules("services", linodego.NetworkAddresses{IPv4: &[]string{"10.96.0.0/12"}}),
+				rules("nodes", linodego.NetworkAddresses{IPv4: &[]string{"10.0.0.0/8"}}),
+			),
+		},
+		{
+			name: "Error - invalid CIDR",
+			network: &clusterv1.ClusterNetwork{
+				Pods: &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.192.0.0"}},
+			},
+			expects:       func(k8sClient *mock.MockK8sClient) {},
+			expectedError: `invalid pods cidr "10.192.0.0"`,
+		},
+		{
+			name:   "Error - get linodevpc fails",
+			vpcRef: &corev1.ObjectReference{Name: "test-vpc"},
+			expects: func(k8sClient *mock.MockK8sClient) {
+				k8sClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("not found"))
+			},
+			expectedError: "get linodevpc default/test-vpc: not found",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockK8sClient := mock.NewMockK8sClient(ctrl)
+			testcase.expects(mockK8sClient)
+
+			mScope := &MachineScope{
+				Client: mockK8sClient,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+					Spec:       clusterv1.ClusterSpec{ClusterNetwork: testcase.network},
+				},
+				LinodeCluster: &infrav1alpha2.LinodeCluster{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+					Spec:       infrav1alpha2.LinodeClusterSpec{VPCRef: testcase.vpcRef},
+				},
+			}
+
+			rules, err := mScope.DeriveClusterFirewallRules(context.Background())
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.want, rules)
+		})
+	}
+}
+
+func TestMachineScopeReconcileClusterFirewallRules(t *testing.T) {
+	t.Parallel()
+
+	userRule := linodego.FirewallRule{Action: "ACCEPT", Label: "ssh", Protocol: linodego.TCP, Ports: "22"}
+	podRule := func(protocol linodego.NetworkProtocol, label, cidr string) linodego.FirewallRule {
+		return linodego.FirewallRule{
+			Action:      "ACCEPT",
+			Label:       "capl-cluster-pods-" + label,
+			Description: "Allow " + string(protocol) + " traffic from the pods of cluster test-cluster",
+			Protocol:    protocol,
+			Addresses:   linodego.NetworkAddresses{IPv4: &[]string{cidr}},
+		}
+	}
+	podRules := func(cidr string) []linodego.FirewallRule {
+		return []linodego.FirewallRule{podRule(linodego.TCP, "tcp", cidr), podRule(linodego.UDP, "udp", cidr), podRule(linodego.ICMP, "icmp", cidr)}
+	}
+
+	tests := []struct {
+		name          string
+		enabled       bool
+		expects       func(mock *mock.MockLinodeClient)
+		wantChanged   bool
+		expectedError string
+	}{
+		{
+			name:    "Cluster firewall rules not requested",
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:    "Replace stale cluster rules and keep user rules",
+			enabled: true,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetFirewallRules(gomock.Any(), 1).Return(&linodego.FirewallRuleSet{
+					Inbound:       append([]linodego.FirewallRule{userRule}, podRules("10.128.0.0/10")...),
+					InboundPolicy: "DROP",
+				}, nil)
+				mock.EXPECT().UpdateFirewallRules(gomock.Any(), 1, linodego.FirewallRuleSet{
+					Inbound:       append([]linodego.FirewallRule{userRule}, podRules("10.192.0.0/10")...),
+					InboundPolicy: "DROP",
+				}).Return(&linodego.FirewallRuleSet{}, nil)
+			},
+			wantChanged: true,
+		},
+		{
+			name:    "Cluster rules are up to date",
+			enabled: true,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetFirewallRules(gomock.Any(), 1).Return(&linodego.FirewallRuleSet{
+					Inbound: append([]linodego.FirewallRule{userRule}, podRules("10.192.0.0/10")...),
+				}, nil)
+			},
+		},
+		{
+			name:    "Error - update fails",
+			enabled: true,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetFirewallRules(gomock.Any(), 1).Return(&linodego.FirewallRuleSet{}, nil)
+				mock.EXPECT().UpdateFirewallRules(gomock.Any(), 1, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "update firewall 1 rules: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+					Spec: clusterv1.ClusterSpec{ClusterNetwork: &clusterv1.ClusterNetwork{
+						Pods: &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.192.0.0/10"}},
+					}},
+				},
+				LinodeCluster: &infrav1alpha2.LinodeCluster{},
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{FirewallID: 1, ClusterFirewallRules: testcase.enabled},
+				},
+			}
+
+			changes, err := mScope.ReconcileFirewall(context.Background())
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, FirewallChanges{ClusterRules: testcase.wantChanged}, changes)
+		})
+	}
+}
+
+func TestMachineScopeAllowManagementAccess(t *testing.T) {
+	t.Parallel()
+
+	userRule := linodego.FirewallRule{Action: "ACCEPT", Label: "ssh", Protocol: linodego.TCP, Ports: "22"}
+	mgmtRules := []linodego.FirewallRule{
+		{
+			Action:      "ACCEPT",
+			Label:       "capl-management-tcp",
+			Description: "Allow TCP traffic from the management cluster",
+			Protocol:    linodego.TCP,
+			Addresses:   linodego.NetworkAddresses{IPv4: &[]string{"192.0.2.0/24"}},
+		},
+		{
+			Action:      "ACCEPT",
+			Label:       "capl-management-icmp",
+			Description: "Allow ICMP traffic from the management cluster",
+			Protocol:    linodego.ICMP,
+			Addresses:   linodego.NetworkAddresses{IPv4: &[]string{"192.0.2.0/24"}},
+		},
+	}
+	device := func(id int) linodego.FirewallDevice {
+		return linodego.FirewallDevice{Entity: linodego.FirewallDeviceEntity{ID: id, Type: linodego.FirewallDeviceLinode}}
+	}
+
+	tests := []struct {
+		name          string
+		firewallID    int
+		cidrs         []string
+		deleting      bool
+		expects       func(mock *mock.MockLinodeClient)
+		expectedError string
+	}{
+		{
+			name:    "No firewall",
+			cidrs:   []string{"192.0.2.0/24"},
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:       "No management CIDRs",
+			firewallID: 1,
+			expects:    func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:       "Add management rules and keep user rules",
+			firewallID: 1,
+			cidrs:      []string{"192.0.2.1/24"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetFirewallRules(gomock.Any(), 1).Return(&linodego.FirewallRuleSet{
+					Inbound: []linodego.FirewallRule{userRule},
+				}, nil)
+				mock.EXPECT().UpdateFirewallRules(gomock.Any(), 1, linodego.FirewallRuleSet{
+					Inbound: append([]linodego.FirewallRule{userRule}, mgmtRules...),
+				}).Return(&linodego.FirewallRuleSet{}, nil)
+			},
+		},
+		{
+			name:       "Management rules are up to date",
+			firewallID: 1,
+			cidrs:      []string{"192.0.2.0/24"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetFirewallRules(gomock.Any(), 1).Return(&linodego.FirewallRuleSet{
+					Inbound: append([]linodego.FirewallRule{userRule}, mgmtRules...),
+				}, nil)
+			},
+		},
+		{
+			name:       "Remove management rules on deletion",
+			firewallID: 1,
+			cidrs:      []string{"192.0.2.0/24"},
+			deleting:   true,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListFirewallDevices(gomock.Any(), 1, gomock.Any()).Return([]linodego.FirewallDevice{device(123)}, nil)
+				mock.EXPECT().GetFirewallRules(gomock.Any(), 1).Return(&linodego.FirewallRuleSet{
+					Inbound: append([]linodego.FirewallRule{userRule}, mgmtRules...),
+				}, nil)
+				mock.EXPECT().UpdateFirewallRules(gomock.Any(), 1, linodego.FirewallRuleSet{
+					Inbound: []linodego.FirewallRule{userRule},
+				}).Return(&linodego.FirewallRuleSet{}, nil)
+			},
+		},
+		{
+			name:       "Keep management rules on deletion of a shared firewall",
+			firewallID: 1,
+			cidrs:      []string{"192.0.2.0/24"},
+			deleting:   true,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListFirewallDevices(gomock.Any(), 1, gomock.Any()).Return([]linodego.FirewallDevice{device(123), device(456)}, nil)
+			},
+		},
+		{
+			name:          "Error - invalid CIDR",
+			firewallID:    1,
+			cidrs:         []string{"192.0.2.0"},
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expectedError: "invalid management cidr \"192.0.2.0\"",
+		},
+		{
+			name:       "Error - get rules fails",
+			firewallID: 1,
+			cidrs:      []string{"192.0.2.0/24"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetFirewallRules(gomock.Any(), 1).Return(nil, errors.New("api error"))
+			},
+			expectedError: "get firewall 1 rules: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			instanceID := 123
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{InstanceID: &instanceID, FirewallID: testcase.firewallID},
+				},
+			}
+			if testcase.deleting {
+				mScope.LinodeMachine.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			}
+
+			err := mScope.AllowManagementAccess(context.Background(), testcase.firewallID, testcase.cidrs)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMachineScopeReconcileFirewallRules(t *testing.T) {
+	t.Parallel()
+
+	userRule := linodego.FirewallRule{Action: "ACCEPT", Label: "ssh", Protocol: linodego.TCP, Ports: "22"}
+	httpsSpec := infrav1alpha2.FirewallRule{
+		Label:       "pol-1234-https",
+		Description: "Policy 1234",
+		Direction:   "inbound",
+		Action:      "ACCEPT",
+		Protocol:    "TCP",
+		Ports:       "443",
+		Addresses:   []string{"0.0.0.0/0"},
+	}
+	httpsRule := linodego.FirewallRule{
+		Action:      "ACCEPT",
+		Label:       "pol-1234-https",
+		Description: "Policy 1234",
+		Protocol:    linodego.TCP,
+		Ports:       "443",
+		Addresses:   linodego.NetworkAddresses{IPv4: &[]string{"0.0.0.0/0"}},
+	}
+	smtpSpec := infrav1alpha2.FirewallRule{Label: "pol-5678-smtp", Direction: "outbound", Action: "DROP", Protocol: "TCP", Ports: "25"}
+	smtpRule := linodego.FirewallRule{Action: "DROP", Label: "pol-5678-smtp", Protocol: linodego.TCP, Ports: "25"}
+	staleRule := httpsRule
+	staleRule.Ports = "8443"
+	oldRule := linodego.FirewallRule{Action: "ACCEPT", Label: "pol-0001-old", Protocol: linodego.UDP}
+
+	tests := []struct {
+		name          string
+		rules         []infrav1alpha2.FirewallRule
+		managed       []string
+		expects       func(mock *mock.MockLinodeClient)
+		wantChanged   bool
+		wantManaged   []string
+		expectedError string
+	}{
+		{
+			name:    "No firewall rules",
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:    "Update drifted rule in place, add and remove rules",
+			rules:   []infrav1alpha2.FirewallRule{httpsSpec, smtpSpec},
+			managed: []string{"pol-1234-https", "pol-0001-old"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetFirewallRules(gomock.Any(), 1).Return(&linodego.FirewallRuleSet{
+					Inbound:       []linodego.FirewallRule{staleRule, userRule, oldRule},
+					InboundPolicy: "DROP",
+				}, nil)
+				mock.EXPECT().UpdateFirewallRules(gomock.Any(), 1, linodego.FirewallRuleSet{
+					Inbound:       []linodego.FirewallRule{httpsRule, userRule},
+					InboundPolicy: "DROP",
+					Outbound:      []linodego.FirewallRule{smtpRule},
+				}).Return(&linodego.FirewallRuleSet{}, nil)
+			},
+			wantChanged: true,
+			wantManaged: []string{"pol-1234-https", "pol-5678-smtp"},
+		},
+		{
+			name:  "Rules are up to date",
+			rules: []infrav1alpha2.FirewallRule{httpsSpec},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetFirewallRules(gomock.Any(), 1).Return(&linodego.FirewallRuleSet{
+					Inbound: []linodego.FirewallRule{userRule, httpsRule},
+				}, nil)
+			},
+			wantManaged: []string{"pol-1234-https"},
+		},
+		{
+			name:    "Remove managed rules when the spec has none",
+			managed: []string{"pol-0001-old"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetFirewallRules(gomock.Any(), 1).Return(&linodego.FirewallRuleSet{
+					Inbound: []linodego.FirewallRule{userRule, oldRule},
+				}, nil)
+				mock.EXPECT().UpdateFirewallRules(gomock.Any(), 1, linodego.FirewallRuleSet{
+					Inbound:  []linodego.FirewallRule{userRule},
+					Outbound: []linodego.FirewallRule{},
+				}).Return(&linodego.FirewallRuleSet{}, nil)
+			},
+			wantChanged: true,
+			wantManaged: []string{},
+		},
+		{
+			name:          "Error - duplicate label",
+			rules:         []infrav1alpha2.FirewallRule{httpsSpec, httpsSpec},
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expectedError: "firewall rule label \"pol-1234-https\" is not unique",
+		},
+		{
+			name:          "Error - reserved label prefix",
+			rules:         []infrav1alpha2.FirewallRule{{Label: "capl-cluster-pods-tcp", Direction: "inbound", Action: "ACCEPT", Protocol: "TCP"}},
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expectedError: "uses a prefix reserved for rules managed by CAPL",
+		},
+		{
+			name:  "Error - update fails",
+			rules: []infrav1alpha2.FirewallRule{httpsSpec},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetFirewallRules(gomock.Any(), 1).Return(&linodego.FirewallRuleSet{}, nil)
+				mock.EXPECT().UpdateFirewallRules(gomock.Any(), 1, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "update firewall 1 rules: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec:   infrav1alpha2.LinodeMachineSpec{FirewallID: 1, FirewallRules: testcase.rules},
+					Status: infrav1alpha2.LinodeMachineStatus{ManagedFirewallRules: testcase.managed},
+				},
+			}
+
+			changes, err := mScope.ReconcileFirewall(context.Background())
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, FirewallChanges{SpecRules: testcase.wantChanged}, changes)
+			assert.Equal(t, testcase.wantManaged, mScope.LinodeMachine.Status.ManagedFirewallRules)
+		})
+	}
+}
+
+func TestMachineScopeReconcileNodeBalancerFirewall(t *testing.T) {
+	t.Parallel()
+
+	notFound := &linodego.Error{Code: http.StatusNotFound}
+
+	tests := []struct {
+		name          string
+		firewallID    int
+		expects       func(mock *mock.MockLinodeClient)
+		expectedError string
+	}{
+		{
+			name:       "Attach nodebalancer to firewall",
+			firewallID: 2,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancer(gomock.Any(), 10).Return(&linodego.NodeBalancer{ID: 10, Tags: []string{"test-cluster"}}, nil)
+				mock.EXPECT().ListNodeBalancerFirewalls(gomock.Any(), 10, gomock.Any()).Return(nil, nil)
+				mock.EXPECT().CreateFirewallDevice(gomock.Any(), 2, linodego.FirewallDeviceCreateOptions{ID: 10, Type: linodego.FirewallDeviceNodeBalancer}).
+					Return(&linodego.FirewallDevice{}, nil)
+				mock.EXPECT().UpdateNodeBalancer(gomock.Any(), 10, linodego.NodeBalancerUpdateOptions{Tags: &[]string{"test-cluster", "capl-firewall:2"}}).
+					Return(&linodego.NodeBalancer{}, nil)
+			},
+		},
+		{
+			name:       "Already attached",
+			firewallID: 2,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancer(gomock.Any(), 10).Return(&linodego.NodeBalancer{ID: 10, Tags: []string{"capl-firewall:2"}}, nil)
+				mock.EXPECT().ListNodeBalancerFirewalls(gomock.Any(), 10, gomock.Any()).Return([]linodego.Firewall{{ID: 2}, {ID: 5}}, nil)
+			},
+		},
+		{
+			name:       "Replace previously attached firewall and keep others",
+			firewallID: 2,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancer(gomock.Any(), 10).Return(&linodego.NodeBalancer{ID: 10, Tags: []string{"capl-firewall:1"}}, nil)
+				mock.EXPECT().ListNodeBalancerFirewalls(gomock.Any(), 10, gomock.Any()).Return([]linodego.Firewall{{ID: 1}, {ID: 5}}, nil)
+				mock.EXPECT().CreateFirewallDevice(gomock.Any(), 2, linodego.FirewallDeviceCreateOptions{ID: 10, Type: linodego.FirewallDeviceNodeBalancer}).
+					Return(&linodego.FirewallDevice{}, nil)
+				mock.EXPECT().ListFirewallDevices(gomock.Any(), 1, gomock.Any()).Return([]linodego.FirewallDevice{
+					{ID: 11, Entity: linodego.FirewallDeviceEntity{ID: 10, Type: linodego.FirewallDeviceLinode}},
+					{ID: 12, Entity: linodego.FirewallDeviceEntity{ID: 10, Type: linodego.FirewallDeviceNodeBalancer}},
+				}, nil)
+				mock.EXPECT().DeleteFirewallDevice(gomock.Any(), 1, 12).Return(nil)
+				mock.EXPECT().UpdateNodeBalancer(gomock.Any(), 10, linodego.NodeBalancerUpdateOptions{Tags: &[]string{"capl-firewall:2"}}).
+					Return(&linodego.NodeBalancer{}, nil)
+			},
+		},
+		{
+			name: "Detach previously attached firewall",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancer(gomock.Any(), 10).Return(&linodego.NodeBalancer{ID: 10, Tags: []string{"capl-firewall:1"}}, nil)
+				mock.EXPECT().ListNodeBalancerFirewalls(gomock.Any(), 10, gomock.Any()).Return([]linodego.Firewall{{ID: 1}}, nil)
+				mock.EXPECT().ListFirewallDevices(gomock.Any(), 1, gomock.Any()).Return([]linodego.FirewallDevice{
+					{ID: 12, Entity: linodego.FirewallDeviceEntity{ID: 10, Type: linodego.FirewallDeviceNodeBalancer}},
+				}, nil)
+				mock.EXPECT().DeleteFirewallDevice(gomock.Any(), 1, 12).Return(nil)
+				mock.EXPECT().UpdateNodeBalancer(gomock.Any(), 10, linodego.NodeBalancerUpdateOptions{Tags: &[]string{}}).
+					Return(&linodego.NodeBalancer{}, nil)
+			},
+		},
+		{
+			name:       "Missing nodebalancer",
+			firewallID: 2,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancer(gomock.Any(), 10).Return(nil, notFound)
+			},
+		},
+		{
+			name:       "Missing firewall",
+			firewallID: 2,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancer(gomock.Any(), 10).Return(&linodego.NodeBalancer{ID: 10}, nil)
+				mock.EXPECT().ListNodeBalancerFirewalls(gomock.Any(), 10, gomock.Any()).Return(nil, nil)
+				mock.EXPECT().CreateFirewallDevice(gomock.Any(), 2, gomock.Any()).Return(nil, notFound)
+			},
+		},
+		{
+			name:       "Error - attach firewall",
+			firewallID: 2,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancer(gomock.Any(), 10).Return(&linodego.NodeBalancer{ID: 10}, nil)
+				mock.EXPECT().ListNodeBalancerFirewalls(gomock.Any(), 10, gomock.Any()).Return(nil, nil)
+				mock.EXPECT().CreateFirewallDevice(gomock.Any(), 2, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "attach nodebalancer 10 to firewall 2: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient:  mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{},
+			}
+
+			err := mScope.ReconcileNodeBalancerFirewall(context.Background(), 10, testcase.firewallID)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,511 @@
+package scope
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/linode/linodego"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/util"
+)
+
+// ErrShutdownTimeout is returned by GracefulShutdown when the instance does not stop in time.
+var ErrShutdownTimeout = errors.New("timed out waiting for instance to shut down")
+
+// ErrMetadataServiceUnavailable is returned by ValidateMetadataServiceAvailable when the
+// machine's region has no Metadata service, so bootstrap data must be delivered through a
+// StackScript instead.
+var ErrMetadataServiceUnavailable = errors.New("does not support the metadata service")
+
+// ErrRebuildDiskLayout is returned by RebuildInstance when the spec configures an OS disk or
+// data disks, since a rebuild replaces every disk with a single disk deployed from the image.
+var ErrRebuildDiskLayout = errors.New("cannot rebuild an instance with a custom disk layout")
+
+// ConditionShutdownRequested reports that the instance was asked to shut down before it is
+// deleted. Its LastTransitionTime is when the graceful shutdown started.
+const ConditionShutdownRequested clusterv1.ConditionType = "ShutdownRequested"
+
+// ImmutableFieldsChanged compares the live instance against the LinodeMachine spec
+// and returns the spec fields that differ and can only be applied by replacing the
+// instance. A type change is only reported when it crosses type classes, since
+// resizing within a class is handled in place. An image change is not reported
+// either, since it is applied in place by RebuildInstance.
+func (s *MachineScope) ImmutableFieldsChanged(ctx context.Context, live *linodego.Instance) ([]string, error) {
+	if live == nil {
+		return nil, nil
+	}
+	spec := s.LinodeMachine.Spec
+
+	var changed []string
+	if spec.Region != "" && spec.Region != live.Region {
+		changed = append(changed, "region")
+	}
+
+	if instanceType := s.InstanceType(); instanceType != "" && instanceType != live.Type {
+		desired, err := s.LinodeClient.GetType(ctx, instanceType)
+		if err != nil {
+			return nil, fmt.Errorf("get type %s: %w", instanceType, err)
+		}
+		current, err := s.LinodeClient.GetType(ctx, live.Type)
+		if err != nil {
+			return nil, fmt.Errorf("get type %s: %w", live.Type, err)
+		}
+		if desired.Class != current.Class {
+			changed = append(changed, "type")
+		}
+	}
+
+	return changed, nil
+}
+
+// IsExternalInstance reports whether the machine is backed by a host provisioned
+// outside of CAPL, in which case instance lifecycle operations must be skipped.
+func (s *MachineScope) IsExternalInstance() bool {
+	return s.LinodeMachine.Spec.ExternalInstance != nil
+}
+
+// ExternalInstanceAddresses returns the machine addresses of an external instance,
+// using the supplied IP address for both the external and internal address.
+func (s *MachineScope) ExternalInstanceAddresses() []clusterv1.MachineAddress {
+	if !s.IsExternalInstance() {
+		return nil
+	}
+	ip := s.LinodeMachine.Spec.ExternalInstance.IPAddress
+
+	return []clusterv1.MachineAddress{
+		{Type: clusterv1.MachineExternalIP, Address: ip},
+		{Type: clusterv1.MachineInternalIP, Address: ip},
+	}
+}
+
+// MigrateRegion moves an instance to the target region using Linode's cross-region
+// migration, preserving the instance ID. It initiates the migration when needed and
+// reports done once the instance is running in the target region, so callers should
+// requeue until done is true.
+func (s *MachineScope) MigrateRegion(ctx context.Context, instanceID int, target string) (bool, error) {
+	instance, err := s.LinodeClient.GetInstance(ctx, instanceID)
+	if err != nil {
+		return false, fmt.Errorf("get instance %d: %w", instanceID, err)
+	}
+	if instance.Status == linodego.InstanceMigrating {
+		return false, nil
+	}
+	if instance.Region == target {
+		return true, nil
+	}
+
+	current, err := s.LinodeClient.GetRegion(ctx, instance.Region)
+	if err != nil {
+		return false, fmt.Errorf("get region %s: %w", instance.Region, err)
+	}
+	desired, err := s.LinodeClient.GetRegion(ctx, target)
+	if err != nil {
+		return false, fmt.Errorf("get region %s: %w", target, err)
+	}
+	if !slices.Contains(desired.Capabilities, linodego.CapabilityLinodes) || desired.Status != "ok" {
+		return false, fmt.Errorf("region %s does not support migrating instances into it", target)
+	}
+	if current.SiteType != desired.SiteType {
+		return false, fmt.Errorf("migration from %s site %s to %s site %s is not supported", current.SiteType, current.ID, desired.SiteType, desired.ID)
+	}
+
+	if err := s.LinodeClient.MigrateInstance(ctx, instanceID, linodego.InstanceMigrateOptions{
+		Type:   linodego.ColdMigration,
+		Region: target,
+	}); err != nil {
+		return false, fmt.Errorf("migrate instance %d to %s: %w", instanceID, target, err)
+	}
+
+	return false, nil
+}
+
+// ResizeInstance changes the plan of an instance to the target type in place, preserving
+// the instance ID. Linode only allows a resize when the instance's disks fit in the target
+// plan, so smaller plans are rejected unless the disks have been shrunk first. A warm
+// resize reboots the instance itself; an instance left offline by the resize is booted.
+// It reports done once the instance is running with the target type, so callers should
+// requeue until done is true.
+func (s *MachineScope) ResizeInstance(ctx context.Context, instanceID int, targetType string) (bool, error) {
+	instance, err := s.LinodeClient.GetInstance(ctx, instanceID)
+	if err != nil {
+		return false, fmt.Errorf("get instance %d: %w", instanceID, err)
+	}
+	if instance.Type == targetType {
+		switch instance.Status {
+		case linodego.InstanceRunning:
+			return true, nil
+		case linodego.InstanceOffline:
+			if err := s.LinodeClient.BootInstance(ctx, instanceID, 0); err != nil {
+				return false, fmt.Errorf("boot instance %d after resize: %w", instanceID, err)
+			}
+		}
+		return false, nil
+	}
+	if instance.Status == linodego.InstanceResizing {
+		return false, nil
+	}
+
+	linodeType, err := s.LinodeClient.GetType(ctx, targetType)
+	if err != nil {
+		return false, fmt.Errorf("get type %s: %w", targetType, err)
+	}
+	disks, err := s.LinodeClient.ListInstanceDisks(ctx, instanceID, &linodego.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("list instance disks: %w", err)
+	}
+	diskSize := 0
+	for _, disk := range disks {
+		diskSize += disk.Size
+	}
+	if diskSize > linodeType.Disk {
+		return false, fmt.Errorf("cannot resize instance %d to %s: its disks use %d MB but the plan only has %d MB", instanceID, targetType, diskSize, linodeType.Disk)
+	}
+
+	if err := s.LinodeClient.ResizeInstance(ctx, instanceID, linodego.InstanceResizeOptions{
+		Type:                targetType,
+		MigrationType:       linodego.WarmMigration,
+		AllowAutoDiskResize: util.Pointer(false),
+	}); err != nil {
+		return false, fmt.Errorf("resize instance %d to %s: %w", instanceID, targetType, err)
+	}
+
+	return false, nil
+}
+
+// ResizeWithDiskMigration changes the plan of an instance to the target type like
+// ResizeInstance, first shrinking a disk when the instance's disks do not fit in the target
+// plan. The largest ext3 or ext4 disk is shrunk by the excess while the instance is offline.
+// Linode only shrinks a disk whose data fits in the new size, so a shrink which leaves the
+// disk at its old size aborts the resize with an error and the instance is booted again.
+// The shrink is recorded in the DiskShrinkAnnotation, which must be removed to retry it. It
+// reports done once the instance is running with the target type, so callers should
+// requeue until done is true.
+func (s *MachineScope) ResizeWithDiskMigration(ctx context.Context, instanceID int, targetType string) (bool, error) {
+	instance, err := s.LinodeClient.GetInstance(ctx, instanceID)
+	if err != nil {
+		return false, fmt.Errorf("get instance %d: %w", instanceID, err)
+	}
+	if instance.Type == targetType || instance.Status == linodego.InstanceResizing {
+		delete(s.LinodeMachine.Annotations, infrav1alpha2.DiskShrinkAnnotation)
+		return s.ResizeInstance(ctx, instanceID, targetType)
+	}
+
+	linodeType, err := s.LinodeClient.GetType(ctx, targetType)
+	if err != nil {
+		return false, fmt.Errorf("get type %s: %w", targetType, err)
+	}
+	disks, err := s.LinodeClient.ListInstanceDisks(ctx, instanceID, &linodego.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("list instance disks: %w", err)
+	}
+	diskSize := 0
+	idx := -1
+	for i, disk := range disks {
+		diskSize += disk.Size
+		if (disk.Filesystem == linodego.FilesystemExt4 || disk.Filesystem == linodego.FilesystemExt3) && (idx < 0 || disk.Size > disks[idx].Size) {
+			idx = i
+		}
+	}
+	if diskSize <= linodeType.Disk {
+		delete(s.LinodeMachine.Annotations, infrav1alpha2.DiskShrinkAnnotation)
+		return s.ResizeInstance(ctx, instanceID, targetType)
+	}
+	excess := diskSize - linodeType.Disk
+	if idx < 0 || disks[idx].Size <= excess {
+		return false, fmt.Errorf("cannot resize instance %d to %s: its disks use %d MB but the plan only has %d MB, and no disk can be shrunk by %d MB", instanceID, targetType, diskSize, linodeType.Disk, excess)
+	}
+
+	disk := disks[idx]
+	if disk.Status != linodego.DiskReady {
+		return false, nil
+	}
+	targetSize := disk.Size - excess
+	shrink := fmt.Sprintf("%d:%d", disk.ID, targetSize)
+	if s.LinodeMachine.Annotations[infrav1alpha2.DiskShrinkAnnotation] == shrink {
+		// The disk is ready again at its old size, so Linode refused to shrink it
+		if instance.Status == linodego.InstanceOffline {
+			if err := s.LinodeClient.BootInstance(ctx, instanceID, 0); err != nil {
+				return false, fmt.Errorf("boot instance %d after failed disk shrink: %w", instanceID, err)
+			}
+		}
+		return false, fmt.Errorf("cannot resize instance %d to %s: disk %d could not be shrunk to %d MB, its used space exceeds the target size", instanceID, targetType, disk.ID, targetSize)
+	}
+
+	switch instance.Status {
+	case linodego.InstanceRunning:
+		if err := s.LinodeClient.ShutdownInstance(ctx, instanceID); err != nil {
+			return false, fmt.Errorf("shut down instance %d: %w", instanceID, err)
+		}
+	case linodego.InstanceOffline:
+		if err := s.LinodeClient.ResizeInstanceDisk(ctx, instanceID, disk.ID, targetSize); err != nil {
+			return false, fmt.Errorf("shrink instance disk %d to %d MB: %w", disk.ID, targetSize, err)
+		}
+		if s.LinodeMachine.Annotations == nil {
+			s.LinodeMachine.Annotations = map[string]string{}
+		}
+		s.LinodeMachine.Annotations[infrav1alpha2.DiskShrinkAnnotation] = shrink
+	}
+
+	return false, nil
+}
+
+// RebuildInstance redeploys the instance's disks from the image with the current bootstrap
+// data, preserving the instance ID and IP addresses, so a new image can be rolled onto a
+// node in place. The image must be available and support cloud-init in a region with the
+// Metadata service, since the bootstrap data is passed through it. The root user is
+// authorized with the same keys and users as at create. A rebuild deletes every disk and
+// config profile of the instance, so machines with an OSDisk or DataDisks are refused with
+// ErrRebuildDiskLayout and must be replaced instead. It reports done once the instance is
+// running the image again, so callers should requeue until done is true.
+func (s *MachineScope) RebuildInstance(ctx context.Context, instanceID int, image string) (bool, error) {
+	instance, err := s.LinodeClient.GetInstance(ctx, instanceID)
+	if err != nil {
+		return false, fmt.Errorf("get instance %d: %w", instanceID, err)
+	}
+	if instance.Status == linodego.InstanceRebuilding {
+		return false, nil
+	}
+	if instance.Image == image {
+		return instance.Status == linodego.InstanceRunning, nil
+	}
+	if s.LinodeMachine.Spec.OSDisk != nil || len(s.LinodeMachine.Spec.DataDisks) > 0 {
+		return false, fmt.Errorf("instance %d: %w", instanceID, ErrRebuildDiskLayout)
+	}
+
+	linodeImage, err := s.LinodeClient.GetImage(ctx, image)
+	if err != nil {
+		return false, fmt.Errorf("get image %s: %w", image, err)
+	}
+	if linodeImage.Status != linodego.ImageStatusAvailable {
+		return false, fmt.Errorf("image %s is not available, its status is %s", image, linodeImage.Status)
+	}
+	if !slices.Contains(linodeImage.Capabilities, "cloud-init") {
+		return false, fmt.Errorf("image %s does not support cloud-init", image)
+	}
+	if err := s.ValidateMetadataServiceAvailable(ctx); err != nil {
+		return false, err
+	}
+
+	authorizedKeys, err := s.ResolveAuthorizedKeys(ctx)
+	if err != nil {
+		return false, err
+	}
+	bootstrapData, err := s.GetBootstrapData(ctx)
+	if err != nil {
+		return false, err
+	}
+	if _, err := s.LinodeClient.RebuildInstance(ctx, instanceID, linodego.InstanceRebuildOptions{
+		Image:           image,
+		RootPass:        uuid.NewString(),
+		AuthorizedKeys:  append(slices.Clone(s.LinodeMachine.Spec.AuthorizedKeys), authorizedKeys...),
+		AuthorizedUsers: s.LinodeMachine.Spec.AuthorizedUsers,
+		Booted:          util.Pointer(true),
+		Metadata: &linodego.InstanceMetadataOptions{
+			UserData: base64.StdEncoding.EncodeToString(bootstrapData),
+		},
+	}); err != nil {
+		return false, fmt.Errorf("rebuild instance %d from %s: %w", instanceID, image, err)
+	}
+
+	return false, nil
+}
+
+// validBackupWindows and validBackupDays are the schedule values accepted by the Linode API.
+var (
+	validBackupWindows = []string{"Scheduling", "W0", "W2", "W4", "W6", "W8", "W10", "W12", "W14", "W16", "W18", "W20", "W22"}
+	validBackupDays    = []string{"Scheduling", "Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+)
+
+// ReconcileBackupSchedule applies the spec's backup schedule to the instance when backups
+// are enabled. The instance is only updated when its schedule has drifted from the spec.
+// Backups are enabled asynchronously after the instance is created, so it reports the
+// schedule as pending, without an error, until they are active on the instance and callers
+// should requeue.
+func (s *MachineScope) ReconcileBackupSchedule(ctx context.Context, instanceID int) (bool, error) {
+	schedule := s.LinodeMachine.Spec.BackupSchedule
+	if schedule == nil || !s.LinodeMachine.Spec.BackupsEnabled {
+		return false, nil
+	}
+	if schedule.Window != "" && !slices.Contains(validBackupWindows, schedule.Window) {
+		return false, fmt.Errorf("invalid backup window %q, must be one of %v", schedule.Window, validBackupWindows)
+	}
+	if schedule.Day != "" && !slices.Contains(validBackupDays, schedule.Day) {
+		return false, fmt.Errorf("invalid backup day %q, must be one of %v", schedule.Day, validBackupDays)
+	}
+
+	instance, err := s.LinodeClient.GetInstance(ctx, instanceID)
+	if err != nil {
+		return false, fmt.Errorf("get instance %d: %w", instanceID, err)
+	}
+	if instance.Backups == nil || !instance.Backups.Enabled {
+		return true, nil
+	}
+
+	backups := &linodego.InstanceBackup{}
+	backups.Schedule.Window = instance.Backups.Schedule.Window
+	backups.Schedule.Day = instance.Backups.Schedule.Day
+	if schedule.Window != "" {
+		backups.Schedule.Window = schedule.Window
+	}
+	if schedule.Day != "" {
+		backups.Schedule.Day = schedule.Day
+	}
+	if backups.Schedule == instance.Backups.Schedule {
+		return false, nil
+	}
+
+	if _, err := s.LinodeClient.UpdateInstance(ctx, instanceID, linodego.InstanceUpdateOptions{Backups: backups}); err != nil {
+		return false, fmt.Errorf("update instance %d backup schedule: %w", instanceID, err)
+	}
+
+	return false, nil
+}
+
+// GracefulShutdown asks the instance to shut down, giving the OS a chance to flush workloads
+// before the instance is deleted, without waiting for it to stop. The time the shutdown was
+// requested is recorded as ConditionShutdownRequested, so callers can requeue until it reports
+// the instance is offline or no longer exists. Once the timeout has passed since the shutdown
+// was requested, an error wrapping ErrShutdownTimeout is returned, so callers can decide to
+// force-delete.
+//
+// Rather than blocking until the instance stops, it reports whether the instance is stopped,
+// so the controller requeues instead of holding a worker for up to the timeout. The shutdown
+// request time is kept in a condition, so the timeout spans reconciles, and now is passed in
+// so the timeout can be checked deterministically.
+func (s *MachineScope) GracefulShutdown(ctx context.Context, instanceID int, timeout time.Duration, now time.Time) (bool, error) {
+	instance, err := s.LinodeClient.GetInstance(ctx, instanceID)
+	if err != nil {
+		if util.IgnoreLinodeAPIError(err, http.StatusNotFound) == nil {
+			return true, nil
+		}
+
+		return false, fmt.Errorf("get instance %d: %w", instanceID, err)
+	}
+	if instance.Status == linodego.InstanceOffline {
+		return true, nil
+	}
+
+	if requested := conditions.Get(s.LinodeMachine, ConditionShutdownRequested); requested != nil {
+		if now.After(requested.LastTransitionTime.Add(timeout)) {
+			return false, fmt.Errorf("shut down instance %d: %w", instanceID, ErrShutdownTimeout)
+		}
+
+		return false, nil
+	}
+
+	if instance.Status != linodego.InstanceShuttingDown {
+		if err := s.LinodeClient.ShutdownInstance(ctx, instanceID); err != nil {
+			if util.IgnoreLinodeAPIError(err, http.StatusNotFound) == nil {
+				return true, nil
+			}
+
+			return false, fmt.Errorf("shut down instance %d: %w", instanceID, err)
+		}
+	}
+	conditions.Set(s.LinodeMachine, &clusterv1.Condition{
+		Type:               ConditionShutdownRequested,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(now),
+	})
+
+	return false, nil
+}
+
+// Region returns the Linode region of the machine. The region is fetched once and
+// cached for the lifetime of the scope.
+func (s *MachineScope) Region(ctx context.Context) (*linodego.Region, error) {
+	if s.region != nil {
+		return s.region, nil
+	}
+
+	region, err := s.LinodeClient.GetRegion(ctx, s.LinodeMachine.Spec.Region)
+	if err != nil {
+		return nil, err
+	}
+	s.region = region
+
+	return region, nil
+}
+
+// ValidateMetadataServiceAvailable returns an error wrapping ErrMetadataServiceUnavailable
+// if the machine's region does not offer the Metadata service, which metadata-based
+// instances need to receive their bootstrap data. Without it such instances boot
+// unconfigured, so callers should fall back to StackScript delivery.
+func (s *MachineScope) ValidateMetadataServiceAvailable(ctx context.Context) error {
+	region, err := s.Region(ctx)
+	if err != nil {
+		return fmt.Errorf("get region %s: %w", s.LinodeMachine.Spec.Region, err)
+	}
+	if !slices.Contains(region.Capabilities, linodego.CapabilityMetadata) {
+		return fmt.Errorf("region %s %w", region.ID, ErrMetadataServiceUnavailable)
+	}
+
+	return nil
+}
+
+// ValidateInstanceCapabilities returns an error listing the required capabilities the
+// machine's region does not offer, so e.g. a misconfigured GPU node pool is caught before
+// the create fails. GPU Linodes additionally requires the instance type to have GPUs, which
+// is the type the instance is created with, e.g. a fallback type, see ResolveInstanceType.
+func (s *MachineScope) ValidateInstanceCapabilities(ctx context.Context, instanceType string, required []string) error {
+	if len(required) == 0 {
+		return nil
+	}
+
+	region, err := s.Region(ctx)
+	if err != nil {
+		return fmt.Errorf("get region %s: %w", s.LinodeMachine.Spec.Region, err)
+	}
+	var missing []string
+	for _, capability := range required {
+		if !slices.Contains(region.Capabilities, capability) {
+			missing = append(missing, capability)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("region %s does not support %s", region.ID, strings.Join(missing, ", "))
+	}
+
+	if slices.Contains(required, linodego.CapabilityGPU) {
+		linodeType, err := s.LinodeClient.GetType(ctx, instanceType)
+		if err != nil {
+			return fmt.Errorf("get type %s: %w", instanceType, err)
+		}
+		if linodeType.GPUs == 0 {
+			return fmt.Errorf("type %s has no GPUs", instanceType)
+		}
+	}
+
+	return nil
+}
+
+// ValidateDiskEncryptionSupported returns an error if disk encryption is enabled in the
+// spec but the machine's region does not offer it. Linode does not report disk encryption
+// support per plan, so the region capability is authoritative.
+func (s *MachineScope) ValidateDiskEncryptionSupported(ctx context.Context) error {
+	if s.LinodeMachine.Spec.DiskEncryption != string(linodego.InstanceDiskEncryptionEnabled) {
+		return nil
+	}
+
+	region, err := s.Region(ctx)
+	if err != nil {
+		return fmt.Errorf("get region %s: %w", s.LinodeMachine.Spec.Region, err)
+	}
+	if !slices.Contains(region.Capabilities, linodego.CapabilityDiskEncryption) {
+		return fmt.Errorf("disk encryption is not supported in region %s", s.LinodeMachine.Spec.Region)
+	}
+
+	return nil
+}
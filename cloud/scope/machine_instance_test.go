@@ -0,0 +1,1054 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeImmutableFieldsChanged(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		spec          infrav1alpha2.LinodeMachineSpec
+		live          *linodego.Instance
+		expects       func(mock *mock.MockLinodeClient)
+		want          []string
+		expectedError string
+	}{
+		{
+			name:    "No changes",
+			spec:    infrav1alpha2.LinodeMachineSpec{Region: "us-ord", Type: "g6-standard-2", Image: "linode/debian12"},
+			live:    &linodego.Instance{Region: "us-ord", Type: "g6-standard-2", Image: "linode/debian12"},
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:    "Image change is not reported",
+			spec:    infrav1alpha2.LinodeMachineSpec{Region: "us-ord", Type: "g6-standard-2", Image: "linode/debian12"},
+			live:    &linodego.Instance{Region: "us-ord", Type: "g6-standard-2", Image: "linode/debian11"},
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:    "Region changed",
+			spec:    infrav1alpha2.LinodeMachineSpec{Region: "us-ord", Type: "g6-standard-2", Image: "linode/debian12"},
+			live:    &linodego.Instance{Region: "us-east", Type: "g6-standard-2", Image: "linode/debian11"},
+			expects: func(mock *mock.MockLinodeClient) {},
+			want:    []string{"region"},
+		},
+		{
+			name: "Type changed within the same class",
+			spec: infrav1alpha2.LinodeMachineSpec{Region: "us-ord", Type: "g6-standard-4", Image: "linode/debian12"},
+			live: &linodego.Instance{Region: "us-ord", Type: "g6-standard-2", Image: "linode/debian12"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-4").Return(&linodego.LinodeType{Class: linodego.ClassStandard}, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-2").Return(&linodego.LinodeType{Class: linodego.ClassStandard}, nil)
+			},
+		},
+		{
+			name: "Type changed across classes",
+			spec: infrav1alpha2.LinodeMachineSpec{Region: "us-ord", Type: "g6-dedicated-2", Image: "linode/debian12"},
+			live: &linodego.Instance{Region: "us-ord", Type: "g6-standard-2", Image: "linode/debian12"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetType(gomock.Any(), "g6-dedicated-2").Return(&linodego.LinodeType{Class: linodego.ClassDedicated}, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-2").Return(&linodego.LinodeType{Class: linodego.ClassStandard}, nil)
+			},
+			want: []string{"type"},
+		},
+		{
+			name: "Error - failed to get type",
+			spec: infrav1alpha2.LinodeMachineSpec{Region: "us-ord", Type: "g6-dedicated-2", Image: "linode/debian12"},
+			live: &linodego.Instance{Region: "us-ord", Type: "g6-standard-2", Image: "linode/debian12"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetType(gomock.Any(), "g6-dedicated-2").Return(nil, errors.New("api error"))
+			},
+			expectedError: "api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient:  mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{Spec: testcase.spec},
+			}
+
+			changed, err := mScope.ImmutableFieldsChanged(context.Background(), testcase.live)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.want, changed)
+		})
+	}
+}
+
+func TestMachineScopeExternalInstanceAddresses(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		spec         infrav1alpha2.LinodeMachineSpec
+		wantExternal bool
+		want         []clusterv1.MachineAddress
+	}{
+		{
+			name: "Managed instance",
+			spec: infrav1alpha2.LinodeMachineSpec{InstanceID: ptr.To(123)},
+		},
+		{
+			name:         "External instance",
+			spec:         infrav1alpha2.LinodeMachineSpec{ExternalInstance: &infrav1alpha2.ExternalInstance{IPAddress: "10.0.0.5"}},
+			wantExternal: true,
+			want: []clusterv1.MachineAddress{
+				{Type: clusterv1.MachineExternalIP, Address: "10.0.0.5"},
+				{Type: clusterv1.MachineInternalIP, Address: "10.0.0.5"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mScope := &MachineScope{LinodeMachine: &infrav1alpha2.LinodeMachine{Spec: testcase.spec}}
+
+			assert.Equal(t, testcase.wantExternal, mScope.IsExternalInstance())
+			assert.Equal(t, testcase.want, mScope.ExternalInstanceAddresses())
+		})
+	}
+}
+
+func TestMachineScopeMigrateRegion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		expects       func(mock *mock.MockLinodeClient)
+		wantDone      bool
+		expectedError string
+	}{
+		{
+			name: "Done - instance already in target region",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Region: "us-ord", Status: linodego.InstanceRunning}, nil)
+			},
+			wantDone: true,
+		},
+		{
+			name: "In progress - instance is migrating",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Region: "us-east", Status: linodego.InstanceMigrating}, nil)
+			},
+		},
+		{
+			name: "Initiate migration",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Region: "us-east", Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().GetRegion(gomock.Any(), "us-east").Return(&linodego.Region{ID: "us-east", SiteType: "core"}, nil)
+				mock.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(&linodego.Region{ID: "us-ord", SiteType: "core", Status: "ok", Capabilities: []string{linodego.CapabilityLinodes}}, nil)
+				mock.EXPECT().MigrateInstance(gomock.Any(), 123, linodego.InstanceMigrateOptions{Type: linodego.ColdMigration, Region: "us-ord"}).Return(nil)
+			},
+		},
+		{
+			name: "Error - target region does not support instances",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Region: "us-east", Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().GetRegion(gomock.Any(), "us-east").Return(&linodego.Region{ID: "us-east", SiteType: "core"}, nil)
+				mock.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(&linodego.Region{ID: "us-ord", SiteType: "core", Status: "ok"}, nil)
+			},
+			expectedError: "region us-ord does not support migrating instances into it",
+		},
+		{
+			name: "Error - migration across site types",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Region: "us-east", Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().GetRegion(gomock.Any(), "us-east").Return(&linodego.Region{ID: "us-east", SiteType: "distributed"}, nil)
+				mock.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(&linodego.Region{ID: "us-ord", SiteType: "core", Status: "ok", Capabilities: []string{linodego.CapabilityLinodes}}, nil)
+			},
+			expectedError: "migration from distributed site us-east to core site us-ord is not supported",
+		},
+		{
+			name: "Error - migrate fails",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Region: "us-east", Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().GetRegion(gomock.Any(), "us-east").Return(&linodego.Region{ID: "us-east", SiteType: "core"}, nil)
+				mock.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(&linodego.Region{ID: "us-ord", SiteType: "core", Status: "ok", Capabilities: []string{linodego.CapabilityLinodes}}, nil)
+				mock.EXPECT().MigrateInstance(gomock.Any(), 123, gomock.Any()).Return(errors.New("api error"))
+			},
+			expectedError: "api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{LinodeClient: mockLinodeClient}
+
+			done, err := mScope.MigrateRegion(context.Background(), 123, "us-ord")
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantDone, done)
+		})
+	}
+}
+
+func TestMachineScopeResizeInstance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		expects       func(mock *mock.MockLinodeClient)
+		wantDone      bool
+		expectedError string
+	}{
+		{
+			name: "Done - instance already has the target type",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-4", Status: linodego.InstanceRunning}, nil)
+			},
+			wantDone: true,
+		},
+		{
+			name: "In progress - instance is resizing",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-2", Status: linodego.InstanceResizing}, nil)
+			},
+		},
+		{
+			name: "In progress - boot instance left offline by the resize",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-4", Status: linodego.InstanceOffline}, nil)
+				mock.EXPECT().BootInstance(gomock.Any(), 123, 0).Return(nil)
+			},
+		},
+		{
+			name: "In progress - resize initiated",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-2", Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-4").Return(&linodego.LinodeType{ID: "g6-standard-4", Disk: 163840}, nil)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceDisk{{Size: 80000}, {Size: 512}}, nil)
+				mock.EXPECT().ResizeInstance(gomock.Any(), 123, linodego.InstanceResizeOptions{
+					Type:                "g6-standard-4",
+					MigrationType:       linodego.WarmMigration,
+					AllowAutoDiskResize: ptr.To(false),
+				}).Return(nil)
+			},
+		},
+		{
+			name: "Error - disks do not fit the target plan",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-8", Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-4").Return(&linodego.LinodeType{ID: "g6-standard-4", Disk: 163840}, nil)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceDisk{{Size: 327680}}, nil)
+			},
+			expectedError: "cannot resize instance 123 to g6-standard-4: its disks use 327680 MB but the plan only has 163840 MB",
+		},
+		{
+			name: "Error - resize fails",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-2", Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-4").Return(&linodego.LinodeType{ID: "g6-standard-4", Disk: 163840}, nil)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return(nil, nil)
+				mock.EXPECT().ResizeInstance(gomock.Any(), 123, gomock.Any()).Return(errors.New("api error"))
+			},
+			expectedError: "resize instance 123 to g6-standard-4: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{LinodeClient: mockLinodeClient}
+
+			done, err := mScope.ResizeInstance(context.Background(), 123, "g6-standard-4")
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantDone, done)
+		})
+	}
+}
+
+func TestMachineScopeResizeWithDiskMigration(t *testing.T) {
+	t.Parallel()
+
+	smallType := &linodego.LinodeType{ID: "g6-standard-2", Disk: 81920}
+	disks := func(status linodego.DiskStatus, rootSize int) []linodego.InstanceDisk {
+		return []linodego.InstanceDisk{
+			{ID: 1, Size: rootSize, Filesystem: linodego.FilesystemExt4, Status: status},
+			{ID: 2, Size: 512, Filesystem: linodego.FilesystemSwap, Status: linodego.DiskReady},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		expects         func(mock *mock.MockLinodeClient)
+		wantDone        bool
+		wantAnnotations map[string]string
+		expectedError   string
+	}{
+		{
+			name:        "Done - instance already has the target type",
+			annotations: map[string]string{infrav1alpha2.DiskShrinkAnnotation: "1:81408"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-2", Status: linodego.InstanceRunning}, nil).Times(2)
+			},
+			wantDone:        true,
+			wantAnnotations: map[string]string{},
+		},
+		{
+			name: "In progress - shut down instance before shrinking its disk",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-4", Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-2").Return(smallType, nil)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return(disks(linodego.DiskReady, 163328), nil)
+				mock.EXPECT().ShutdownInstance(gomock.Any(), 123).Return(nil)
+			},
+		},
+		{
+			name: "In progress - shrink disk of offline instance",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-4", Status: linodego.InstanceOffline}, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-2").Return(smallType, nil)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return(disks(linodego.DiskReady, 163328), nil)
+				mock.EXPECT().ResizeInstanceDisk(gomock.Any(), 123, 1, 81408).Return(nil)
+			},
+			wantAnnotations: map[string]string{infrav1alpha2.DiskShrinkAnnotation: "1:81408"},
+		},
+		{
+			name:        "In progress - disk is shrinking",
+			annotations: map[string]string{infrav1alpha2.DiskShrinkAnnotation: "1:81408"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-4", Status: linodego.InstanceOffline}, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-2").Return(smallType, nil)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return(disks(linodego.DiskNotReady, 163328), nil)
+			},
+			wantAnnotations: map[string]string{infrav1alpha2.DiskShrinkAnnotation: "1:81408"},
+		},
+		{
+			name:        "In progress - resize once the disks fit",
+			annotations: map[string]string{infrav1alpha2.DiskShrinkAnnotation: "1:81408"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-4", Status: linodego.InstanceOffline}, nil).Times(2)
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-2").Return(smallType, nil).Times(2)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return(disks(linodego.DiskReady, 81408), nil).Times(2)
+				mock.EXPECT().ResizeInstance(gomock.Any(), 123, linodego.InstanceResizeOptions{
+					Type:                "g6-standard-2",
+					MigrationType:       linodego.WarmMigration,
+					AllowAutoDiskResize: ptr.To(false),
+				}).Return(nil)
+			},
+			wantAnnotations: map[string]string{},
+		},
+		{
+			name:        "Error - disk data does not fit the target size",
+			annotations: map[string]string{infrav1alpha2.DiskShrinkAnnotation: "1:81408"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-4", Status: linodego.InstanceOffline}, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-2").Return(smallType, nil)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return(disks(linodego.DiskReady, 163328), nil)
+				mock.EXPECT().BootInstance(gomock.Any(), 123, 0).Return(nil)
+			},
+			wantAnnotations: map[string]string{infrav1alpha2.DiskShrinkAnnotation: "1:81408"},
+			expectedError:   "cannot resize instance 123 to g6-standard-2: disk 1 could not be shrunk to 81408 MB, its used space exceeds the target size",
+		},
+		{
+			name: "Error - no disk can be shrunk",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-4", Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-2").Return(smallType, nil)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceDisk{
+					{ID: 1, Size: 163840, Filesystem: linodego.FilesystemRaw, Status: linodego.DiskReady},
+				}, nil)
+			},
+			expectedError: "cannot resize instance 123 to g6-standard-2: its disks use 163840 MB but the plan only has 81920 MB, and no disk can be shrunk by 81920 MB",
+		},
+		{
+			name: "Error - shrink fails",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Type: "g6-standard-4", Status: linodego.InstanceOffline}, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-2").Return(smallType, nil)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return(disks(linodego.DiskReady, 163328), nil)
+				mock.EXPECT().ResizeInstanceDisk(gomock.Any(), 123, 1, 81408).Return(errors.New("api error"))
+			},
+			expectedError: "shrink instance disk 1 to 81408 MB: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient:  mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{},
+			}
+			mScope.LinodeMachine.Annotations = testcase.annotations
+
+			done, err := mScope.ResizeWithDiskMigration(context.Background(), 123, "g6-standard-2")
+			assert.Equal(t, testcase.wantAnnotations, mScope.LinodeMachine.Annotations)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantDone, done)
+		})
+	}
+}
+
+func TestMachineScopeRebuildInstance(t *testing.T) {
+	t.Parallel()
+
+	image := &linodego.Image{ID: "linode/ubuntu24.04", Status: linodego.ImageStatusAvailable, Capabilities: []string{"cloud-init"}}
+	region := &linodego.Region{ID: "us-ord", Capabilities: []string{linodego.CapabilityMetadata}}
+
+	tests := []struct {
+		name          string
+		spec          *infrav1alpha2.LinodeMachineSpec
+		expects       func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient)
+		wantDone      bool
+		expectedError string
+	}{
+		{
+			name: "Done - instance runs the image",
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Image: "linode/ubuntu24.04", Status: linodego.InstanceRunning}, nil)
+			},
+			wantDone: true,
+		},
+		{
+			name: "In progress - instance is rebuilding",
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Image: "linode/ubuntu22.04", Status: linodego.InstanceRebuilding}, nil)
+			},
+		},
+		{
+			name: "In progress - instance is booting the image",
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Image: "linode/ubuntu24.04", Status: linodego.InstanceBooting}, nil)
+			},
+		},
+		{
+			name: "In progress - rebuild initiated",
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Image: "linode/ubuntu22.04", Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().GetImage(gomock.Any(), "linode/ubuntu24.04").Return(image, nil)
+				mock.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(region, nil)
+				mock.EXPECT().ListSSHKeys(gomock.Any(), gomock.Any()).Return([]linodego.SSHKey{{Label: "ops", SSHKey: "ssh-ed25519 ops"}}, nil)
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, key client.ObjectKey, obj *corev1.Secret, opts ...client.GetOption) error {
+						*obj = corev1.Secret{Data: map[string][]byte{"value": []byte("#cloud-config")}}
+						return nil
+					})
+				mock.EXPECT().RebuildInstance(gomock.Any(), 123, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ int, opts linodego.InstanceRebuildOptions) (*linodego.Instance, error) {
+						assert.Equal(t, "linode/ubuntu24.04", opts.Image)
+						assert.Equal(t, []string{"ssh-ed25519 key", "ssh-ed25519 ops"}, opts.AuthorizedKeys)
+						assert.Equal(t, []string{"admin"}, opts.AuthorizedUsers)
+						assert.Equal(t, ptr.To(true), opts.Booted)
+						assert.Equal(t, "I2Nsb3VkLWNvbmZpZw==", opts.Metadata.UserData)
+						assert.NotEmpty(t, opts.RootPass)
+						return &linodego.Instance{ID: 123, Status: linodego.InstanceRebuilding}, nil
+					})
+			},
+		},
+		{
+			name: "Error - spec has data disks",
+			spec: &infrav1alpha2.LinodeMachineSpec{
+				Region:    "us-ord",
+				DataDisks: map[string]*infrav1alpha2.InstanceDisk{"sdb": {Label: "etcd"}},
+			},
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Image: "linode/ubuntu22.04", Status: linodego.InstanceRunning}, nil)
+			},
+			expectedError: "instance 123: cannot rebuild an instance with a custom disk layout",
+		},
+		{
+			name: "Error - ssh key label does not exist",
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Image: "linode/ubuntu22.04", Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().GetImage(gomock.Any(), "linode/ubuntu24.04").Return(image, nil)
+				mock.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(region, nil)
+				mock.EXPECT().ListSSHKeys(gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+			expectedError: "ssh keys ops do not exist in the Linode profile",
+		},
+		{
+			name: "Error - image is not available",
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Image: "linode/ubuntu22.04", Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().GetImage(gomock.Any(), "linode/ubuntu24.04").Return(&linodego.Image{Status: linodego.ImageStatusCreating}, nil)
+			},
+			expectedError: "image linode/ubuntu24.04 is not available, its status is creating",
+		},
+		{
+			name: "Error - image does not support cloud-init",
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Image: "linode/ubuntu22.04", Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().GetImage(gomock.Any(), "linode/ubuntu24.04").Return(&linodego.Image{Status: linodego.ImageStatusAvailable}, nil)
+			},
+			expectedError: "image linode/ubuntu24.04 does not support cloud-init",
+		},
+		{
+			name: "Error - region does not support metadata",
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Image: "linode/ubuntu22.04", Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().GetImage(gomock.Any(), "linode/ubuntu24.04").Return(image, nil)
+				mock.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(&linodego.Region{ID: "us-ord"}, nil)
+			},
+			expectedError: "region us-ord does not support the metadata service",
+		},
+		{
+			name: "Error - rebuild fails",
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Image: "linode/ubuntu22.04", Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().GetImage(gomock.Any(), "linode/ubuntu24.04").Return(image, nil)
+				mock.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(region, nil)
+				mock.EXPECT().ListSSHKeys(gomock.Any(), gomock.Any()).Return([]linodego.SSHKey{{Label: "ops", SSHKey: "ssh-ed25519 ops"}}, nil)
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, key client.ObjectKey, obj *corev1.Secret, opts ...client.GetOption) error {
+						*obj = corev1.Secret{Data: map[string][]byte{"value": []byte("#cloud-config")}}
+						return nil
+					})
+				mock.EXPECT().RebuildInstance(gomock.Any(), 123, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "rebuild instance 123 from linode/ubuntu24.04: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			mockK8sClient := mock.NewMockK8sClient(ctrl)
+			testcase.expects(mockLinodeClient, mockK8sClient)
+
+			spec := infrav1alpha2.LinodeMachineSpec{
+				Region:              "us-ord",
+				AuthorizedKeys:      []string{"ssh-ed25519 key"},
+				AuthorizedKeyLabels: []string{"ops"},
+				AuthorizedUsers:     []string{"admin"},
+			}
+			if testcase.spec != nil {
+				spec = *testcase.spec
+			}
+
+			mScope := &MachineScope{
+				Client:       mockK8sClient,
+				LinodeClient: mockLinodeClient,
+				Machine: &clusterv1.Machine{
+					Spec: clusterv1.MachineSpec{Bootstrap: clusterv1.Bootstrap{DataSecretName: ptr.To("bootstrap")}},
+				},
+				LinodeMachine: &infrav1alpha2.LinodeMachine{Spec: spec},
+			}
+
+			done, err := mScope.RebuildInstance(context.Background(), 123, "linode/ubuntu24.04")
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantDone, done)
+		})
+	}
+}
+
+func TestMachineScopeReconcileBackupSchedule(t *testing.T) {
+	t.Parallel()
+
+	withSchedule := func(day, window string) *linodego.InstanceBackup {
+		backups := &linodego.InstanceBackup{Enabled: true}
+		backups.Schedule.Day = day
+		backups.Schedule.Window = window
+		return backups
+	}
+
+	tests := []struct {
+		name          string
+		spec          infrav1alpha2.LinodeMachineSpec
+		expects       func(mock *mock.MockLinodeClient)
+		wantPending   bool
+		expectedError string
+	}{
+		{
+			name:    "No schedule configured",
+			spec:    infrav1alpha2.LinodeMachineSpec{BackupsEnabled: true},
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:    "Backups disabled",
+			spec:    infrav1alpha2.LinodeMachineSpec{BackupSchedule: &infrav1alpha2.BackupSchedule{Window: "W4"}},
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name: "Schedule drifted",
+			spec: infrav1alpha2.LinodeMachineSpec{BackupsEnabled: true, BackupSchedule: &infrav1alpha2.BackupSchedule{Window: "W4", Day: "Monday"}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Backups: withSchedule("Sunday", "W4")}, nil)
+				want := &linodego.InstanceBackup{}
+				want.Schedule.Day = "Monday"
+				want.Schedule.Window = "W4"
+				mock.EXPECT().UpdateInstance(gomock.Any(), 123, linodego.InstanceUpdateOptions{Backups: want}).Return(&linodego.Instance{}, nil)
+			},
+		},
+		{
+			name: "Schedule in sync",
+			spec: infrav1alpha2.LinodeMachineSpec{BackupsEnabled: true, BackupSchedule: &infrav1alpha2.BackupSchedule{Window: "W4"}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Backups: withSchedule("Sunday", "W4")}, nil)
+			},
+		},
+		{
+			name: "Pending until backups are enabled on instance",
+			spec: infrav1alpha2.LinodeMachineSpec{BackupsEnabled: true, BackupSchedule: &infrav1alpha2.BackupSchedule{Day: "Monday"}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Backups: &linodego.InstanceBackup{}}, nil)
+			},
+			wantPending: true,
+		},
+		{
+			name: "Pending while instance reports no backups",
+			spec: infrav1alpha2.LinodeMachineSpec{BackupsEnabled: true, BackupSchedule: &infrav1alpha2.BackupSchedule{Day: "Monday"}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123}, nil)
+			},
+			wantPending: true,
+		},
+		{
+			name:          "Error - invalid window",
+			spec:          infrav1alpha2.LinodeMachineSpec{BackupsEnabled: true, BackupSchedule: &infrav1alpha2.BackupSchedule{Window: "W3"}},
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expectedError: "invalid backup window \"W3\"",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient:  mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{Spec: testcase.spec},
+			}
+
+			pending, err := mScope.ReconcileBackupSchedule(context.Background(), 123)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantPending, pending)
+		})
+	}
+}
+
+func TestMachineScopeGracefulShutdown(t *testing.T) {
+	t.Parallel()
+
+	notFound := &linodego.Error{Code: http.StatusNotFound}
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		requestedAt     *time.Time
+		expects         func(mock *mock.MockLinodeClient)
+		expectStopped   bool
+		expectRequested bool
+		expectTimeout   bool
+		expectedError   string
+	}{
+		{
+			name: "Success - Already offline",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceOffline}, nil)
+			},
+			expectStopped: true,
+		},
+		{
+			name: "Success - Already deleted",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(nil, notFound)
+			},
+			expectStopped: true,
+		},
+		{
+			name: "Success - Requests shutdown",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().ShutdownInstance(gomock.Any(), 123).Return(nil)
+			},
+			expectRequested: true,
+		},
+		{
+			name: "Success - Already shutting down",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceShuttingDown}, nil)
+			},
+			expectRequested: true,
+		},
+		{
+			name:        "Success - Waits for requested shutdown",
+			requestedAt: ptr.To(now.Add(-time.Minute)),
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceShuttingDown}, nil)
+			},
+			expectRequested: true,
+		},
+		{
+			name:        "Success - Stops after requested shutdown",
+			requestedAt: ptr.To(now.Add(-time.Minute)),
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceOffline}, nil)
+			},
+			expectStopped:   true,
+			expectRequested: true,
+		},
+		{
+			name: "Error - Shutdown fails",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().ShutdownInstance(gomock.Any(), 123).Return(errors.New("api error"))
+			},
+			expectedError: "api error",
+		},
+		{
+			name:        "Error - Times out",
+			requestedAt: ptr.To(now.Add(-3 * time.Minute)),
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceShuttingDown}, nil)
+			},
+			expectRequested: true,
+			expectTimeout:   true,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{LinodeClient: mockLinodeClient, LinodeMachine: &infrav1alpha2.LinodeMachine{}}
+			if testcase.requestedAt != nil {
+				conditions.Set(mScope.LinodeMachine, &clusterv1.Condition{
+					Type:               ConditionShutdownRequested,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(*testcase.requestedAt),
+				})
+			}
+
+			stopped, err := mScope.GracefulShutdown(context.Background(), 123, 2*time.Minute, now)
+			switch {
+			case testcase.expectTimeout:
+				require.ErrorIs(t, err, ErrShutdownTimeout)
+			case testcase.expectedError != "":
+				require.ErrorContains(t, err, testcase.expectedError)
+				assert.NotErrorIs(t, err, ErrShutdownTimeout)
+			default:
+				require.NoError(t, err)
+			}
+			assert.Equal(t, testcase.expectStopped, stopped)
+			assert.Equal(t, testcase.expectRequested, conditions.IsTrue(mScope.LinodeMachine, ConditionShutdownRequested))
+			if testcase.requestedAt == nil && testcase.expectRequested {
+				assert.Equal(t, now, conditions.Get(mScope.LinodeMachine, ConditionShutdownRequested).LastTransitionTime.Time)
+			}
+		})
+	}
+}
+
+func TestMachineScopeValidateMetadataServiceAvailable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		expects       func(mock *mock.MockLinodeClient)
+		unavailable   bool
+		expectedError string
+	}{
+		{
+			name: "Success - Region supports the metadata service",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(&linodego.Region{ID: "us-ord", Capabilities: []string{linodego.CapabilityLinodes, linodego.CapabilityMetadata}}, nil)
+			},
+		},
+		{
+			name: "Error - Region does not support the metadata service",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(&linodego.Region{ID: "us-ord", Capabilities: []string{linodego.CapabilityLinodes}}, nil)
+			},
+			unavailable:   true,
+			expectedError: "region us-ord does not support the metadata service",
+		},
+		{
+			name: "Error - Get region fails",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(nil, errors.New("api error"))
+			},
+			expectedError: "get region us-ord: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{Region: "us-ord"},
+				},
+			}
+
+			err := mScope.ValidateMetadataServiceAvailable(context.Background())
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				assert.Equal(t, testcase.unavailable, errors.Is(err, ErrMetadataServiceUnavailable))
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMachineScopeValidateInstanceCapabilities(t *testing.T) {
+	t.Parallel()
+
+	gpuRegion := &linodego.Region{ID: "us-ord", Capabilities: []string{linodego.CapabilityLinodes, linodego.CapabilityGPU, linodego.CapabilityMetadata}}
+
+	tests := []struct {
+		name          string
+		instanceType  string
+		required      []string
+		expects       func(mock *mock.MockLinodeClient)
+		expectedError string
+	}{
+		{
+			name:    "Success - No capabilities required",
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:     "Success - Region and type support GPUs",
+			required: []string{linodego.CapabilityGPU, linodego.CapabilityMetadata},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(gpuRegion, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g1-gpu-rtx6000-1").Return(&linodego.LinodeType{ID: "g1-gpu-rtx6000-1", GPUs: 1}, nil)
+			},
+		},
+		{
+			name:         "Success - Fallback type is checked for GPUs",
+			instanceType: "g2-gpu-rtx4000a1-s",
+			required:     []string{linodego.CapabilityGPU},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(gpuRegion, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g2-gpu-rtx4000a1-s").Return(&linodego.LinodeType{ID: "g2-gpu-rtx4000a1-s", GPUs: 1}, nil)
+			},
+		},
+		{
+			name:         "Error - Fallback type has no GPUs",
+			instanceType: "g6-standard-2",
+			required:     []string{linodego.CapabilityGPU},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(gpuRegion, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g6-standard-2").Return(&linodego.LinodeType{ID: "g6-standard-2"}, nil)
+			},
+			expectedError: "type g6-standard-2 has no GPUs",
+		},
+		{
+			name:     "Error - Region lacks capabilities",
+			required: []string{"Block Storage Encryption", linodego.CapabilityMetadata, linodego.CapabilityPlacementGroup},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(gpuRegion, nil)
+			},
+			expectedError: "region us-ord does not support Block Storage Encryption, Placement Group",
+		},
+		{
+			name:     "Error - Type has no GPUs",
+			required: []string{linodego.CapabilityGPU},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(gpuRegion, nil)
+				mock.EXPECT().GetType(gomock.Any(), "g1-gpu-rtx6000-1").Return(&linodego.LinodeType{ID: "g1-gpu-rtx6000-1"}, nil)
+			},
+			expectedError: "type g1-gpu-rtx6000-1 has no GPUs",
+		},
+		{
+			name:     "Error - Get region fails",
+			required: []string{linodego.CapabilityGPU},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(nil, errors.New("api error"))
+			},
+			expectedError: "get region us-ord: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{Region: "us-ord", Type: "g1-gpu-rtx6000-1"},
+				},
+			}
+
+			instanceType := testcase.instanceType
+			if instanceType == "" {
+				instanceType = mScope.LinodeMachine.Spec.Type
+			}
+			err := mScope.ValidateInstanceCapabilities(context.Background(), instanceType, testcase.required)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMachineScopeValidateDiskEncryptionSupported(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		diskEncryption string
+		expects        func(mock *mock.MockLinodeClient)
+		expectedError  string
+	}{
+		{
+			name:           "Success - Disk encryption is not enabled",
+			diskEncryption: string(linodego.InstanceDiskEncryptionDisabled),
+			expects:        func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:           "Success - Region supports disk encryption",
+			diskEncryption: string(linodego.InstanceDiskEncryptionEnabled),
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(&linodego.Region{ID: "us-ord", Capabilities: []string{linodego.CapabilityDiskEncryption}}, nil)
+			},
+		},
+		{
+			name:           "Error - Region does not support disk encryption",
+			diskEncryption: string(linodego.InstanceDiskEncryptionEnabled),
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(&linodego.Region{ID: "us-ord", Capabilities: []string{linodego.CapabilityLinodes}}, nil)
+			},
+			expectedError: "disk encryption is not supported in region us-ord",
+		},
+		{
+			name:           "Error - Get region fails",
+			diskEncryption: string(linodego.InstanceDiskEncryptionEnabled),
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(nil, errors.New("api error"))
+			},
+			expectedError: "api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{Region: "us-ord", DiskEncryption: testcase.diskEncryption},
+				},
+			}
+
+			err := mScope.ValidateDiskEncryptionSupported(context.Background())
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			// The region is cached for the scope lifetime
+			require.NoError(t, mScope.ValidateDiskEncryptionSupported(context.Background()))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package scope
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/linode/linodego"
+
+	"github.com/linode/cluster-api-provider-linode/util"
+)
+
+// ReconcileAdditionalIPs allocates public IPv4 addresses to the instance until it has the
+// spec's additional IPv4 count, and releases the ones it allocated beyond that count, or
+// all of them once the LinodeMachine is being deleted. The allocated addresses are recorded
+// in the status, so the instance's default address and addresses added by other means are
+// never released. Linode limits the public addresses of an instance and requires additional
+// addresses to be approved for the account, and the API error is returned when an address
+// cannot be allocated. It returns the addresses allocated to the instance.
+func (s *MachineScope) ReconcileAdditionalIPs(ctx context.Context, instanceID int) ([]string, error) {
+	desired := s.LinodeMachine.Spec.AdditionalIPv4Count
+	if !s.LinodeMachine.DeletionTimestamp.IsZero() {
+		desired = 0
+	}
+	managed := s.LinodeMachine.Status.AdditionalIPv4s
+	if desired == 0 && len(managed) == 0 {
+		return nil, nil
+	}
+
+	addresses, err := s.LinodeClient.GetInstanceIPAddresses(ctx, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("get instance %d IP addresses: %w", instanceID, err)
+	}
+	var allocated []string
+	if addresses.IPv4 != nil {
+		for _, address := range managed {
+			if slices.ContainsFunc(addresses.IPv4.Public, func(ip *linodego.InstanceIP) bool { return ip.Address == address }) {
+				allocated = append(allocated, address)
+			}
+		}
+	}
+
+	for len(allocated) < desired {
+		ip, err := s.LinodeClient.AddInstanceIPAddress(ctx, instanceID, true)
+		if err != nil {
+			s.LinodeMachine.Status.AdditionalIPv4s = allocated
+			return allocated, fmt.Errorf("allocate additional IPv4 address for instance %d: %w", instanceID, err)
+		}
+		allocated = append(allocated, ip.Address)
+	}
+
+	for len(allocated) > desired {
+		address := allocated[len(allocated)-1]
+		if err := s.LinodeClient.DeleteInstanceIPAddress(ctx, instanceID, address); util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
+			s.LinodeMachine.Status.AdditionalIPv4s = allocated
+			return allocated, fmt.Errorf("release IPv4 address %s of instance %d: %w", address, instanceID, err)
+		}
+		allocated = allocated[:len(allocated)-1]
+	}
+	s.LinodeMachine.Status.AdditionalIPv4s = allocated
+
+	return allocated, nil
+}
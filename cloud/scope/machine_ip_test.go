@@ -0,0 +1,145 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeReconcileAdditionalIPs(t *testing.T) {
+	t.Parallel()
+
+	publicIPs := func(addresses ...string) *linodego.InstanceIPAddressResponse {
+		ips := make([]*linodego.InstanceIP, 0, len(addresses))
+		for _, address := range addresses {
+			ips = append(ips, &linodego.InstanceIP{Address: address, Public: true})
+		}
+		return &linodego.InstanceIPAddressResponse{IPv4: &linodego.InstanceIPv4Response{Public: ips}}
+	}
+
+	tests := []struct {
+		name          string
+		count         int
+		deleting      bool
+		managed       []string
+		expects       func(mock *mock.MockLinodeClient)
+		want          []string
+		expectedError string
+	}{
+		{
+			name:    "No additional addresses",
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:  "Allocate addresses",
+			count: 2,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceIPAddresses(gomock.Any(), 123).Return(publicIPs("1.1.1.1"), nil)
+				mock.EXPECT().AddInstanceIPAddress(gomock.Any(), 123, true).Return(&linodego.InstanceIP{Address: "2.2.2.2"}, nil)
+				mock.EXPECT().AddInstanceIPAddress(gomock.Any(), 123, true).Return(&linodego.InstanceIP{Address: "3.3.3.3"}, nil)
+			},
+			want: []string{"2.2.2.2", "3.3.3.3"},
+		},
+		{
+			name:    "Addresses already allocated",
+			count:   1,
+			managed: []string{"2.2.2.2"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceIPAddresses(gomock.Any(), 123).Return(publicIPs("1.1.1.1", "2.2.2.2"), nil)
+			},
+			want: []string{"2.2.2.2"},
+		},
+		{
+			name:    "Replace address removed outside the controller",
+			count:   1,
+			managed: []string{"2.2.2.2"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceIPAddresses(gomock.Any(), 123).Return(publicIPs("1.1.1.1"), nil)
+				mock.EXPECT().AddInstanceIPAddress(gomock.Any(), 123, true).Return(&linodego.InstanceIP{Address: "3.3.3.3"}, nil)
+			},
+			want: []string{"3.3.3.3"},
+		},
+		{
+			name:    "Release addresses beyond the count",
+			count:   1,
+			managed: []string{"2.2.2.2", "3.3.3.3"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceIPAddresses(gomock.Any(), 123).Return(publicIPs("1.1.1.1", "2.2.2.2", "3.3.3.3"), nil)
+				mock.EXPECT().DeleteInstanceIPAddress(gomock.Any(), 123, "3.3.3.3").Return(nil)
+			},
+			want: []string{"2.2.2.2"},
+		},
+		{
+			name:     "Release all addresses on deletion",
+			count:    1,
+			deleting: true,
+			managed:  []string{"2.2.2.2"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceIPAddresses(gomock.Any(), 123).Return(publicIPs("1.1.1.1", "2.2.2.2"), nil)
+				mock.EXPECT().DeleteInstanceIPAddress(gomock.Any(), 123, "2.2.2.2").Return(&linodego.Error{Code: http.StatusNotFound})
+			},
+			want: []string{},
+		},
+		{
+			name:  "Error - additional addresses not approved",
+			count: 1,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceIPAddresses(gomock.Any(), 123).Return(publicIPs("1.1.1.1"), nil)
+				mock.EXPECT().AddInstanceIPAddress(gomock.Any(), 123, true).
+					Return(nil, errors.New("Additional IPv4 addresses require technical justification"))
+			},
+			expectedError: "allocate additional IPv4 address for instance 123: Additional IPv4 addresses require technical justification",
+		},
+		{
+			name:  "Error - get addresses fails",
+			count: 1,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceIPAddresses(gomock.Any(), 123).Return(nil, errors.New("api error"))
+			},
+			expectedError: "get instance 123 IP addresses: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec:   infrav1alpha2.LinodeMachineSpec{AdditionalIPv4Count: testcase.count},
+					Status: infrav1alpha2.LinodeMachineStatus{AdditionalIPv4s: testcase.managed},
+				},
+			}
+			if testcase.deleting {
+				mScope.LinodeMachine.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			}
+
+			allocated, err := mScope.ReconcileAdditionalIPs(context.Background(), 123)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.want, allocated)
+			assert.Equal(t, testcase.want, mScope.LinodeMachine.Status.AdditionalIPv4s)
+		})
+	}
+}
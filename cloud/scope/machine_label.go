@@ -0,0 +1,136 @@
+package scope
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/linode/linodego"
+)
+
+const (
+	// defaultLabelTemplate renders the instance label from the LinodeMachine name.
+	defaultLabelTemplate = "{machine}"
+
+	// minLabelLength and maxLabelLength are the instance label length limits enforced by the Linode API.
+	minLabelLength = 3
+	maxLabelLength = 64
+
+	// uidHashLength is the number of hex characters of the machine UID hash used in labels.
+	uidHashLength = 8
+)
+
+// ErrRenameDisruptive is returned when the instance label differs from the expected one but
+// the instance is running, and renaming running instances is not allowed by the spec.
+var ErrRenameDisruptive = errors.New("renaming a running instance is disruptive")
+
+var (
+	// labelPlaceholderRegex matches the placeholders of a label template.
+	labelPlaceholderRegex = regexp.MustCompile(`\{[^{}]*\}`)
+	// invalidLabelCharsRegex matches the characters not allowed in instance labels.
+	invalidLabelCharsRegex = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
+	// repeatedLabelSeparatorsRegex matches consecutive separators, which are not allowed in instance labels.
+	repeatedLabelSeparatorsRegex = regexp.MustCompile(`[_.-]{2,}`)
+)
+
+// InstanceLabel renders the Linode instance label from the LinodeMachine's label template,
+// defaulting to the LinodeMachine name. The rendered label is sanitized to the characters
+// allowed by the Linode API, and an error is returned for unknown placeholders or labels
+// that are too short to be valid.
+func (s *MachineScope) InstanceLabel() (string, error) {
+	template := s.LinodeMachine.Spec.LabelTemplate
+	if template == "" {
+		template = defaultLabelTemplate
+	}
+
+	label, unknown := s.renderTemplate(template)
+	if len(unknown) > 0 {
+		return "", fmt.Errorf("label template %q has unknown placeholders %s", template, strings.Join(unknown, ", "))
+	}
+
+	label = sanitizeLabel(label)
+	if len(label) < minLabelLength {
+		return "", fmt.Errorf("label %q rendered from template %q must be at least %d characters", label, template, minLabelLength)
+	}
+
+	return label, nil
+}
+
+// renderTemplate replaces the {cluster}, {machine}, {role} and {uid-hash} placeholders of the
+// template with the cluster name, machine name, machine role and a short hash of the machine
+// UID, returning the placeholders it does not know.
+func (s *MachineScope) renderTemplate(template string) (string, []string) {
+	role := "worker"
+	if s.IsControlPlane() {
+		role = "control-plane"
+	}
+	uidHash := sha256.Sum256([]byte(s.LinodeMachine.UID))
+	var clusterName string
+	if s.Cluster != nil {
+		clusterName = s.Cluster.Name
+	}
+	values := map[string]string{
+		"{cluster}":  clusterName,
+		"{machine}":  s.LinodeMachine.Name,
+		"{role}":     role,
+		"{uid-hash}": hex.EncodeToString(uidHash[:])[:uidHashLength],
+	}
+
+	var unknown []string
+	rendered := labelPlaceholderRegex.ReplaceAllStringFunc(template, func(placeholder string) string {
+		value, ok := values[placeholder]
+		if !ok {
+			unknown = append(unknown, placeholder)
+		}
+		return value
+	})
+
+	return rendered, unknown
+}
+
+// ReconcileHostname renames the instance to the label rendered by InstanceLabel, which is
+// the hostname the instance's node registers with. Linode only applies the new hostname on
+// boot, so a running instance is only renamed when the spec allows it; otherwise an error
+// wrapping ErrRenameDisruptive is returned and the instance is left untouched.
+func (s *MachineScope) ReconcileHostname(ctx context.Context, instanceID int) error {
+	label, err := s.InstanceLabel()
+	if err != nil {
+		return err
+	}
+
+	instance, err := s.LinodeClient.GetInstance(ctx, instanceID)
+	if err != nil {
+		return fmt.Errorf("get instance %d: %w", instanceID, err)
+	}
+	if instance.Label == label {
+		return nil
+	}
+	if instance.Status != linodego.InstanceOffline && !s.LinodeMachine.Spec.AllowRunningRename {
+		return fmt.Errorf("rename instance %d from %s to %s: %w", instanceID, instance.Label, label, ErrRenameDisruptive)
+	}
+
+	if _, err := s.LinodeClient.UpdateInstance(ctx, instanceID, linodego.InstanceUpdateOptions{Label: label}); err != nil {
+		return fmt.Errorf("rename instance %d to %s: %w", instanceID, label, err)
+	}
+
+	return nil
+}
+
+// sanitizeLabel replaces the characters not allowed in instance labels, collapses consecutive
+// separators and truncates the label to the maximum length. Labels must begin and end with an
+// alphanumeric character, so leading and trailing separators are removed.
+func sanitizeLabel(label string) string {
+	label = invalidLabelCharsRegex.ReplaceAllString(label, "-")
+	label = repeatedLabelSeparatorsRegex.ReplaceAllStringFunc(label, func(separators string) string {
+		return separators[:1]
+	})
+	if len(label) > maxLabelLength {
+		label = label[:maxLabelLength]
+	}
+
+	return strings.Trim(label, "_.-")
+}
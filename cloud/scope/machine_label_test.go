@@ -0,0 +1,187 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeInstanceLabel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		machineName   string
+		template      string
+		controlPlane  bool
+		expected      string
+		expectedError string
+	}{
+		{
+			name:        "Success - Defaults to the machine name",
+			machineName: "test-machine-abc12",
+			expected:    "test-machine-abc12",
+		},
+		{
+			name:         "Success - Control plane template",
+			machineName:  "test-machine-abc12",
+			template:     "{cluster}-{role}-{uid-hash}",
+			controlPlane: true,
+			expected:     "test-cluster-control-plane-a856e8d8",
+		},
+		{
+			name:        "Success - Worker template",
+			machineName: "test-machine-abc12",
+			template:    "{cluster}.{role}",
+			expected:    "test-cluster.worker",
+		},
+		{
+			name:        "Success - Label is sanitized",
+			machineName: "test-machine-abc12",
+			template:    "-{cluster}/{machine}--",
+			expected:    "test-cluster-test-machine-abc12",
+		},
+		{
+			name:        "Success - Label is truncated",
+			machineName: strings.Repeat("a", 70),
+			expected:    strings.Repeat("a", 64),
+		},
+		{
+			name:          "Error - Unknown placeholder",
+			machineName:   "test-machine-abc12",
+			template:      "{cluster}-{index}",
+			expectedError: `label template "{cluster}-{index}" has unknown placeholders {index}`,
+		},
+		{
+			name:          "Error - Empty placeholder",
+			machineName:   "test-machine-abc12",
+			template:      "a-{}",
+			expectedError: `has unknown placeholders {}`,
+		},
+		{
+			name:          "Error - Rendered label too short",
+			machineName:   "a",
+			template:      "{machine}",
+			expectedError: `label "a" rendered from template "{machine}" must be at least 3 characters`,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			machine := &clusterv1.Machine{}
+			if testcase.controlPlane {
+				machine.Labels = map[string]string{clusterv1.MachineControlPlaneLabel: ""}
+			}
+			mScope := &MachineScope{
+				Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+				Machine: machine,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: testcase.machineName, UID: "test-uid"},
+					Spec:       infrav1alpha2.LinodeMachineSpec{LabelTemplate: testcase.template},
+				},
+			}
+
+			label, err := mScope.InstanceLabel()
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.expected, label)
+		})
+	}
+}
+
+func TestMachineScopeReconcileHostname(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		allowRunning  bool
+		expects       func(mock *mock.MockLinodeClient)
+		expectedError error
+		errorContains string
+	}{
+		{
+			name: "Label already matches",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Label: "test-machine", Status: linodego.InstanceRunning}, nil)
+			},
+		},
+		{
+			name: "Rename offline instance",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Label: "old-label", Status: linodego.InstanceOffline}, nil)
+				mock.EXPECT().UpdateInstance(gomock.Any(), 123, linodego.InstanceUpdateOptions{Label: "test-machine"}).Return(&linodego.Instance{}, nil)
+			},
+		},
+		{
+			name:         "Rename running instance when allowed",
+			allowRunning: true,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Label: "old-label", Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().UpdateInstance(gomock.Any(), 123, linodego.InstanceUpdateOptions{Label: "test-machine"}).Return(&linodego.Instance{}, nil)
+			},
+		},
+		{
+			name: "Error - running instance is not renamed",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Label: "old-label", Status: linodego.InstanceRunning}, nil)
+			},
+			expectedError: ErrRenameDisruptive,
+		},
+		{
+			name: "Error - update fails",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Label: "old-label", Status: linodego.InstanceOffline}, nil)
+				mock.EXPECT().UpdateInstance(gomock.Any(), 123, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			errorContains: "rename instance 123 to test-machine: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				Cluster:      &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+				Machine:      &clusterv1.Machine{},
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-machine", UID: "test-uid"},
+					Spec:       infrav1alpha2.LinodeMachineSpec{AllowRunningRename: testcase.allowRunning},
+				},
+			}
+
+			err := mScope.ReconcileHostname(context.Background(), 123)
+			switch {
+			case testcase.expectedError != nil:
+				require.ErrorIs(t, err, testcase.expectedError)
+			case testcase.errorContains != "":
+				require.ErrorContains(t, err, testcase.errorContains)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
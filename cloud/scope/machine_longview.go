@@ -0,0 +1,62 @@
+package scope
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/linode/linodego"
+
+	"github.com/linode/cluster-api-provider-linode/util"
+)
+
+// maxLongviewLabelLength is the Longview client label length limit enforced by the Linode API.
+const maxLongviewLabelLength = 32
+
+// EnsureLongview returns the API key of the machine's Longview client, creating the
+// client if the machine does not have one yet or it was deleted out of band. The client
+// ID is recorded in the status, and the key can be handed to cloud-init to install the
+// Longview agent.
+func (s *MachineScope) EnsureLongview(ctx context.Context) (string, error) {
+	if id := s.LinodeMachine.Status.LongviewClientID; id != nil {
+		longviewClient, err := s.LinodeClient.GetLongviewClient(ctx, *id)
+		if err == nil {
+			return longviewClient.APIKey, nil
+		}
+		if util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
+			return "", fmt.Errorf("get longview client %d: %w", *id, err)
+		}
+	}
+
+	label, err := s.InstanceLabel()
+	if err != nil {
+		return "", err
+	}
+	if len(label) > maxLongviewLabelLength {
+		label = strings.Trim(label[:maxLongviewLabelLength], "_.-")
+	}
+
+	longviewClient, err := s.LinodeClient.CreateLongviewClient(ctx, linodego.LongviewClientCreateOptions{Label: label})
+	if err != nil {
+		return "", fmt.Errorf("create longview client %s: %w", label, err)
+	}
+	s.LinodeMachine.Status.LongviewClientID = &longviewClient.ID
+
+	return longviewClient.APIKey, nil
+}
+
+// DeleteLongview deletes the machine's Longview client, if it has one.
+func (s *MachineScope) DeleteLongview(ctx context.Context) error {
+	id := s.LinodeMachine.Status.LongviewClientID
+	if id == nil {
+		return nil
+	}
+
+	if err := s.LinodeClient.DeleteLongviewClient(ctx, *id); util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
+		return fmt.Errorf("delete longview client %d: %w", *id, err)
+	}
+	s.LinodeMachine.Status.LongviewClientID = nil
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeEnsureLongview(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		machineName   string
+		clientID      *int
+		expects       func(mock *mock.MockLinodeClient)
+		wantKey       string
+		wantClientID  *int
+		expectedError string
+	}{
+		{
+			name:        "Create Longview client",
+			machineName: "test-machine",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().CreateLongviewClient(gomock.Any(), linodego.LongviewClientCreateOptions{Label: "test-machine"}).
+					Return(&linodego.LongviewClient{ID: 10, APIKey: "key"}, nil)
+			},
+			wantKey:      "key",
+			wantClientID: ptr.To(10),
+		},
+		{
+			name:        "Reuse existing Longview client",
+			machineName: "test-machine",
+			clientID:    ptr.To(10),
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetLongviewClient(gomock.Any(), 10).Return(&linodego.LongviewClient{ID: 10, APIKey: "key"}, nil)
+			},
+			wantKey:      "key",
+			wantClientID: ptr.To(10),
+		},
+		{
+			name:        "Recreate deleted Longview client with a truncated label",
+			machineName: "a-very-long-machine-name-from-a-machine-deployment",
+			clientID:    ptr.To(10),
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetLongviewClient(gomock.Any(), 10).Return(nil, &linodego.Error{Code: 404})
+				mock.EXPECT().CreateLongviewClient(gomock.Any(), linodego.LongviewClientCreateOptions{Label: "a-very-long-machine-name-from-a"}).
+					Return(&linodego.LongviewClient{ID: 11, APIKey: "new-key"}, nil)
+			},
+			wantKey:      "new-key",
+			wantClientID: ptr.To(11),
+		},
+		{
+			name:        "Error - get Longview client fails",
+			machineName: "test-machine",
+			clientID:    ptr.To(10),
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetLongviewClient(gomock.Any(), 10).Return(nil, errors.New("api error"))
+			},
+			wantClientID:  ptr.To(10),
+			expectedError: "get longview client 10: api error",
+		},
+		{
+			name:        "Error - create Longview client fails",
+			machineName: "test-machine",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().CreateLongviewClient(gomock.Any(), gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "create longview client test-machine: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: testcase.machineName},
+					Status:     infrav1alpha2.LinodeMachineStatus{LongviewClientID: testcase.clientID},
+				},
+			}
+
+			key, err := mScope.EnsureLongview(context.Background())
+			assert.Equal(t, testcase.wantClientID, mScope.LinodeMachine.Status.LongviewClientID)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantKey, key)
+		})
+	}
+}
+
+func TestMachineScopeDeleteLongview(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		clientID      *int
+		expects       func(mock *mock.MockLinodeClient)
+		wantClientID  *int
+		expectedError string
+	}{
+		{
+			name:    "No Longview client",
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:     "Delete Longview client",
+			clientID: ptr.To(10),
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().DeleteLongviewClient(gomock.Any(), 10).Return(nil)
+			},
+		},
+		{
+			name:     "Longview client is already gone",
+			clientID: ptr.To(10),
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().DeleteLongviewClient(gomock.Any(), 10).Return(&linodego.Error{Code: 404})
+			},
+		},
+		{
+			name:     "Error - delete fails",
+			clientID: ptr.To(10),
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().DeleteLongviewClient(gomock.Any(), 10).Return(errors.New("api error"))
+			},
+			wantClientID:  ptr.To(10),
+			expectedError: "delete longview client 10: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Status: infrav1alpha2.LinodeMachineStatus{LongviewClientID: testcase.clientID},
+				},
+			}
+
+			err := mScope.DeleteLongview(context.Background())
+			assert.Equal(t, testcase.wantClientID, mScope.LinodeMachine.Status.LongviewClientID)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+)
+
+// ConditionRebootRequired reports that configuration changes are waiting for the instance to be
+// rebooted. Its message names the changed configuration. It is removed once the instance is
+// rebooted.
+const ConditionRebootRequired clusterv1.ConditionType = "RebootRequired"
+
+// RebootAllowed reports whether the instance may be rebooted at the given time, which is the
+// case when the spec has no maintenance window or the time falls within it.
+func (s *MachineScope) RebootAllowed(now time.Time) bool {
+	window := s.LinodeMachine.Spec.MaintenanceWindow
+	if window == nil {
+		return true
+	}
+
+	return windowOpen(*window, now)
+}
+
+// windowOpen reports whether the time falls within the recurring window. A window opened on
+// an earlier day may still be open, e.g. one opening at 23:00 for two hours.
+func windowOpen(window infrav1alpha2.TimeWindow, now time.Time) bool {
+	start, err := time.Parse("15:04", window.Start)
+	if err != nil {
+		return false
+	}
+
+	now = now.UTC()
+	for daysAgo := 0; daysAgo <= int(window.Duration.Duration/(24*time.Hour))+1; daysAgo++ {
+		day := now.AddDate(0, 0, -daysAgo)
+		opens := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+		if len(window.Days) > 0 && !slices.Contains(window.Days, opens.Weekday().String()) {
+			continue
+		}
+		if !now.Before(opens) && now.Before(opens.Add(window.Duration.Duration)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ScheduleReboot records that the instance needs a reboot and reboots it once the maintenance
+// window allows it, clearing the pending reboot from the status. The instance is rebooted into
+// the config profile recorded by ReconcileBootConfig, if any. Callers should keep calling it
+// while a reboot is pending.
+func (s *MachineScope) ScheduleReboot(ctx context.Context) error {
+	now := time.Now()
+	if s.LinodeMachine.Status.RebootPendingSince == nil {
+		s.LinodeMachine.Status.RebootPendingSince = &metav1.Time{Time: now}
+	}
+	if !s.RebootAllowed(now) {
+		return nil
+	}
+	if s.LinodeMachine.Spec.InstanceID == nil {
+		return errors.New("missing instance ID")
+	}
+
+	instanceID := *s.LinodeMachine.Spec.InstanceID
+	if err := s.LinodeClient.RebootInstance(ctx, instanceID, s.bootConfigID()); err != nil {
+		return fmt.Errorf("reboot instance %d: %w", instanceID, err)
+	}
+	s.clearPendingReboot()
+
+	return nil
+}
+
+// markRebootRequired records the configuration classes waiting for a reboot in
+// ConditionRebootRequired, keeping the classes recorded by earlier changes.
+func (s *MachineScope) markRebootRequired(classes []string) {
+	var pending []string
+	if condition := conditions.Get(s.LinodeMachine, ConditionRebootRequired); condition != nil && condition.Message != "" {
+		pending = strings.Split(condition.Message, ", ")
+	}
+	for _, class := range classes {
+		if !slices.Contains(pending, class) {
+			pending = append(pending, class)
+		}
+	}
+	conditions.Set(s.LinodeMachine, &clusterv1.Condition{
+		Type:    ConditionRebootRequired,
+		Status:  corev1.ConditionTrue,
+		Reason:  "ConfigurationChanged",
+		Message: strings.Join(pending, ", "),
+	})
+}
+
+// clearPendingReboot records that the instance was rebooted, so no configuration changes are
+// waiting for a reboot anymore.
+func (s *MachineScope) clearPendingReboot() {
+	s.LinodeMachine.Status.RebootPendingSince = nil
+	conditions.Delete(s.LinodeMachine, ConditionRebootRequired)
+}
+
+// ConfigChange is the outcome of reconciling one class of the instance's configuration.
+type ConfigChange struct {
+	// Class names the reconciled configuration, e.g. kernel.
+	Class string
+	// Changed reports whether the configuration was changed.
+	Changed bool
+	// RebootRequired reports whether the change only takes effect once the instance is rebooted.
+	RebootRequired bool
+}
+
+// configReconciler reconciles one class of the instance's configuration.
+type configReconciler struct {
+	class string
+	// bootOnly is set when every change only takes effect on boot. Other reconcilers report
+	// whether a reboot is required with ErrRebootRequired.
+	bootOnly  bool
+	reconcile func() (bool, error)
+}
+
+// ReconcileConfigChanges reconciles the parts of the instance's configuration which only take
+// effect on boot: its VPC and VLAN interfaces, VPC NAT mapping, default route, kernel and
+// config profile devices. It returns the outcome of each, so callers can decide whether to
+// reboot the instance.
+func (s *MachineScope) ReconcileConfigChanges(ctx context.Context, instanceID int) ([]ConfigChange, error) {
+	reconcilers := []configReconciler{
+		{class: "vpc-interface", reconcile: func() (bool, error) { return s.ReconcileVPCInterface(ctx, instanceID) }},
+		{class: "vpc-nat", reconcile: func() (bool, error) { return s.ReconcileVPCNAT(ctx, instanceID) }},
+		{class: "vlan-interface", reconcile: func() (bool, error) { return s.ReconcileVLANInterface(ctx, instanceID) }},
+		{class: "default-route", reconcile: func() (bool, error) { return s.ReconcileDefaultRoute(ctx, instanceID) }},
+	}
+	if configuration := s.LinodeMachine.Spec.Configuration; configuration != nil {
+		reconcilers = append(reconcilers,
+			configReconciler{class: "kernel", bootOnly: true, reconcile: func() (bool, error) {
+				return s.ReconcileKernel(ctx, instanceID, configuration.Kernel)
+			}},
+			configReconciler{class: "config-devices", bootOnly: true, reconcile: func() (bool, error) {
+				return s.ReconcileConfigDevices(ctx, instanceID, 0, DeviceMapFromSpec(configuration.Devices))
+			}},
+		)
+	}
+
+	changes := make([]ConfigChange, 0, len(reconcilers))
+	for _, reconciler := range reconcilers {
+		changed, err := reconciler.reconcile()
+		rebootRequired := errors.Is(err, ErrRebootRequired) || (reconciler.bootOnly && changed)
+		if err != nil && !errors.Is(err, ErrRebootRequired) {
+			return changes, fmt.Errorf("reconcile %s: %w", reconciler.class, err)
+		}
+		changes = append(changes, ConfigChange{Class: reconciler.class, Changed: changed, RebootRequired: rebootRequired})
+	}
+
+	return changes, nil
+}
+
+// RebootPolicy returns the spec's reboot policy, defaulting to rebooting in the maintenance
+// window when the spec has one, and to never rebooting otherwise.
+func (s *MachineScope) RebootPolicy() infrav1alpha2.RebootPolicy {
+	switch {
+	case s.LinodeMachine.Spec.RebootPolicy != "":
+		return s.LinodeMachine.Spec.RebootPolicy
+	case s.LinodeMachine.Spec.MaintenanceWindow != nil:
+		return infrav1alpha2.RebootPolicyMaintenanceWindow
+	default:
+		return infrav1alpha2.RebootPolicyNever
+	}
+}
+
+// ApplyConfigChangesWithReboot reconciles the instance's configuration, see
+// ReconcileConfigChanges, and reboots the instance according to the policy when a change
+// requires it. Until the instance is rebooted, the reboot is recorded as pending in the status
+// and ConditionRebootRequired names the changes, so callers should keep calling it while a
+// reboot is pending. With the MaintenanceWindow policy the instance is rebooted once the window
+// opens, see ScheduleReboot. With the Never policy an error wrapping ErrRebootRequired names
+// the changes when they are applied, and the pending reboot is cleared once the instance was
+// rebooted by other means.
+func (s *MachineScope) ApplyConfigChangesWithReboot(ctx context.Context, instanceID int, policy infrav1alpha2.RebootPolicy) error {
+	changes, err := s.ReconcileConfigChanges(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+	var pending []string
+	for _, change := range changes {
+		if change.RebootRequired {
+			pending = append(pending, change.Class)
+		}
+	}
+	if len(pending) == 0 && s.LinodeMachine.Status.RebootPendingSince == nil {
+		return nil
+	}
+	if len(pending) > 0 {
+		s.markRebootRequired(pending)
+	}
+
+	switch policy {
+	case infrav1alpha2.RebootPolicyImmediate:
+		if err := s.LinodeClient.RebootInstance(ctx, instanceID, s.bootConfigID()); err != nil {
+			return fmt.Errorf("reboot instance %d: %w", instanceID, err)
+		}
+		s.clearPendingReboot()
+
+		return nil
+	case infrav1alpha2.RebootPolicyMaintenanceWindow:
+		return s.ScheduleReboot(ctx)
+	default:
+		if len(pending) > 0 {
+			if s.LinodeMachine.Status.RebootPendingSince == nil {
+				s.LinodeMachine.Status.RebootPendingSince = &metav1.Time{Time: time.Now()}
+			}
+
+			return fmt.Errorf("%s changed: %w", strings.Join(pending, ", "), ErrRebootRequired)
+		}
+
+		rebooted, err := s.bootedSince(ctx, instanceID, s.LinodeMachine.Status.RebootPendingSince.Time)
+		if err != nil {
+			return err
+		}
+		if rebooted {
+			s.clearPendingReboot()
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,355 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeRebootAllowed(t *testing.T) {
+	t.Parallel()
+
+	// 2024-06-03 is a Monday.
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2024, time.June, 3, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name   string
+		window *infrav1alpha2.TimeWindow
+		now    time.Time
+		want   bool
+	}{
+		{
+			name: "No window",
+			now:  monday(12, 0),
+			want: true,
+		},
+		{
+			name:   "Within a daily window",
+			window: &infrav1alpha2.TimeWindow{Start: "02:00", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+			now:    monday(3, 30),
+			want:   true,
+		},
+		{
+			name:   "After a daily window",
+			window: &infrav1alpha2.TimeWindow{Start: "02:00", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+			now:    monday(4, 0),
+		},
+		{
+			name:   "Outside the window's days",
+			window: &infrav1alpha2.TimeWindow{Days: []string{"Sunday"}, Start: "02:00", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+			now:    monday(3, 0),
+		},
+		{
+			name:   "Window opened on the previous day",
+			window: &infrav1alpha2.TimeWindow{Days: []string{"Sunday"}, Start: "23:00", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+			now:    monday(0, 30),
+			want:   true,
+		},
+		{
+			name:   "Time in another zone is compared in UTC",
+			window: &infrav1alpha2.TimeWindow{Days: []string{"Monday"}, Start: "02:00", Duration: metav1.Duration{Duration: time.Hour}},
+			now:    monday(2, 30).In(time.FixedZone("UTC-5", -5*60*60)),
+			want:   true,
+		},
+		{
+			name:   "Invalid start",
+			window: &infrav1alpha2.TimeWindow{Start: "2am", Duration: metav1.Duration{Duration: 24 * time.Hour}},
+			now:    monday(3, 0),
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mScope := &MachineScope{
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{MaintenanceWindow: testcase.window},
+				},
+			}
+
+			assert.Equal(t, testcase.want, mScope.RebootAllowed(testcase.now))
+		})
+	}
+}
+
+func TestMachineScopeScheduleReboot(t *testing.T) {
+	t.Parallel()
+
+	// closedWindow opens at the current time on another day of the week.
+	now := time.Now().UTC()
+	closedWindow := &infrav1alpha2.TimeWindow{
+		Days:     []string{now.AddDate(0, 0, 3).Weekday().String()},
+		Start:    now.Format("15:04"),
+		Duration: metav1.Duration{Duration: time.Minute},
+	}
+
+	tests := []struct {
+		name          string
+		window        *infrav1alpha2.TimeWindow
+		annotations   map[string]string
+		expects       func(mock *mock.MockLinodeClient)
+		wantPending   bool
+		expectedError string
+	}{
+		{
+			name:   "Reboot when allowed",
+			window: nil,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().RebootInstance(gomock.Any(), 123, 0).Return(nil)
+			},
+		},
+		{
+			name:        "Reboot into the recorded boot config",
+			annotations: map[string]string{infrav1alpha2.BootConfigAnnotation: "2"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().RebootInstance(gomock.Any(), 123, 2).Return(nil)
+			},
+		},
+		{
+			name:        "Defer reboot outside the window",
+			window:      closedWindow,
+			expects:     func(mock *mock.MockLinodeClient) {},
+			wantPending: true,
+		},
+		{
+			name: "Error - reboot fails",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().RebootInstance(gomock.Any(), 123, 0).Return(errors.New("api error"))
+			},
+			expectedError: "reboot instance 123: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{Annotations: testcase.annotations},
+					Spec:       infrav1alpha2.LinodeMachineSpec{InstanceID: ptr.To(123), MaintenanceWindow: testcase.window},
+				},
+			}
+
+			err := mScope.ScheduleReboot(context.Background())
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantPending, mScope.LinodeMachine.Status.RebootPendingSince != nil)
+		})
+	}
+}
+
+func TestMachineScopeApplyConfigChangesWithReboot(t *testing.T) {
+	t.Parallel()
+
+	// closedWindow opens at the current time on another day of the week.
+	now := time.Now().UTC()
+	closedWindow := &infrav1alpha2.TimeWindow{
+		Days:     []string{now.AddDate(0, 0, 3).Weekday().String()},
+		Start:    now.Format("15:04"),
+		Duration: metav1.Duration{Duration: time.Minute},
+	}
+	kernelChanged := func(mock *mock.MockLinodeClient) {
+		mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceConfig{{ID: 1, Kernel: "linode/grub2"}}, nil)
+		mock.EXPECT().GetKernel(gomock.Any(), "linode/direct-disk").Return(&linodego.LinodeKernel{ID: "linode/direct-disk"}, nil)
+		mock.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 1, linodego.InstanceConfigUpdateOptions{Kernel: "linode/direct-disk"}).
+			Return(&linodego.InstanceConfig{}, nil)
+	}
+	kernelUnchanged := func(mock *mock.MockLinodeClient) {
+		mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceConfig{{ID: 1, Kernel: "linode/direct-disk"}}, nil)
+	}
+
+	tests := []struct {
+		name          string
+		policy        infrav1alpha2.RebootPolicy
+		window        *infrav1alpha2.TimeWindow
+		pendingSince  *metav1.Time
+		expects       func(mock *mock.MockLinodeClient)
+		wantPending   bool
+		expectedError string
+	}{
+		{
+			name:    "No reboot without changes",
+			policy:  infrav1alpha2.RebootPolicyImmediate,
+			expects: kernelUnchanged,
+		},
+		{
+			name:   "Reboot immediately",
+			policy: infrav1alpha2.RebootPolicyImmediate,
+			expects: func(mock *mock.MockLinodeClient) {
+				kernelChanged(mock)
+				mock.EXPECT().RebootInstance(gomock.Any(), 123, 0).Return(nil)
+			},
+		},
+		{
+			name:        "Defer reboot to the maintenance window",
+			policy:      infrav1alpha2.RebootPolicyMaintenanceWindow,
+			window:      closedWindow,
+			expects:     kernelChanged,
+			wantPending: true,
+		},
+		{
+			name:         "Reboot pending from an earlier change",
+			policy:       infrav1alpha2.RebootPolicyMaintenanceWindow,
+			pendingSince: &metav1.Time{Time: now.Add(-time.Hour)},
+			expects: func(mock *mock.MockLinodeClient) {
+				kernelUnchanged(mock)
+				mock.EXPECT().RebootInstance(gomock.Any(), 123, 0).Return(nil)
+			},
+		},
+		{
+			name:          "Never reboot",
+			policy:        infrav1alpha2.RebootPolicyNever,
+			expects:       kernelChanged,
+			wantPending:   true,
+			expectedError: "kernel changed: instance must be rebooted for the change to take effect",
+		},
+		{
+			name:         "Never reboot keeps the pending reboot until the instance is rebooted",
+			policy:       infrav1alpha2.RebootPolicyNever,
+			pendingSince: &metav1.Time{Time: now.Add(-time.Hour)},
+			expects: func(mock *mock.MockLinodeClient) {
+				kernelUnchanged(mock)
+				mock.EXPECT().ListEvents(gomock.Any(), gomock.Any()).
+					Return([]linodego.Event{{Action: linodego.ActionLinodeBoot, Created: ptr.To(now.Add(-2 * time.Hour))}}, nil)
+			},
+			wantPending: true,
+		},
+		{
+			name:         "Never reboot clears the pending reboot once the instance is rebooted",
+			policy:       infrav1alpha2.RebootPolicyNever,
+			pendingSince: &metav1.Time{Time: now.Add(-time.Hour)},
+			expects: func(mock *mock.MockLinodeClient) {
+				kernelUnchanged(mock)
+				mock.EXPECT().ListEvents(gomock.Any(), gomock.Any()).
+					Return([]linodego.Event{{Action: linodego.ActionLinodeReboot, Created: &now}}, nil)
+			},
+		},
+		{
+			name:   "Error - reconcile kernel",
+			policy: infrav1alpha2.RebootPolicyImmediate,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "reconcile kernel: list instance configs: api error",
+		},
+		{
+			name:   "Error - reboot fails",
+			policy: infrav1alpha2.RebootPolicyImmediate,
+			expects: func(mock *mock.MockLinodeClient) {
+				kernelChanged(mock)
+				mock.EXPECT().RebootInstance(gomock.Any(), 123, 0).Return(errors.New("api error"))
+			},
+			expectedError: "reboot instance 123: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient:  mockLinodeClient,
+				LinodeCluster: &infrav1alpha2.LinodeCluster{},
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{
+						InstanceID:        ptr.To(123),
+						MaintenanceWindow: testcase.window,
+						Configuration:     &infrav1alpha2.InstanceConfiguration{Kernel: "linode/direct-disk"},
+					},
+					Status: infrav1alpha2.LinodeMachineStatus{RebootPendingSince: testcase.pendingSince},
+				},
+			}
+
+			if testcase.pendingSince != nil {
+				conditions.Set(mScope.LinodeMachine, &clusterv1.Condition{
+					Type:    ConditionRebootRequired,
+					Status:  corev1.ConditionTrue,
+					Message: "kernel",
+				})
+			}
+
+			err := mScope.ApplyConfigChangesWithReboot(context.Background(), 123, testcase.policy)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				if !errors.Is(err, ErrRebootRequired) {
+					return
+				}
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, testcase.wantPending, mScope.LinodeMachine.Status.RebootPendingSince != nil)
+			assert.Equal(t, testcase.wantPending, conditions.IsTrue(mScope.LinodeMachine, ConditionRebootRequired))
+		})
+	}
+}
+
+func TestMachineScopeRebootPolicy(t *testing.T) {
+	t.Parallel()
+
+	window := &infrav1alpha2.TimeWindow{Start: "02:00", Duration: metav1.Duration{Duration: time.Hour}}
+
+	tests := []struct {
+		name   string
+		spec   infrav1alpha2.LinodeMachineSpec
+		policy infrav1alpha2.RebootPolicy
+	}{
+		{
+			name:   "Spec policy",
+			spec:   infrav1alpha2.LinodeMachineSpec{RebootPolicy: infrav1alpha2.RebootPolicyImmediate, MaintenanceWindow: window},
+			policy: infrav1alpha2.RebootPolicyImmediate,
+		},
+		{
+			name:   "Default with maintenance window",
+			spec:   infrav1alpha2.LinodeMachineSpec{MaintenanceWindow: window},
+			policy: infrav1alpha2.RebootPolicyMaintenanceWindow,
+		},
+		{
+			name:   "Default without maintenance window",
+			policy: infrav1alpha2.RebootPolicyNever,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mScope := &MachineScope{LinodeMachine: &infrav1alpha2.LinodeMachine{Spec: testcase.spec}}
+
+			assert.Equal(t, testcase.policy, mScope.RebootPolicy())
+		})
+	}
+}
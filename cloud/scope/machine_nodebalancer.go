@@ -0,0 +1,360 @@
+package scope
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/linode/linodego"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Health check limits enforced by the Linode API for NodeBalancer configs.
+const (
+	minHealthCheckInterval = 2
+	maxHealthCheckInterval = 3600
+	minHealthCheckTimeout  = 1
+	maxHealthCheckTimeout  = 30
+	minHealthCheckAttempts = 1
+	maxHealthCheckAttempts = 30
+
+	// minClientConnThrottle and maxClientConnThrottle are the connection throttle values accepted by the Linode API.
+	minClientConnThrottle = 0
+	maxClientConnThrottle = 20
+)
+
+// HealthCheckSpec describes the active health check of a NodeBalancer config.
+// Zero-valued fields are left unchanged on the config.
+type HealthCheckSpec struct {
+	// Check is the type of health check, e.g. connection or http.
+	Check linodego.ConfigCheck
+	// Interval is the number of seconds between health checks.
+	Interval int
+	// Timeout is the number of seconds to wait for a health check response.
+	Timeout int
+	// Attempts is the number of failed health checks before a backend is taken out of rotation.
+	Attempts int
+	// Path is the HTTP path requested by http and http_body health checks.
+	Path string
+}
+
+// validate checks the health check against the ranges accepted by the Linode API.
+func (hc HealthCheckSpec) validate() error {
+	if hc.Interval != 0 && (hc.Interval < minHealthCheckInterval || hc.Interval > maxHealthCheckInterval) {
+		return fmt.Errorf("health check interval %d must be between %d and %d seconds", hc.Interval, minHealthCheckInterval, maxHealthCheckInterval)
+	}
+	if hc.Timeout != 0 && (hc.Timeout < minHealthCheckTimeout || hc.Timeout > maxHealthCheckTimeout) {
+		return fmt.Errorf("health check timeout %d must be between %d and %d seconds", hc.Timeout, minHealthCheckTimeout, maxHealthCheckTimeout)
+	}
+	if hc.Attempts != 0 && (hc.Attempts < minHealthCheckAttempts || hc.Attempts > maxHealthCheckAttempts) {
+		return fmt.Errorf("health check attempts %d must be between %d and %d", hc.Attempts, minHealthCheckAttempts, maxHealthCheckAttempts)
+	}
+	if hc.Interval != 0 && hc.Timeout != 0 && hc.Timeout >= hc.Interval {
+		return fmt.Errorf("health check timeout %d must be less than the interval %d", hc.Timeout, hc.Interval)
+	}
+	if (hc.Check == linodego.CheckHTTP || hc.Check == linodego.CheckHTTPBody) && hc.Path == "" {
+		return errors.New("health check path is required for http health checks")
+	}
+
+	return nil
+}
+
+// apply sets the non-zero fields of the health check on the config update options.
+func (hc HealthCheckSpec) apply(opts *linodego.NodeBalancerConfigUpdateOptions) {
+	if hc.Check != "" {
+		opts.Check = hc.Check
+	}
+	if hc.Interval != 0 {
+		opts.CheckInterval = hc.Interval
+	}
+	if hc.Timeout != 0 {
+		opts.CheckTimeout = hc.Timeout
+	}
+	if hc.Attempts != 0 {
+		opts.CheckAttempts = hc.Attempts
+	}
+	if hc.Path != "" {
+		opts.CheckPath = hc.Path
+	}
+}
+
+// healthCheckChanged reports whether the update options change the health check of the config.
+func healthCheckChanged(config *linodego.NodeBalancerConfig, opts linodego.NodeBalancerConfigUpdateOptions) bool {
+	return opts.Check != config.Check ||
+		opts.CheckInterval != config.CheckInterval ||
+		opts.CheckTimeout != config.CheckTimeout ||
+		opts.CheckAttempts != config.CheckAttempts ||
+		opts.CheckPath != config.CheckPath
+}
+
+// ReconcileNodeBalancerHealthCheck updates the health check of a NodeBalancer config to match
+// the spec. The config is only updated when its health check has drifted from the spec.
+func (s *MachineScope) ReconcileNodeBalancerHealthCheck(ctx context.Context, nbID, configID int, hc HealthCheckSpec) error {
+	if err := hc.validate(); err != nil {
+		return err
+	}
+
+	config, err := s.LinodeClient.GetNodeBalancerConfig(ctx, nbID, configID)
+	if err != nil {
+		return fmt.Errorf("get nodebalancer %d config %d: %w", nbID, configID, err)
+	}
+
+	opts := config.GetUpdateOptions()
+	hc.apply(&opts)
+	if !healthCheckChanged(config, opts) {
+		return nil
+	}
+	if opts.CheckTimeout >= opts.CheckInterval {
+		return fmt.Errorf("health check timeout %d must be less than the interval %d", opts.CheckTimeout, opts.CheckInterval)
+	}
+
+	if _, err := s.LinodeClient.UpdateNodeBalancerConfig(ctx, nbID, configID, opts); err != nil {
+		return fmt.Errorf("update nodebalancer %d config %d health check: %w", nbID, configID, err)
+	}
+
+	return nil
+}
+
+// AdoptNodeBalancer brings a NodeBalancer created outside of CAPL under management as the
+// cluster's control-plane load balancer. The NodeBalancer is tagged with the LinodeCluster UID,
+// the same ownership tag CAPL sets on the NodeBalancers it creates, and its ID is recorded on
+// the LinodeCluster. Adoption is refused if the NodeBalancer is owned by another cluster or the
+// cluster already uses a different NodeBalancer.
+func (s *MachineScope) AdoptNodeBalancer(ctx context.Context, nbID int) error {
+	owner := string(s.LinodeCluster.UID)
+	if owner == "" {
+		return errors.New("cannot adopt nodebalancer: linodecluster has no UID")
+	}
+	network := s.LinodeCluster.Spec.Network
+	if network.NodeBalancerID != nil && *network.NodeBalancerID != 0 && *network.NodeBalancerID != nbID {
+		return fmt.Errorf("cannot adopt nodebalancer %d: cluster already uses nodebalancer %d", nbID, *network.NodeBalancerID)
+	}
+
+	nb, err := s.LinodeClient.GetNodeBalancer(ctx, nbID)
+	if err != nil {
+		return fmt.Errorf("get nodebalancer %d: %w", nbID, err)
+	}
+	if nb.Region != s.LinodeCluster.Spec.Region {
+		return fmt.Errorf("cannot adopt nodebalancer %d: region %s does not match cluster region %s", nbID, nb.Region, s.LinodeCluster.Spec.Region)
+	}
+	for _, tag := range nb.Tags {
+		if _, err := uuid.Parse(tag); err == nil && tag != owner {
+			return fmt.Errorf("cannot adopt nodebalancer %d: already owned by cluster %s", nbID, tag)
+		}
+	}
+
+	if !slices.Contains(nb.Tags, owner) {
+		tags := append(slices.Clone(nb.Tags), owner)
+		if _, err := s.LinodeClient.UpdateNodeBalancer(ctx, nbID, linodego.NodeBalancerUpdateOptions{Tags: &tags}); err != nil {
+			return fmt.Errorf("tag nodebalancer %d: %w", nbID, err)
+		}
+	}
+
+	helper, err := patch.NewHelper(s.LinodeCluster, s.Client)
+	if err != nil {
+		return fmt.Errorf("init linodecluster patch helper: %w", err)
+	}
+	s.LinodeCluster.Spec.Network.NodeBalancerID = &nbID
+	if err := helper.Patch(ctx, s.LinodeCluster); err != nil {
+		return fmt.Errorf("record nodebalancer %d on linodecluster: %w", nbID, err)
+	}
+
+	return nil
+}
+
+// NBSettings describes the NodeBalancer settings which are kept authoritative against
+// out-of-band edits. Zero-valued fields are left unchanged on the NodeBalancer.
+type NBSettings struct {
+	// Region is the region the NodeBalancer must be in. A NodeBalancer cannot be moved
+	// between regions, so a region mismatch is reported as an error.
+	Region string
+	// ClientConnThrottle is the number of connections per second allowed from a single client.
+	ClientConnThrottle *int
+	// Protocols maps the port of each config to the protocol it must use. Configs on
+	// other ports are left unchanged.
+	Protocols map[int]linodego.ConfigProtocol
+}
+
+// ReconcileNodeBalancerConfig corrects drift of the NodeBalancer's settings from the desired
+// settings. The NodeBalancer and its configs are only updated when they have drifted, and
+// backend nodes are never modified. It reports whether anything was updated.
+func (s *MachineScope) ReconcileNodeBalancerConfig(ctx context.Context, nbID int, desired NBSettings) (bool, error) {
+	if throttle := desired.ClientConnThrottle; throttle != nil && (*throttle < minClientConnThrottle || *throttle > maxClientConnThrottle) {
+		return false, fmt.Errorf("client connection throttle %d must be between %d and %d", *throttle, minClientConnThrottle, maxClientConnThrottle)
+	}
+
+	nb, err := s.LinodeClient.GetNodeBalancer(ctx, nbID)
+	if err != nil {
+		return false, fmt.Errorf("get nodebalancer %d: %w", nbID, err)
+	}
+	if desired.Region != "" && nb.Region != desired.Region {
+		return false, fmt.Errorf("nodebalancer %d is in region %s instead of %s and must be recreated", nbID, nb.Region, desired.Region)
+	}
+
+	changed := false
+	if throttle := desired.ClientConnThrottle; throttle != nil && nb.ClientConnThrottle != *throttle {
+		if _, err := s.LinodeClient.UpdateNodeBalancer(ctx, nbID, linodego.NodeBalancerUpdateOptions{ClientConnThrottle: throttle}); err != nil {
+			return changed, fmt.Errorf("update nodebalancer %d client connection throttle: %w", nbID, err)
+		}
+		changed = true
+	}
+
+	if len(desired.Protocols) == 0 {
+		return changed, nil
+	}
+	configs, err := s.LinodeClient.ListNodeBalancerConfigs(ctx, nbID, &linodego.ListOptions{})
+	if err != nil {
+		return changed, fmt.Errorf("list nodebalancer %d configs: %w", nbID, err)
+	}
+	for _, config := range configs {
+		protocol, ok := desired.Protocols[config.Port]
+		if !ok || config.Protocol == protocol {
+			continue
+		}
+
+		opts := config.GetUpdateOptions()
+		opts.Protocol = protocol
+		if _, err := s.LinodeClient.UpdateNodeBalancerConfig(ctx, nbID, config.ID, opts); err != nil {
+			return changed, fmt.Errorf("update nodebalancer %d config %d protocol: %w", nbID, config.ID, err)
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// NBConfigSettings describes the balancing settings of a NodeBalancer config. Zero-valued
+// fields are left unchanged on the config.
+type NBConfigSettings struct {
+	// Algorithm is how new connections are distributed across the backends.
+	Algorithm linodego.ConfigAlgorithm
+	// Stickiness is how subsequent requests from a client are routed to the same backend.
+	Stickiness linodego.ConfigStickiness
+	// HealthCheck is the active health check of the config.
+	HealthCheck *HealthCheckSpec
+}
+
+// validate checks the settings against the values accepted by the Linode API for a config
+// using the given protocol.
+func (settings NBConfigSettings) validate(protocol linodego.ConfigProtocol) error {
+	switch settings.Algorithm {
+	case "", linodego.AlgorithmRoundRobin, linodego.AlgorithmLeastConn, linodego.AlgorithmSource:
+	default:
+		return fmt.Errorf("invalid algorithm %q, must be one of roundrobin, leastconn or source", settings.Algorithm)
+	}
+	switch settings.Stickiness {
+	case "", linodego.StickinessNone, linodego.StickinessTable:
+	case linodego.StickinessHTTPCookie:
+		if protocol != linodego.ProtocolHTTP && protocol != linodego.ProtocolHTTPS {
+			return fmt.Errorf("http_cookie stickiness requires the http or https protocol, not %s", protocol)
+		}
+	default:
+		return fmt.Errorf("invalid stickiness %q, must be one of none, table or http_cookie", settings.Stickiness)
+	}
+	if settings.HealthCheck != nil {
+		return settings.HealthCheck.validate()
+	}
+
+	return nil
+}
+
+// ReconcileNodeBalancerConfigAdvanced sets the algorithm, stickiness and health check of a
+// NodeBalancer config, e.g. for a NodeBalancer shared by the control plane and ingress whose
+// configs need different balancing. The settings are validated against the config's protocol,
+// and the config is only updated when it has drifted. It reports whether the config was updated.
+func (s *MachineScope) ReconcileNodeBalancerConfigAdvanced(ctx context.Context, nbID, configID int, settings NBConfigSettings) (bool, error) {
+	config, err := s.LinodeClient.GetNodeBalancerConfig(ctx, nbID, configID)
+	if err != nil {
+		return false, fmt.Errorf("get nodebalancer %d config %d: %w", nbID, configID, err)
+	}
+	if err := settings.validate(config.Protocol); err != nil {
+		return false, err
+	}
+
+	opts := config.GetUpdateOptions()
+	if settings.Algorithm != "" {
+		opts.Algorithm = settings.Algorithm
+	}
+	if settings.Stickiness != "" {
+		opts.Stickiness = settings.Stickiness
+	}
+	if settings.HealthCheck != nil {
+		settings.HealthCheck.apply(&opts)
+	}
+	checkChanged := healthCheckChanged(config, opts)
+	if opts.Algorithm == config.Algorithm && opts.Stickiness == config.Stickiness && !checkChanged {
+		return false, nil
+	}
+	if checkChanged && opts.CheckTimeout >= opts.CheckInterval {
+		return false, fmt.Errorf("health check timeout %d must be less than the interval %d", opts.CheckTimeout, opts.CheckInterval)
+	}
+
+	if _, err := s.LinodeClient.UpdateNodeBalancerConfig(ctx, nbID, configID, opts); err != nil {
+		return false, fmt.Errorf("update nodebalancer %d config %d: %w", nbID, configID, err)
+	}
+
+	return true, nil
+}
+
+// ReconcileNodeBalancerTLS sets the certificate and private key of an https NodeBalancer config
+// to the tls.crt and tls.key of the Secret, so the NodeBalancer terminates TLS with it. The pair
+// is validated before it is sent. The Linode API does not return the certificate, so the config
+// is only updated when its fingerprint differs from the Secret's certificate, e.g. after the
+// certificate was renewed. The Secret defaults to the LinodeMachine's namespace.
+func (s *MachineScope) ReconcileNodeBalancerTLS(ctx context.Context, nbID, configID int, certSecretRef corev1.SecretReference) error {
+	key := client.ObjectKey{Namespace: certSecretRef.Namespace, Name: certSecretRef.Name}
+	if key.Namespace == "" {
+		key.Namespace = s.LinodeMachine.Namespace
+	}
+	secret := &corev1.Secret{}
+	if err := s.Client.Get(ctx, key, secret); err != nil {
+		return fmt.Errorf("get certificate secret %s: %w", key, err)
+	}
+	certPEM, keyPEM := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+	if len(certPEM) == 0 || len(keyPEM) == 0 {
+		return fmt.Errorf("certificate secret %s must have %s and %s keys", key, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("invalid certificate in secret %s: %w", key, err)
+	}
+
+	config, err := s.LinodeClient.GetNodeBalancerConfig(ctx, nbID, configID)
+	if err != nil {
+		return fmt.Errorf("get nodebalancer %d config %d: %w", nbID, configID, err)
+	}
+	if config.Protocol != linodego.ProtocolHTTPS {
+		return fmt.Errorf("nodebalancer %d config %d uses the %s protocol, tls termination requires https", nbID, configID, config.Protocol)
+	}
+	if certFingerprint(pair.Certificate[0]) == strings.ToUpper(config.SSLFingerprint) {
+		return nil
+	}
+
+	opts := config.GetUpdateOptions()
+	opts.SSLCert = string(certPEM)
+	opts.SSLKey = string(keyPEM)
+	if _, err := s.LinodeClient.UpdateNodeBalancerConfig(ctx, nbID, configID, opts); err != nil {
+		return fmt.Errorf("update nodebalancer %d config %d certificate: %w", nbID, configID, err)
+	}
+
+	return nil
+}
+
+// certFingerprint returns the SHA-256 fingerprint of the DER encoded certificate in the
+// colon separated form reported by the Linode API.
+func certFingerprint(der []byte) string {
+	sum := sha256.Sum256(der)
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+
+	return strings.Join(parts, ":")
+}
@@ -0,0 +1,585 @@
+package scope
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeReconcileNodeBalancerHealthCheck(t *testing.T) {
+	t.Parallel()
+
+	current := &linodego.NodeBalancerConfig{
+		ID:            2,
+		Port:          6443,
+		Protocol:      linodego.ProtocolTCP,
+		Check:         linodego.CheckConnection,
+		CheckInterval: 31,
+		CheckTimeout:  30,
+		CheckAttempts: 3,
+	}
+
+	tests := []struct {
+		name          string
+		hc            HealthCheckSpec
+		expects       func(mock *mock.MockLinodeClient)
+		expectedError string
+	}{
+		{
+			name: "Success - Health check is updated",
+			hc:   HealthCheckSpec{Check: linodego.CheckHTTP, Interval: 5, Timeout: 3, Attempts: 2, Path: "/readyz"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancerConfig(gomock.Any(), 1, 2).Return(current, nil)
+				mock.EXPECT().UpdateNodeBalancerConfig(gomock.Any(), 1, 2, gomock.Cond(func(x any) bool {
+					opts, ok := x.(linodego.NodeBalancerConfigUpdateOptions)
+					return ok && opts.Port == 6443 && opts.Check == linodego.CheckHTTP && opts.CheckInterval == 5 &&
+						opts.CheckTimeout == 3 && opts.CheckAttempts == 2 && opts.CheckPath == "/readyz"
+				})).Return(&linodego.NodeBalancerConfig{}, nil)
+			},
+		},
+		{
+			name: "Success - Health check is unchanged",
+			hc:   HealthCheckSpec{Check: linodego.CheckConnection, Interval: 31, Timeout: 30},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancerConfig(gomock.Any(), 1, 2).Return(current, nil)
+			},
+		},
+		{
+			name:          "Error - Interval out of range",
+			hc:            HealthCheckSpec{Interval: 1},
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expectedError: "health check interval 1 must be between 2 and 3600 seconds",
+		},
+		{
+			name:          "Error - Timeout out of range",
+			hc:            HealthCheckSpec{Timeout: 31},
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expectedError: "health check timeout 31 must be between 1 and 30 seconds",
+		},
+		{
+			name:          "Error - Attempts out of range",
+			hc:            HealthCheckSpec{Attempts: 31},
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expectedError: "health check attempts 31 must be between 1 and 30",
+		},
+		{
+			name:          "Error - Missing http path",
+			hc:            HealthCheckSpec{Check: linodego.CheckHTTP},
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expectedError: "health check path is required for http health checks",
+		},
+		{
+			name: "Error - Timeout not less than the current interval",
+			hc:   HealthCheckSpec{Interval: 10},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancerConfig(gomock.Any(), 1, 2).Return(current, nil)
+			},
+			expectedError: "health check timeout 30 must be less than the interval 10",
+		},
+		{
+			name: "Error - Update fails",
+			hc:   HealthCheckSpec{Attempts: 5},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancerConfig(gomock.Any(), 1, 2).Return(current, nil)
+				mock.EXPECT().UpdateNodeBalancerConfig(gomock.Any(), 1, 2, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{LinodeClient: mockLinodeClient}
+
+			err := mScope.ReconcileNodeBalancerHealthCheck(context.Background(), 1, 2, testcase.hc)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMachineScopeAdoptNodeBalancer(t *testing.T) {
+	t.Parallel()
+
+	const (
+		clusterUID = "5b3c2f9e-0a2e-4d7b-9a3d-3f1c1e7c2a11"
+		otherUID   = "8f1d2c3b-4a5e-4f6a-8b7c-9d0e1f2a3b4c"
+	)
+
+	tests := []struct {
+		name           string
+		nodeBalancerID *int
+		expects        func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient)
+		expectedError  string
+	}{
+		{
+			name: "Success - NodeBalancer is tagged and recorded",
+			expects: func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {
+				linodeClient.EXPECT().GetNodeBalancer(gomock.Any(), 10).Return(&linodego.NodeBalancer{ID: 10, Region: "us-ord", Tags: []string{"manual"}}, nil)
+				linodeClient.EXPECT().UpdateNodeBalancer(gomock.Any(), 10, linodego.NodeBalancerUpdateOptions{Tags: &[]string{"manual", clusterUID}}).
+					Return(&linodego.NodeBalancer{}, nil)
+				k8sClient.EXPECT().Scheme().DoAndReturn(func() *runtime.Scheme {
+					s := runtime.NewScheme()
+					infrav1alpha2.AddToScheme(s)
+					return s
+				}).AnyTimes()
+				k8sClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:           "Success - NodeBalancer is already adopted",
+			nodeBalancerID: ptr.To(10),
+			expects: func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {
+				linodeClient.EXPECT().GetNodeBalancer(gomock.Any(), 10).Return(&linodego.NodeBalancer{ID: 10, Region: "us-ord", Tags: []string{clusterUID}}, nil)
+				k8sClient.EXPECT().Scheme().DoAndReturn(func() *runtime.Scheme {
+					s := runtime.NewScheme()
+					infrav1alpha2.AddToScheme(s)
+					return s
+				}).AnyTimes()
+			},
+		},
+		{
+			name: "Error - NodeBalancer is owned by another cluster",
+			expects: func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {
+				linodeClient.EXPECT().GetNodeBalancer(gomock.Any(), 10).Return(&linodego.NodeBalancer{ID: 10, Region: "us-ord", Tags: []string{otherUID}}, nil)
+			},
+			expectedError: "already owned by cluster " + otherUID,
+		},
+		{
+			name:           "Error - Cluster uses another NodeBalancer",
+			nodeBalancerID: ptr.To(20),
+			expects:        func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {},
+			expectedError:  "cluster already uses nodebalancer 20",
+		},
+		{
+			name: "Error - NodeBalancer is in another region",
+			expects: func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {
+				linodeClient.EXPECT().GetNodeBalancer(gomock.Any(), 10).Return(&linodego.NodeBalancer{ID: 10, Region: "us-east"}, nil)
+			},
+			expectedError: "region us-east does not match cluster region us-ord",
+		},
+		{
+			name: "Error - Tagging fails",
+			expects: func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {
+				linodeClient.EXPECT().GetNodeBalancer(gomock.Any(), 10).Return(&linodego.NodeBalancer{ID: 10, Region: "us-ord"}, nil)
+				linodeClient.EXPECT().UpdateNodeBalancer(gomock.Any(), 10, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			mockK8sClient := mock.NewMockK8sClient(ctrl)
+			testcase.expects(mockLinodeClient, mockK8sClient)
+
+			mScope := &MachineScope{
+				Client:       mockK8sClient,
+				LinodeClient: mockLinodeClient,
+				LinodeCluster: &infrav1alpha2.LinodeCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", UID: clusterUID},
+					Spec: infrav1alpha2.LinodeClusterSpec{
+						Region:  "us-ord",
+						Network: infrav1alpha2.NetworkSpec{NodeBalancerID: testcase.nodeBalancerID},
+					},
+				},
+			}
+
+			err := mScope.AdoptNodeBalancer(context.Background(), 10)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, ptr.To(10), mScope.LinodeCluster.Spec.Network.NodeBalancerID)
+		})
+	}
+}
+
+func TestMachineScopeReconcileNodeBalancerConfig(t *testing.T) {
+	t.Parallel()
+
+	nb := &linodego.NodeBalancer{ID: 10, Region: "us-ord", ClientConnThrottle: 0}
+	configs := []linodego.NodeBalancerConfig{
+		{ID: 1, Port: 6443, Protocol: linodego.ProtocolTCP},
+		{ID: 2, Port: 80, Protocol: linodego.ProtocolTCP},
+	}
+
+	tests := []struct {
+		name          string
+		desired       NBSettings
+		expects       func(mock *mock.MockLinodeClient)
+		wantChanged   bool
+		expectedError string
+	}{
+		{
+			name:    "No drift",
+			desired: NBSettings{Region: "us-ord", ClientConnThrottle: ptr.To(0), Protocols: map[int]linodego.ConfigProtocol{6443: linodego.ProtocolTCP}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancer(gomock.Any(), 10).Return(nb, nil)
+				mock.EXPECT().ListNodeBalancerConfigs(gomock.Any(), 10, gomock.Any()).Return(configs, nil)
+			},
+		},
+		{
+			name:    "Correct throttle drift",
+			desired: NBSettings{ClientConnThrottle: ptr.To(5)},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancer(gomock.Any(), 10).Return(nb, nil)
+				mock.EXPECT().UpdateNodeBalancer(gomock.Any(), 10, linodego.NodeBalancerUpdateOptions{ClientConnThrottle: ptr.To(5)}).Return(&linodego.NodeBalancer{}, nil)
+			},
+			wantChanged: true,
+		},
+		{
+			name:    "Correct protocol drift of a single config",
+			desired: NBSettings{Protocols: map[int]linodego.ConfigProtocol{80: linodego.ProtocolHTTP, 6443: linodego.ProtocolTCP}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancer(gomock.Any(), 10).Return(nb, nil)
+				mock.EXPECT().ListNodeBalancerConfigs(gomock.Any(), 10, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().UpdateNodeBalancerConfig(gomock.Any(), 10, 2, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, nbID, configID int, opts linodego.NodeBalancerConfigUpdateOptions) (*linodego.NodeBalancerConfig, error) {
+						require.Equal(t, linodego.ProtocolHTTP, opts.Protocol)
+						require.Equal(t, 80, opts.Port)
+						return &linodego.NodeBalancerConfig{}, nil
+					})
+			},
+			wantChanged: true,
+		},
+		{
+			name:          "Error - throttle out of range",
+			desired:       NBSettings{ClientConnThrottle: ptr.To(21)},
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expectedError: "client connection throttle 21 must be between 0 and 20",
+		},
+		{
+			name:    "Error - region drift",
+			desired: NBSettings{Region: "us-east"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancer(gomock.Any(), 10).Return(nb, nil)
+			},
+			expectedError: "nodebalancer 10 is in region us-ord instead of us-east and must be recreated",
+		},
+		{
+			name:    "Error - config update fails after throttle update",
+			desired: NBSettings{ClientConnThrottle: ptr.To(5), Protocols: map[int]linodego.ConfigProtocol{80: linodego.ProtocolHTTP}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancer(gomock.Any(), 10).Return(nb, nil)
+				mock.EXPECT().UpdateNodeBalancer(gomock.Any(), 10, gomock.Any()).Return(&linodego.NodeBalancer{}, nil)
+				mock.EXPECT().ListNodeBalancerConfigs(gomock.Any(), 10, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().UpdateNodeBalancerConfig(gomock.Any(), 10, 2, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			wantChanged:   true,
+			expectedError: "update nodebalancer 10 config 2 protocol: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient:  mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{},
+			}
+
+			changed, err := mScope.ReconcileNodeBalancerConfig(context.Background(), 10, testcase.desired)
+			require.Equal(t, testcase.wantChanged, changed)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMachineScopeReconcileNodeBalancerConfigAdvanced(t *testing.T) {
+	t.Parallel()
+
+	current := &linodego.NodeBalancerConfig{
+		ID:            2,
+		Port:          443,
+		Protocol:      linodego.ProtocolHTTPS,
+		Algorithm:     linodego.AlgorithmRoundRobin,
+		Stickiness:    linodego.StickinessNone,
+		Check:         linodego.CheckConnection,
+		CheckInterval: 31,
+		CheckTimeout:  30,
+		CheckAttempts: 3,
+	}
+	tcpConfig := &linodego.NodeBalancerConfig{ID: 2, Port: 6443, Protocol: linodego.ProtocolTCP}
+
+	tests := []struct {
+		name          string
+		settings      NBConfigSettings
+		expects       func(mock *mock.MockLinodeClient)
+		wantChanged   bool
+		expectedError string
+	}{
+		{
+			name: "Success - Settings are updated",
+			settings: NBConfigSettings{
+				Algorithm:   linodego.AlgorithmLeastConn,
+				Stickiness:  linodego.StickinessHTTPCookie,
+				HealthCheck: &HealthCheckSpec{Check: linodego.CheckHTTP, Interval: 5, Timeout: 3, Path: "/healthz"},
+			},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancerConfig(gomock.Any(), 1, 2).Return(current, nil)
+				mock.EXPECT().UpdateNodeBalancerConfig(gomock.Any(), 1, 2, gomock.Cond(func(x any) bool {
+					opts, ok := x.(linodego.NodeBalancerConfigUpdateOptions)
+					return ok && opts.Port == 443 && opts.Algorithm == linodego.AlgorithmLeastConn &&
+						opts.Stickiness == linodego.StickinessHTTPCookie && opts.Check == linodego.CheckHTTP &&
+						opts.CheckInterval == 5 && opts.CheckTimeout == 3 && opts.CheckPath == "/healthz"
+				})).Return(&linodego.NodeBalancerConfig{}, nil)
+			},
+			wantChanged: true,
+		},
+		{
+			name:     "Success - Settings are unchanged",
+			settings: NBConfigSettings{Algorithm: linodego.AlgorithmRoundRobin, Stickiness: linodego.StickinessNone},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancerConfig(gomock.Any(), 1, 2).Return(current, nil)
+			},
+		},
+		{
+			name:     "Success - Algorithm is updated without a health check",
+			settings: NBConfigSettings{Algorithm: linodego.AlgorithmSource},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancerConfig(gomock.Any(), 1, 2).Return(tcpConfig, nil)
+				mock.EXPECT().UpdateNodeBalancerConfig(gomock.Any(), 1, 2, gomock.Cond(func(x any) bool {
+					opts, ok := x.(linodego.NodeBalancerConfigUpdateOptions)
+					return ok && opts.Algorithm == linodego.AlgorithmSource
+				})).Return(&linodego.NodeBalancerConfig{}, nil)
+			},
+			wantChanged: true,
+		},
+		{
+			name:     "Error - Invalid algorithm",
+			settings: NBConfigSettings{Algorithm: "random"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancerConfig(gomock.Any(), 1, 2).Return(current, nil)
+			},
+			expectedError: `invalid algorithm "random"`,
+		},
+		{
+			name:     "Error - Cookie stickiness on tcp",
+			settings: NBConfigSettings{Stickiness: linodego.StickinessHTTPCookie},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancerConfig(gomock.Any(), 1, 2).Return(tcpConfig, nil)
+			},
+			expectedError: "http_cookie stickiness requires the http or https protocol, not tcp",
+		},
+		{
+			name:     "Error - Invalid health check",
+			settings: NBConfigSettings{HealthCheck: &HealthCheckSpec{Check: linodego.CheckHTTPBody}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancerConfig(gomock.Any(), 1, 2).Return(current, nil)
+			},
+			expectedError: "health check path is required for http health checks",
+		},
+		{
+			name:     "Error - Update fails",
+			settings: NBConfigSettings{Stickiness: linodego.StickinessTable},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetNodeBalancerConfig(gomock.Any(), 1, 2).Return(current, nil)
+				mock.EXPECT().UpdateNodeBalancerConfig(gomock.Any(), 1, 2, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "update nodebalancer 1 config 2: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{LinodeClient: mockLinodeClient}
+
+			changed, err := mScope.ReconcileNodeBalancerConfigAdvanced(context.Background(), 1, 2, testcase.settings)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantChanged, changed)
+		})
+	}
+}
+
+// selfSignedCert returns a PEM encoded self-signed certificate and its private key.
+func selfSignedCert(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "api.example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestMachineScopeReconcileNodeBalancerTLS(t *testing.T) {
+	t.Parallel()
+
+	certPEM, keyPEM := selfSignedCert(t)
+	otherCertPEM, _ := selfSignedCert(t)
+	block, _ := pem.Decode(certPEM)
+	fingerprint := certFingerprint(block.Bytes)
+
+	secretWith := func(data map[string][]byte) func(k8s *mock.MockK8sClient) {
+		return func(k8s *mock.MockK8sClient) {
+			k8s.EXPECT().Get(gomock.Any(), client.ObjectKey{Namespace: "default", Name: "api-tls"}, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, key client.ObjectKey, obj *corev1.Secret, opts ...client.GetOption) error {
+					obj.Data = data
+					return nil
+				})
+		}
+	}
+	validSecret := secretWith(map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM})
+
+	tests := []struct {
+		name          string
+		k8sExpects    func(k8s *mock.MockK8sClient)
+		expects       func(linode *mock.MockLinodeClient)
+		expectedError string
+	}{
+		{
+			name:       "Set certificate on the config",
+			k8sExpects: validSecret,
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().GetNodeBalancerConfig(gomock.Any(), 1, 2).
+					Return(&linodego.NodeBalancerConfig{ID: 2, Port: 443, Protocol: linodego.ProtocolHTTPS}, nil)
+				linode.EXPECT().UpdateNodeBalancerConfig(gomock.Any(), 1, 2, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, nbID, configID int, opts linodego.NodeBalancerConfigUpdateOptions) (*linodego.NodeBalancerConfig, error) {
+						assert.Equal(t, string(certPEM), opts.SSLCert)
+						assert.Equal(t, string(keyPEM), opts.SSLKey)
+						assert.Equal(t, 443, opts.Port)
+						return &linodego.NodeBalancerConfig{}, nil
+					})
+			},
+		},
+		{
+			name:       "Certificate is up to date",
+			k8sExpects: validSecret,
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().GetNodeBalancerConfig(gomock.Any(), 1, 2).
+					Return(&linodego.NodeBalancerConfig{ID: 2, Protocol: linodego.ProtocolHTTPS, SSLFingerprint: strings.ToLower(fingerprint)}, nil)
+			},
+		},
+		{
+			name:          "Error - missing key",
+			k8sExpects:    secretWith(map[string][]byte{corev1.TLSCertKey: certPEM}),
+			expects:       func(linode *mock.MockLinodeClient) {},
+			expectedError: "certificate secret default/api-tls must have tls.crt and tls.key keys",
+		},
+		{
+			name:          "Error - key does not match certificate",
+			k8sExpects:    secretWith(map[string][]byte{corev1.TLSCertKey: otherCertPEM, corev1.TLSPrivateKeyKey: keyPEM}),
+			expects:       func(linode *mock.MockLinodeClient) {},
+			expectedError: "invalid certificate in secret default/api-tls",
+		},
+		{
+			name:       "Error - config is not https",
+			k8sExpects: validSecret,
+			expects: func(linode *mock.MockLinodeClient) {
+				linode.EXPECT().GetNodeBalancerConfig(gomock.Any(), 1, 2).
+					Return(&linodego.NodeBalancerConfig{ID: 2, Protocol: linodego.ProtocolTCP}, nil)
+			},
+			expectedError: "nodebalancer 1 config 2 uses the tcp protocol, tls termination requires https",
+		},
+		{
+			name: "Error - secret not found",
+			k8sExpects: func(k8s *mock.MockK8sClient) {
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("not found"))
+			},
+			expects:       func(linode *mock.MockLinodeClient) {},
+			expectedError: "get certificate secret default/api-tls: not found",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+			mockK8sClient := mock.NewMockK8sClient(ctrl)
+			testcase.k8sExpects(mockK8sClient)
+
+			mScope := &MachineScope{
+				Client:       mockK8sClient,
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				},
+			}
+
+			err := mScope.ReconcileNodeBalancerTLS(context.Background(), 1, 2, corev1.SecretReference{Name: "api-tls"})
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/linode/linodego"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/linode/cluster-api-provider-linode/util"
+)
+
+const (
+	// machineObjectStorageKeyNameTemplate is the name of the Secret holding a machine's Object Storage key.
+	machineObjectStorageKeyNameTemplate = "%s-obj-key"
+	// maxObjectStorageKeyLabelLength is the Object Storage key label length limit enforced by the Linode API.
+	maxObjectStorageKeyLabelLength = 50
+)
+
+// Grant is the access of an Object Storage key to a bucket.
+type Grant struct {
+	// BucketName is the name of the bucket.
+	BucketName string
+	// Region is the region of the bucket.
+	Region string
+	// Permissions is either read_only or read_write.
+	Permissions string
+}
+
+// EnsureObjectStorageKey ensures the machine has an Object Storage key scoped to the bucket
+// grants, and returns its ID and the name of the Secret holding it in the machine's namespace.
+// The Secret is owned by the LinodeMachine and has the access_key and secret_key keys. Linode
+// does not allow changing the grants of a key nor reading its secret back, so a new key is
+// created, and the previous one revoked, when the grants change or the Secret was deleted. The
+// key ID is recorded in the status.
+func (s *MachineScope) EnsureObjectStorageKey(ctx context.Context, bucketGrants []Grant) (string, string, error) {
+	if len(bucketGrants) == 0 {
+		return "", "", errors.New("object storage key requires at least one bucket grant")
+	}
+	access := make([]linodego.ObjectStorageKeyBucketAccess, 0, len(bucketGrants))
+	for _, grant := range bucketGrants {
+		if grant.Permissions != "read_only" && grant.Permissions != "read_write" {
+			return "", "", fmt.Errorf("invalid permissions %q for bucket %s, must be read_only or read_write", grant.Permissions, grant.BucketName)
+		}
+		access = append(access, linodego.ObjectStorageKeyBucketAccess{
+			BucketName:  grant.BucketName,
+			Region:      grant.Region,
+			Permissions: grant.Permissions,
+		})
+	}
+	secretName := fmt.Sprintf(machineObjectStorageKeyNameTemplate, s.LinodeMachine.Name)
+
+	previousID := s.LinodeMachine.Status.ObjectStorageKeyID
+	if previousID != nil {
+		key, err := s.LinodeClient.GetObjectStorageKey(ctx, *previousID)
+		if util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
+			return "", "", fmt.Errorf("get object storage key %d: %w", *previousID, err)
+		}
+		if err == nil && key.BucketAccess != nil && sameBucketAccess(*key.BucketAccess, access) {
+			err := s.Client.Get(ctx, client.ObjectKey{Namespace: s.LinodeMachine.Namespace, Name: secretName}, &corev1.Secret{})
+			if err == nil {
+				return strconv.Itoa(key.ID), secretName, nil
+			}
+			if !apierrors.IsNotFound(err) {
+				return "", "", fmt.Errorf("get object storage key secret %s: %w", secretName, err)
+			}
+		}
+	}
+
+	label := s.LinodeMachine.Name
+	if len(label) > maxObjectStorageKeyLabelLength {
+		label = label[:maxObjectStorageKeyLabelLength]
+	}
+	key, err := s.LinodeClient.CreateObjectStorageKey(ctx, linodego.ObjectStorageKeyCreateOptions{
+		Label:        label,
+		BucketAccess: &access,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("create object storage key %s: %w", label, err)
+	}
+	s.LinodeMachine.Status.ObjectStorageKeyID = &key.ID
+
+	secret := &corev1.Secret{}
+	secret.Name = secretName
+	secret.Namespace = s.LinodeMachine.Namespace
+	if _, err := controllerutil.CreateOrUpdate(ctx, s.Client, secret, func() error {
+		secret.StringData = map[string]string{
+			"access_key": key.AccessKey,
+			"secret_key": key.SecretKey,
+		}
+
+		return controllerutil.SetControllerReference(s.LinodeMachine, secret, s.Client.Scheme())
+	}); err != nil {
+		return "", "", fmt.Errorf("write object storage key secret %s: %w", secretName, err)
+	}
+
+	if previousID != nil {
+		if err := s.LinodeClient.DeleteObjectStorageKey(ctx, *previousID); util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
+			return "", "", fmt.Errorf("revoke object storage key %d: %w", *previousID, err)
+		}
+	}
+
+	return strconv.Itoa(key.ID), secretName, nil
+}
+
+// DeleteObjectStorageKey revokes the machine's Object Storage key, if it has one. Its Secret
+// is garbage collected with the LinodeMachine.
+func (s *MachineScope) DeleteObjectStorageKey(ctx context.Context) error {
+	id := s.LinodeMachine.Status.ObjectStorageKeyID
+	if id == nil {
+		return nil
+	}
+
+	if err := s.LinodeClient.DeleteObjectStorageKey(ctx, *id); util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
+		return fmt.Errorf("revoke object storage key %d: %w", *id, err)
+	}
+	s.LinodeMachine.Status.ObjectStorageKeyID = nil
+
+	return nil
+}
+
+// sameBucketAccess reports whether both bucket access lists grant the same access, in any order.
+func sameBucketAccess(current, desired []linodego.ObjectStorageKeyBucketAccess) bool {
+	if len(current) != len(desired) {
+		return false
+	}
+	for _, access := range desired {
+		if !slices.ContainsFunc(current, func(other linodego.ObjectStorageKeyBucketAccess) bool {
+			return other.BucketName == access.BucketName && other.Region == access.Region && other.Permissions == access.Permissions
+		}) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,226 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeEnsureObjectStorageKey(t *testing.T) {
+	t.Parallel()
+
+	grants := []Grant{{BucketName: "logs", Region: "us-ord", Permissions: "read_write"}}
+	access := []linodego.ObjectStorageKeyBucketAccess{{BucketName: "logs", Region: "us-ord", Permissions: "read_write"}}
+	scheme := func() *runtime.Scheme {
+		s := runtime.NewScheme()
+		_ = infrav1alpha2.AddToScheme(s)
+		return s
+	}
+	secretNotFound := apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "test-machine-obj-key")
+
+	tests := []struct {
+		name          string
+		grants        []Grant
+		keyID         *int
+		expects       func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient)
+		wantKeyID     string
+		wantStatusID  *int
+		expectedError string
+	}{
+		{
+			name:   "Create key and secret",
+			grants: grants,
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				mock.EXPECT().CreateObjectStorageKey(gomock.Any(), linodego.ObjectStorageKeyCreateOptions{Label: "test-machine", BucketAccess: &access}).
+					Return(&linodego.ObjectStorageKey{ID: 10, AccessKey: "access", SecretKey: "secret"}, nil)
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(secretNotFound)
+				k8s.EXPECT().Scheme().DoAndReturn(scheme)
+				k8s.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+						secret := obj.(*corev1.Secret)
+						assert.Equal(t, "test-machine-obj-key", secret.Name)
+						assert.Equal(t, "default", secret.Namespace)
+						assert.Equal(t, map[string]string{"access_key": "access", "secret_key": "secret"}, secret.StringData)
+						assert.Len(t, secret.OwnerReferences, 1)
+						return nil
+					})
+			},
+			wantKeyID:    "10",
+			wantStatusID: ptr.To(10),
+		},
+		{
+			name:   "Reuse existing key",
+			grants: grants,
+			keyID:  ptr.To(10),
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				mock.EXPECT().GetObjectStorageKey(gomock.Any(), 10).Return(&linodego.ObjectStorageKey{ID: 10, BucketAccess: &access}, nil)
+				k8s.EXPECT().Get(gomock.Any(), client.ObjectKey{Namespace: "default", Name: "test-machine-obj-key"}, gomock.Any()).Return(nil)
+			},
+			wantKeyID:    "10",
+			wantStatusID: ptr.To(10),
+		},
+		{
+			name:   "Rotate key when grants change",
+			grants: grants,
+			keyID:  ptr.To(10),
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				mock.EXPECT().GetObjectStorageKey(gomock.Any(), 10).Return(&linodego.ObjectStorageKey{ID: 10, BucketAccess: &[]linodego.ObjectStorageKeyBucketAccess{
+					{BucketName: "logs", Region: "us-ord", Permissions: "read_only"},
+				}}, nil)
+				mock.EXPECT().CreateObjectStorageKey(gomock.Any(), gomock.Any()).Return(&linodego.ObjectStorageKey{ID: 11}, nil)
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				k8s.EXPECT().Scheme().DoAndReturn(scheme)
+				k8s.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				mock.EXPECT().DeleteObjectStorageKey(gomock.Any(), 10).Return(nil)
+			},
+			wantKeyID:    "11",
+			wantStatusID: ptr.To(11),
+		},
+		{
+			name:   "Rotate key when the secret was deleted",
+			grants: grants,
+			keyID:  ptr.To(10),
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				mock.EXPECT().GetObjectStorageKey(gomock.Any(), 10).Return(&linodego.ObjectStorageKey{ID: 10, BucketAccess: &access}, nil)
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(secretNotFound).Times(2)
+				mock.EXPECT().CreateObjectStorageKey(gomock.Any(), gomock.Any()).Return(&linodego.ObjectStorageKey{ID: 11}, nil)
+				k8s.EXPECT().Scheme().DoAndReturn(scheme)
+				k8s.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				mock.EXPECT().DeleteObjectStorageKey(gomock.Any(), 10).Return(&linodego.Error{Code: 404})
+			},
+			wantKeyID:    "11",
+			wantStatusID: ptr.To(11),
+		},
+		{
+			name:          "Error - no grants",
+			expects:       func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {},
+			expectedError: "object storage key requires at least one bucket grant",
+		},
+		{
+			name:          "Error - invalid permissions",
+			grants:        []Grant{{BucketName: "logs", Region: "us-ord", Permissions: "write_only"}},
+			expects:       func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {},
+			expectedError: `invalid permissions "write_only" for bucket logs, must be read_only or read_write`,
+		},
+		{
+			name:   "Error - create fails",
+			grants: grants,
+			expects: func(mock *mock.MockLinodeClient, k8s *mock.MockK8sClient) {
+				mock.EXPECT().CreateObjectStorageKey(gomock.Any(), gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "create object storage key test-machine: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			mockK8sClient := mock.NewMockK8sClient(ctrl)
+			testcase.expects(mockLinodeClient, mockK8sClient)
+
+			mScope := &MachineScope{
+				Client:       mockK8sClient,
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: "default"},
+					Status:     infrav1alpha2.LinodeMachineStatus{ObjectStorageKeyID: testcase.keyID},
+				},
+			}
+
+			keyID, secretName, err := mScope.EnsureObjectStorageKey(context.Background(), testcase.grants)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantKeyID, keyID)
+			assert.Equal(t, "test-machine-obj-key", secretName)
+			assert.Equal(t, testcase.wantStatusID, mScope.LinodeMachine.Status.ObjectStorageKeyID)
+		})
+	}
+}
+
+func TestMachineScopeDeleteObjectStorageKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		keyID         *int
+		expects       func(mock *mock.MockLinodeClient)
+		expectedError string
+	}{
+		{
+			name:    "No key",
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:  "Revoke key",
+			keyID: ptr.To(10),
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().DeleteObjectStorageKey(gomock.Any(), 10).Return(nil)
+			},
+		},
+		{
+			name:  "Key already revoked",
+			keyID: ptr.To(10),
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().DeleteObjectStorageKey(gomock.Any(), 10).Return(&linodego.Error{Code: 404})
+			},
+		},
+		{
+			name:  "Error - revoke fails",
+			keyID: ptr.To(10),
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().DeleteObjectStorageKey(gomock.Any(), 10).Return(errors.New("api error"))
+			},
+			expectedError: "revoke object storage key 10: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Status: infrav1alpha2.LinodeMachineStatus{ObjectStorageKeyID: testcase.keyID},
+				},
+			}
+
+			err := mScope.DeleteObjectStorageKey(context.Background())
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Nil(t, mScope.LinodeMachine.Status.ObjectStorageKeyID)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/linode/linodego"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+)
+
+// PlacementGroupID resolves the Linode ID of the placement group referenced by the spec.
+// The referenced LinodePlacementGroup must be ready.
+func (s *MachineScope) PlacementGroupID(ctx context.Context) (int, error) {
+	ref := s.LinodeMachine.Spec.PlacementGroupRef
+	if ref == nil {
+		return 0, errors.New("no placement group is referenced")
+	}
+	key := client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}
+	if key.Namespace == "" {
+		key.Namespace = s.LinodeMachine.Namespace
+	}
+
+	var placementGroup infrav1alpha2.LinodePlacementGroup
+	if err := s.Client.Get(ctx, key, &placementGroup); err != nil {
+		return 0, fmt.Errorf("get placement group %s: %w", key, err)
+	}
+	if !placementGroup.Status.Ready || placementGroup.Spec.PGID == nil {
+		return 0, fmt.Errorf("placement group %s is not ready", key)
+	}
+
+	return *placementGroup.Spec.PGID, nil
+}
+
+// ReconcilePlacementGroup moves the instance into the placement group referenced by the
+// spec if it is a member of a different group, or of none. Machines without a placement
+// group reference are left alone. A move which would put the instance in a group in another
+// region, or in a group that is already at the region's member limit, is refused with an
+// error describing why. It reports whether the instance's membership changed.
+func (s *MachineScope) ReconcilePlacementGroup(ctx context.Context, instanceID int) (bool, error) {
+	if s.LinodeMachine.Spec.PlacementGroupRef == nil {
+		return false, nil
+	}
+
+	desiredID, err := s.PlacementGroupID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	instance, err := s.LinodeClient.GetInstance(ctx, instanceID)
+	if err != nil {
+		return false, fmt.Errorf("get instance %d: %w", instanceID, err)
+	}
+	if instance.PlacementGroup != nil && instance.PlacementGroup.ID == desiredID {
+		return false, nil
+	}
+
+	desired, err := s.LinodeClient.GetPlacementGroup(ctx, desiredID)
+	if err != nil {
+		return false, fmt.Errorf("get placement group %d: %w", desiredID, err)
+	}
+	if desired.Region != instance.Region {
+		return false, fmt.Errorf("placement group %d is in region %s but instance %d is in region %s", desiredID, desired.Region, instanceID, instance.Region)
+	}
+
+	region, err := s.Region(ctx)
+	if err != nil {
+		return false, fmt.Errorf("get region %s: %w", s.LinodeMachine.Spec.Region, err)
+	}
+	if limits := region.PlacementGroupLimits; limits != nil && limits.MaximumLinodesPerPG > 0 && len(desired.Members) >= limits.MaximumLinodesPerPG {
+		return false, fmt.Errorf("placement group %d is full, region %s allows at most %d instances per group", desiredID, region.ID, limits.MaximumLinodesPerPG)
+	}
+
+	if current := instance.PlacementGroup; current != nil {
+		if _, err := s.LinodeClient.UnassignPlacementGroupLinodes(ctx, current.ID, linodego.PlacementGroupUnAssignOptions{
+			Linodes: []int{instanceID},
+		}); err != nil {
+			return false, fmt.Errorf("unassign instance %d from placement group %d: %w", instanceID, current.ID, err)
+		}
+	}
+
+	if _, err := s.LinodeClient.AssignPlacementGroupLinodes(ctx, desiredID, linodego.PlacementGroupAssignOptions{
+		Linodes: []int{instanceID},
+	}); err != nil {
+		// the instance may already have left its previous group
+		return instance.PlacementGroup != nil, fmt.Errorf("assign instance %d to placement group %d: %w", instanceID, desiredID, err)
+	}
+
+	return true, nil
+}
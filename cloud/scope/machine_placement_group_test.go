@@ -0,0 +1,152 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeReconcilePlacementGroup(t *testing.T) {
+	t.Parallel()
+
+	readyPlacementGroup := func(ctx context.Context, key client.ObjectKey, obj *infrav1alpha2.LinodePlacementGroup, opts ...client.GetOption) error {
+		obj.Spec.PGID = ptr.To(2)
+		obj.Status.Ready = true
+		return nil
+	}
+	region := &linodego.Region{ID: "us-ord", PlacementGroupLimits: &linodego.RegionPlacementGroupLimits{MaximumLinodesPerPG: 2}}
+
+	tests := []struct {
+		name          string
+		ref           *corev1.ObjectReference
+		expects       func(k8s *mock.MockK8sClient, linode *mock.MockLinodeClient)
+		wantChanged   bool
+		expectedError string
+	}{
+		{
+			name:    "No placement group referenced",
+			expects: func(k8s *mock.MockK8sClient, linode *mock.MockLinodeClient) {},
+		},
+		{
+			name: "Instance is already in the placement group",
+			ref:  &corev1.ObjectReference{Name: "pg"},
+			expects: func(k8s *mock.MockK8sClient, linode *mock.MockLinodeClient) {
+				k8s.EXPECT().Get(gomock.Any(), client.ObjectKey{Name: "pg", Namespace: "default"}, gomock.Any()).DoAndReturn(readyPlacementGroup)
+				linode.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Region: "us-ord", PlacementGroup: &linodego.InstancePlacementGroup{ID: 2}}, nil)
+			},
+		},
+		{
+			name: "Move instance between placement groups",
+			ref:  &corev1.ObjectReference{Name: "pg"},
+			expects: func(k8s *mock.MockK8sClient, linode *mock.MockLinodeClient) {
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(readyPlacementGroup)
+				linode.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Region: "us-ord", PlacementGroup: &linodego.InstancePlacementGroup{ID: 1}}, nil)
+				linode.EXPECT().GetPlacementGroup(gomock.Any(), 2).Return(&linodego.PlacementGroup{ID: 2, Region: "us-ord", Members: []linodego.PlacementGroupMember{{LinodeID: 456}}}, nil)
+				linode.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(region, nil)
+				linode.EXPECT().UnassignPlacementGroupLinodes(gomock.Any(), 1, linodego.PlacementGroupUnAssignOptions{Linodes: []int{123}}).Return(&linodego.PlacementGroup{}, nil)
+				linode.EXPECT().AssignPlacementGroupLinodes(gomock.Any(), 2, linodego.PlacementGroupAssignOptions{Linodes: []int{123}}).Return(&linodego.PlacementGroup{}, nil)
+			},
+			wantChanged: true,
+		},
+		{
+			name: "Assign instance without a placement group",
+			ref:  &corev1.ObjectReference{Name: "pg", Namespace: "other"},
+			expects: func(k8s *mock.MockK8sClient, linode *mock.MockLinodeClient) {
+				k8s.EXPECT().Get(gomock.Any(), client.ObjectKey{Name: "pg", Namespace: "other"}, gomock.Any()).DoAndReturn(readyPlacementGroup)
+				linode.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Region: "us-ord"}, nil)
+				linode.EXPECT().GetPlacementGroup(gomock.Any(), 2).Return(&linodego.PlacementGroup{ID: 2, Region: "us-ord"}, nil)
+				linode.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(region, nil)
+				linode.EXPECT().AssignPlacementGroupLinodes(gomock.Any(), 2, gomock.Any()).Return(&linodego.PlacementGroup{}, nil)
+			},
+			wantChanged: true,
+		},
+		{
+			name: "Error - placement group is not ready",
+			ref:  &corev1.ObjectReference{Name: "pg"},
+			expects: func(k8s *mock.MockK8sClient, linode *mock.MockLinodeClient) {
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedError: "placement group default/pg is not ready",
+		},
+		{
+			name: "Error - placement group is in another region",
+			ref:  &corev1.ObjectReference{Name: "pg"},
+			expects: func(k8s *mock.MockK8sClient, linode *mock.MockLinodeClient) {
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(readyPlacementGroup)
+				linode.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Region: "us-ord"}, nil)
+				linode.EXPECT().GetPlacementGroup(gomock.Any(), 2).Return(&linodego.PlacementGroup{ID: 2, Region: "us-east"}, nil)
+			},
+			expectedError: "placement group 2 is in region us-east but instance 123 is in region us-ord",
+		},
+		{
+			name: "Error - placement group is full",
+			ref:  &corev1.ObjectReference{Name: "pg"},
+			expects: func(k8s *mock.MockK8sClient, linode *mock.MockLinodeClient) {
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(readyPlacementGroup)
+				linode.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Region: "us-ord"}, nil)
+				linode.EXPECT().GetPlacementGroup(gomock.Any(), 2).Return(&linodego.PlacementGroup{ID: 2, Region: "us-ord", Members: []linodego.PlacementGroupMember{{LinodeID: 456}, {LinodeID: 789}}}, nil)
+				linode.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(region, nil)
+			},
+			expectedError: "placement group 2 is full, region us-ord allows at most 2 instances per group",
+		},
+		{
+			name: "Error - assign fails after leaving the previous group",
+			ref:  &corev1.ObjectReference{Name: "pg"},
+			expects: func(k8s *mock.MockK8sClient, linode *mock.MockLinodeClient) {
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(readyPlacementGroup)
+				linode.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Region: "us-ord", PlacementGroup: &linodego.InstancePlacementGroup{ID: 1}}, nil)
+				linode.EXPECT().GetPlacementGroup(gomock.Any(), 2).Return(&linodego.PlacementGroup{ID: 2, Region: "us-ord"}, nil)
+				linode.EXPECT().GetRegion(gomock.Any(), "us-ord").Return(region, nil)
+				linode.EXPECT().UnassignPlacementGroupLinodes(gomock.Any(), 1, gomock.Any()).Return(&linodego.PlacementGroup{}, nil)
+				linode.EXPECT().AssignPlacementGroupLinodes(gomock.Any(), 2, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			wantChanged:   true,
+			expectedError: "api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockK8sClient := mock.NewMockK8sClient(ctrl)
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockK8sClient, mockLinodeClient)
+
+			mScope := &MachineScope{
+				Client:       mockK8sClient,
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: "default"},
+					Spec: infrav1alpha2.LinodeMachineSpec{
+						Region:            "us-ord",
+						PlacementGroupRef: testcase.ref,
+					},
+				},
+			}
+
+			changed, err := mScope.ReconcilePlacementGroup(context.Background(), 123)
+			assert.Equal(t, testcase.wantChanged, changed)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
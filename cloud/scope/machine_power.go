@@ -0,0 +1,46 @@
+package scope
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/linode/linodego"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+)
+
+// ReconcileScheduledPower boots the instance when the given time falls within one of the
+// spec's power-on windows, and shuts it down when it falls outside of all of them. Instances
+// in any other state than running or offline, e.g. still booting, are left alone. The time of
+// the last boot or shutdown is recorded in the status. It returns whether the instance should
+// be powered on, which is always the case when the spec has no power-on windows.
+func (s *MachineScope) ReconcileScheduledPower(ctx context.Context, instanceID int, now time.Time) (bool, error) {
+	windows := s.LinodeMachine.Spec.PowerOnWindows
+	if len(windows) == 0 {
+		return true, nil
+	}
+	desiredOn := slices.ContainsFunc(windows, func(window infrav1alpha2.TimeWindow) bool { return windowOpen(window, now) })
+
+	instance, err := s.LinodeClient.GetInstance(ctx, instanceID)
+	if err != nil {
+		return desiredOn, fmt.Errorf("get instance %d: %w", instanceID, err)
+	}
+	switch {
+	case desiredOn && instance.Status == linodego.InstanceOffline:
+		if err := s.LinodeClient.BootInstance(ctx, instanceID, 0); err != nil {
+			return desiredOn, fmt.Errorf("boot instance %d: %w", instanceID, err)
+		}
+	case !desiredOn && instance.Status == linodego.InstanceRunning:
+		if err := s.LinodeClient.ShutdownInstance(ctx, instanceID); err != nil {
+			return desiredOn, fmt.Errorf("shut down instance %d: %w", instanceID, err)
+		}
+	default:
+		return desiredOn, nil
+	}
+	s.LinodeMachine.Status.LastPowerTransition = &metav1.Time{Time: now}
+
+	return desiredOn, nil
+}
@@ -0,0 +1,127 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeReconcileScheduledPower(t *testing.T) {
+	t.Parallel()
+
+	workingHours := []infrav1alpha2.TimeWindow{{
+		Days:     []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+		Start:    "08:00",
+		Duration: metav1.Duration{Duration: 10 * time.Hour},
+	}}
+	// Wednesday
+	onHours := time.Date(2024, time.July, 3, 12, 0, 0, 0, time.UTC)
+	offHours := time.Date(2024, time.July, 3, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		windows        []infrav1alpha2.TimeWindow
+		now            time.Time
+		expects        func(mock *mock.MockLinodeClient)
+		wantOn         bool
+		wantTransition bool
+		expectedError  string
+	}{
+		{
+			name:    "No power schedule",
+			now:     offHours,
+			expects: func(mock *mock.MockLinodeClient) {},
+			wantOn:  true,
+		},
+		{
+			name:    "Boot instance within a window",
+			windows: workingHours,
+			now:     onHours,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceOffline}, nil)
+				mock.EXPECT().BootInstance(gomock.Any(), 123, 0).Return(nil)
+			},
+			wantOn:         true,
+			wantTransition: true,
+		},
+		{
+			name:    "Shut down instance outside of the windows",
+			windows: workingHours,
+			now:     offHours,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().ShutdownInstance(gomock.Any(), 123).Return(nil)
+			},
+			wantTransition: true,
+		},
+		{
+			name:    "Instance already powered off",
+			windows: workingHours,
+			now:     offHours,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceOffline}, nil)
+			},
+		},
+		{
+			name:    "Instance still booting",
+			windows: workingHours,
+			now:     onHours,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceBooting}, nil)
+			},
+			wantOn: true,
+		},
+		{
+			name:    "Error - shutdown fails",
+			windows: workingHours,
+			now:     offHours,
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().ShutdownInstance(gomock.Any(), 123).Return(errors.New("api error"))
+			},
+			expectedError: "shut down instance 123: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{PowerOnWindows: testcase.windows},
+				},
+			}
+
+			desiredOn, err := mScope.ReconcileScheduledPower(context.Background(), 123, testcase.now)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantOn, desiredOn)
+			if testcase.wantTransition {
+				assert.Equal(t, &metav1.Time{Time: testcase.now}, mScope.LinodeMachine.Status.LastPowerTransition)
+			} else {
+				assert.Nil(t, mScope.LinodeMachine.Status.LastPowerTransition)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package scope
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// ccmProviderIDPrefix is the provider ID scheme expected by the Linode Cloud Controller Manager.
+const ccmProviderIDPrefix = "linode://"
+
+// legacyProviderIDRegex matches provider IDs written in formats the CCM does not recognize,
+// i.e. with an empty or region host ("linode:///123", "linode://us-east/123") or a bare instance ID.
+var legacyProviderIDRegex = regexp.MustCompile(`^(?:linode://[^/]*/)?(\d+)$`)
+
+// CCMProviderID returns the provider ID of the machine's instance in the format expected by
+// the Linode Cloud Controller Manager, or an empty string if the instance does not exist yet.
+func (s *MachineScope) CCMProviderID() string {
+	if s.LinodeMachine.Spec.InstanceID == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s%d", ccmProviderIDPrefix, *s.LinodeMachine.Spec.InstanceID)
+}
+
+// ValidateProviderID returns an error if the machine's provider ID does not match the
+// format expected by the Linode Cloud Controller Manager, which would prevent the CCM
+// from initializing the node.
+func (s *MachineScope) ValidateProviderID() error {
+	providerID := s.LinodeMachine.Spec.ProviderID
+	if providerID == nil || s.LinodeMachine.Spec.InstanceID == nil {
+		return nil
+	}
+	if expected := s.CCMProviderID(); *providerID != expected {
+		return fmt.Errorf("provider ID %q does not match the cloud controller manager format %q", *providerID, expected)
+	}
+
+	return nil
+}
+
+// MigrateProviderID rewrites a provider ID written in a legacy format to the format expected
+// by the Linode Cloud Controller Manager. It returns whether the provider ID was changed.
+// Provider IDs which do not refer to the machine's instance are left untouched.
+func (s *MachineScope) MigrateProviderID() bool {
+	providerID := s.LinodeMachine.Spec.ProviderID
+	if providerID == nil || s.LinodeMachine.Spec.InstanceID == nil || s.ValidateProviderID() == nil {
+		return false
+	}
+
+	match := legacyProviderIDRegex.FindStringSubmatch(*providerID)
+	if match == nil {
+		return false
+	}
+	if id, err := strconv.Atoi(match[1]); err != nil || id != *s.LinodeMachine.Spec.InstanceID {
+		return false
+	}
+
+	ccmProviderID := s.CCMProviderID()
+	s.LinodeMachine.Spec.ProviderID = &ccmProviderID
+
+	return true
+}
@@ -0,0 +1,109 @@
+package scope
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+)
+
+func TestMachineScopeProviderID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		instanceID         *int
+		providerID         *string
+		expectCCMID        string
+		expectValidateErr  bool
+		expectMigrated     bool
+		expectedProviderID *string
+	}{
+		{
+			name:        "Success - No instance yet",
+			expectCCMID: "",
+		},
+		{
+			name:               "Success - CCM format",
+			instanceID:         ptr.To(123),
+			providerID:         ptr.To("linode://123"),
+			expectCCMID:        "linode://123",
+			expectedProviderID: ptr.To("linode://123"),
+		},
+		{
+			name:               "Success - Provider ID not set yet",
+			instanceID:         ptr.To(123),
+			expectCCMID:        "linode://123",
+			expectedProviderID: nil,
+		},
+		{
+			name:               "Success - Legacy empty host format is migrated",
+			instanceID:         ptr.To(123),
+			providerID:         ptr.To("linode:///123"),
+			expectCCMID:        "linode://123",
+			expectValidateErr:  true,
+			expectMigrated:     true,
+			expectedProviderID: ptr.To("linode://123"),
+		},
+		{
+			name:               "Success - Legacy region format is migrated",
+			instanceID:         ptr.To(123),
+			providerID:         ptr.To("linode://us-east/123"),
+			expectCCMID:        "linode://123",
+			expectValidateErr:  true,
+			expectMigrated:     true,
+			expectedProviderID: ptr.To("linode://123"),
+		},
+		{
+			name:               "Success - Legacy bare ID format is migrated",
+			instanceID:         ptr.To(123),
+			providerID:         ptr.To("123"),
+			expectCCMID:        "linode://123",
+			expectValidateErr:  true,
+			expectMigrated:     true,
+			expectedProviderID: ptr.To("linode://123"),
+		},
+		{
+			name:               "Error - Provider ID of another instance is not migrated",
+			instanceID:         ptr.To(123),
+			providerID:         ptr.To("linode:///456"),
+			expectCCMID:        "linode://123",
+			expectValidateErr:  true,
+			expectedProviderID: ptr.To("linode:///456"),
+		},
+		{
+			name:               "Error - Unknown format is not migrated",
+			instanceID:         ptr.To(123),
+			providerID:         ptr.To("aws:///us-east-1a/i-123"),
+			expectCCMID:        "linode://123",
+			expectValidateErr:  true,
+			expectedProviderID: ptr.To("aws:///us-east-1a/i-123"),
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mScope := &MachineScope{
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{
+						InstanceID: testcase.instanceID,
+						ProviderID: testcase.providerID,
+					},
+				},
+			}
+
+			assert.Equal(t, testcase.expectCCMID, mScope.CCMProviderID())
+			if testcase.expectValidateErr {
+				assert.Error(t, mScope.ValidateProviderID())
+			} else {
+				assert.NoError(t, mScope.ValidateProviderID())
+			}
+			assert.Equal(t, testcase.expectMigrated, mScope.MigrateProviderID())
+			assert.Equal(t, testcase.expectedProviderID, mScope.LinodeMachine.Spec.ProviderID)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package scope
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// MarkReady marks the machine ready and forgets when its instance was last unhealthy.
+func (s *MachineScope) MarkReady() {
+	s.LinodeMachine.Status.Ready = true
+	s.LinodeMachine.Status.UnhealthySince = nil
+	conditions.MarkTrue(s.LinodeMachine, clusterv1.ReadyCondition)
+}
+
+// MarkNotReady marks a ready machine not ready once its instance has been unhealthy for
+// longer than the readiness grace period, so brief status flaps such as a quick reboot
+// do not trigger remediation. The time the instance was first observed unhealthy is
+// recorded in the status. It reports whether the machine was marked not ready.
+func (s *MachineScope) MarkNotReady(reason, message string) bool {
+	now := metav1.Now()
+	if s.LinodeMachine.Status.UnhealthySince == nil {
+		s.LinodeMachine.Status.UnhealthySince = &now
+	}
+
+	if s.ReadinessGracePeriod > 0 &&
+		conditions.IsTrue(s.LinodeMachine, clusterv1.ReadyCondition) &&
+		now.Sub(s.LinodeMachine.Status.UnhealthySince.Time) < s.ReadinessGracePeriod {
+		s.LinodeMachine.Status.Ready = true
+
+		return false
+	}
+
+	s.LinodeMachine.Status.Ready = false
+	conditions.MarkFalse(s.LinodeMachine, clusterv1.ReadyCondition, reason, clusterv1.ConditionSeverityInfo, "%s", message)
+
+	return true
+}
+
+// ConditionPoweredOn reports whether the instance of a machine with power-on windows is
+// powered on, or powered off by its power schedule.
+const ConditionPoweredOn clusterv1.ConditionType = "PoweredOn"
+
+// MarkPoweredOff marks the PoweredOn condition false while the instance is shut down by its
+// power schedule. The instance is shut down on purpose, so it is not considered unhealthy and
+// the machine's Ready status and condition are left as they are, so MachineHealthChecks do
+// not remediate it.
+func (s *MachineScope) MarkPoweredOff() {
+	s.LinodeMachine.Status.UnhealthySince = nil
+	conditions.MarkFalse(s.LinodeMachine, ConditionPoweredOn, "PoweredOff", clusterv1.ConditionSeverityInfo, "instance is powered off by its power schedule")
+}
+
+// MarkPoweredOn marks the PoweredOn condition true for machines with power-on windows, and
+// removes it from machines without any.
+func (s *MachineScope) MarkPoweredOn() {
+	if len(s.LinodeMachine.Spec.PowerOnWindows) == 0 {
+		conditions.Delete(s.LinodeMachine, ConditionPoweredOn)
+
+		return
+	}
+
+	conditions.MarkTrue(s.LinodeMachine, ConditionPoweredOn)
+}
@@ -0,0 +1,112 @@
+package scope
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+)
+
+func TestMachineScopeMarkNotReady(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		gracePeriod    time.Duration
+		ready          bool
+		unhealthySince *metav1.Time
+		wantMarked     bool
+	}{
+		{
+			name:       "No grace period",
+			ready:      true,
+			wantMarked: true,
+		},
+		{
+			name:        "Within grace period",
+			gracePeriod: time.Minute,
+			ready:       true,
+			wantMarked:  false,
+		},
+		{
+			name:           "Grace period elapsed",
+			gracePeriod:    time.Minute,
+			ready:          true,
+			unhealthySince: &metav1.Time{Time: time.Now().Add(-2 * time.Minute)},
+			wantMarked:     true,
+		},
+		{
+			name:        "Machine was not ready yet",
+			gracePeriod: time.Minute,
+			wantMarked:  true,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			linodeMachine := &infrav1alpha2.LinodeMachine{
+				Status: infrav1alpha2.LinodeMachineStatus{UnhealthySince: testcase.unhealthySince},
+			}
+			if testcase.ready {
+				conditions.MarkTrue(linodeMachine, clusterv1.ReadyCondition)
+			}
+			mScope := &MachineScope{
+				LinodeMachine:        linodeMachine,
+				ReadinessGracePeriod: testcase.gracePeriod,
+			}
+
+			marked := mScope.MarkNotReady("rebooting", "incompatible status")
+			assert.Equal(t, testcase.wantMarked, marked)
+			assert.Equal(t, !testcase.wantMarked, linodeMachine.Status.Ready)
+			assert.Equal(t, !testcase.wantMarked, conditions.IsTrue(linodeMachine, clusterv1.ReadyCondition))
+			assert.NotNil(t, linodeMachine.Status.UnhealthySince)
+			if testcase.unhealthySince != nil {
+				assert.Equal(t, testcase.unhealthySince, linodeMachine.Status.UnhealthySince)
+			}
+
+			mScope.MarkReady()
+			assert.True(t, linodeMachine.Status.Ready)
+			assert.Nil(t, linodeMachine.Status.UnhealthySince)
+		})
+	}
+}
+
+func TestMachineScopeMarkPoweredOff(t *testing.T) {
+	t.Parallel()
+
+	linodeMachine := &infrav1alpha2.LinodeMachine{
+		Spec: infrav1alpha2.LinodeMachineSpec{
+			PowerOnWindows: []infrav1alpha2.TimeWindow{{Start: "08:00", Duration: metav1.Duration{Duration: 10 * time.Hour}}},
+		},
+		Status: infrav1alpha2.LinodeMachineStatus{
+			Ready:          true,
+			UnhealthySince: &metav1.Time{Time: time.Now()},
+		},
+	}
+	conditions.MarkTrue(linodeMachine, clusterv1.ReadyCondition)
+	mScope := &MachineScope{LinodeMachine: linodeMachine}
+
+	mScope.MarkPoweredOff()
+
+	assert.True(t, linodeMachine.Status.Ready)
+	assert.Nil(t, linodeMachine.Status.UnhealthySince)
+	assert.True(t, conditions.IsTrue(linodeMachine, clusterv1.ReadyCondition))
+	assert.True(t, conditions.IsFalse(linodeMachine, ConditionPoweredOn))
+	assert.Equal(t, "PoweredOff", conditions.GetReason(linodeMachine, ConditionPoweredOn))
+
+	mScope.MarkPoweredOn()
+
+	assert.True(t, conditions.IsTrue(linodeMachine, ConditionPoweredOn))
+
+	linodeMachine.Spec.PowerOnWindows = nil
+	mScope.MarkPoweredOn()
+
+	assert.False(t, conditions.Has(linodeMachine, ConditionPoweredOn))
+}
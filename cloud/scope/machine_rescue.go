@@ -0,0 +1,70 @@
+package scope
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/linode/linodego"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+)
+
+// rescueDeviceSlots are the devices disks can be mounted as in rescue mode; sdh is
+// taken by the rescue environment itself.
+var rescueDeviceSlots = []string{"sda", "sdb", "sdc", "sdd", "sde", "sdf", "sdg"}
+
+// BootRescue reboots the instance into Linode's rescue mode with the disks in diskMap
+// attached as the given devices, e.g. {"sda": rootDiskID}, for filesystem recovery.
+// Since it takes the node down, the LinodeMachine must have the rescue annotation set
+// to "true". BootNormal boots the instance back into its config profile.
+func (s *MachineScope) BootRescue(ctx context.Context, instanceID int, diskMap map[string]int) error {
+	if s.LinodeMachine.Annotations[infrav1alpha2.RescueAnnotation] != "true" {
+		return fmt.Errorf("rescue mode is not allowed for instance %d, set the %s annotation to \"true\"", instanceID, infrav1alpha2.RescueAnnotation)
+	}
+
+	devices := linodego.InstanceConfigDeviceMap{}
+	for slot, diskID := range diskMap {
+		if !slices.Contains(rescueDeviceSlots, slot) {
+			return fmt.Errorf("disk %d requests device %q, must be one of %v", diskID, slot, rescueDeviceSlots)
+		}
+		*configDevice(&devices, slot) = &linodego.InstanceConfigDevice{DiskID: diskID}
+	}
+
+	if err := s.LinodeClient.RescueInstance(ctx, instanceID, linodego.InstanceRescueOptions{Devices: devices}); err != nil {
+		return fmt.Errorf("boot instance %d into rescue mode: %w", instanceID, err)
+	}
+
+	return nil
+}
+
+// BootNormal boots the instance out of rescue mode into its boot config profile, which
+// is the one recorded by ReconcileBootConfig, or the first profile otherwise.
+func (s *MachineScope) BootNormal(ctx context.Context, instanceID int) error {
+	configs, err := s.LinodeClient.ListInstanceConfigs(ctx, instanceID, &linodego.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("list instance configs: %w", err)
+	}
+	config, err := s.bootConfig(configs, instanceID)
+	if err != nil {
+		return err
+	}
+
+	instance, err := s.LinodeClient.GetInstance(ctx, instanceID)
+	if err != nil {
+		return fmt.Errorf("get instance %d: %w", instanceID, err)
+	}
+	switch instance.Status {
+	case linodego.InstanceRunning:
+		err = s.LinodeClient.RebootInstance(ctx, instanceID, config.ID)
+	case linodego.InstanceOffline:
+		err = s.LinodeClient.BootInstance(ctx, instanceID, config.ID)
+	default:
+		return fmt.Errorf("instance %d is %s, cannot boot it yet", instanceID, instance.Status)
+	}
+	if err != nil {
+		return fmt.Errorf("boot instance %d into config profile %d: %w", instanceID, config.ID, err)
+	}
+
+	return nil
+}
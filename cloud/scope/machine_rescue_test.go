@@ -0,0 +1,163 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeBootRescue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		diskMap       map[string]int
+		expects       func(mock *mock.MockLinodeClient)
+		expectedError string
+	}{
+		{
+			name:        "Boot into rescue mode",
+			annotations: map[string]string{infrav1alpha2.RescueAnnotation: "true"},
+			diskMap:     map[string]int{"sda": 1, "sdb": 2},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().RescueInstance(gomock.Any(), 123, linodego.InstanceRescueOptions{
+					Devices: linodego.InstanceConfigDeviceMap{
+						SDA: &linodego.InstanceConfigDevice{DiskID: 1},
+						SDB: &linodego.InstanceConfigDevice{DiskID: 2},
+					},
+				}).Return(nil)
+			},
+		},
+		{
+			name:          "Error - rescue not allowed",
+			diskMap:       map[string]int{"sda": 1},
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expectedError: "rescue mode is not allowed for instance 123",
+		},
+		{
+			name:          "Error - invalid device",
+			annotations:   map[string]string{infrav1alpha2.RescueAnnotation: "true"},
+			diskMap:       map[string]int{"sdh": 1},
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expectedError: `disk 1 requests device "sdh"`,
+		},
+		{
+			name:        "Error - rescue fails",
+			annotations: map[string]string{infrav1alpha2.RescueAnnotation: "true"},
+			diskMap:     map[string]int{"sda": 1},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().RescueInstance(gomock.Any(), 123, gomock.Any()).Return(errors.New("api error"))
+			},
+			expectedError: "boot instance 123 into rescue mode: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{Annotations: testcase.annotations},
+				},
+			}
+
+			err := mScope.BootRescue(context.Background(), 123, testcase.diskMap)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMachineScopeBootNormal(t *testing.T) {
+	t.Parallel()
+
+	configs := []linodego.InstanceConfig{{ID: 1}, {ID: 2}}
+
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		expects       func(mock *mock.MockLinodeClient)
+		expectedError string
+	}{
+		{
+			name: "Reboot running instance into first config",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().RebootInstance(gomock.Any(), 123, 1).Return(nil)
+			},
+		},
+		{
+			name:        "Boot offline instance into recorded config",
+			annotations: map[string]string{infrav1alpha2.BootConfigAnnotation: "2"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceOffline}, nil)
+				mock.EXPECT().BootInstance(gomock.Any(), 123, 2).Return(nil)
+			},
+		},
+		{
+			name: "Error - instance is busy",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceRebooting}, nil)
+			},
+			expectedError: "instance 123 is rebooting, cannot boot it yet",
+		},
+		{
+			name: "Error - reboot fails",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().RebootInstance(gomock.Any(), 123, 1).Return(errors.New("api error"))
+			},
+			expectedError: "boot instance 123 into config profile 1: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{Annotations: testcase.annotations},
+				},
+			}
+
+			err := mScope.BootNormal(context.Background(), 123)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
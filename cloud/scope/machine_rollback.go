@@ -0,0 +1,28 @@
+package scope
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/linode/cluster-api-provider-linode/util"
+)
+
+// RollbackPartialCreate deletes an instance whose provisioning failed part way, together with
+// its disks, configs and additional addresses, and forgets it so that the next reconcile
+// creates the instance from scratch. The LinodeMachine's CreateFailurePolicy is left to the
+// caller to check.
+func (s *MachineScope) RollbackPartialCreate(ctx context.Context, instanceID int) error {
+	if err := s.LinodeClient.DeleteInstance(ctx, instanceID); util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
+		return fmt.Errorf("delete partially created instance %d: %w", instanceID, err)
+	}
+
+	s.LinodeMachine.Spec.InstanceID = nil
+	s.LinodeMachine.Spec.ProviderID = nil
+	s.LinodeMachine.Status.Addresses = nil
+	s.LinodeMachine.Status.AdditionalIPv4s = nil
+	s.LinodeMachine.Status.InstanceState = nil
+	s.LinodeMachine.Status.InstanceType = ""
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+	"github.com/linode/cluster-api-provider-linode/util"
+)
+
+func TestMachineScopeRollbackPartialCreate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		expects       func(mock *mock.MockLinodeClient)
+		expectedError string
+	}{
+		{
+			name: "Delete partially created instance",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().DeleteInstance(gomock.Any(), 123).Return(nil)
+			},
+		},
+		{
+			name: "Instance already deleted",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().DeleteInstance(gomock.Any(), 123).Return(&linodego.Error{Code: http.StatusNotFound})
+			},
+		},
+		{
+			name: "Error - delete instance",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().DeleteInstance(gomock.Any(), 123).Return(errors.New("api error"))
+			},
+			expectedError: "delete partially created instance 123: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{
+						InstanceID: util.Pointer(123),
+						ProviderID: util.Pointer("linode://123"),
+					},
+					Status: infrav1alpha2.LinodeMachineStatus{
+						Addresses:       []clusterv1.MachineAddress{{Type: clusterv1.MachineExternalIP, Address: "192.0.2.10"}},
+						AdditionalIPv4s: []string{"192.0.2.11"},
+						InstanceState:   util.Pointer(linodego.InstanceOffline),
+						InstanceType:    "g6-standard-2",
+					},
+				},
+			}
+
+			err := mScope.RollbackPartialCreate(context.Background(), 123)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				assert.Equal(t, util.Pointer(123), mScope.LinodeMachine.Spec.InstanceID)
+				return
+			}
+			require.NoError(t, err)
+			assert.Nil(t, mScope.LinodeMachine.Spec.InstanceID)
+			assert.Nil(t, mScope.LinodeMachine.Spec.ProviderID)
+			assert.Empty(t, mScope.LinodeMachine.Status.Addresses)
+			assert.Empty(t, mScope.LinodeMachine.Status.AdditionalIPv4s)
+			assert.Nil(t, mScope.LinodeMachine.Status.InstanceState)
+			assert.Empty(t, mScope.LinodeMachine.Status.InstanceType)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package scope
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/linode/linodego"
+)
+
+// ApplyDefaultRoute marks the interface carrying the spec's default IPv4 route as the primary
+// interface, and every other interface as not primary. Linode's interfaces model only selects
+// the default route through the primary interface, and IPv6 is only routed over the public
+// interface, so the IPv6 route is validated rather than applied. An empty interface list is an
+// implicit public interface. An error is returned if a route's family has no interface of the
+// requested purpose or more than one. It reports whether any interface was changed.
+func (s *MachineScope) ApplyDefaultRoute(interfaces []linodego.InstanceConfigInterfaceCreateOptions) (bool, error) {
+	route := s.LinodeMachine.Spec.DefaultRoute
+	if route == nil {
+		return false, nil
+	}
+
+	if route.IPv6 != "" {
+		if _, err := routeInterface(interfaces, route.IPv6, "ipv6"); err != nil {
+			return false, err
+		}
+	}
+	if route.IPv4 == "" {
+		return false, nil
+	}
+	idx, err := routeInterface(interfaces, route.IPv4, "ipv4")
+	if err != nil || idx < 0 {
+		return false, err
+	}
+
+	changed := false
+	for i := range interfaces {
+		if primary := i == idx; interfaces[i].Primary != primary {
+			interfaces[i].Primary = primary
+			changed = true
+		}
+	}
+
+	return changed, nil
+}
+
+// routeInterface returns the index of the only interface with the purpose, or -1 for the
+// implicit public interface of an empty interface list.
+func routeInterface(interfaces []linodego.InstanceConfigInterfaceCreateOptions, purpose linodego.ConfigInterfacePurpose, family string) (int, error) {
+	if len(interfaces) == 0 && purpose == linodego.InterfacePurposePublic {
+		return -1, nil
+	}
+
+	idx := -1
+	for i, iface := range interfaces {
+		if iface.Purpose != purpose {
+			continue
+		}
+		if idx >= 0 {
+			return -1, fmt.Errorf("default %s route is ambiguous: more than one %s interface", family, purpose)
+		}
+		idx = i
+	}
+	if idx < 0 {
+		return -1, fmt.Errorf("default %s route requires a %s interface", family, purpose)
+	}
+
+	return idx, nil
+}
+
+// ReconcileDefaultRoute moves the primary flag of the instance's boot config profile to the
+// interface carrying the spec's default IPv4 route, see ApplyDefaultRoute. Linode only applies
+// interface changes on boot, so an error wrapping ErrRebootRequired is returned alongside
+// changed when the instance is running.
+func (s *MachineScope) ReconcileDefaultRoute(ctx context.Context, instanceID int) (bool, error) {
+	if s.LinodeMachine.Spec.DefaultRoute == nil {
+		return false, nil
+	}
+
+	configs, err := s.LinodeClient.ListInstanceConfigs(ctx, instanceID, &linodego.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("list instance configs: %w", err)
+	}
+	config, err := s.bootConfig(configs, instanceID)
+	if err != nil {
+		return false, err
+	}
+
+	interfaces := make([]linodego.InstanceConfigInterfaceCreateOptions, 0, len(config.Interfaces))
+	for _, iface := range config.Interfaces {
+		interfaces = append(interfaces, iface.GetCreateOptions())
+	}
+	changed, err := s.ApplyDefaultRoute(interfaces)
+	if err != nil || !changed {
+		return false, err
+	}
+	if _, err := s.LinodeClient.UpdateInstanceConfig(ctx, instanceID, config.ID, linodego.InstanceConfigUpdateOptions{Interfaces: interfaces}); err != nil {
+		return false, fmt.Errorf("update instance config %d interfaces: %w", config.ID, err)
+	}
+
+	instance, err := s.LinodeClient.GetInstance(ctx, instanceID)
+	if err != nil {
+		return true, fmt.Errorf("get instance %d: %w", instanceID, err)
+	}
+	if instance.Status != linodego.InstanceOffline {
+		return true, fmt.Errorf("set default ipv4 route to %s interface: %w", s.LinodeMachine.Spec.DefaultRoute.IPv4, ErrRebootRequired)
+	}
+
+	return true, nil
+}
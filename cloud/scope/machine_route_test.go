@@ -0,0 +1,135 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeReconcileDefaultRoute(t *testing.T) {
+	t.Parallel()
+
+	vpcID := 5
+	public := linodego.InstanceConfigInterface{ID: 1, Purpose: linodego.InterfacePurposePublic}
+	vpc := linodego.InstanceConfigInterface{ID: 2, Purpose: linodego.InterfacePurposeVPC, VPCID: &vpcID, SubnetID: &vpcID, Primary: true}
+	configWith := func(interfaces ...linodego.InstanceConfigInterface) []linodego.InstanceConfig {
+		return []linodego.InstanceConfig{{ID: 7, Interfaces: interfaces}}
+	}
+
+	tests := []struct {
+		name          string
+		route         *infrav1alpha2.DefaultRoute
+		expects       func(mock *mock.MockLinodeClient)
+		wantChanged   bool
+		expectedError string
+		rebootError   bool
+	}{
+		{
+			name:    "No default route",
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:  "Primary already on the route interface",
+			route: &infrav1alpha2.DefaultRoute{IPv4: linodego.InterfacePurposeVPC, IPv6: linodego.InterfacePurposePublic},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configWith(vpc, public), nil)
+			},
+		},
+		{
+			name:  "Implicit public interface",
+			route: &infrav1alpha2.DefaultRoute{IPv4: linodego.InterfacePurposePublic, IPv6: linodego.InterfacePurposePublic},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configWith(), nil)
+			},
+		},
+		{
+			name:  "Move primary to the public interface of an offline instance",
+			route: &infrav1alpha2.DefaultRoute{IPv4: linodego.InterfacePurposePublic},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configWith(vpc, public), nil)
+				mock.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 7, linodego.InstanceConfigUpdateOptions{
+					Interfaces: []linodego.InstanceConfigInterfaceCreateOptions{
+						{Purpose: linodego.InterfacePurposeVPC, SubnetID: &vpcID},
+						{Purpose: linodego.InterfacePurposePublic, Primary: true},
+					},
+				}).Return(&linodego.InstanceConfig{}, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{Status: linodego.InstanceOffline}, nil)
+			},
+			wantChanged: true,
+		},
+		{
+			name:  "Move primary on a running instance requires a reboot",
+			route: &infrav1alpha2.DefaultRoute{IPv4: linodego.InterfacePurposePublic},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configWith(vpc, public), nil)
+				mock.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 7, gomock.Any()).Return(&linodego.InstanceConfig{}, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{Status: linodego.InstanceRunning}, nil)
+			},
+			wantChanged: true,
+			rebootError: true,
+		},
+		{
+			name:  "Error - no interface for the route",
+			route: &infrav1alpha2.DefaultRoute{IPv4: linodego.InterfacePurposeVPC},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configWith(), nil)
+			},
+			expectedError: "default ipv4 route requires a vpc interface",
+		},
+		{
+			name:  "Error - ambiguous route",
+			route: &infrav1alpha2.DefaultRoute{IPv6: linodego.InterfacePurposePublic},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configWith(public, public), nil)
+			},
+			expectedError: "default ipv6 route is ambiguous: more than one public interface",
+		},
+		{
+			name:  "Error - update fails",
+			route: &infrav1alpha2.DefaultRoute{IPv4: linodego.InterfacePurposePublic},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configWith(vpc, public), nil)
+				mock.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 7, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "update instance config 7 interfaces: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{DefaultRoute: testcase.route},
+				},
+			}
+
+			changed, err := mScope.ReconcileDefaultRoute(context.Background(), 123)
+			assert.Equal(t, testcase.wantChanged, changed)
+			switch {
+			case testcase.expectedError != "":
+				require.ErrorContains(t, err, testcase.expectedError)
+			case testcase.rebootError:
+				require.ErrorIs(t, err, ErrRebootRequired)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
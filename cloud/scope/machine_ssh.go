@@ -0,0 +1,51 @@
+package scope
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/linode/linodego"
+)
+
+// ResolveAuthorizedKeys returns the public keys of the Linode profile SSH keys referenced by
+// the spec's authorized key labels, in the order of the labels. Profile key labels need not
+// be unique, so every key with a referenced label is returned. An error naming the missing
+// labels is returned if any label does not match a profile key.
+func (s *MachineScope) ResolveAuthorizedKeys(ctx context.Context) ([]string, error) {
+	labels := s.LinodeMachine.Spec.AuthorizedKeyLabels
+	if len(labels) == 0 {
+		return nil, nil
+	}
+
+	sshKeys, err := s.LinodeClient.ListSSHKeys(ctx, &linodego.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list profile ssh keys: %w", err)
+	}
+
+	var (
+		keys    []string
+		missing []string
+	)
+	for _, label := range labels {
+		found := false
+		for _, sshKey := range sshKeys {
+			if sshKey.Label != label {
+				continue
+			}
+			found = true
+			if !slices.Contains(keys, sshKey.SSHKey) {
+				keys = append(keys, sshKey.SSHKey)
+			}
+		}
+		if !found {
+			missing = append(missing, label)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("ssh keys %s do not exist in the Linode profile", strings.Join(missing, ", "))
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,89 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeResolveAuthorizedKeys(t *testing.T) {
+	t.Parallel()
+
+	profileKeys := []linodego.SSHKey{
+		{ID: 1, Label: "alice", SSHKey: "ssh-ed25519 AAAA alice@laptop"},
+		{ID: 2, Label: "bob", SSHKey: "ssh-ed25519 BBBB bob@laptop"},
+		{ID: 3, Label: "bob", SSHKey: "ssh-ed25519 CCCC bob@desktop"},
+	}
+
+	tests := []struct {
+		name          string
+		labels        []string
+		expects       func(mock *mock.MockLinodeClient)
+		wantKeys      []string
+		expectedError string
+	}{
+		{
+			name:    "No key labels",
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:   "Resolve keys in label order",
+			labels: []string{"bob", "alice"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListSSHKeys(gomock.Any(), gomock.Any()).Return(profileKeys, nil)
+			},
+			wantKeys: []string{"ssh-ed25519 BBBB bob@laptop", "ssh-ed25519 CCCC bob@desktop", "ssh-ed25519 AAAA alice@laptop"},
+		},
+		{
+			name:   "Error - missing key labels",
+			labels: []string{"alice", "carol", "dave"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListSSHKeys(gomock.Any(), gomock.Any()).Return(profileKeys, nil)
+			},
+			expectedError: "ssh keys carol, dave do not exist in the Linode profile",
+		},
+		{
+			name:   "Error - list fails",
+			labels: []string{"alice"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListSSHKeys(gomock.Any(), gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "list profile ssh keys: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{AuthorizedKeyLabels: testcase.labels},
+				},
+			}
+
+			keys, err := mScope.ResolveAuthorizedKeys(context.Background())
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantKeys, keys)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package scope
+
+import (
+	"context"
+	"crypto/sha256"
+	b64 "encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// StackScriptUDF returns the UDF values passed to the CAPL StackScript to bootstrap the
+// instance with the bootstrap data, on images or regions without cloud-init metadata support.
+func (m *MachineScope) StackScriptUDF(bootstrapData []byte) map[string]string {
+	// WARNING: label, region and type are currently supported as cloud-init variables,
+	// any changes to this could be potentially backwards incompatible and should be noted through a backwards incompatible version update
+	instanceData := fmt.Sprintf("label: %s\nregion: %s\ntype: %s", m.LinodeMachine.Name, m.LinodeMachine.Spec.Region, m.LinodeMachine.Spec.Type)
+
+	return map[string]string{
+		"instancedata": b64.StdEncoding.EncodeToString([]byte(instanceData)),
+		"userdata":     b64.StdEncoding.EncodeToString(bootstrapData),
+	}
+}
+
+// RecordStackScriptUDF records a hash of the UDF values the instance was bootstrapped with in
+// the status, for StackScriptUDFChanged.
+func (m *MachineScope) RecordStackScriptUDF(udf map[string]string) error {
+	hash, err := hashStackScriptUDF(udf)
+	if err != nil {
+		return err
+	}
+	m.LinodeMachine.Status.StackScriptUDFHash = hash
+
+	return nil
+}
+
+// StackScriptUDFChanged reports whether the UDF values resolved from the current bootstrap
+// data and spec differ from those the instance was bootstrapped with, in which case the
+// instance must be rebuilt to re-run the StackScript. It is always false for instances not
+// bootstrapped by the StackScript.
+func (m *MachineScope) StackScriptUDFChanged(ctx context.Context) (bool, error) {
+	if m.LinodeMachine.Status.StackScriptUDFHash == "" {
+		return false, nil
+	}
+
+	bootstrapData := m.bootstrapData
+	if bootstrapData == nil {
+		var err error
+		if bootstrapData, err = m.GetBootstrapData(ctx); err != nil {
+			return false, err
+		}
+	}
+	hash, err := hashStackScriptUDF(m.StackScriptUDF(bootstrapData))
+	if err != nil {
+		return false, err
+	}
+
+	return hash != m.LinodeMachine.Status.StackScriptUDFHash, nil
+}
+
+// hashStackScriptUDF returns a sha256 checksum of the UDF values. Maps are marshalled with
+// sorted keys, so the checksum does not depend on their ordering.
+func hashStackScriptUDF(udf map[string]string) (string, error) {
+	data, err := json.Marshal(udf)
+	if err != nil {
+		return "", fmt.Errorf("marshal stackscript udf: %w", err)
+	}
+	sum := sha256.Sum256(data)
+
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,110 @@
+package scope
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestHashStackScriptUDF(t *testing.T) {
+	t.Parallel()
+
+	first, err := hashStackScriptUDF(map[string]string{"a": "1", "b": "2", "c": "3"})
+	require.NoError(t, err)
+	second, err := hashStackScriptUDF(map[string]string{"c": "3", "a": "1", "b": "2"})
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	changed, err := hashStackScriptUDF(map[string]string{"a": "1", "b": "2", "c": "4"})
+	require.NoError(t, err)
+	assert.NotEqual(t, first, changed)
+}
+
+func TestMachineScopeStackScriptUDFChanged(t *testing.T) {
+	t.Parallel()
+
+	linodeMachine := func(instanceType string) *infrav1alpha2.LinodeMachine {
+		return &infrav1alpha2.LinodeMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: "default"},
+			Spec:       infrav1alpha2.LinodeMachineSpec{Region: "us-east", Type: instanceType},
+		}
+	}
+	recorded := &MachineScope{LinodeMachine: linodeMachine("g6-standard-1")}
+	require.NoError(t, recorded.RecordStackScriptUDF(recorded.StackScriptUDF([]byte("#cloud-config"))))
+	hash := recorded.LinodeMachine.Status.StackScriptUDFHash
+
+	tests := []struct {
+		name          string
+		linodeMachine *infrav1alpha2.LinodeMachine
+		hash          string
+		bootstrapData string
+		want          bool
+	}{
+		{
+			name:          "Not bootstrapped by the StackScript",
+			linodeMachine: linodeMachine("g6-standard-1"),
+		},
+		{
+			name:          "Unchanged",
+			linodeMachine: linodeMachine("g6-standard-1"),
+			hash:          hash,
+			bootstrapData: "#cloud-config",
+		},
+		{
+			name:          "Bootstrap data changed",
+			linodeMachine: linodeMachine("g6-standard-1"),
+			hash:          hash,
+			bootstrapData: "#cloud-config\nruncmd: []",
+			want:          true,
+		},
+		{
+			name:          "Instance type changed",
+			linodeMachine: linodeMachine("g6-standard-2"),
+			hash:          hash,
+			bootstrapData: "#cloud-config",
+			want:          true,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockK8sClient := mock.NewMockK8sClient(ctrl)
+			if testcase.hash != "" {
+				mockK8sClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, key client.ObjectKey, obj *corev1.Secret, opts ...client.GetOption) error {
+						*obj = corev1.Secret{Data: map[string][]byte{"value": []byte(testcase.bootstrapData)}}
+						return nil
+					})
+			}
+
+			testcase.linodeMachine.Status.StackScriptUDFHash = testcase.hash
+			mScope := &MachineScope{
+				Client: mockK8sClient,
+				Machine: &clusterv1.Machine{
+					Spec: clusterv1.MachineSpec{Bootstrap: clusterv1.Bootstrap{DataSecretName: ptr.To("bootstrap")}},
+				},
+				LinodeMachine: testcase.linodeMachine,
+			}
+
+			changed, err := mScope.StackScriptUDFChanged(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, testcase.want, changed)
+		})
+	}
+}
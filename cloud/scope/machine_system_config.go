@@ -0,0 +1,143 @@
+package scope
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// localeRegex matches POSIX locale names, e.g. en_US.UTF-8 or C.UTF-8.
+var localeRegex = regexp.MustCompile(`^[A-Za-z]+(_[A-Za-z]+)?(\.[A-Za-z0-9-]+)?(@[A-Za-z0-9]+)?$`)
+
+// SystemConfig describes system settings applied by cloud-init on first boot.
+// Zero-valued fields are left to the image.
+type SystemConfig struct {
+	// NTPServers are the NTP servers the instance synchronizes its clock with.
+	NTPServers []string
+	// Timezone is the IANA name of the instance's timezone, e.g. Europe/Berlin.
+	Timezone string
+	// Locale is the instance's default locale, e.g. en_US.UTF-8.
+	Locale string
+}
+
+// validate checks the settings before they are handed to cloud-init, which ignores invalid
+// values with only a warning in its logs.
+func (c SystemConfig) validate() error {
+	for _, server := range c.NTPServers {
+		if server == "" || strings.ContainsAny(server, " \t\n/") {
+			return fmt.Errorf("invalid ntp server %q", server)
+		}
+	}
+	if c.Timezone != "" {
+		if _, err := time.LoadLocation(c.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", c.Timezone, err)
+		}
+	}
+	if c.Locale != "" && !localeRegex.MatchString(c.Locale) {
+		return fmt.Errorf("invalid locale %q", c.Locale)
+	}
+
+	return nil
+}
+
+// cloudConfig returns the settings as a cloud-config document.
+func (c SystemConfig) cloudConfig() ([]byte, error) {
+	config := map[string]any{}
+	if len(c.NTPServers) > 0 {
+		config["ntp"] = map[string]any{"enabled": true, "servers": c.NTPServers}
+	}
+	if c.Timezone != "" {
+		config["timezone"] = c.Timezone
+	}
+	if c.Locale != "" {
+		config["locale"] = c.Locale
+	}
+	document, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte("#cloud-config\n"), document...), nil
+}
+
+// BootstrapDataWithSystemConfig returns the bootstrap data with a cloud-config part applying the
+// system settings, so NTP, timezone and locale are the same across images without editing each
+// bootstrap template. The merged document is parsed back to make sure cloud-init can read it.
+// The bootstrap data is returned unchanged when no setting is given.
+func (m *MachineScope) BootstrapDataWithSystemConfig(ctx context.Context, sysCfg SystemConfig) ([]byte, error) {
+	if len(sysCfg.NTPServers) == 0 && sysCfg.Timezone == "" && sysCfg.Locale == "" {
+		return m.BootstrapUserData(ctx)
+	}
+	if err := sysCfg.validate(); err != nil {
+		return nil, err
+	}
+	config, err := sysCfg.cloudConfig()
+	if err != nil {
+		return nil, fmt.Errorf("marshal system config: %w", err)
+	}
+
+	data, err := m.BootstrapUserData(ctx, UserDataPart{ContentType: "text/cloud-config", Filename: "system-config", Content: config})
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMultipartUserData(data); err != nil {
+		return nil, fmt.Errorf("invalid merged user-data: %w", err)
+	}
+
+	return data, nil
+}
+
+// validateMultipartUserData checks that the multipart user-data parses, that every part has a
+// content type understood by cloud-init, and that its cloud-config parts are valid YAML.
+func validateMultipartUserData(data []byte) error {
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		return err
+	}
+	if mediaType != "multipart/mixed" {
+		return fmt.Errorf("unexpected content type %s", mediaType)
+	}
+
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for i := 0; ; i++ {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("part %d: %w", i, err)
+		}
+		contentType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if err != nil {
+			return fmt.Errorf("part %d: %w", i, err)
+		}
+		if !slices.Contains(userDataContentTypes, contentType) {
+			return fmt.Errorf("part %d has unsupported content type %q", i, contentType)
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return fmt.Errorf("part %d: %w", i, err)
+		}
+		if contentType == "text/cloud-config" {
+			var config map[string]any
+			if err := yaml.Unmarshal(content, &config); err != nil {
+				return fmt.Errorf("part %d is not valid cloud-config: %w", i, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package scope
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+)
+
+func TestMachineScopeBootstrapDataWithSystemConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		bootstrapData []byte
+		sysCfg        SystemConfig
+		wantParts     [][2]string
+		wantData      []byte
+		expectedError string
+	}{
+		{
+			name:          "No system config",
+			bootstrapData: []byte("#cloud-config\n"),
+			wantData:      []byte("#cloud-config\n"),
+		},
+		{
+			name:          "System config merged as a cloud-config part",
+			bootstrapData: []byte("#cloud-config\nruncmd: []\n"),
+			sysCfg: SystemConfig{
+				NTPServers: []string{"ntp1.example.com", "10.0.0.1"},
+				Timezone:   "Europe/Berlin",
+				Locale:     "en_US.UTF-8",
+			},
+			wantParts: [][2]string{
+				{"text/cloud-config", "#cloud-config\nruncmd: []\n"},
+				{"text/cloud-config", "#cloud-config\nlocale: en_US.UTF-8\nntp:\n  enabled: true\n  servers:\n  - ntp1.example.com\n  - 10.0.0.1\ntimezone: Europe/Berlin\n"},
+			},
+		},
+		{
+			name:          "Only timezone",
+			bootstrapData: []byte("#!/bin/sh\n"),
+			sysCfg:        SystemConfig{Timezone: "UTC"},
+			wantParts: [][2]string{
+				{"text/x-shellscript", "#!/bin/sh\n"},
+				{"text/cloud-config", "#cloud-config\ntimezone: UTC\n"},
+			},
+		},
+		{
+			name:          "Error - invalid ntp server",
+			bootstrapData: []byte("#cloud-config\n"),
+			sysCfg:        SystemConfig{NTPServers: []string{"ntp.example.com pool"}},
+			expectedError: `invalid ntp server "ntp.example.com pool"`,
+		},
+		{
+			name:          "Error - unknown timezone",
+			bootstrapData: []byte("#cloud-config\n"),
+			sysCfg:        SystemConfig{Timezone: "Mars/Olympus_Mons"},
+			expectedError: `invalid timezone "Mars/Olympus_Mons"`,
+		},
+		{
+			name:          "Error - invalid locale",
+			bootstrapData: []byte("#cloud-config\n"),
+			sysCfg:        SystemConfig{Locale: "en US"},
+			expectedError: `invalid locale "en US"`,
+		},
+		{
+			name:          "Error - invalid cloud-config in bootstrap data",
+			bootstrapData: []byte("#cloud-config\nruncmd: [\n"),
+			sysCfg:        SystemConfig{Timezone: "UTC"},
+			expectedError: "invalid merged user-data: part 0 is not valid cloud-config",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mScope := &MachineScope{
+				LinodeMachine: &infrav1alpha2.LinodeMachine{},
+				bootstrapData: testcase.bootstrapData,
+			}
+
+			data, err := mScope.BootstrapDataWithSystemConfig(context.Background(), testcase.sysCfg)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			if testcase.wantParts != nil {
+				assert.Equal(t, testcase.wantParts, parseMultipartUserData(t, data))
+			} else {
+				assert.Equal(t, testcase.wantData, data)
+			}
+		})
+	}
+}
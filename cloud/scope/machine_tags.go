@@ -0,0 +1,388 @@
+package scope
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/linode/linodego"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// maxTagUpdateAttempts bounds the number of tag writes attempted when a concurrent
+	// writer overwrites the merged tags.
+	maxTagUpdateAttempts = 3
+
+	// minTagLength and maxTagLength are the tag length limits enforced by the Linode API.
+	minTagLength = 3
+	maxTagLength = 50
+	// k8sVersionTagPrefix is the prefix of the tag holding the owner Machine's Kubernetes version.
+	k8sVersionTagPrefix = "k8s-version:"
+	// TerminatingTag marks instances whose machine is being deleted.
+	TerminatingTag = "capl-terminating"
+	// machineTagPrefix is the prefix of the volume tag holding the name of the LinodeMachine.
+	machineTagPrefix = "machine:"
+	// remediatedTag marks instances whose Machine is being remediated by a MachineHealthCheck.
+	remediatedTag = "remediated:true"
+	// ephemeralTagTimeFormat is the format of the timestamp of ephemeral tags, which only
+	// uses characters allowed in tags.
+	ephemeralTagTimeFormat = "20060102T1504Z"
+
+	// ConditionTagTemplateAvailable reports whether the tag template ConfigMap could be read.
+	ConditionTagTemplateAvailable clusterv1.ConditionType = "TagTemplateAvailable"
+)
+
+// EphemeralTag is an instance tag holding the time it was last refreshed, e.g.
+// last-reconciled:20240601T1200Z, which ReconcileManagedTags refreshes and removes once expired.
+type EphemeralTag struct {
+	// Prefix is the part of the tag before the timestamp, e.g. last-reconciled.
+	Prefix string
+	// TTL is how long the tag is valid after it was refreshed. The timestamp is only
+	// refreshed once half of the TTL has passed, so that reconciles do not churn tags.
+	TTL time.Duration
+}
+
+// ValidateEphemeralTag checks the ephemeral tag is a valid tag once its timestamp is appended.
+func ValidateEphemeralTag(tag EphemeralTag) error {
+	if tag.TTL <= 0 {
+		return fmt.Errorf("ephemeral tag %s must have a positive TTL", tag.Prefix)
+	}
+
+	return ValidateTag(tag.Prefix + ":" + ephemeralTagTimeFormat)
+}
+
+// ConfigMapReference references a key of a ConfigMap in the LinodeMachine's namespace.
+type ConfigMapReference struct {
+	// Name is the name of the ConfigMap.
+	Name string
+	// Key is the key of the ConfigMap data holding the value.
+	Key string
+}
+
+// topologyTagLabels maps the CAPI topology labels propagated onto instance tags to their tag prefix.
+var topologyTagLabels = []struct {
+	label  string
+	prefix string
+}{
+	{label: clusterv1.ClusterTopologyMachineDeploymentNameLabel, prefix: "deployment-name"},
+	{label: clusterv1.ClusterTopologyMachinePoolNameLabel, prefix: "pool-name"},
+}
+
+// invalidTagCharsRegex matches the characters which are replaced when sanitizing tags.
+var invalidTagCharsRegex = regexp.MustCompile(`[^a-zA-Z0-9_.:-]`)
+
+// UpdateInstanceTagsMerge adds and removes the given tags on an instance without
+// replacing the tags set by other writers. The Linode API has no conditional update, so a
+// writer that read the tags before this update can overwrite it: the tags are re-read after
+// every write, and the merge is re-applied when it was lost, up to maxTagUpdateAttempts
+// writes. Tags another writer sets between the read and the write of an attempt may still
+// be overwritten, so the merge is best-effort for tags CAPL does not manage.
+func (s *MachineScope) UpdateInstanceTagsMerge(ctx context.Context, instanceID int, add, remove []string) error {
+	for attempt := 0; ; attempt++ {
+		instance, err := s.LinodeClient.GetInstance(ctx, instanceID)
+		if err != nil {
+			return fmt.Errorf("get instance %d: %w", instanceID, err)
+		}
+
+		tags := mergeTags(instance.Tags, add, remove)
+		if slices.Equal(tags, instance.Tags) {
+			return nil
+		}
+		if attempt == maxTagUpdateAttempts {
+			return fmt.Errorf("update instance %d tags: overwritten by a concurrent writer after %d attempts", instanceID, maxTagUpdateAttempts)
+		}
+
+		if _, err := s.LinodeClient.UpdateInstance(ctx, instanceID, linodego.InstanceUpdateOptions{Tags: &tags}); err != nil {
+			return fmt.Errorf("update instance %d tags: %w", instanceID, err)
+		}
+	}
+}
+
+// MarkInstanceTerminating adds the terminating tag to the instance, so external tooling can
+// stop scheduling to and evacuate the node before the instance is shut down and deleted.
+// Tags set by other writers are kept, and the instance is left untouched if already tagged.
+func (s *MachineScope) MarkInstanceTerminating(ctx context.Context, instanceID int) error {
+	return s.UpdateInstanceTagsMerge(ctx, instanceID, []string{TerminatingTag}, nil)
+}
+
+// ManagedTags returns the instance tags CAPL sets on the machine's instance: the
+// LinodeCluster name followed by the spec's tags and, when the owner Machine has a
+// Kubernetes version, a k8s-version tag, so version skew can be audited across instances.
+// The owner Cluster's labels with one of the ClusterLabelTags keys are added as key:value
+// tags, e.g. so Linode billing can be broken down by team. When TopologyTags is enabled, the
+// owner Machine's ClusterClass topology is added as tags. When RemediationTags is enabled,
+// a remediated tag is added while the owner Machine is being remediated, and is removed by
+// ReconcileManagedTags once the Machine is healthy again.
+func (s *MachineScope) ManagedTags() []string {
+	tags := []string{s.LinodeCluster.Name}
+	for _, tag := range s.LinodeMachine.Spec.Tags {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	if s.Machine != nil && s.Machine.Spec.Version != nil && *s.Machine.Spec.Version != "" {
+		if tag := sanitizeTag(k8sVersionTagPrefix + *s.Machine.Spec.Version); tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	if s.Cluster != nil {
+		for _, key := range s.ClusterLabelTags {
+			value, ok := s.Cluster.Labels[key]
+			if !ok || value == "" {
+				continue
+			}
+			if tag := sanitizeTag(key + ":" + value); tag != "" && !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	if s.TopologyTags {
+		for _, tag := range s.topologyTags() {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	if s.RemediationTags && s.remediating() && !slices.Contains(tags, remediatedTag) {
+		tags = append(tags, remediatedTag)
+	}
+
+	return tags
+}
+
+// ephemeralTags returns the ephemeral tags to set on the instance. The tag recorded in the
+// status is kept until half of its TTL has passed, after which it is replaced by a tag with
+// the current time. Tags with an unparseable timestamp are replaced as if expired.
+func (s *MachineScope) ephemeralTags(now time.Time) []string {
+	tags := make([]string, 0, len(s.EphemeralTags))
+	for _, ephemeral := range s.EphemeralTags {
+		tag := ephemeral.Prefix + ":" + now.UTC().Format(ephemeralTagTimeFormat)
+		for _, current := range s.LinodeMachine.Status.ManagedTags {
+			timestamp, ok := strings.CutPrefix(current, ephemeral.Prefix+":")
+			if !ok {
+				continue
+			}
+			refreshed, err := time.Parse(ephemeralTagTimeFormat, timestamp)
+			if err == nil && now.Sub(refreshed) < ephemeral.TTL/2 {
+				tag = current
+				break
+			}
+		}
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// remediating reports whether the owner Machine is being remediated: it was marked for
+// remediation, or a MachineHealthCheck found it unhealthy and its owner has yet to remediate it.
+func (s *MachineScope) remediating() bool {
+	if s.Machine == nil {
+		return false
+	}
+	if _, ok := s.Machine.Annotations[clusterv1.RemediateMachineAnnotation]; ok {
+		return true
+	}
+
+	return conditions.IsFalse(s.Machine, clusterv1.MachineOwnerRemediatedCondition)
+}
+
+// ReconcileManagedTags sets the managed tags, the tags of the tag template and the ephemeral
+// tags on the instance and removes the tags CAPL previously set which are no longer desired,
+// such as expired ephemeral tags. The tags CAPL set are recorded in the status, so tags added
+// to the instance by other tools are never removed. Protected tags are left on the instance
+// even when CAPL no longer manages them.
+func (s *MachineScope) ReconcileManagedTags(ctx context.Context, instanceID int) error {
+	desired, err := s.TemplatedTags(ctx)
+	if err != nil {
+		return err
+	}
+	desired = append(desired, s.ephemeralTags(time.Now())...)
+
+	var remove []string
+	for _, tag := range s.LinodeMachine.Status.ManagedTags {
+		if !slices.Contains(desired, tag) && !slices.Contains(s.ProtectedTags, tag) {
+			remove = append(remove, tag)
+		}
+	}
+
+	if err := s.UpdateInstanceTagsMerge(ctx, instanceID, desired, remove); err != nil {
+		return err
+	}
+	s.LinodeMachine.Status.ManagedTags = desired
+
+	return nil
+}
+
+// TemplatedTags returns the managed tags merged with the tags of the scope's tag template, if
+// any, see TagsFromConfigMap.
+func (s *MachineScope) TemplatedTags(ctx context.Context) ([]string, error) {
+	if s.TagTemplate == nil {
+		return s.ManagedTags(), nil
+	}
+
+	return s.TagsFromConfigMap(ctx, *s.TagTemplate)
+}
+
+// TagsFromConfigMap returns the managed tags merged with the tags of the template held by the
+// referenced ConfigMap key, so a tagging policy can be applied to all machines of a namespace.
+// The template has one tag per line or comma-separated entry, and each tag is rendered with the
+// same placeholders as the label template, see InstanceLabel, then sanitized. A missing
+// ConfigMap or key is not an error: the ConditionTagTemplateAvailable condition is marked false
+// and only the managed tags are returned.
+func (s *MachineScope) TagsFromConfigMap(ctx context.Context, ref ConfigMapReference) ([]string, error) {
+	tags := s.ManagedTags()
+
+	var configMap corev1.ConfigMap
+	if err := s.Client.Get(ctx, client.ObjectKey{Namespace: s.LinodeMachine.Namespace, Name: ref.Name}, &configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("get tag template configmap %s: %w", ref.Name, err)
+		}
+		conditions.MarkFalse(s.LinodeMachine, ConditionTagTemplateAvailable, "ConfigMapNotFound", clusterv1.ConditionSeverityWarning,
+			"tag template configmap %s not found", ref.Name)
+
+		return tags, nil
+	}
+	template, ok := configMap.Data[ref.Key]
+	if !ok {
+		conditions.MarkFalse(s.LinodeMachine, ConditionTagTemplateAvailable, "KeyNotFound", clusterv1.ConditionSeverityWarning,
+			"tag template configmap %s has no key %s", ref.Name, ref.Key)
+
+		return tags, nil
+	}
+
+	for _, entry := range strings.FieldsFunc(template, func(r rune) bool { return r == '\n' || r == ',' }) {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		rendered, unknown := s.renderTemplate(entry)
+		if len(unknown) > 0 {
+			return nil, fmt.Errorf("tag template %q has unknown placeholders %s", strings.TrimSpace(entry), strings.Join(unknown, ", "))
+		}
+		if tag := sanitizeTag(rendered); tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	conditions.MarkTrue(s.LinodeMachine, ConditionTagTemplateAvailable)
+
+	return tags, nil
+}
+
+// VolumeTags returns the tags CAPL sets on the machine's volumes, sorted so the same tags
+// are always sent in the same order. They are the managed tags without the Kubernetes
+// version and remediated tag, which volumes outlive, plus a machine tag, so the orphaned
+// resource sweep can find the volumes of a cluster and tell which machine they belonged to.
+func (s *MachineScope) VolumeTags() []string {
+	tags := make([]string, 0, len(s.ManagedTags())+1)
+	for _, tag := range s.ManagedTags() {
+		if !strings.HasPrefix(tag, k8sVersionTagPrefix) && tag != remediatedTag {
+			tags = append(tags, tag)
+		}
+	}
+	if tag := sanitizeTag(machineTagPrefix + s.LinodeMachine.Name); tag != "" && !slices.Contains(tags, tag) {
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+
+	return tags
+}
+
+// ReconcileVolumeTags adds the volume tags to the volume, keeping the tags set by other
+// writers. The tags are sorted before they are written, so the volume is only updated
+// when a tag is missing.
+func (s *MachineScope) ReconcileVolumeTags(ctx context.Context, volumeID int) error {
+	volume, err := s.LinodeClient.GetVolume(ctx, volumeID)
+	if err != nil {
+		return fmt.Errorf("get volume %d: %w", volumeID, err)
+	}
+
+	desired := s.VolumeTags()
+	if !slices.ContainsFunc(desired, func(tag string) bool { return !slices.Contains(volume.Tags, tag) }) {
+		return nil
+	}
+	tags := mergeTags(volume.Tags, desired, nil)
+	slices.Sort(tags)
+
+	if _, err := s.LinodeClient.UpdateVolume(ctx, volumeID, linodego.VolumeUpdateOptions{Tags: &tags}); err != nil {
+		return fmt.Errorf("update volume %d tags: %w", volumeID, err)
+	}
+
+	return nil
+}
+
+// mergeTags applies the additions and removals to the current tags, preserving
+// the order of existing tags and appending new ones.
+func mergeTags(current, add, remove []string) []string {
+	merged := make([]string, 0, len(current)+len(add))
+	for _, tag := range current {
+		if !slices.Contains(remove, tag) && !slices.Contains(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+	for _, tag := range add {
+		if !slices.Contains(remove, tag) && !slices.Contains(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+
+	return merged
+}
+
+// topologyTags returns instance tags derived from the CAPI topology labels on the owner
+// Machine, such as the MachineDeployment topology name, so instances of ClusterClass-based
+// clusters can be grouped by their topology. Tags are sanitized to the characters and length
+// accepted by the Linode API.
+func (s *MachineScope) topologyTags() []string {
+	if s.Machine == nil {
+		return nil
+	}
+
+	var tags []string
+	for _, topology := range topologyTagLabels {
+		value, ok := s.Machine.Labels[topology.label]
+		if !ok || value == "" {
+			continue
+		}
+		if tag := sanitizeTag(topology.prefix + ":" + value); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// ValidateTag returns an error if the tag is not accepted by the Linode API.
+func ValidateTag(tag string) error {
+	if len(tag) < minTagLength || len(tag) > maxTagLength {
+		return fmt.Errorf("tag %q must be between %d and %d characters", tag, minTagLength, maxTagLength)
+	}
+	if invalidTagCharsRegex.MatchString(tag) {
+		return fmt.Errorf("tag %q must only contain letters, digits and the characters _.:-", tag)
+	}
+
+	return nil
+}
+
+// sanitizeTag replaces the characters not allowed in Linode tags and truncates the tag to
+// the maximum length. An empty string is returned if the tag is too short to be valid.
+func sanitizeTag(tag string) string {
+	tag = invalidTagCharsRegex.ReplaceAllString(strings.TrimSpace(tag), "-")
+	if len(tag) > maxTagLength {
+		tag = tag[:maxTagLength]
+	}
+	if len(tag) < minTagLength {
+		return ""
+	}
+
+	return tag
+}
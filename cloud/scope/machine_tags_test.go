@@ -0,0 +1,728 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeUpdateInstanceTagsMerge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		add           []string
+		remove        []string
+		expects       func(mock *mock.MockLinodeClient)
+		expectedError string
+	}{
+		{
+			name:   "Merge with existing tags",
+			add:    []string{"b", "c"},
+			remove: []string{"x"},
+			expects: func(mock *mock.MockLinodeClient) {
+				gomock.InOrder(
+					mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"a", "x"}}, nil),
+					mock.EXPECT().UpdateInstance(gomock.Any(), 123, linodego.InstanceUpdateOptions{Tags: &[]string{"a", "b", "c"}}).Return(&linodego.Instance{}, nil),
+					mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"a", "b", "c"}}, nil),
+				)
+			},
+		},
+		{
+			name: "No update when tags already match",
+			add:  []string{"a"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"a"}}, nil)
+			},
+		},
+		{
+			name: "Re-apply the merge when a concurrent writer overwrote it",
+			add:  []string{"b"},
+			expects: func(mock *mock.MockLinodeClient) {
+				gomock.InOrder(
+					mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"a"}}, nil),
+					mock.EXPECT().UpdateInstance(gomock.Any(), 123, linodego.InstanceUpdateOptions{Tags: &[]string{"a", "b"}}).Return(&linodego.Instance{}, nil),
+					mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"a", "other"}}, nil),
+					mock.EXPECT().UpdateInstance(gomock.Any(), 123, linodego.InstanceUpdateOptions{Tags: &[]string{"a", "other", "b"}}).Return(&linodego.Instance{}, nil),
+					mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"a", "b", "other"}}, nil),
+				)
+			},
+		},
+		{
+			name: "Error - merge keeps being overwritten",
+			add:  []string{"b"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123}, nil).Times(maxTagUpdateAttempts + 1)
+				mock.EXPECT().UpdateInstance(gomock.Any(), 123, gomock.Any()).Return(&linodego.Instance{}, nil).Times(maxTagUpdateAttempts)
+			},
+			expectedError: "overwritten by a concurrent writer after 3 attempts",
+		},
+		{
+			name: "Error - update fails",
+			add:  []string{"b"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123}, nil)
+				mock.EXPECT().UpdateInstance(gomock.Any(), 123, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{LinodeClient: mockLinodeClient}
+
+			err := mScope.UpdateInstanceTagsMerge(context.Background(), 123, testcase.add, testcase.remove)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMachineScopeReconcileManagedTags(t *testing.T) {
+	t.Parallel()
+
+	lastReconciled := "last-reconciled:" + time.Now().UTC().Add(-time.Hour).Format(ephemeralTagTimeFormat)
+
+	tests := []struct {
+		name            string
+		specTags        []string
+		managedTags     []string
+		protectedTags   []string
+		ephemeralTags   []EphemeralTag
+		version         string
+		clusterLabels   map[string]string
+		expects         func(mock *mock.MockLinodeClient)
+		wantManagedTags []string
+		expectedError   string
+	}{
+		{
+			name:     "Add managed tags and keep external tags",
+			specTags: []string{"db"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"backup:nightly"}}, nil)
+				mock.EXPECT().UpdateInstance(gomock.Any(), 123, linodego.InstanceUpdateOptions{Tags: &[]string{"backup:nightly", "test-cluster", "db"}}).
+					Return(&linodego.Instance{}, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"backup:nightly", "test-cluster", "db"}}, nil)
+			},
+			wantManagedTags: []string{"test-cluster", "db"},
+		},
+		{
+			name:        "Remove tags no longer managed",
+			specTags:    []string{"db"},
+			managedTags: []string{"test-cluster", "db", "old"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"test-cluster", "db", "old", "backup:nightly"}}, nil)
+				mock.EXPECT().UpdateInstance(gomock.Any(), 123, linodego.InstanceUpdateOptions{Tags: &[]string{"test-cluster", "db", "backup:nightly"}}).
+					Return(&linodego.Instance{}, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"test-cluster", "db", "backup:nightly"}}, nil)
+			},
+			wantManagedTags: []string{"test-cluster", "db"},
+		},
+		{
+			name:          "Keep fresh ephemeral tags",
+			managedTags:   []string{"test-cluster", lastReconciled},
+			ephemeralTags: []EphemeralTag{{Prefix: "last-reconciled", TTL: 24 * time.Hour}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"test-cluster", lastReconciled}}, nil)
+			},
+			wantManagedTags: []string{"test-cluster", lastReconciled},
+		},
+		{
+			name:          "Keep protected tags no longer managed",
+			managedTags:   []string{"test-cluster", "terraform"},
+			protectedTags: []string{"terraform"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"test-cluster", "terraform"}}, nil)
+			},
+			wantManagedTags: []string{"test-cluster"},
+		},
+		{
+			name:        "Replace Kubernetes version tag on upgrade",
+			version:     "v1.31.1",
+			managedTags: []string{"test-cluster", "k8s-version:v1.30.4"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"test-cluster", "k8s-version:v1.30.4"}}, nil)
+				mock.EXPECT().UpdateInstance(gomock.Any(), 123, linodego.InstanceUpdateOptions{Tags: &[]string{"test-cluster", "k8s-version:v1.31.1"}}).
+					Return(&linodego.Instance{}, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"test-cluster", "k8s-version:v1.31.1"}}, nil)
+			},
+			wantManagedTags: []string{"test-cluster", "k8s-version:v1.31.1"},
+		},
+		{
+			name:          "Mirror allowed Cluster labels and replace changed ones",
+			clusterLabels: map[string]string{"team": "platform", "env": "prod", "owner": "alice"},
+			managedTags:   []string{"test-cluster", "team:storage"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"test-cluster", "team:storage"}}, nil)
+				mock.EXPECT().UpdateInstance(gomock.Any(), 123, linodego.InstanceUpdateOptions{Tags: &[]string{"test-cluster", "team:platform", "env:prod"}}).
+					Return(&linodego.Instance{}, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"test-cluster", "team:platform", "env:prod"}}, nil)
+			},
+			wantManagedTags: []string{"test-cluster", "team:platform", "env:prod"},
+		},
+		{
+			name:        "Restore removed managed tag",
+			managedTags: []string{"test-cluster"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"other"}}, nil)
+				mock.EXPECT().UpdateInstance(gomock.Any(), 123, linodego.InstanceUpdateOptions{Tags: &[]string{"other", "test-cluster"}}).
+					Return(&linodego.Instance{}, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"other", "test-cluster"}}, nil)
+			},
+			wantManagedTags: []string{"test-cluster"},
+		},
+		{
+			name:        "Error - update fails",
+			managedTags: []string{"test-cluster", "old"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"test-cluster", "old"}}, nil)
+				mock.EXPECT().UpdateInstance(gomock.Any(), 123, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			wantManagedTags: []string{"test-cluster", "old"},
+			expectedError:   "api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient:     mockLinodeClient,
+				LinodeCluster:    &infrav1alpha2.LinodeCluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+				ProtectedTags:    testcase.protectedTags,
+				EphemeralTags:    testcase.ephemeralTags,
+				Machine:          &clusterv1.Machine{},
+				Cluster:          &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Labels: testcase.clusterLabels}},
+				ClusterLabelTags: []string{"team", "env"},
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec:   infrav1alpha2.LinodeMachineSpec{Tags: testcase.specTags},
+					Status: infrav1alpha2.LinodeMachineStatus{ManagedTags: testcase.managedTags},
+				},
+			}
+
+			if testcase.version != "" {
+				mScope.Machine.Spec.Version = ptr.To(testcase.version)
+			}
+
+			err := mScope.ReconcileManagedTags(context.Background(), 123)
+			assert.Equal(t, testcase.wantManagedTags, mScope.LinodeMachine.Status.ManagedTags)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMachineScopeEphemeralTags(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 2, 12, 0, 0, 0, time.UTC)
+	ephemeralTags := []EphemeralTag{{Prefix: "last-reconciled", TTL: 24 * time.Hour}}
+
+	tests := []struct {
+		name          string
+		ephemeralTags []EphemeralTag
+		managedTags   []string
+		expectedTags  []string
+	}{
+		{
+			name:         "No ephemeral tags",
+			managedTags:  []string{"test-cluster"},
+			expectedTags: []string{},
+		},
+		{
+			name:          "Add ephemeral tag",
+			ephemeralTags: ephemeralTags,
+			managedTags:   []string{"test-cluster"},
+			expectedTags:  []string{"last-reconciled:20240602T1200Z"},
+		},
+		{
+			name:          "Keep tag refreshed within half of its TTL",
+			ephemeralTags: ephemeralTags,
+			managedTags:   []string{"test-cluster", "last-reconciled:20240602T0100Z"},
+			expectedTags:  []string{"last-reconciled:20240602T0100Z"},
+		},
+		{
+			name:          "Refresh tag older than half of its TTL",
+			ephemeralTags: ephemeralTags,
+			managedTags:   []string{"test-cluster", "last-reconciled:20240601T2300Z"},
+			expectedTags:  []string{"last-reconciled:20240602T1200Z"},
+		},
+		{
+			name:          "Replace expired and malformed tags",
+			ephemeralTags: ephemeralTags,
+			managedTags:   []string{"last-reconciled:20240520T1200Z", "last-reconciled:yesterday"},
+			expectedTags:  []string{"last-reconciled:20240602T1200Z"},
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mScope := &MachineScope{
+				EphemeralTags: testcase.ephemeralTags,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Status: infrav1alpha2.LinodeMachineStatus{ManagedTags: testcase.managedTags},
+				},
+			}
+
+			assert.Equal(t, testcase.expectedTags, mScope.ephemeralTags(now))
+		})
+	}
+}
+
+func TestMachineScopeTopologyTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		enabled      bool
+		labels       map[string]string
+		expectedTags []string
+	}{
+		{
+			name:         "Success - No topology labels",
+			enabled:      true,
+			labels:       map[string]string{clusterv1.ClusterNameLabel: "test-cluster"},
+			expectedTags: []string{"test-cluster"},
+		},
+		{
+			name:         "Success - MachineDeployment topology",
+			enabled:      true,
+			labels:       map[string]string{clusterv1.ClusterTopologyMachineDeploymentNameLabel: "md-0"},
+			expectedTags: []string{"test-cluster", "deployment-name:md-0"},
+		},
+		{
+			name:    "Success - MachineDeployment and MachinePool topology",
+			enabled: true,
+			labels: map[string]string{
+				clusterv1.ClusterTopologyMachineDeploymentNameLabel: "md-0",
+				clusterv1.ClusterTopologyMachinePoolNameLabel:       "mp-0",
+			},
+			expectedTags: []string{"test-cluster", "deployment-name:md-0", "pool-name:mp-0"},
+		},
+		{
+			name:         "Success - Tags are sanitized and truncated",
+			enabled:      true,
+			labels:       map[string]string{clusterv1.ClusterTopologyMachineDeploymentNameLabel: "workers/us east@" + strings.Repeat("a", 50)},
+			expectedTags: []string{"test-cluster", "deployment-name:workers-us-east-" + strings.Repeat("a", 18)},
+		},
+		{
+			name:         "Success - Topology tags disabled",
+			labels:       map[string]string{clusterv1.ClusterTopologyMachineDeploymentNameLabel: "md-0"},
+			expectedTags: []string{"test-cluster"},
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mScope := &MachineScope{
+				Machine:       &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Labels: testcase.labels}},
+				LinodeCluster: &infrav1alpha2.LinodeCluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+				LinodeMachine: &infrav1alpha2.LinodeMachine{ObjectMeta: metav1.ObjectMeta{Name: "test-machine"}},
+				TopologyTags:  testcase.enabled,
+			}
+
+			require.Equal(t, testcase.expectedTags, mScope.ManagedTags())
+		})
+	}
+}
+
+func TestMachineScopeRemediationTags(t *testing.T) {
+	t.Parallel()
+
+	unhealthy := func(machine *clusterv1.Machine) {
+		conditions.MarkFalse(machine, clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediationReason, clusterv1.ConditionSeverityWarning, "")
+	}
+	tests := []struct {
+		name         string
+		enabled      bool
+		annotations  map[string]string
+		mutate       func(machine *clusterv1.Machine)
+		volumeTags   []string
+		expectedTags []string
+	}{
+		{
+			name:         "Success - Healthy machine",
+			enabled:      true,
+			expectedTags: []string{"test-cluster"},
+		},
+		{
+			name:         "Success - Waiting for remediation",
+			enabled:      true,
+			mutate:       unhealthy,
+			expectedTags: []string{"test-cluster", "remediated:true"},
+		},
+		{
+			name:         "Success - Marked for remediation",
+			enabled:      true,
+			annotations:  map[string]string{clusterv1.RemediateMachineAnnotation: ""},
+			expectedTags: []string{"test-cluster", "remediated:true"},
+		},
+		{
+			name:    "Success - Remediated machine healthy again",
+			enabled: true,
+			mutate: func(machine *clusterv1.Machine) {
+				conditions.MarkTrue(machine, clusterv1.MachineOwnerRemediatedCondition)
+			},
+			expectedTags: []string{"test-cluster"},
+		},
+		{
+			name:         "Success - Remediation tags disabled",
+			mutate:       unhealthy,
+			expectedTags: []string{"test-cluster"},
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Annotations: testcase.annotations}}
+			if testcase.mutate != nil {
+				testcase.mutate(machine)
+			}
+			mScope := &MachineScope{
+				Machine:         machine,
+				LinodeCluster:   &infrav1alpha2.LinodeCluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+				LinodeMachine:   &infrav1alpha2.LinodeMachine{ObjectMeta: metav1.ObjectMeta{Name: "test-machine"}},
+				RemediationTags: testcase.enabled,
+			}
+
+			require.Equal(t, testcase.expectedTags, mScope.ManagedTags())
+			assert.NotContains(t, mScope.VolumeTags(), remediatedTag)
+		})
+	}
+}
+
+func TestMachineScopeReconcileVolumeTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		expects       func(mock *mock.MockLinodeClient)
+		expectedError string
+	}{
+		{
+			name: "Add missing tags in sorted order",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetVolume(gomock.Any(), 7).Return(&linodego.Volume{ID: 7, Tags: []string{"zz-other", "test-cluster"}}, nil)
+				mock.EXPECT().UpdateVolume(gomock.Any(), 7, linodego.VolumeUpdateOptions{
+					Tags: &[]string{"env:prod", "machine:test-machine", "test-cluster", "zz-other"},
+				}).Return(&linodego.Volume{}, nil)
+			},
+		},
+		{
+			name: "Tags are up to date",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetVolume(gomock.Any(), 7).Return(&linodego.Volume{ID: 7, Tags: []string{"zz-other", "test-cluster", "machine:test-machine", "env:prod"}}, nil)
+			},
+		},
+		{
+			name: "Error - get volume fails",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetVolume(gomock.Any(), 7).Return(nil, errors.New("api error"))
+			},
+			expectedError: "get volume 7: api error",
+		},
+		{
+			name: "Error - update volume fails",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetVolume(gomock.Any(), 7).Return(&linodego.Volume{ID: 7}, nil)
+				mock.EXPECT().UpdateVolume(gomock.Any(), 7, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "update volume 7 tags: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient:  mockLinodeClient,
+				LinodeCluster: &infrav1alpha2.LinodeCluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-machine"},
+					Spec:       infrav1alpha2.LinodeMachineSpec{Tags: []string{"env:prod"}},
+				},
+				Machine: &clusterv1.Machine{Spec: clusterv1.MachineSpec{Version: ptr.To("v1.30.2")}},
+			}
+
+			err := mScope.ReconcileVolumeTags(context.Background(), 7)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMachineScopeMarkInstanceTerminating(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		expects       func(mock *mock.MockLinodeClient)
+		expectedError string
+	}{
+		{
+			name: "Add terminating tag",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"test-cluster"}}, nil)
+				mock.EXPECT().UpdateInstance(gomock.Any(), 123, linodego.InstanceUpdateOptions{Tags: &[]string{"test-cluster", "capl-terminating"}}).Return(&linodego.Instance{}, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"test-cluster", "capl-terminating"}}, nil)
+			},
+		},
+		{
+			name: "Already tagged",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"capl-terminating", "test-cluster"}}, nil)
+			},
+		},
+		{
+			name: "Error - get instance fails",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(nil, errors.New("api error"))
+			},
+			expectedError: "get instance 123: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{LinodeClient: mockLinodeClient}
+
+			err := mScope.MarkInstanceTerminating(context.Background(), 123)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMachineScopeTagsFromConfigMap(t *testing.T) {
+	t.Parallel()
+
+	ref := ConfigMapReference{Name: "tag-policy", Key: "tags"}
+	configMap := func(data map[string]string) func(context.Context, client.ObjectKey, client.Object, ...client.GetOption) error {
+		return func(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+			assert.Equal(t, client.ObjectKey{Namespace: "default", Name: "tag-policy"}, key)
+			obj.(*corev1.ConfigMap).Data = data
+			return nil
+		}
+	}
+
+	tests := []struct {
+		name          string
+		expects       func(k8s *mock.MockK8sClient)
+		wantTags      []string
+		wantCondition corev1.ConditionStatus
+		wantReason    string
+		expectedError string
+	}{
+		{
+			name: "Rendered tags are merged into the managed tags",
+			expects: func(k8s *mock.MockK8sClient) {
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(configMap(map[string]string{"tags": "team:platform\nrole:{role}, machine:{machine}\n\ntest-cluster\n"}))
+			},
+			wantTags:      []string{"test-cluster", "team:platform", "role:worker", "machine:test-machine"},
+			wantCondition: corev1.ConditionTrue,
+		},
+		{
+			name: "Missing configmap warns",
+			expects: func(k8s *mock.MockK8sClient) {
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "tag-policy"))
+			},
+			wantTags:      []string{"test-cluster"},
+			wantCondition: corev1.ConditionFalse,
+			wantReason:    "ConfigMapNotFound",
+		},
+		{
+			name: "Missing key warns",
+			expects: func(k8s *mock.MockK8sClient) {
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(configMap(map[string]string{"labels": "team:platform"}))
+			},
+			wantTags:      []string{"test-cluster"},
+			wantCondition: corev1.ConditionFalse,
+			wantReason:    "KeyNotFound",
+		},
+		{
+			name: "Error - unknown placeholder",
+			expects: func(k8s *mock.MockK8sClient) {
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(configMap(map[string]string{"tags": "zone:{zone}"}))
+			},
+			expectedError: `tag template "zone:{zone}" has unknown placeholders {zone}`,
+		},
+		{
+			name: "Error - get configmap",
+			expects: func(k8s *mock.MockK8sClient) {
+				k8s.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("api error"))
+			},
+			expectedError: "get tag template configmap tag-policy: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockK8sClient := mock.NewMockK8sClient(ctrl)
+			testcase.expects(mockK8sClient)
+
+			mScope := &MachineScope{
+				Client:        mockK8sClient,
+				LinodeCluster: &infrav1alpha2.LinodeCluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+				LinodeMachine: &infrav1alpha2.LinodeMachine{ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: "default"}},
+			}
+
+			tags, err := mScope.TagsFromConfigMap(context.Background(), ref)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantTags, tags)
+			condition := conditions.Get(mScope.LinodeMachine, ConditionTagTemplateAvailable)
+			require.NotNil(t, condition)
+			assert.Equal(t, testcase.wantCondition, condition.Status)
+			assert.Equal(t, testcase.wantReason, condition.Reason)
+		})
+	}
+}
+
+func TestMachineScopeReconcileManagedTagsFromTemplate(t *testing.T) {
+	t.Parallel()
+
+	ref := &ConfigMapReference{Name: "tag-policy", Key: "tags"}
+
+	tests := []struct {
+		name            string
+		template        map[string]string
+		managedTags     []string
+		expects         func(mock *mock.MockLinodeClient)
+		wantManagedTags []string
+	}{
+		{
+			name:     "Template tags are added",
+			template: map[string]string{"tags": "team:platform"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"test-cluster"}}, nil)
+				mock.EXPECT().UpdateInstance(gomock.Any(), 123, linodego.InstanceUpdateOptions{Tags: &[]string{"test-cluster", "team:platform"}}).
+					Return(&linodego.Instance{}, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"test-cluster", "team:platform"}}, nil)
+			},
+			wantManagedTags: []string{"test-cluster", "team:platform"},
+		},
+		{
+			name:        "Tags dropped from the template are removed",
+			template:    map[string]string{"tags": "team:storage"},
+			managedTags: []string{"test-cluster", "team:platform"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"test-cluster", "team:platform"}}, nil)
+				mock.EXPECT().UpdateInstance(gomock.Any(), 123, linodego.InstanceUpdateOptions{Tags: &[]string{"test-cluster", "team:storage"}}).
+					Return(&linodego.Instance{}, nil)
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Tags: []string{"test-cluster", "team:storage"}}, nil)
+			},
+			wantManagedTags: []string{"test-cluster", "team:storage"},
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			mockK8sClient := mock.NewMockK8sClient(ctrl)
+			testcase.expects(mockLinodeClient)
+			mockK8sClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Namespace: "default", Name: "tag-policy"}, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+					obj.(*corev1.ConfigMap).Data = testcase.template
+					return nil
+				})
+
+			mScope := &MachineScope{
+				Client:        mockK8sClient,
+				LinodeClient:  mockLinodeClient,
+				TagTemplate:   ref,
+				LinodeCluster: &infrav1alpha2.LinodeCluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: "default"},
+					Status:     infrav1alpha2.LinodeMachineStatus{ManagedTags: testcase.managedTags},
+				},
+			}
+
+			require.NoError(t, mScope.ReconcileManagedTags(context.Background(), 123))
+			assert.Equal(t, testcase.wantManagedTags, mScope.LinodeMachine.Status.ManagedTags)
+		})
+	}
+}
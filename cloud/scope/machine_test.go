@@ -3,8 +3,10 @@ package scope
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/linode/linodego"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -47,6 +49,19 @@ func TestValidateMachineScopeParams(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"Valid MachineScopeParams - protected tags",
+			args{
+				params: MachineScopeParams{
+					Cluster:       &clusterv1.Cluster{},
+					Machine:       &clusterv1.Machine{},
+					LinodeCluster: &infrav1alpha2.LinodeCluster{},
+					LinodeMachine: &infrav1alpha2.LinodeMachine{},
+					ProtectedTags: []string{"terraform", "owner:team"},
+				},
+			},
+			false,
+		},
 		{
 			"Invalid MachineScopeParams - empty MachineScopeParams",
 			args{
@@ -395,6 +410,72 @@ func TestMachineScopeGetBootstrapData(t *testing.T) {
 	)
 }
 
+func TestMachineScopeValidateBootstrapSize(t *testing.T) {
+	t.Parallel()
+
+	NewSuite(t, mock.MockK8sClient{}).Run(
+		OneOf(
+			Path(Result("bootstrap data not retrieved", func(ctx context.Context, mck Mock) {
+				mScope := MachineScope{LinodeMachine: &infrav1alpha2.LinodeMachine{}}
+
+				require.ErrorContains(t, mScope.ValidateBootstrapSize(16384), "bootstrap data has not been retrieved")
+			})),
+			Path(
+				Call("able to get secret", func(ctx context.Context, mck Mock) {
+					mck.K8sClient.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, key client.ObjectKey, obj *corev1.Secret, opts ...client.GetOption) error {
+							*obj = corev1.Secret{Data: map[string][]byte{"value": []byte("test-data")}}
+							return nil
+						})
+				}),
+				Result("data fits within limit", func(ctx context.Context, mck Mock) {
+					mScope := MachineScope{
+						Client: mck.K8sClient,
+						Machine: &clusterv1.Machine{
+							Spec: clusterv1.MachineSpec{
+								Bootstrap: clusterv1.Bootstrap{
+									DataSecretName: ptr.To("test-data"),
+								},
+							},
+						},
+						LinodeMachine: &infrav1alpha2.LinodeMachine{},
+					}
+
+					_, err := mScope.GetBootstrapData(ctx)
+					require.NoError(t, err)
+					require.NoError(t, mScope.ValidateBootstrapSize(9))
+				}),
+			),
+			Path(
+				Call("able to get secret", func(ctx context.Context, mck Mock) {
+					mck.K8sClient.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, key client.ObjectKey, obj *corev1.Secret, opts ...client.GetOption) error {
+							*obj = corev1.Secret{Data: map[string][]byte{"value": []byte("test-data")}}
+							return nil
+						})
+				}),
+				Result("data exceeds limit", func(ctx context.Context, mck Mock) {
+					mScope := MachineScope{
+						Client: mck.K8sClient,
+						Machine: &clusterv1.Machine{
+							Spec: clusterv1.MachineSpec{
+								Bootstrap: clusterv1.Bootstrap{
+									DataSecretName: ptr.To("test-data"),
+								},
+							},
+						},
+						LinodeMachine: &infrav1alpha2.LinodeMachine{},
+					}
+
+					_, err := mScope.GetBootstrapData(ctx)
+					require.NoError(t, err)
+					require.ErrorContains(t, mScope.ValidateBootstrapSize(4), "9 bytes exceeds the limit of 4 bytes")
+				}),
+			),
+		),
+	)
+}
+
 func TestMachineAddCredentialsRefFinalizer(t *testing.T) {
 	t.Parallel()
 	type fields struct {
@@ -582,3 +663,274 @@ func TestMachineRemoveCredentialsRefFinalizer(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineScopeComputeReadiness(t *testing.T) {
+	t.Parallel()
+
+	controlPlane := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{clusterv1.MachineControlPlaneLabel: "true"},
+		},
+	}
+	addresses := []clusterv1.MachineAddress{
+		{Type: clusterv1.MachineExternalIP, Address: "172.0.0.2"},
+		{Type: clusterv1.MachineInternalIP, Address: "192.168.0.2"},
+	}
+
+	tests := []struct {
+		name          string
+		machine       *clusterv1.Machine
+		linodeCluster *infrav1alpha2.LinodeCluster
+		linodeMachine *infrav1alpha2.LinodeMachine
+		expects       func(mock *mock.MockLinodeClient)
+		wantReady     bool
+		wantReasons   []string
+		expectedError string
+	}{
+		{
+			name:          "Not ready - instance not created",
+			machine:       &clusterv1.Machine{},
+			linodeCluster: &infrav1alpha2.LinodeCluster{},
+			linodeMachine: &infrav1alpha2.LinodeMachine{},
+			expects:       func(mock *mock.MockLinodeClient) {},
+			wantReasons:   []string{"instance has not been created"},
+		},
+		{
+			name:          "Ready - worker instance running with ready disks",
+			machine:       &clusterv1.Machine{},
+			linodeCluster: &infrav1alpha2.LinodeCluster{},
+			linodeMachine: &infrav1alpha2.LinodeMachine{Spec: infrav1alpha2.LinodeMachineSpec{InstanceID: ptr.To(123)}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceDisk{{Label: "root", Status: linodego.DiskReady}}, nil)
+			},
+			wantReady: true,
+		},
+		{
+			name:          "Not ready - instance offline and disk not ready",
+			machine:       &clusterv1.Machine{},
+			linodeCluster: &infrav1alpha2.LinodeCluster{},
+			linodeMachine: &infrav1alpha2.LinodeMachine{Spec: infrav1alpha2.LinodeMachineSpec{InstanceID: ptr.To(123)}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceOffline}, nil)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceDisk{{Label: "root", Status: linodego.DiskNotReady}}, nil)
+			},
+			wantReasons: []string{"instance is offline", "disk root is not ready"},
+		},
+		{
+			name:    "Ready - control plane backend is up on the NodeBalancer",
+			machine: controlPlane,
+			linodeCluster: &infrav1alpha2.LinodeCluster{
+				Spec: infrav1alpha2.LinodeClusterSpec{
+					Network: infrav1alpha2.NetworkSpec{
+						NodeBalancerID:                ptr.To(1),
+						ApiserverNodeBalancerConfigID: ptr.To(2),
+					},
+				},
+			},
+			linodeMachine: &infrav1alpha2.LinodeMachine{
+				Spec:   infrav1alpha2.LinodeMachineSpec{InstanceID: ptr.To(123)},
+				Status: infrav1alpha2.LinodeMachineStatus{Addresses: addresses},
+			},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return(nil, nil)
+				mock.EXPECT().ListNodeBalancerNodes(gomock.Any(), 1, 2, gomock.Any()).Return([]linodego.NodeBalancerNode{{Address: "192.168.0.2:6443", Status: "UP"}}, nil)
+			},
+			wantReady: true,
+		},
+		{
+			name:    "Not ready - control plane DNS record missing",
+			machine: controlPlane,
+			linodeCluster: &infrav1alpha2.LinodeCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				Spec: infrav1alpha2.LinodeClusterSpec{
+					Network: infrav1alpha2.NetworkSpec{
+						LoadBalancerType:    "dns",
+						DNSRootDomain:       "lkedevs.net",
+						DNSUniqueIdentifier: "abc123",
+					},
+				},
+			},
+			linodeMachine: &infrav1alpha2.LinodeMachine{
+				Spec:   infrav1alpha2.LinodeMachineSpec{InstanceID: ptr.To(123)},
+				Status: infrav1alpha2.LinodeMachineStatus{Addresses: addresses},
+			},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return(nil, nil)
+				mock.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return([]linodego.Domain{{ID: 1, Domain: "lkedevs.net"}}, nil)
+				mock.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).Return(nil, nil)
+			},
+			wantReasons: []string{"dns record A for 172.0.0.2 is not registered"},
+		},
+		{
+			name:    "Ready - control plane DNS record without unique identifier",
+			machine: controlPlane,
+			linodeCluster: &infrav1alpha2.LinodeCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				Spec: infrav1alpha2.LinodeClusterSpec{
+					Network: infrav1alpha2.NetworkSpec{
+						LoadBalancerType: "dns",
+						DNSRootDomain:    "lkedevs.net",
+					},
+				},
+			},
+			linodeMachine: &infrav1alpha2.LinodeMachine{
+				Spec:   infrav1alpha2.LinodeMachineSpec{InstanceID: ptr.To(123)},
+				Status: infrav1alpha2.LinodeMachineStatus{Addresses: addresses},
+			},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceRunning}, nil)
+				mock.EXPECT().ListInstanceDisks(gomock.Any(), 123, gomock.Any()).Return(nil, nil)
+				mock.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return([]linodego.Domain{{ID: 1, Domain: "lkedevs.net"}}, nil)
+				mock.EXPECT().ListDomainRecords(gomock.Any(), 1, linodego.NewListOptions(0, `{"name":"test-cluster","target":"172.0.0.2","type":"A"}`)).
+					Return([]linodego.DomainRecord{{Type: linodego.RecordTypeA, Name: "test-cluster", Target: "172.0.0.2"}}, nil)
+			},
+			wantReady: true,
+		},
+		{
+			name:          "Error - failed to get instance",
+			machine:       &clusterv1.Machine{},
+			linodeCluster: &infrav1alpha2.LinodeCluster{},
+			linodeMachine: &infrav1alpha2.LinodeMachine{Spec: infrav1alpha2.LinodeMachineSpec{InstanceID: ptr.To(123)}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstance(gomock.Any(), 123).Return(nil, errors.New("api error"))
+			},
+			expectedError: "api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				Machine:             testcase.machine,
+				LinodeClient:        mockLinodeClient,
+				LinodeDomainsClient: mockLinodeClient,
+				LinodeCluster:       testcase.linodeCluster,
+				LinodeMachine:       testcase.linodeMachine,
+			}
+
+			ready, reasons, err := mScope.ComputeReadiness(context.Background())
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantReady, ready)
+			assert.Equal(t, testcase.wantReasons, reasons)
+		})
+	}
+}
+
+func TestMachineScopeSetInfoAnnotations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		spec        infrav1alpha2.LinodeMachineSpec
+		want        map[string]string
+	}{
+		{
+			name: "Annotations are added",
+			spec: infrav1alpha2.LinodeMachineSpec{InstanceID: ptr.To(123), Region: "us-ord", Type: "g6-standard-2"},
+			want: map[string]string{
+				infrav1alpha2.InstanceIDAnnotation: "123",
+				infrav1alpha2.RegionAnnotation:     "us-ord",
+				infrav1alpha2.TypeAnnotation:       "g6-standard-2",
+			},
+		},
+		{
+			name:        "Unrelated annotations are preserved and stale values updated",
+			annotations: map[string]string{"other": "value", infrav1alpha2.TypeAnnotation: "g6-standard-1"},
+			spec:        infrav1alpha2.LinodeMachineSpec{InstanceID: ptr.To(123), Region: "us-ord", Type: "g6-standard-2"},
+			want: map[string]string{
+				"other":                            "value",
+				infrav1alpha2.InstanceIDAnnotation: "123",
+				infrav1alpha2.RegionAnnotation:     "us-ord",
+				infrav1alpha2.TypeAnnotation:       "g6-standard-2",
+			},
+		},
+		{
+			name:        "Instance ID annotation is removed once the instance is gone",
+			annotations: map[string]string{infrav1alpha2.InstanceIDAnnotation: "123"},
+			spec:        infrav1alpha2.LinodeMachineSpec{Region: "us-ord", Type: "g6-standard-2"},
+			want: map[string]string{
+				infrav1alpha2.RegionAnnotation: "us-ord",
+				infrav1alpha2.TypeAnnotation:   "g6-standard-2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mScope := &MachineScope{
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{Annotations: testcase.annotations},
+					Spec:       testcase.spec,
+				},
+			}
+
+			mScope.SetInfoAnnotations()
+			assert.Equal(t, testcase.want, mScope.LinodeMachine.Annotations)
+
+			// Applying again must not change anything.
+			mScope.SetInfoAnnotations()
+			assert.Equal(t, testcase.want, mScope.LinodeMachine.Annotations)
+		})
+	}
+}
+
+func TestMachineScopeDebugSnapshot(t *testing.T) {
+	t.Parallel()
+
+	mScope := &MachineScope{
+		LinodeCluster: &infrav1alpha2.LinodeCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+			Spec: infrav1alpha2.LinodeClusterSpec{
+				CredentialsRef: &corev1.SecretReference{Name: "cluster-creds"},
+			},
+		},
+		LinodeMachine: &infrav1alpha2.LinodeMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: "default"},
+			Spec: infrav1alpha2.LinodeMachineSpec{
+				ProviderID: ptr.To("linode://123"),
+				InstanceID: ptr.To(123),
+				Region:     "us-ord",
+				Type:       "g6-standard-2",
+				RootPass:   "hunter2",
+				Tags:       []string{"extra"},
+			},
+		},
+	}
+
+	snapshot := mScope.DebugSnapshot()
+
+	assert.Equal(t, "default/test-machine", snapshot["linodeMachine"])
+	assert.Equal(t, "default/test-cluster", snapshot["linodeCluster"])
+	assert.Equal(t, "linode://123", snapshot["providerID"])
+	assert.Equal(t, 123, snapshot["instanceID"])
+	assert.Equal(t, "LinodeCluster secret default/cluster-creds", snapshot["credentialSource"])
+	assert.Equal(t, map[string]any{
+		"region": "us-ord",
+		"type":   "g6-standard-2",
+		"image":  "linode/ubuntu22.04",
+		"tags":   []string{"extra", "test-cluster"},
+	}, snapshot["resolved"])
+
+	spec, ok := snapshot["spec"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "<redacted>", spec["rootPass"])
+	assert.NotContains(t, fmt.Sprint(snapshot), "hunter2")
+}
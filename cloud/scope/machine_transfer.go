@@ -0,0 +1,62 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/util/reconciler"
+)
+
+// bytesPerGB converts the instance transfer usage, which Linode reports in bytes, to GB.
+const bytesPerGB = 1024 * 1024 * 1024
+
+// UpdateTransferPoolStatus records the instance's network transfer for the current month
+// in the status, along with the usage of the transfer pool it contributes to. Regions with
+// their own transfer pool are reported separately by Linode; all other instances share the
+// account-wide pool. Usage changes slowly, so the status is only refreshed once it is
+// older than DefaultMachineControllerTransferStatusInterval. The status is informational,
+// so callers should treat errors as non-fatal.
+func (s *MachineScope) UpdateTransferPoolStatus(ctx context.Context, now time.Time) error {
+	if s.LinodeMachine.Spec.InstanceID == nil {
+		return errors.New("instance has not been created yet")
+	}
+	if current := s.LinodeMachine.Status.Transfer; current != nil && current.UpdatedAt != nil &&
+		now.Before(current.UpdatedAt.Add(reconciler.DefaultMachineControllerTransferStatusInterval)) {
+		return nil
+	}
+	instanceID := *s.LinodeMachine.Spec.InstanceID
+
+	instanceTransfer, err := s.LinodeClient.GetInstanceTransfer(ctx, instanceID)
+	if err != nil {
+		return fmt.Errorf("get instance %d transfer: %w", instanceID, err)
+	}
+	accountTransfer, err := s.LinodeClient.GetAccountTransfer(ctx)
+	if err != nil {
+		return fmt.Errorf("get account transfer: %w", err)
+	}
+
+	transfer := &infrav1alpha2.TransferStatus{
+		Quota:        instanceTransfer.Quota,
+		Used:         instanceTransfer.Used / bytesPerGB,
+		PoolQuota:    accountTransfer.Quota,
+		PoolUsed:     accountTransfer.Used,
+		PoolBillable: accountTransfer.Billable,
+		UpdatedAt:    &metav1.Time{Time: now},
+	}
+	for _, region := range accountTransfer.RegionTransfers {
+		if region.ID != s.LinodeMachine.Spec.Region {
+			continue
+		}
+		transfer.PoolQuota = region.Quota
+		transfer.PoolUsed = region.Used
+		transfer.PoolBillable = region.Billable
+	}
+	s.LinodeMachine.Status.Transfer = transfer
+
+	return nil
+}
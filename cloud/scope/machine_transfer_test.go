@@ -0,0 +1,127 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+	"github.com/linode/cluster-api-provider-linode/util"
+)
+
+func TestMachineScopeUpdateTransferPoolStatus(t *testing.T) {
+	t.Parallel()
+
+	accountTransfer := &linodego.AccountTransfer{
+		Quota:    10000,
+		Used:     2500,
+		Billable: 0,
+		RegionTransfers: []linodego.AccountTransferRegion{
+			{ID: "id-cgk", Quota: 1000, Used: 1200, Billable: 200},
+		},
+	}
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	refreshed := &metav1.Time{Time: now}
+
+	tests := []struct {
+		name           string
+		region         string
+		instanceID     *int
+		current        *infrav1alpha2.TransferStatus
+		expects        func(mock *mock.MockLinodeClient)
+		expectedStatus *infrav1alpha2.TransferStatus
+		expectedError  string
+	}{
+		{
+			name:       "Account pool",
+			region:     "us-east",
+			instanceID: util.Pointer(123),
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceTransfer(gomock.Any(), 123).Return(&linodego.InstanceTransfer{Quota: 1000, Used: 5 * bytesPerGB}, nil)
+				mock.EXPECT().GetAccountTransfer(gomock.Any()).Return(accountTransfer, nil)
+			},
+			expectedStatus: &infrav1alpha2.TransferStatus{Quota: 1000, Used: 5, PoolQuota: 10000, PoolUsed: 2500, UpdatedAt: refreshed},
+		},
+		{
+			name:       "Regional pool",
+			region:     "id-cgk",
+			instanceID: util.Pointer(123),
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceTransfer(gomock.Any(), 123).Return(&linodego.InstanceTransfer{Quota: 1000, Used: 700 * bytesPerGB}, nil)
+				mock.EXPECT().GetAccountTransfer(gomock.Any()).Return(accountTransfer, nil)
+			},
+			expectedStatus: &infrav1alpha2.TransferStatus{Quota: 1000, Used: 700, PoolQuota: 1000, PoolUsed: 1200, PoolBillable: 200, UpdatedAt: refreshed},
+		},
+		{
+			name:       "Skip recently refreshed status",
+			region:     "us-east",
+			instanceID: util.Pointer(123),
+			current:    &infrav1alpha2.TransferStatus{Quota: 1000, Used: 1, UpdatedAt: &metav1.Time{Time: now.Add(-30 * time.Minute)}},
+			expects:    func(mock *mock.MockLinodeClient) {},
+			expectedStatus: &infrav1alpha2.TransferStatus{
+				Quota: 1000, Used: 1, UpdatedAt: &metav1.Time{Time: now.Add(-30 * time.Minute)},
+			},
+		},
+		{
+			name:       "Refresh stale status",
+			region:     "us-east",
+			instanceID: util.Pointer(123),
+			current:    &infrav1alpha2.TransferStatus{Quota: 1000, Used: 1, UpdatedAt: &metav1.Time{Time: now.Add(-2 * time.Hour)}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceTransfer(gomock.Any(), 123).Return(&linodego.InstanceTransfer{Quota: 1000, Used: 5 * bytesPerGB}, nil)
+				mock.EXPECT().GetAccountTransfer(gomock.Any()).Return(accountTransfer, nil)
+			},
+			expectedStatus: &infrav1alpha2.TransferStatus{Quota: 1000, Used: 5, PoolQuota: 10000, PoolUsed: 2500, UpdatedAt: refreshed},
+		},
+		{
+			name:          "Error - no instance",
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expectedError: "instance has not been created yet",
+		},
+		{
+			name:       "Error - get account transfer fails",
+			instanceID: util.Pointer(123),
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceTransfer(gomock.Any(), 123).Return(&linodego.InstanceTransfer{}, nil)
+				mock.EXPECT().GetAccountTransfer(gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "get account transfer: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec:   infrav1alpha2.LinodeMachineSpec{Region: testcase.region, InstanceID: testcase.instanceID},
+					Status: infrav1alpha2.LinodeMachineStatus{Transfer: testcase.current},
+				},
+			}
+
+			err := mScope.UpdateTransferPoolStatus(context.Background(), now)
+			assert.Equal(t, testcase.expectedStatus, mScope.LinodeMachine.Status.Transfer)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
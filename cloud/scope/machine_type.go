@@ -0,0 +1,54 @@
+package scope
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/linode/linodego"
+)
+
+// InstanceType returns the type the instance was created with, falling back to the
+// spec's type before one has been resolved.
+func (s *MachineScope) InstanceType() string {
+	if s.LinodeMachine.Status.InstanceType != "" {
+		return s.LinodeMachine.Status.InstanceType
+	}
+
+	return s.LinodeMachine.Spec.Type
+}
+
+// ResolveInstanceType picks the type to create the instance with: the spec's type if
+// it is available in the machine's region, otherwise the first available of the spec's
+// fallback types. Types the region does not report availability for are assumed to be
+// available. The chosen type is recorded in the status and returned on later calls, so
+// the instance keeps its type once created. An error is returned if none are available.
+func (s *MachineScope) ResolveInstanceType(ctx context.Context) (string, error) {
+	if s.LinodeMachine.Status.InstanceType != "" {
+		return s.LinodeMachine.Status.InstanceType, nil
+	}
+	spec := s.LinodeMachine.Spec
+	if len(spec.FallbackTypes) == 0 {
+		return spec.Type, nil
+	}
+
+	availabilities, err := s.LinodeClient.ListRegionsAvailability(ctx, &linodego.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("list region availability: %w", err)
+	}
+	candidates := append([]string{spec.Type}, spec.FallbackTypes...)
+	for _, candidate := range candidates {
+		unavailable := slices.ContainsFunc(availabilities, func(availability linodego.RegionAvailability) bool {
+			return availability.Region == spec.Region && availability.Plan == candidate && !availability.Available
+		})
+		if unavailable {
+			continue
+		}
+		s.LinodeMachine.Status.InstanceType = candidate
+
+		return candidate, nil
+	}
+
+	return "", fmt.Errorf("none of types %s are available in region %s", strings.Join(candidates, ", "), spec.Region)
+}
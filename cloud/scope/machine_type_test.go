@@ -0,0 +1,116 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeResolveInstanceType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		fallbackTypes []string
+		resolved      string
+		expects       func(mock *mock.MockLinodeClient)
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "No fallback types",
+			expects:  func(mock *mock.MockLinodeClient) {},
+			expected: "g6-dedicated-4",
+		},
+		{
+			name:          "Already resolved",
+			fallbackTypes: []string{"g6-standard-4"},
+			resolved:      "g6-standard-4",
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expected:      "g6-standard-4",
+		},
+		{
+			name:          "Preferred type available",
+			fallbackTypes: []string{"g6-standard-4"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListRegionsAvailability(gomock.Any(), gomock.Any()).Return([]linodego.RegionAvailability{
+					{Region: "us-east", Plan: "g6-dedicated-4", Available: true},
+					{Region: "us-west", Plan: "g6-dedicated-4", Available: false},
+				}, nil)
+			},
+			expected: "g6-dedicated-4",
+		},
+		{
+			name:          "Fall back to the first available type",
+			fallbackTypes: []string{"g6-standard-4", "g6-standard-6"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListRegionsAvailability(gomock.Any(), gomock.Any()).Return([]linodego.RegionAvailability{
+					{Region: "us-east", Plan: "g6-dedicated-4", Available: false},
+					{Region: "us-east", Plan: "g6-standard-4", Available: false},
+				}, nil)
+			},
+			expected: "g6-standard-6",
+		},
+		{
+			name:          "Error - no type available",
+			fallbackTypes: []string{"g6-standard-4"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListRegionsAvailability(gomock.Any(), gomock.Any()).Return([]linodego.RegionAvailability{
+					{Region: "us-east", Plan: "g6-dedicated-4", Available: false},
+					{Region: "us-east", Plan: "g6-standard-4", Available: false},
+				}, nil)
+			},
+			expectedError: "none of types g6-dedicated-4, g6-standard-4 are available in region us-east",
+		},
+		{
+			name:          "Error - list availability fails",
+			fallbackTypes: []string{"g6-standard-4"},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListRegionsAvailability(gomock.Any(), gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "list region availability: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{
+						Region:        "us-east",
+						Type:          "g6-dedicated-4",
+						FallbackTypes: testcase.fallbackTypes,
+					},
+					Status: infrav1alpha2.LinodeMachineStatus{InstanceType: testcase.resolved},
+				},
+			}
+
+			instanceType, err := mScope.ResolveInstanceType(context.Background())
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				assert.Empty(t, mScope.LinodeMachine.Status.InstanceType)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.expected, instanceType)
+			assert.Equal(t, testcase.expected, mScope.InstanceType())
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package scope
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// userDataContentTypes are the part content types understood by cloud-init.
+var userDataContentTypes = []string{
+	"text/cloud-boothook",
+	"text/cloud-config",
+	"text/cloud-config-archive",
+	"text/jinja2",
+	"text/part-handler",
+	"text/x-include-once-url",
+	"text/x-include-url",
+	"text/x-shellscript",
+	"text/x-shellscript-per-boot",
+	"text/x-shellscript-per-instance",
+	"text/x-shellscript-per-once",
+}
+
+// userDataPrefixContentTypes maps the first line prefixes cloud-init uses to detect the type
+// of a user-data document to the matching part content type.
+var userDataPrefixContentTypes = []struct {
+	prefix      string
+	contentType string
+}{
+	{"#cloud-config-archive", "text/cloud-config-archive"},
+	{"#cloud-config", "text/cloud-config"},
+	{"#cloud-boothook", "text/cloud-boothook"},
+	{"#include-once", "text/x-include-once-url"},
+	{"#include", "text/x-include-url"},
+	{"#part-handler", "text/part-handler"},
+	{"## template: jinja", "text/jinja2"},
+	{"#!", "text/x-shellscript"},
+}
+
+// UserDataPart is a single document of a multipart cloud-init user-data.
+type UserDataPart struct {
+	// ContentType is the cloud-init content type of the part, e.g. text/cloud-config.
+	ContentType string
+	// Filename is the optional name of the part, used by cloud-init for logging and ordering.
+	Filename string
+	// Content is the document itself.
+	Content []byte
+}
+
+// BuildMultipartUserData assembles the parts into a MIME multipart document that cloud-init
+// processes part by part, in order. Every part must have a content type understood by
+// cloud-init.
+func BuildMultipartUserData(parts []UserDataPart) ([]byte, error) {
+	if len(parts) == 0 {
+		return nil, errors.New("multipart user-data requires at least one part")
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for i, part := range parts {
+		if !slices.Contains(userDataContentTypes, part.ContentType) {
+			return nil, fmt.Errorf("user-data part %d has unsupported content type %q", i, part.ContentType)
+		}
+		if bytes.Contains(part.Content, []byte("--"+writer.Boundary())) {
+			return nil, fmt.Errorf("user-data part %d contains the multipart boundary", i)
+		}
+
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", part.ContentType+`; charset="utf-8"`)
+		header.Set("MIME-Version", "1.0")
+		header.Set("Content-Transfer-Encoding", "7bit")
+		if part.Filename != "" {
+			header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", part.Filename))
+		}
+		partWriter, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, fmt.Errorf("create user-data part %d: %w", i, err)
+		}
+		if _, err := partWriter.Write(part.Content); err != nil {
+			return nil, fmt.Errorf("write user-data part %d: %w", i, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("close multipart user-data: %w", err)
+	}
+
+	var document bytes.Buffer
+	fmt.Fprintf(&document, "Content-Type: multipart/mixed; boundary=%q\r\n", writer.Boundary())
+	document.WriteString("MIME-Version: 1.0\r\n\r\n")
+	document.Write(body.Bytes())
+
+	return document.Bytes(), nil
+}
+
+// userDataContentType detects the cloud-init content type of a user-data document from its
+// first line, the same way cloud-init does.
+func userDataContentType(data []byte) (string, error) {
+	for _, p := range userDataPrefixContentTypes {
+		if bytes.HasPrefix(data, []byte(p.prefix)) {
+			return p.contentType, nil
+		}
+	}
+
+	return "", errors.New("unable to detect the content type of the user-data")
+}
+
+// BootstrapUserData returns the bootstrap data merged with the extra parts into a multipart
+// user-data document, with the bootstrap data as the first part. The bootstrap data is
+// returned unchanged when there are no extra parts.
+func (m *MachineScope) BootstrapUserData(ctx context.Context, extra ...UserDataPart) ([]byte, error) {
+	bootstrapData := m.bootstrapData
+	if bootstrapData == nil {
+		var err error
+		if bootstrapData, err = m.GetBootstrapData(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if len(extra) == 0 {
+		return bootstrapData, nil
+	}
+
+	contentType, err := userDataContentType(bootstrapData)
+	if err != nil {
+		return nil, fmt.Errorf("bootstrap data: %w", err)
+	}
+	parts := append([]UserDataPart{{ContentType: contentType, Filename: "bootstrap", Content: bootstrapData}}, extra...)
+
+	return BuildMultipartUserData(parts)
+}
+
+// SecretKeyRef references a key of a Secret holding a cloud-init user-data document.
+type SecretKeyRef struct {
+	// Namespace is the namespace of the Secret, defaulting to the LinodeMachine's namespace.
+	Namespace string
+	// Name is the name of the Secret.
+	Name string
+	// Key is the key of the Secret holding the document.
+	Key string
+}
+
+// AssembleUserData reads the user-data documents referenced by refs and assembles them into a
+// multipart user-data document, one part per reference in the given order, so cloud-init can be
+// composed from Secrets owned by different teams. An error naming the reference is returned if
+// a Secret or key does not exist, or if the type of a document cannot be detected.
+func (m *MachineScope) AssembleUserData(ctx context.Context, refs []SecretKeyRef) ([]byte, error) {
+	parts := make([]UserDataPart, 0, len(refs))
+	for _, ref := range refs {
+		key := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+		if key.Namespace == "" {
+			key.Namespace = m.LinodeMachine.Namespace
+		}
+
+		secret := &corev1.Secret{}
+		if err := m.Client.Get(ctx, key, secret); err != nil {
+			return nil, fmt.Errorf("get user-data secret %s: %w", key, err)
+		}
+		content, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("user-data secret %s has no %s key", key, ref.Key)
+		}
+		contentType, err := userDataContentType(content)
+		if err != nil {
+			return nil, fmt.Errorf("user-data secret %s key %s: %w", key, ref.Key, err)
+		}
+
+		parts = append(parts, UserDataPart{
+			ContentType: contentType,
+			Filename:    ref.Name + "-" + ref.Key,
+			Content:     content,
+		})
+	}
+
+	return BuildMultipartUserData(parts)
+}
@@ -0,0 +1,241 @@
+package scope
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+// parseMultipartUserData returns the content type and content of each part of a multipart user-data.
+func parseMultipartUserData(t *testing.T, data []byte) [][2]string {
+	t.Helper()
+
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	require.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/mixed", mediaType)
+
+	var parts [][2]string
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(part)
+		require.NoError(t, err)
+		contentType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		parts = append(parts, [2]string{contentType, string(content)})
+	}
+
+	return parts
+}
+
+func TestBuildMultipartUserData(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		parts         []UserDataPart
+		wantParts     [][2]string
+		expectedError string
+	}{
+		{
+			name: "Multiple parts",
+			parts: []UserDataPart{
+				{ContentType: "text/cloud-config", Content: []byte("#cloud-config\nruncmd: []\n")},
+				{ContentType: "text/x-shellscript", Filename: "extra.sh", Content: []byte("#!/bin/sh\necho hello\n")},
+			},
+			wantParts: [][2]string{
+				{"text/cloud-config", "#cloud-config\nruncmd: []\n"},
+				{"text/x-shellscript", "#!/bin/sh\necho hello\n"},
+			},
+		},
+		{
+			name:          "Error - no parts",
+			expectedError: "requires at least one part",
+		},
+		{
+			name: "Error - unsupported content type",
+			parts: []UserDataPart{
+				{ContentType: "text/cloud-config", Content: []byte("#cloud-config\n")},
+				{ContentType: "application/json", Content: []byte("{}")},
+			},
+			expectedError: "user-data part 1 has unsupported content type \"application/json\"",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := BuildMultipartUserData(testcase.parts)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantParts, parseMultipartUserData(t, data))
+		})
+	}
+}
+
+func TestMachineScopeBootstrapUserData(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		bootstrapData []byte
+		extra         []UserDataPart
+		wantParts     [][2]string
+		wantData      []byte
+		expectedError string
+	}{
+		{
+			name:          "Bootstrap data without extra parts",
+			bootstrapData: []byte("#cloud-config\n"),
+			wantData:      []byte("#cloud-config\n"),
+		},
+		{
+			name:          "Bootstrap data merged with extra parts",
+			bootstrapData: []byte("## template: jinja\n#cloud-config\n"),
+			extra:         []UserDataPart{{ContentType: "text/x-shellscript", Content: []byte("#!/bin/sh\n")}},
+			wantParts: [][2]string{
+				{"text/jinja2", "## template: jinja\n#cloud-config\n"},
+				{"text/x-shellscript", "#!/bin/sh\n"},
+			},
+		},
+		{
+			name:          "Error - unknown bootstrap format",
+			bootstrapData: []byte(`{"ignition": {}}`),
+			extra:         []UserDataPart{{ContentType: "text/x-shellscript", Content: []byte("#!/bin/sh\n")}},
+			expectedError: "bootstrap data: unable to detect the content type",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mScope := &MachineScope{
+				LinodeMachine: &infrav1alpha2.LinodeMachine{},
+				bootstrapData: testcase.bootstrapData,
+			}
+
+			data, err := mScope.BootstrapUserData(context.Background(), testcase.extra...)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			if testcase.wantParts != nil {
+				assert.Equal(t, testcase.wantParts, parseMultipartUserData(t, data))
+			} else {
+				assert.Equal(t, testcase.wantData, data)
+			}
+		})
+	}
+}
+
+func TestMachineScopeAssembleUserData(t *testing.T) {
+	t.Parallel()
+
+	secrets := map[client.ObjectKey]map[string][]byte{
+		{Namespace: "default", Name: "base"}:       {"cloud-config": []byte("#cloud-config\nusers: []\n")},
+		{Namespace: "network", Name: "networking"}: {"script": []byte("#!/bin/sh\nip link\n")},
+		{Namespace: "default", Name: "app"}:        {"config": []byte("{}")},
+	}
+	getSecret := func(k8sClient *mock.MockK8sClient) {
+		k8sClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, key client.ObjectKey, obj *corev1.Secret, opts ...client.GetOption) error {
+				data, ok := secrets[key]
+				if !ok {
+					return apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, key.Name)
+				}
+				obj.Data = data
+				return nil
+			}).AnyTimes()
+	}
+
+	tests := []struct {
+		name          string
+		refs          []SecretKeyRef
+		wantParts     [][2]string
+		expectedError string
+	}{
+		{
+			name: "Parts in declared order",
+			refs: []SecretKeyRef{
+				{Namespace: "network", Name: "networking", Key: "script"},
+				{Name: "base", Key: "cloud-config"},
+			},
+			wantParts: [][2]string{
+				{"text/x-shellscript", "#!/bin/sh\nip link\n"},
+				{"text/cloud-config", "#cloud-config\nusers: []\n"},
+			},
+		},
+		{
+			name:          "Error - missing secret",
+			refs:          []SecretKeyRef{{Name: "base", Key: "cloud-config"}, {Name: "missing", Key: "cloud-config"}},
+			expectedError: "get user-data secret default/missing",
+		},
+		{
+			name:          "Error - missing key",
+			refs:          []SecretKeyRef{{Name: "base", Key: "script"}},
+			expectedError: "user-data secret default/base has no script key",
+		},
+		{
+			name:          "Error - unknown document type",
+			refs:          []SecretKeyRef{{Name: "app", Key: "config"}},
+			expectedError: "user-data secret default/app key config: unable to detect the content type",
+		},
+		{
+			name:          "Error - no refs",
+			expectedError: "multipart user-data requires at least one part",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockK8sClient := mock.NewMockK8sClient(ctrl)
+			getSecret(mockK8sClient)
+
+			mScope := &MachineScope{
+				Client:        mockK8sClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}},
+			}
+
+			data, err := mScope.AssembleUserData(context.Background(), testcase.refs)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantParts, parseMultipartUserData(t, data))
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package scope
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+
+	"github.com/linode/linodego"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+)
+
+// ReconcileVLANInterface sets the label and static IPAM address of the VLAN interface of the
+// instance's boot config profile to those of the spec's VLAN interface, e.g. for point-to-point
+// links addressed with a /30. Nothing is done when the spec's VLAN interface has no static
+// address. The address must be an IPv4 address in CIDR notation that no other LinodeMachine
+// of the cluster uses on the same VLAN. Linode only applies interface changes on boot, so an
+// error wrapping ErrRebootRequired is returned alongside changed when the instance is running.
+func (s *MachineScope) ReconcileVLANInterface(ctx context.Context, instanceID int) (bool, error) {
+	idx := slices.IndexFunc(s.LinodeMachine.Spec.Interfaces, isVLANInterface)
+	if idx < 0 || s.LinodeMachine.Spec.Interfaces[idx].IPAMAddress == "" {
+		return false, nil
+	}
+	vlan := s.LinodeMachine.Spec.Interfaces[idx]
+
+	prefix, err := netip.ParsePrefix(vlan.IPAMAddress)
+	if err != nil || !prefix.Addr().Is4() {
+		return false, fmt.Errorf("vlan ipam address %q is not a valid IPv4 address in CIDR notation", vlan.IPAMAddress)
+	}
+	if err := s.validateVLANAddressUnique(ctx, vlan.Label, prefix.Addr()); err != nil {
+		return false, err
+	}
+
+	configs, err := s.LinodeClient.ListInstanceConfigs(ctx, instanceID, &linodego.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("list instance configs: %w", err)
+	}
+	config, err := s.bootConfig(configs, instanceID)
+	if err != nil {
+		return false, err
+	}
+
+	ifaceIdx := slices.IndexFunc(config.Interfaces, func(iface linodego.InstanceConfigInterface) bool {
+		return iface.Purpose == linodego.InterfacePurposeVLAN
+	})
+	if ifaceIdx >= 0 && config.Interfaces[ifaceIdx].Label == vlan.Label && config.Interfaces[ifaceIdx].IPAMAddress == vlan.IPAMAddress {
+		return false, nil
+	}
+
+	interfaces := make([]linodego.InstanceConfigInterfaceCreateOptions, 0, len(config.Interfaces)+1)
+	for _, iface := range config.Interfaces {
+		interfaces = append(interfaces, iface.GetCreateOptions())
+	}
+	desired := linodego.InstanceConfigInterfaceCreateOptions{
+		Purpose:     linodego.InterfacePurposeVLAN,
+		Label:       vlan.Label,
+		IPAMAddress: vlan.IPAMAddress,
+	}
+	if ifaceIdx >= 0 {
+		interfaces[ifaceIdx] = desired
+	} else {
+		interfaces = append(interfaces, desired)
+	}
+	if _, err := s.LinodeClient.UpdateInstanceConfig(ctx, instanceID, config.ID, linodego.InstanceConfigUpdateOptions{Interfaces: interfaces}); err != nil {
+		return false, fmt.Errorf("update instance config %d interfaces: %w", config.ID, err)
+	}
+
+	instance, err := s.LinodeClient.GetInstance(ctx, instanceID)
+	if err != nil {
+		return true, fmt.Errorf("get instance %d: %w", instanceID, err)
+	}
+	if instance.Status != linodego.InstanceOffline {
+		return true, fmt.Errorf("set vlan %s address to %s: %w", vlan.Label, vlan.IPAMAddress, ErrRebootRequired)
+	}
+
+	return true, nil
+}
+
+// validateVLANAddressUnique returns an error if another LinodeMachine of the cluster has a
+// VLAN interface on the same VLAN with the same address.
+func (s *MachineScope) validateVLANAddressUnique(ctx context.Context, label string, addr netip.Addr) error {
+	var machines infrav1alpha2.LinodeMachineList
+	if err := s.Client.List(ctx, &machines,
+		client.InNamespace(s.LinodeMachine.Namespace),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: s.Cluster.Name},
+	); err != nil {
+		return fmt.Errorf("list linodemachines: %w", err)
+	}
+
+	for _, machine := range machines.Items {
+		if machine.Name == s.LinodeMachine.Name {
+			continue
+		}
+		for _, iface := range machine.Spec.Interfaces {
+			if !isVLANInterface(iface) || iface.Label != label {
+				continue
+			}
+			if prefix, err := netip.ParsePrefix(iface.IPAMAddress); err == nil && prefix.Addr() == addr {
+				return fmt.Errorf("vlan ipam address %s is already used on vlan %s by linodemachine %s", addr, label, machine.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// isVLANInterface reports whether the interface is a VLAN interface.
+func isVLANInterface(iface infrav1alpha2.InstanceConfigInterfaceCreateOptions) bool {
+	return iface.Purpose == linodego.InterfacePurposeVLAN
+}
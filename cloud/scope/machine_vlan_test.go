@@ -0,0 +1,152 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeReconcileVLANInterface(t *testing.T) {
+	t.Parallel()
+
+	vlanSpec := func(address string) []infrav1alpha2.InstanceConfigInterfaceCreateOptions {
+		return []infrav1alpha2.InstanceConfigInterfaceCreateOptions{
+			{Purpose: linodego.InterfacePurposePublic},
+			{Purpose: linodego.InterfacePurposeVLAN, Label: "storage", IPAMAddress: address},
+		}
+	}
+	listMachines := func(k8sClient *mock.MockK8sClient, address string) {
+		k8sClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, list *infrav1alpha2.LinodeMachineList, opts ...client.ListOption) error {
+				list.Items = []infrav1alpha2.LinodeMachine{
+					{ObjectMeta: metav1.ObjectMeta{Name: "test-machine"}, Spec: infrav1alpha2.LinodeMachineSpec{Interfaces: vlanSpec("10.0.0.1/30")}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "peer-machine"}, Spec: infrav1alpha2.LinodeMachineSpec{Interfaces: vlanSpec(address)}},
+				}
+				return nil
+			})
+	}
+	configs := func(vlanAddress string) []linodego.InstanceConfig {
+		return []linodego.InstanceConfig{{ID: 7, Interfaces: []linodego.InstanceConfigInterface{
+			{Purpose: linodego.InterfacePurposePublic},
+			{Purpose: linodego.InterfacePurposeVLAN, Label: "storage", IPAMAddress: vlanAddress},
+		}}}
+	}
+
+	tests := []struct {
+		name          string
+		interfaces    []infrav1alpha2.InstanceConfigInterfaceCreateOptions
+		expects       func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient)
+		wantChanged   bool
+		expectedError error
+		errorContains string
+	}{
+		{
+			name:       "No static VLAN address",
+			interfaces: vlanSpec(""),
+			expects:    func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {},
+		},
+		{
+			name:       "VLAN interface already in place",
+			interfaces: vlanSpec("10.0.0.1/30"),
+			expects: func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {
+				listMachines(k8sClient, "10.0.0.2/30")
+				linodeClient.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs("10.0.0.1/30"), nil)
+			},
+		},
+		{
+			name:       "Set address of offline instance",
+			interfaces: vlanSpec("10.0.0.1/30"),
+			expects: func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {
+				listMachines(k8sClient, "10.0.0.2/30")
+				linodeClient.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs(""), nil)
+				linodeClient.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 7, linodego.InstanceConfigUpdateOptions{
+					Interfaces: []linodego.InstanceConfigInterfaceCreateOptions{
+						{Purpose: linodego.InterfacePurposePublic},
+						{Purpose: linodego.InterfacePurposeVLAN, Label: "storage", IPAMAddress: "10.0.0.1/30"},
+					},
+				}).Return(&linodego.InstanceConfig{}, nil)
+				linodeClient.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceOffline}, nil)
+			},
+			wantChanged: true,
+		},
+		{
+			name:       "Set address of running instance requires reboot",
+			interfaces: vlanSpec("10.0.0.1/30"),
+			expects: func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {
+				listMachines(k8sClient, "10.0.0.2/30")
+				linodeClient.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs("10.0.0.5/30"), nil)
+				linodeClient.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 7, gomock.Any()).Return(&linodego.InstanceConfig{}, nil)
+				linodeClient.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceRunning}, nil)
+			},
+			wantChanged:   true,
+			expectedError: ErrRebootRequired,
+		},
+		{
+			name:          "Error - invalid address",
+			interfaces:    vlanSpec("10.0.0.1"),
+			expects:       func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {},
+			errorContains: `vlan ipam address "10.0.0.1" is not a valid IPv4 address in CIDR notation`,
+		},
+		{
+			name:       "Error - address used by another machine",
+			interfaces: vlanSpec("10.0.0.1/30"),
+			expects: func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {
+				listMachines(k8sClient, "10.0.0.1/30")
+			},
+			errorContains: "vlan ipam address 10.0.0.1 is already used on vlan storage by linodemachine peer-machine",
+		},
+		{
+			name:       "Error - list machines fails",
+			interfaces: vlanSpec("10.0.0.1/30"),
+			expects: func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {
+				k8sClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("k8s error"))
+			},
+			errorContains: "list linodemachines: k8s error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			mockK8sClient := mock.NewMockK8sClient(ctrl)
+			testcase.expects(mockLinodeClient, mockK8sClient)
+
+			mScope := &MachineScope{
+				Client:       mockK8sClient,
+				LinodeClient: mockLinodeClient,
+				Cluster:      &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: "default"},
+					Spec:       infrav1alpha2.LinodeMachineSpec{Interfaces: testcase.interfaces},
+				},
+			}
+
+			changed, err := mScope.ReconcileVLANInterface(context.Background(), 123)
+			assert.Equal(t, testcase.wantChanged, changed)
+			switch {
+			case testcase.expectedError != nil:
+				require.ErrorIs(t, err, testcase.expectedError)
+			case testcase.errorContains != "":
+				require.ErrorContains(t, err, testcase.errorContains)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
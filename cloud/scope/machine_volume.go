@@ -0,0 +1,144 @@
+package scope
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/linode/linodego"
+)
+
+// volumeDeviceSlots are the devices of a config profile volumes can be attached as, in order.
+var volumeDeviceSlots = []string{"sdb", "sdc", "sdd", "sde", "sdf", "sdg", "sdh"}
+
+// configDevice returns the device of the config profile device map for the slot.
+func configDevice(devices *linodego.InstanceConfigDeviceMap, slot string) **linodego.InstanceConfigDevice {
+	switch slot {
+	case "sda":
+		return &devices.SDA
+	case "sdb":
+		return &devices.SDB
+	case "sdc":
+		return &devices.SDC
+	case "sdd":
+		return &devices.SDD
+	case "sde":
+		return &devices.SDE
+	case "sdf":
+		return &devices.SDF
+	case "sdg":
+		return &devices.SDG
+	case "sdh":
+		return &devices.SDH
+	default:
+		return nil
+	}
+}
+
+// ReconcileVolumes attaches the spec's volumes to the instance's boot config profile.
+// Volumes with a device slot are placed in that slot, moving them if they are attached
+// as another device, so their device paths are the same on every boot. The remaining
+// volumes are attached to the first free device. An error is returned, and nothing is
+// changed, if a requested slot is taken by a disk or another volume. It reports whether
+// the config profile was updated.
+func (s *MachineScope) ReconcileVolumes(ctx context.Context, instanceID int) (bool, error) {
+	volumes := s.LinodeMachine.Spec.Volumes
+	if len(volumes) == 0 {
+		return false, nil
+	}
+
+	configs, err := s.LinodeClient.ListInstanceConfigs(ctx, instanceID, &linodego.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("list instance configs: %w", err)
+	}
+	config, err := s.bootConfig(configs, instanceID)
+	if err != nil {
+		return false, err
+	}
+	if config.Devices == nil {
+		config.Devices = &linodego.InstanceConfigDeviceMap{}
+	}
+	devices := *config.Devices
+
+	// slotOf returns the slot the volume is currently attached as.
+	slotOf := func(volumeID int) string {
+		for _, slot := range volumeDeviceSlots {
+			if device := *configDevice(&devices, slot); device != nil && device.VolumeID == volumeID {
+				return slot
+			}
+		}
+		return ""
+	}
+
+	changed := false
+	requested := map[string]int{}
+	for _, volume := range volumes {
+		if volume.DeviceSlot == "" {
+			continue
+		}
+		if other, ok := requested[volume.DeviceSlot]; ok {
+			return false, fmt.Errorf("volumes %d and %d both request device %s", other, volume.VolumeID, volume.DeviceSlot)
+		}
+		requested[volume.DeviceSlot] = volume.VolumeID
+
+		device := configDevice(&devices, volume.DeviceSlot)
+		if device == nil {
+			return false, fmt.Errorf("volume %d requests unknown device %q", volume.VolumeID, volume.DeviceSlot)
+		}
+		if *device != nil {
+			if (*device).VolumeID == volume.VolumeID {
+				continue
+			}
+			if (*device).DiskID != 0 {
+				return false, fmt.Errorf("device %s requested by volume %d is taken by disk %d", volume.DeviceSlot, volume.VolumeID, (*device).DiskID)
+			}
+			if !s.specVolume((*device).VolumeID) {
+				return false, fmt.Errorf("device %s requested by volume %d is taken by volume %d", volume.DeviceSlot, volume.VolumeID, (*device).VolumeID)
+			}
+		}
+		if slot := slotOf(volume.VolumeID); slot != "" {
+			*configDevice(&devices, slot) = nil
+		}
+		*device = &linodego.InstanceConfigDevice{VolumeID: volume.VolumeID}
+		changed = true
+	}
+
+	for _, volume := range volumes {
+		if volume.DeviceSlot != "" || slotOf(volume.VolumeID) != "" {
+			continue
+		}
+		placed := false
+		for _, slot := range volumeDeviceSlots {
+			if _, ok := requested[slot]; ok {
+				continue
+			}
+			if device := configDevice(&devices, slot); *device == nil {
+				*device = &linodego.InstanceConfigDevice{VolumeID: volume.VolumeID}
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			return false, fmt.Errorf("no free device for volume %d", volume.VolumeID)
+		}
+		changed = true
+	}
+
+	if !changed {
+		return false, nil
+	}
+	if _, err := s.LinodeClient.UpdateInstanceConfig(ctx, instanceID, config.ID, linodego.InstanceConfigUpdateOptions{Devices: &devices}); err != nil {
+		return false, fmt.Errorf("update instance config %d devices: %w", config.ID, err)
+	}
+
+	return true, nil
+}
+
+// specVolume reports whether the volume is one of the spec's volumes.
+func (s *MachineScope) specVolume(volumeID int) bool {
+	for _, volume := range s.LinodeMachine.Spec.Volumes {
+		if volume.VolumeID == volumeID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeReconcileVolumes(t *testing.T) {
+	t.Parallel()
+
+	rootDisk := &linodego.InstanceConfigDevice{DiskID: 1}
+	configWith := func(devices linodego.InstanceConfigDeviceMap) []linodego.InstanceConfig {
+		return []linodego.InstanceConfig{{ID: 7, Devices: &devices}}
+	}
+
+	tests := []struct {
+		name          string
+		volumes       []infrav1alpha2.InstanceVolume
+		expects       func(mock *mock.MockLinodeClient)
+		wantChanged   bool
+		expectedError string
+	}{
+		{
+			name:    "No volumes",
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:    "Attach volumes to requested and free devices",
+			volumes: []infrav1alpha2.InstanceVolume{{VolumeID: 10}, {VolumeID: 11, DeviceSlot: "sdb"}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).
+					Return(configWith(linodego.InstanceConfigDeviceMap{SDA: rootDisk}), nil)
+				mock.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 7, linodego.InstanceConfigUpdateOptions{
+					Devices: &linodego.InstanceConfigDeviceMap{
+						SDA: rootDisk,
+						SDB: &linodego.InstanceConfigDevice{VolumeID: 11},
+						SDC: &linodego.InstanceConfigDevice{VolumeID: 10},
+					},
+				}).Return(&linodego.InstanceConfig{}, nil)
+			},
+			wantChanged: true,
+		},
+		{
+			name:    "Move volume to the requested device",
+			volumes: []infrav1alpha2.InstanceVolume{{VolumeID: 10, DeviceSlot: "sdd"}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).
+					Return(configWith(linodego.InstanceConfigDeviceMap{SDA: rootDisk, SDB: &linodego.InstanceConfigDevice{VolumeID: 10}}), nil)
+				mock.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 7, linodego.InstanceConfigUpdateOptions{
+					Devices: &linodego.InstanceConfigDeviceMap{
+						SDA: rootDisk,
+						SDD: &linodego.InstanceConfigDevice{VolumeID: 10},
+					},
+				}).Return(&linodego.InstanceConfig{}, nil)
+			},
+			wantChanged: true,
+		},
+		{
+			name:    "Swap volumes between devices",
+			volumes: []infrav1alpha2.InstanceVolume{{VolumeID: 10, DeviceSlot: "sdb"}, {VolumeID: 11, DeviceSlot: "sdc"}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).
+					Return(configWith(linodego.InstanceConfigDeviceMap{
+						SDB: &linodego.InstanceConfigDevice{VolumeID: 11},
+						SDC: &linodego.InstanceConfigDevice{VolumeID: 10},
+					}), nil)
+				mock.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 7, linodego.InstanceConfigUpdateOptions{
+					Devices: &linodego.InstanceConfigDeviceMap{
+						SDB: &linodego.InstanceConfigDevice{VolumeID: 10},
+						SDC: &linodego.InstanceConfigDevice{VolumeID: 11},
+					},
+				}).Return(&linodego.InstanceConfig{}, nil)
+			},
+			wantChanged: true,
+		},
+		{
+			name:    "Volumes already in place",
+			volumes: []infrav1alpha2.InstanceVolume{{VolumeID: 10, DeviceSlot: "sdc"}, {VolumeID: 11}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).
+					Return(configWith(linodego.InstanceConfigDeviceMap{
+						SDC: &linodego.InstanceConfigDevice{VolumeID: 10},
+						SDE: &linodego.InstanceConfigDevice{VolumeID: 11},
+					}), nil)
+			},
+		},
+		{
+			name:    "Error - device taken by a disk",
+			volumes: []infrav1alpha2.InstanceVolume{{VolumeID: 10, DeviceSlot: "sdb"}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).
+					Return(configWith(linodego.InstanceConfigDeviceMap{SDB: &linodego.InstanceConfigDevice{DiskID: 2}}), nil)
+			},
+			expectedError: "device sdb requested by volume 10 is taken by disk 2",
+		},
+		{
+			name:    "Error - device taken by another volume",
+			volumes: []infrav1alpha2.InstanceVolume{{VolumeID: 10, DeviceSlot: "sdb"}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).
+					Return(configWith(linodego.InstanceConfigDeviceMap{SDB: &linodego.InstanceConfigDevice{VolumeID: 20}}), nil)
+			},
+			expectedError: "device sdb requested by volume 10 is taken by volume 20",
+		},
+		{
+			name:    "Error - volumes request the same device",
+			volumes: []infrav1alpha2.InstanceVolume{{VolumeID: 10, DeviceSlot: "sdb"}, {VolumeID: 11, DeviceSlot: "sdb"}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).
+					Return(configWith(linodego.InstanceConfigDeviceMap{}), nil)
+			},
+			expectedError: "volumes 10 and 11 both request device sdb",
+		},
+		{
+			name:    "Error - update fails",
+			volumes: []infrav1alpha2.InstanceVolume{{VolumeID: 10}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).
+					Return(configWith(linodego.InstanceConfigDeviceMap{}), nil)
+				mock.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 7, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "update instance config 7 devices: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{Volumes: testcase.volumes},
+				},
+			}
+
+			changed, err := mScope.ReconcileVolumes(context.Background(), 123)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantChanged, changed)
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+
+	"github.com/linode/linodego"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+)
+
+// ErrRebootRequired is returned when a change was applied to the instance's configuration
+// which only takes effect once the instance is rebooted.
+var ErrRebootRequired = errors.New("instance must be rebooted for the change to take effect")
+
+// ReconcileVPCInterface re-points the instance's VPC interface when its subnet no longer exists
+// in the cluster's LinodeVPC, e.g. after the subnet layout was changed. The interface is moved to
+// the least busy subnet of the VPC and changed is reported. Linode only applies interface changes
+// on boot, so an error wrapping ErrRebootRequired is returned alongside changed when the instance
+// is running.
+func (s *MachineScope) ReconcileVPCInterface(ctx context.Context, instanceID int) (bool, error) {
+	vpcRef := s.LinodeCluster.Spec.VPCRef
+	if vpcRef == nil {
+		return false, nil
+	}
+
+	linodeVPC := &infrav1alpha2.LinodeVPC{}
+	key := client.ObjectKey{Namespace: vpcRef.Namespace, Name: vpcRef.Name}
+	if key.Namespace == "" {
+		key.Namespace = s.LinodeCluster.Namespace
+	}
+	if err := s.Client.Get(ctx, key, linodeVPC); err != nil {
+		return false, fmt.Errorf("get linodevpc %s: %w", key, err)
+	}
+	if !linodeVPC.Status.Ready || linodeVPC.Spec.VPCID == nil {
+		return false, fmt.Errorf("linodevpc %s is not available", key)
+	}
+
+	vpc, err := s.LinodeClient.GetVPC(ctx, *linodeVPC.Spec.VPCID)
+	if err != nil {
+		return false, fmt.Errorf("get vpc %d: %w", *linodeVPC.Spec.VPCID, err)
+	}
+	if len(vpc.Subnets) == 0 {
+		return false, fmt.Errorf("vpc %d has no subnets", vpc.ID)
+	}
+
+	configs, err := s.LinodeClient.ListInstanceConfigs(ctx, instanceID, &linodego.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("list instance configs: %w", err)
+	}
+	config, ifaceIdx := findVPCInterface(configs)
+	if config == nil {
+		return false, nil
+	}
+	current := config.Interfaces[ifaceIdx].SubnetID
+	if current != nil && slices.ContainsFunc(vpc.Subnets, func(subnet linodego.VPCSubnet) bool { return subnet.ID == *current }) {
+		return false, nil
+	}
+
+	// Place the interface into the least busy subnet
+	desired := slices.MinFunc(vpc.Subnets, func(a, b linodego.VPCSubnet) int {
+		return len(a.Linodes) - len(b.Linodes)
+	})
+
+	interfaces := make([]linodego.InstanceConfigInterfaceCreateOptions, 0, len(config.Interfaces))
+	for i, iface := range config.Interfaces {
+		opts := iface.GetCreateOptions()
+		if i == ifaceIdx {
+			opts.SubnetID = &desired.ID
+			// The VPC address of the old subnet is not valid in the new one
+			if opts.IPv4 != nil {
+				opts.IPv4.VPC = ""
+			}
+		}
+		interfaces = append(interfaces, opts)
+	}
+	if _, err := s.LinodeClient.UpdateInstanceConfig(ctx, instanceID, config.ID, linodego.InstanceConfigUpdateOptions{Interfaces: interfaces}); err != nil {
+		return false, fmt.Errorf("update instance config %d interfaces: %w", config.ID, err)
+	}
+
+	instance, err := s.LinodeClient.GetInstance(ctx, instanceID)
+	if err != nil {
+		return true, fmt.Errorf("get instance %d: %w", instanceID, err)
+	}
+	if instance.Status != linodego.InstanceOffline {
+		return true, fmt.Errorf("move vpc interface to subnet %d: %w", desired.ID, ErrRebootRequired)
+	}
+
+	return true, nil
+}
+
+// VPCNAT1To1 returns the 1:1 NAT mapping of the instance's VPC interface, which defaults
+// to "any" so the instance keeps public connectivity. It returns nil when the spec removes
+// the mapping.
+func (s *MachineScope) VPCNAT1To1() *string {
+	nat1To1 := s.LinodeMachine.Spec.NAT1To1
+	switch {
+	case nat1To1 == nil:
+		return ptr.To("any")
+	case *nat1To1 == "":
+		return nil
+	default:
+		return ptr.To(*nat1To1)
+	}
+}
+
+// ReconcileVPCNAT sets the 1:1 NAT mapping of the instance's VPC interface to the spec's
+// NAT1To1, leaving the interface untouched when NAT1To1 is not provided. Linode reports
+// the mapped address rather than "any", so any mapping satisfies "any". Linode only
+// applies interface changes on boot, so an error wrapping ErrRebootRequired is returned
+// alongside changed when the instance is running.
+func (s *MachineScope) ReconcileVPCNAT(ctx context.Context, instanceID int) (bool, error) {
+	if s.LinodeMachine.Spec.NAT1To1 == nil {
+		return false, nil
+	}
+	desired := s.VPCNAT1To1()
+
+	configs, err := s.LinodeClient.ListInstanceConfigs(ctx, instanceID, &linodego.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("list instance configs: %w", err)
+	}
+	config, ifaceIdx := findVPCInterface(configs)
+	if config == nil {
+		return false, nil
+	}
+	current := ""
+	if ipv4 := config.Interfaces[ifaceIdx].IPv4; ipv4 != nil && ipv4.NAT1To1 != nil {
+		current = *ipv4.NAT1To1
+	}
+	switch {
+	case desired == nil && current == "",
+		desired != nil && *desired == "any" && current != "",
+		desired != nil && *desired == current:
+		return false, nil
+	}
+
+	interfaces := make([]linodego.InstanceConfigInterfaceCreateOptions, 0, len(config.Interfaces))
+	for i, iface := range config.Interfaces {
+		opts := iface.GetCreateOptions()
+		if i == ifaceIdx {
+			if opts.IPv4 == nil {
+				opts.IPv4 = &linodego.VPCIPv4{}
+			}
+			// The interfaces are replaced as a whole, so an omitted mapping is removed
+			opts.IPv4.NAT1To1 = desired
+		}
+		interfaces = append(interfaces, opts)
+	}
+	if _, err := s.LinodeClient.UpdateInstanceConfig(ctx, instanceID, config.ID, linodego.InstanceConfigUpdateOptions{Interfaces: interfaces}); err != nil {
+		return false, fmt.Errorf("update instance config %d interfaces: %w", config.ID, err)
+	}
+
+	instance, err := s.LinodeClient.GetInstance(ctx, instanceID)
+	if err != nil {
+		return true, fmt.Errorf("get instance %d: %w", instanceID, err)
+	}
+	if instance.Status != linodego.InstanceOffline {
+		return true, fmt.Errorf("set vpc interface nat_1_1 mapping: %w", ErrRebootRequired)
+	}
+
+	return true, nil
+}
+
+// VPCIPv4Subnet returns the ID of the VPC subnet containing the spec's static VPC address.
+// An error is returned if the address is not within any subnet of the VPC, or if it is
+// already assigned to another instance. It returns 0 when no static address is requested.
+func (s *MachineScope) VPCIPv4Subnet(ctx context.Context, vpc *linodego.VPC) (int, error) {
+	vpcIPv4 := s.LinodeMachine.Spec.VPCIPv4
+	if vpcIPv4 == "" {
+		return 0, nil
+	}
+	addr, err := netip.ParseAddr(vpcIPv4)
+	if err != nil || !addr.Is4() {
+		return 0, fmt.Errorf("vpc ipv4 %q is not a valid IPv4 address", vpcIPv4)
+	}
+
+	idx := slices.IndexFunc(vpc.Subnets, func(subnet linodego.VPCSubnet) bool {
+		prefix, err := netip.ParsePrefix(subnet.IPv4)
+		return err == nil && prefix.Contains(addr)
+	})
+	if idx < 0 {
+		return 0, fmt.Errorf("vpc ipv4 %s is not within any subnet of vpc %d", vpcIPv4, vpc.ID)
+	}
+
+	ips, err := s.LinodeClient.ListVPCIPAddresses(ctx, vpc.ID, &linodego.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("list vpc %d ip addresses: %w", vpc.ID, err)
+	}
+	for _, ip := range ips {
+		if ip.Address == nil || *ip.Address != vpcIPv4 {
+			continue
+		}
+		if instanceID := s.LinodeMachine.Spec.InstanceID; instanceID == nil || ip.LinodeID != *instanceID {
+			return 0, fmt.Errorf("vpc ipv4 %s is already assigned to instance %d", vpcIPv4, ip.LinodeID)
+		}
+	}
+
+	return vpc.Subnets[idx].ID, nil
+}
+
+// findVPCInterface returns the first instance config with a VPC interface and the index of that interface.
+func findVPCInterface(configs []linodego.InstanceConfig) (*linodego.InstanceConfig, int) {
+	for i := range configs {
+		for j, iface := range configs[i].Interfaces {
+			if iface.Purpose == linodego.InterfacePurposeVPC {
+				return &configs[i], j
+			}
+		}
+	}
+
+	return nil, -1
+}
@@ -0,0 +1,370 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestMachineScopeReconcileVPCInterface(t *testing.T) {
+	t.Parallel()
+
+	readyVPC := func(k8sClient *mock.MockK8sClient) {
+		k8sClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Namespace: "default", Name: "test-vpc"}, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, key client.ObjectKey, obj *infrav1alpha2.LinodeVPC, opts ...client.GetOption) error {
+				obj.Spec.VPCID = ptr.To(1)
+				obj.Status.Ready = true
+				return nil
+			})
+	}
+	vpc := &linodego.VPC{
+		ID: 1,
+		Subnets: []linodego.VPCSubnet{
+			{ID: 10, Linodes: []linodego.VPCSubnetLinode{{ID: 1}, {ID: 2}}},
+			{ID: 11, Linodes: []linodego.VPCSubnetLinode{{ID: 3}}},
+		},
+	}
+	configs := func(subnetID int) []linodego.InstanceConfig {
+		return []linodego.InstanceConfig{{
+			ID: 100,
+			Interfaces: []linodego.InstanceConfigInterface{
+				{Purpose: linodego.InterfacePurposeVPC, Primary: true, SubnetID: ptr.To(subnetID), IPv4: &linodego.VPCIPv4{VPC: "10.0.0.5", NAT1To1: ptr.To("any")}},
+				{Purpose: linodego.InterfacePurposePublic},
+			},
+		}}
+	}
+
+	tests := []struct {
+		name          string
+		vpcRef        *corev1.ObjectReference
+		expects       func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient)
+		expectChanged bool
+		expectReboot  bool
+		expectedError string
+	}{
+		{
+			name: "Success - No VPC",
+			expects: func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {
+			},
+		},
+		{
+			name:   "Success - Subnet still exists",
+			vpcRef: &corev1.ObjectReference{Name: "test-vpc"},
+			expects: func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {
+				readyVPC(k8sClient)
+				linodeClient.EXPECT().GetVPC(gomock.Any(), 1).Return(vpc, nil)
+				linodeClient.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs(10), nil)
+			},
+		},
+		{
+			name:   "Success - Offline instance is moved to the least busy subnet",
+			vpcRef: &corev1.ObjectReference{Name: "test-vpc"},
+			expects: func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {
+				readyVPC(k8sClient)
+				linodeClient.EXPECT().GetVPC(gomock.Any(), 1).Return(vpc, nil)
+				linodeClient.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs(9), nil)
+				linodeClient.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 100, gomock.Cond(func(x any) bool {
+					opts, ok := x.(linodego.InstanceConfigUpdateOptions)
+					return ok && len(opts.Interfaces) == 2 && *opts.Interfaces[0].SubnetID == 11 &&
+						opts.Interfaces[0].IPv4.VPC == "" && opts.Interfaces[1].Purpose == linodego.InterfacePurposePublic
+				})).Return(&linodego.InstanceConfig{}, nil)
+				linodeClient.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceOffline}, nil)
+			},
+			expectChanged: true,
+		},
+		{
+			name:   "Success - Running instance requires a reboot",
+			vpcRef: &corev1.ObjectReference{Name: "test-vpc"},
+			expects: func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {
+				readyVPC(k8sClient)
+				linodeClient.EXPECT().GetVPC(gomock.Any(), 1).Return(vpc, nil)
+				linodeClient.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs(9), nil)
+				linodeClient.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 100, gomock.Any()).Return(&linodego.InstanceConfig{}, nil)
+				linodeClient.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceRunning}, nil)
+			},
+			expectChanged: true,
+			expectReboot:  true,
+		},
+		{
+			name:   "Error - VPC not ready",
+			vpcRef: &corev1.ObjectReference{Name: "test-vpc"},
+			expects: func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {
+				k8sClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedError: "linodevpc default/test-vpc is not available",
+		},
+		{
+			name:   "Error - Update fails",
+			vpcRef: &corev1.ObjectReference{Name: "test-vpc"},
+			expects: func(linodeClient *mock.MockLinodeClient, k8sClient *mock.MockK8sClient) {
+				readyVPC(k8sClient)
+				linodeClient.EXPECT().GetVPC(gomock.Any(), 1).Return(vpc, nil)
+				linodeClient.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs(9), nil)
+				linodeClient.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 100, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			mockK8sClient := mock.NewMockK8sClient(ctrl)
+			testcase.expects(mockLinodeClient, mockK8sClient)
+
+			mScope := &MachineScope{
+				Client:       mockK8sClient,
+				LinodeClient: mockLinodeClient,
+				LinodeCluster: &infrav1alpha2.LinodeCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+					Spec:       infrav1alpha2.LinodeClusterSpec{VPCRef: testcase.vpcRef},
+				},
+			}
+
+			changed, err := mScope.ReconcileVPCInterface(context.Background(), 123)
+			switch {
+			case testcase.expectedError != "":
+				require.ErrorContains(t, err, testcase.expectedError)
+			case testcase.expectReboot:
+				require.ErrorIs(t, err, ErrRebootRequired)
+			default:
+				require.NoError(t, err)
+			}
+			assert.Equal(t, testcase.expectChanged, changed)
+		})
+	}
+}
+
+func TestMachineScopeReconcileVPCNAT(t *testing.T) {
+	t.Parallel()
+
+	configs := func(nat1To1 *string) []linodego.InstanceConfig {
+		return []linodego.InstanceConfig{{
+			ID: 100,
+			Interfaces: []linodego.InstanceConfigInterface{
+				{Purpose: linodego.InterfacePurposeVPC, Primary: true, SubnetID: ptr.To(10), IPv4: &linodego.VPCIPv4{VPC: "10.0.0.5", NAT1To1: nat1To1}},
+				{Purpose: linodego.InterfacePurposeVLAN, Label: "vlan"},
+			},
+		}}
+	}
+	natUpdate := func(nat1To1 *string) gomock.Matcher {
+		return gomock.Cond(func(x any) bool {
+			opts, ok := x.(linodego.InstanceConfigUpdateOptions)
+			return ok && len(opts.Interfaces) == 2 && *opts.Interfaces[0].SubnetID == 10 && opts.Interfaces[0].IPv4.VPC == "10.0.0.5" &&
+				assert.ObjectsAreEqual(nat1To1, opts.Interfaces[0].IPv4.NAT1To1) && opts.Interfaces[1].Label == "vlan"
+		})
+	}
+
+	tests := []struct {
+		name          string
+		nat1To1       *string
+		expects       func(linodeClient *mock.MockLinodeClient)
+		expectChanged bool
+		expectReboot  bool
+		expectedError string
+	}{
+		{
+			name:    "Success - No NAT setting",
+			expects: func(linodeClient *mock.MockLinodeClient) {},
+		},
+		{
+			name:    "Success - No VPC interface",
+			nat1To1: ptr.To("any"),
+			expects: func(linodeClient *mock.MockLinodeClient) {
+				linodeClient.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceConfig{{ID: 100}}, nil)
+			},
+		},
+		{
+			name:    "Success - Public address already mapped",
+			nat1To1: ptr.To("any"),
+			expects: func(linodeClient *mock.MockLinodeClient) {
+				linodeClient.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs(ptr.To("172.233.0.4")), nil)
+			},
+		},
+		{
+			name:    "Success - Mapping already removed",
+			nat1To1: ptr.To(""),
+			expects: func(linodeClient *mock.MockLinodeClient) {
+				linodeClient.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs(nil), nil)
+			},
+		},
+		{
+			name:    "Success - Offline instance is mapped",
+			nat1To1: ptr.To("any"),
+			expects: func(linodeClient *mock.MockLinodeClient) {
+				linodeClient.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs(nil), nil)
+				linodeClient.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 100, natUpdate(ptr.To("any"))).Return(&linodego.InstanceConfig{}, nil)
+				linodeClient.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceOffline}, nil)
+			},
+			expectChanged: true,
+		},
+		{
+			name:    "Success - Mapping removed from running instance requires a reboot",
+			nat1To1: ptr.To(""),
+			expects: func(linodeClient *mock.MockLinodeClient) {
+				linodeClient.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs(ptr.To("172.233.0.4")), nil)
+				linodeClient.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 100, natUpdate(nil)).Return(&linodego.InstanceConfig{}, nil)
+				linodeClient.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceRunning}, nil)
+			},
+			expectChanged: true,
+			expectReboot:  true,
+		},
+		{
+			name:    "Error - Update fails",
+			nat1To1: ptr.To("172.233.0.4"),
+			expects: func(linodeClient *mock.MockLinodeClient) {
+				linodeClient.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(configs(ptr.To("172.233.0.9")), nil)
+				linodeClient.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 100, natUpdate(ptr.To("172.233.0.4"))).Return(nil, errors.New("api error"))
+			},
+			expectedError: "update instance config 100 interfaces: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{NAT1To1: testcase.nat1To1},
+				},
+			}
+
+			changed, err := mScope.ReconcileVPCNAT(context.Background(), 123)
+			switch {
+			case testcase.expectedError != "":
+				require.ErrorContains(t, err, testcase.expectedError)
+			case testcase.expectReboot:
+				require.ErrorIs(t, err, ErrRebootRequired)
+			default:
+				require.NoError(t, err)
+			}
+			assert.Equal(t, testcase.expectChanged, changed)
+		})
+	}
+}
+
+func TestMachineScopeVPCIPv4Subnet(t *testing.T) {
+	t.Parallel()
+
+	vpc := &linodego.VPC{
+		ID: 5,
+		Subnets: []linodego.VPCSubnet{
+			{ID: 1, IPv4: "10.0.0.0/24"},
+			{ID: 2, IPv4: "10.0.1.0/24"},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		vpcIPv4       string
+		instanceID    *int
+		expects       func(mock *mock.MockLinodeClient)
+		wantSubnetID  int
+		expectedError string
+	}{
+		{
+			name:    "No static address",
+			expects: func(mock *mock.MockLinodeClient) {},
+		},
+		{
+			name:    "Address in the second subnet",
+			vpcIPv4: "10.0.1.10",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListVPCIPAddresses(gomock.Any(), 5, gomock.Any()).
+					Return([]linodego.VPCIP{{Address: ptr.To("10.0.1.11"), LinodeID: 7}}, nil)
+			},
+			wantSubnetID: 2,
+		},
+		{
+			name:       "Address already assigned to this instance",
+			vpcIPv4:    "10.0.0.10",
+			instanceID: ptr.To(7),
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListVPCIPAddresses(gomock.Any(), 5, gomock.Any()).
+					Return([]linodego.VPCIP{{Address: ptr.To("10.0.0.10"), LinodeID: 7}}, nil)
+			},
+			wantSubnetID: 1,
+		},
+		{
+			name:          "Error - not an IPv4 address",
+			vpcIPv4:       "fd00::1",
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expectedError: `vpc ipv4 "fd00::1" is not a valid IPv4 address`,
+		},
+		{
+			name:          "Error - address outside of the subnets",
+			vpcIPv4:       "10.0.2.10",
+			expects:       func(mock *mock.MockLinodeClient) {},
+			expectedError: "vpc ipv4 10.0.2.10 is not within any subnet of vpc 5",
+		},
+		{
+			name:    "Error - address taken by another instance",
+			vpcIPv4: "10.0.0.10",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListVPCIPAddresses(gomock.Any(), 5, gomock.Any()).
+					Return([]linodego.VPCIP{{Address: ptr.To("10.0.0.10"), LinodeID: 8}}, nil)
+			},
+			expectedError: "vpc ipv4 10.0.0.10 is already assigned to instance 8",
+		},
+		{
+			name:    "Error - list fails",
+			vpcIPv4: "10.0.0.10",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().ListVPCIPAddresses(gomock.Any(), 5, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError: "list vpc 5 ip addresses: api error",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			mScope := &MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{VPCIPv4: testcase.vpcIPv4, InstanceID: testcase.instanceID},
+				},
+			}
+
+			subnetID, err := mScope.VPCIPv4Subnet(context.Background(), vpc)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.wantSubnetID, subnetID)
+		})
+	}
+}
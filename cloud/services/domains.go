@@ -79,7 +79,7 @@ func EnsureAkamaiDNSEntries(ctx context.Context, mscope *scope.MachineScope, ope
 	linodeCluster := mscope.LinodeCluster
 	linodeClusterNetworkSpec := linodeCluster.Spec.Network
 	rootDomain := linodeClusterNetworkSpec.DNSRootDomain
-	fqdn := linodeCluster.Name + "-" + linodeClusterNetworkSpec.DNSUniqueIdentifier + "." + rootDomain
+	fqdn := scope.ControlPlaneHostname(linodeCluster) + "." + rootDomain
 	akaDNSClient := mscope.AkamaiDomainsClient
 
 	for _, dnsEntry := range dnsEntries {
@@ -150,7 +150,7 @@ func (d *DNSEntries) getDNSEntriesToEnsure(mscope *scope.MachineScope) ([]DNSOpt
 	if mscope.LinodeMachine.Status.Addresses == nil {
 		return nil, fmt.Errorf("no addresses available on the LinodeMachine resource")
 	}
-	domainHostname := mscope.LinodeCluster.ObjectMeta.Name + "-" + mscope.LinodeCluster.Spec.Network.DNSUniqueIdentifier
+	domainHostname := scope.ControlPlaneHostname(mscope.LinodeCluster)
 
 	for _, IPs := range mscope.LinodeMachine.Status.Addresses {
 		recordType := linodego.RecordTypeA
@@ -173,20 +173,7 @@ func (d *DNSEntries) getDNSEntriesToEnsure(mscope *scope.MachineScope) ([]DNSOpt
 
 // GetDomainID gets the domains linode id
 func GetDomainID(ctx context.Context, mscope *scope.MachineScope) (int, error) {
-	rootDomain := mscope.LinodeCluster.Spec.Network.DNSRootDomain
-	filter, err := json.Marshal(map[string]string{"domain": rootDomain})
-	if err != nil {
-		return 0, err
-	}
-	domains, err := mscope.LinodeDomainsClient.ListDomains(ctx, linodego.NewListOptions(0, string(filter)))
-	if err != nil {
-		return 0, err
-	}
-	if len(domains) != 1 || domains[0].Domain != rootDomain {
-		return 0, fmt.Errorf("domain %s not found in list of domains owned by this account", rootDomain)
-	}
-
-	return domains[0].ID, nil
+	return mscope.ValidateDNSZone(ctx)
 }
 
 func CreateDomainRecord(ctx context.Context, mscope *scope.MachineScope, domainID int, dnsEntry DNSOptions) error {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 
 	"github.com/go-logr/logr"
@@ -122,17 +123,8 @@ func AddNodeToNB(
 		return nil
 	}
 
-	// Get the private IP that was assigned
-	addresses, err := machineScope.LinodeClient.GetInstanceIPAddresses(ctx, *machineScope.LinodeMachine.Spec.InstanceID)
+	privateIP, err := getPrivateIP(ctx, logger, machineScope)
 	if err != nil {
-		logger.Error(err, "Failed get instance IP addresses")
-
-		return err
-	}
-	if len(addresses.IPv4.Private) == 0 {
-		err := errors.New("no private IP address")
-		logger.Error(err, "no private IPV4 addresses set for LinodeInstance")
-
 		return err
 	}
 
@@ -154,7 +146,7 @@ func AddNodeToNB(
 		*machineScope.LinodeCluster.Spec.Network.ApiserverNodeBalancerConfigID,
 		linodego.NodeBalancerNodeCreateOptions{
 			Label:   machineScope.Cluster.Name,
-			Address: fmt.Sprintf("%s:%d", addresses.IPv4.Private[0].Address, apiserverLBPort),
+			Address: fmt.Sprintf("%s:%d", privateIP, apiserverLBPort),
 			Mode:    linodego.ModeAccept,
 		},
 	)
@@ -170,7 +162,7 @@ func AddNodeToNB(
 			*portConfig.NodeBalancerConfigID,
 			linodego.NodeBalancerNodeCreateOptions{
 				Label:   machineScope.Cluster.Name,
-				Address: fmt.Sprintf("%s:%d", addresses.IPv4.Private[0].Address, portConfig.Port),
+				Address: fmt.Sprintf("%s:%d", privateIP, portConfig.Port),
 				Mode:    linodego.ModeAccept,
 			},
 		)
@@ -200,30 +192,76 @@ func DeleteNodeFromNB(
 		return nil
 	}
 
-	err := machineScope.LinodeClient.DeleteNodeBalancerNode(
-		ctx,
-		*machineScope.LinodeCluster.Spec.Network.NodeBalancerID,
-		*machineScope.LinodeCluster.Spec.Network.ApiserverNodeBalancerConfigID,
-		*machineScope.LinodeMachine.Spec.InstanceID,
-	)
-	if util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
-		logger.Error(err, "Failed to update Node Balancer")
-
-		return err
+	configIDs := []int{*machineScope.LinodeCluster.Spec.Network.ApiserverNodeBalancerConfigID}
+	for _, portConfig := range machineScope.LinodeCluster.Spec.Network.AdditionalPorts {
+		configIDs = append(configIDs, *portConfig.NodeBalancerConfigID)
 	}
 
-	for _, portConfig := range machineScope.LinodeCluster.Spec.Network.AdditionalPorts {
-		err = machineScope.LinodeClient.DeleteNodeBalancerNode(
-			ctx,
-			*machineScope.LinodeCluster.Spec.Network.NodeBalancerID,
-			*portConfig.NodeBalancerConfigID,
-			*machineScope.LinodeMachine.Spec.InstanceID,
-		)
-		if util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
-			logger.Error(err, "Failed to update Node Balancer")
+	for _, configID := range configIDs {
+		nodeIDs, err := getNodeBalancerNodeIDs(ctx, machineScope, configID)
+		if err != nil {
+			logger.Error(err, "Failed to list Node Balancer nodes")
 			return err
 		}
+		for _, nodeID := range nodeIDs {
+			err = machineScope.LinodeClient.DeleteNodeBalancerNode(
+				ctx,
+				*machineScope.LinodeCluster.Spec.Network.NodeBalancerID,
+				configID,
+				nodeID,
+			)
+			if util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
+				logger.Error(err, "Failed to update Node Balancer")
+				return err
+			}
+		}
 	}
 
 	return nil
 }
+
+// getPrivateIP returns the private IP used to register the machine as a NodeBalancer backend.
+// External instances are registered using their supplied IP address.
+func getPrivateIP(ctx context.Context, logger logr.Logger, machineScope *scope.MachineScope) (string, error) {
+	if machineScope.IsExternalInstance() {
+		return machineScope.LinodeMachine.Spec.ExternalInstance.IPAddress, nil
+	}
+
+	// Get the private IP that was assigned
+	addresses, err := machineScope.LinodeClient.GetInstanceIPAddresses(ctx, *machineScope.LinodeMachine.Spec.InstanceID)
+	if err != nil {
+		logger.Error(err, "Failed get instance IP addresses")
+
+		return "", err
+	}
+	if len(addresses.IPv4.Private) == 0 {
+		err := errors.New("no private IP address")
+		logger.Error(err, "no private IPV4 addresses set for LinodeInstance")
+
+		return "", err
+	}
+
+	return addresses.IPv4.Private[0].Address, nil
+}
+
+// getNodeBalancerNodeIDs returns the IDs of the machine's backend nodes on a NodeBalancer config.
+// External instances have no Linode instance ID, so their nodes are matched by address.
+func getNodeBalancerNodeIDs(ctx context.Context, machineScope *scope.MachineScope, configID int) ([]int, error) {
+	if !machineScope.IsExternalInstance() {
+		return []int{*machineScope.LinodeMachine.Spec.InstanceID}, nil
+	}
+
+	nodes, err := machineScope.LinodeClient.ListNodeBalancerNodes(ctx, *machineScope.LinodeCluster.Spec.Network.NodeBalancerID, configID, &linodego.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var nodeIDs []int
+	for _, node := range nodes {
+		host, _, err := net.SplitHostPort(node.Address)
+		if err == nil && host == machineScope.LinodeMachine.Spec.ExternalInstance.IPAddress {
+			nodeIDs = append(nodeIDs, node.ID)
+		}
+	}
+
+	return nodeIDs, nil
+}
@@ -632,6 +632,54 @@ func TestAddNodeToNBFullWorkflow(t *testing.T) {
 				mockClient.EXPECT().CreateNodeBalancerNode(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(&linodego.NodeBalancerNode{}, nil)
 			},
 		},
+		{
+			name: "Success - External instance is added using the supplied IP",
+			machineScope: &scope.MachineScope{
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-machine",
+						UID:  "test-uid",
+						Labels: map[string]string{
+							clusterv1.MachineControlPlaneLabel: "true",
+						},
+					},
+				},
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-cluster",
+						UID:  "test-uid",
+					},
+				},
+				LinodeCluster: &infrav1alpha2.LinodeCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-cluster",
+						UID:  "test-uid",
+					},
+					Spec: infrav1alpha2.LinodeClusterSpec{
+						Network: infrav1alpha2.NetworkSpec{
+							NodeBalancerID:                ptr.To(1234),
+							ApiserverNodeBalancerConfigID: ptr.To(5678),
+						},
+					},
+				},
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-machine",
+						UID:  "test-uid",
+					},
+					Spec: infrav1alpha2.LinodeMachineSpec{
+						ExternalInstance: &infrav1alpha2.ExternalInstance{IPAddress: "10.0.0.5"},
+					},
+				},
+			},
+			expects: func(mockClient *mock.MockLinodeClient) {
+				mockClient.EXPECT().CreateNodeBalancerNode(gomock.Any(), 1234, 5678, linodego.NodeBalancerNodeCreateOptions{
+					Label:   "test-cluster",
+					Address: "10.0.0.5:6443",
+					Mode:    linodego.ModeAccept,
+				}).Return(&linodego.NodeBalancerNode{}, nil)
+			},
+		},
 		{
 			name: "Error - CreateNodeBalancerNode() returns an error",
 			machineScope: &scope.MachineScope{
@@ -815,6 +863,49 @@ func TestDeleteNodeFromNB(t *testing.T) {
 				mockClient.EXPECT().DeleteNodeBalancerNode(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 		},
+		{
+			name: "Success - Delete external instance Node matched by address",
+			machineScope: &scope.MachineScope{
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-machine",
+						UID:  "test-uid",
+						Labels: map[string]string{
+							clusterv1.MachineControlPlaneLabel: "true",
+						},
+					},
+				},
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-machine",
+						UID:  "test-uid",
+					},
+					Spec: infrav1alpha2.LinodeMachineSpec{
+						ExternalInstance: &infrav1alpha2.ExternalInstance{IPAddress: "10.0.0.5"},
+					},
+				},
+				LinodeCluster: &infrav1alpha2.LinodeCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-cluster",
+						UID:  "test-uid",
+					},
+					Spec: infrav1alpha2.LinodeClusterSpec{
+						ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "1.2.3.4"},
+						Network: infrav1alpha2.NetworkSpec{
+							NodeBalancerID:                ptr.To(1234),
+							ApiserverNodeBalancerConfigID: ptr.To(5678),
+						},
+					},
+				},
+			},
+			expects: func(mockClient *mock.MockLinodeClient) {
+				mockClient.EXPECT().ListNodeBalancerNodes(gomock.Any(), 1234, 5678, gomock.Any()).Return([]linodego.NodeBalancerNode{
+					{ID: 1, Address: "10.0.0.4:6443"},
+					{ID: 2, Address: "10.0.0.5:6443"},
+				}, nil)
+				mockClient.EXPECT().DeleteNodeBalancerNode(gomock.Any(), 1234, 5678, 2).Return(nil)
+			},
+		},
 		{
 			name: "Error - Deleting Apiserver Node from NodeBalancer",
 			machineScope: &scope.MachineScope{
@@ -21,6 +21,8 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"net/http"
+	"net/netip"
 	"os"
 	"strings"
 	"sync"
@@ -28,11 +30,13 @@ import (
 
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -41,8 +45,10 @@ import (
 
 	infrastructurev1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
 	infrastructurev1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/cloud/scope"
 	"github.com/linode/cluster-api-provider-linode/controller"
 	"github.com/linode/cluster-api-provider-linode/observability/tracing"
+	"github.com/linode/cluster-api-provider-linode/util/reconciler"
 	"github.com/linode/cluster-api-provider-linode/version"
 
 	_ "go.uber.org/automaxprocs"
@@ -80,6 +86,9 @@ func main() {
 		// Environment variables
 		linodeToken    = os.Getenv("LINODE_TOKEN")
 		linodeDNSToken = os.Getenv("LINODE_DNS_TOKEN")
+		// Comma-separated Name=value headers added to every Linode API request, e.g. for an
+		// authenticating proxy. They are read from the environment as they may hold secrets.
+		linodeAPIHeaders = os.Getenv("LINODE_API_HEADERS")
 
 		machineWatchFilter             string
 		clusterWatchFilter             string
@@ -95,6 +104,21 @@ func main() {
 		linodeObjectStorageBucketConcurrency int
 		linodeVPCConcurrency                 int
 		linodePlacementGroupConcurrency      int
+
+		circuitBreakerFailureThreshold int
+		circuitBreakerWindow           time.Duration
+		circuitBreakerCooldown         time.Duration
+
+		enableTopologyTags    bool
+		enableRemediationTags bool
+		waitForDNSPropagation bool
+		readinessGracePeriod  time.Duration
+		shutdownTimeout       time.Duration
+		protectedTags         string
+		clusterLabelTags      string
+		ephemeralTags         string
+		tagTemplate           string
+		managementCIDRs       string
 	)
 	flag.StringVar(&machineWatchFilter, "machine-watch-filter", "", "The machines to watch by label.")
 	flag.StringVar(&clusterWatchFilter, "cluster-watch-filter", "", "The clusters to watch by label.")
@@ -118,6 +142,32 @@ func main() {
 		"Number of LinodeVPCs to process simultaneously. Default 10")
 	flag.IntVar(&linodePlacementGroupConcurrency, "linodeplacementgroup-concurrency", concurrencyDefault,
 		"Number of Linode Placement Groups to process simultaneously. Default 10")
+	flag.IntVar(&circuitBreakerFailureThreshold, "linode-api-failure-threshold", reconciler.DefaultCircuitBreakerFailureThreshold,
+		"Number of Linode API server errors or rate limits within the failure window after which reconciles back off. Default 5")
+	flag.DurationVar(&circuitBreakerWindow, "linode-api-failure-window", reconciler.DefaultCircuitBreakerWindow,
+		"Period over which Linode API failures are counted. Default 1m")
+	flag.DurationVar(&circuitBreakerCooldown, "linode-api-failure-cooldown", reconciler.DefaultCircuitBreakerCooldown,
+		"Period reconciles back off for once the Linode API failure threshold is reached. Default 1m")
+	flag.BoolVar(&enableTopologyTags, "enable-topology-tags", false,
+		"Tag Linode instances with the ClusterClass topology labels of their Machine, e.g. the MachineDeployment name.")
+	flag.BoolVar(&enableRemediationTags, "enable-remediation-tags", false,
+		"Tag Linode instances with remediated:true while their Machine is being remediated by a MachineHealthCheck.")
+	flag.BoolVar(&waitForDNSPropagation, "wait-for-dns-propagation", false,
+		"Wait for the DNS records of control plane machines to resolve on the authoritative nameservers before marking them ready, when the cluster load balancer type is dns.")
+	flag.DurationVar(&readinessGracePeriod, "machine-readiness-grace-period", 0,
+		"Period a Linode instance may be unhealthy, e.g. during a quick reboot, before its machine is marked not ready. Default 0")
+	flag.DurationVar(&shutdownTimeout, "machine-shutdown-timeout", reconciler.DefaultMachineControllerShutdownTimeout,
+		"Period a Linode instance may take to shut down gracefully before it is deleted anyway. Default 2m")
+	flag.StringVar(&protectedTags, "protected-instance-tags", "",
+		"Comma-separated Linode instance tags which are never removed, e.g. because they are managed by Terraform.")
+	flag.StringVar(&clusterLabelTags, "cluster-label-tags", "",
+		"Comma-separated keys of the Cluster labels mirrored onto Linode instance tags as key:value, e.g. team,env.")
+	flag.StringVar(&ephemeralTags, "ephemeral-instance-tags", "",
+		"Comma-separated prefix=TTL pairs of timestamped Linode instance tags which are refreshed periodically and removed once expired, e.g. last-reconciled=24h.")
+	flag.StringVar(&tagTemplate, "tag-template-configmap", "",
+		"The name/key of the ConfigMap key holding the tag template applied to the Linode instances of every machine in the ConfigMap's namespace, e.g. tag-policy/tags.")
+	flag.StringVar(&managementCIDRs, "management-cidrs", "",
+		"Comma-separated egress CIDRs of the management cluster which machine firewalls accept TCP and ICMP traffic from.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -134,6 +184,37 @@ func main() {
 	if linodeDNSToken == "" {
 		linodeDNSToken = linodeToken
 	}
+	ephemeralInstanceTags, err := parseEphemeralTags(ephemeralTags)
+	if err != nil {
+		setupLog.Error(err, "invalid --ephemeral-instance-tags")
+		os.Exit(1)
+	}
+	httpHeaders, err := parseHTTPHeaders(linodeAPIHeaders)
+	if err != nil {
+		setupLog.Error(err, "invalid LINODE_API_HEADERS environment variable")
+		os.Exit(1)
+	}
+	protectedInstanceTags, err := parseProtectedTags(protectedTags)
+	if err != nil {
+		setupLog.Error(err, "invalid --protected-instance-tags")
+		os.Exit(1)
+	}
+	tagTemplateRef, err := parseTagTemplate(tagTemplate)
+	if err != nil {
+		setupLog.Error(err, "invalid --tag-template-configmap")
+		os.Exit(1)
+	}
+	managementCIDRList, err := parseManagementCIDRs(managementCIDRs)
+	if err != nil {
+		setupLog.Error(err, "invalid --management-cidrs")
+		os.Exit(1)
+	}
+
+	scope.SetCircuitBreakerConfig(scope.CircuitBreakerConfig{
+		FailureThreshold: circuitBreakerFailureThreshold,
+		Window:           circuitBreakerWindow,
+		Cooldown:         circuitBreakerCooldown,
+	})
 
 	restConfig := ctrl.GetConfigOrDie()
 	restConfig.QPS = float32(restConfigQPS)
@@ -146,6 +227,9 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "3cfd31c3.cluster.x-k8s.io",
+		// ConfigMaps are only written for boot diagnostics, so they are read directly instead
+		// of through an informer, which would need list and watch on every ConfigMap.
+		Client: client.Options{Cache: &client.CacheOptions{DisableFor: []client.Object{&corev1.ConfigMap{}}}},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -168,17 +252,30 @@ func main() {
 		Recorder:         mgr.GetEventRecorderFor("LinodeClusterReconciler"),
 		WatchFilterValue: clusterWatchFilter,
 		LinodeApiKey:     linodeToken,
+		LinodeDNSAPIKey:  linodeDNSToken,
+		HTTPHeaders:      httpHeaders,
 	}).SetupWithManager(mgr, crcontroller.Options{MaxConcurrentReconciles: linodeClusterConcurrency}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "LinodeCluster")
 		os.Exit(1)
 	}
 
 	if err = (&controller.LinodeMachineReconciler{
-		Client:           mgr.GetClient(),
-		Recorder:         mgr.GetEventRecorderFor("LinodeMachineReconciler"),
-		WatchFilterValue: machineWatchFilter,
-		LinodeApiKey:     linodeToken,
-		LinodeDNSAPIKey:  linodeDNSToken,
+		Client:                mgr.GetClient(),
+		Recorder:              mgr.GetEventRecorderFor("LinodeMachineReconciler"),
+		WatchFilterValue:      machineWatchFilter,
+		LinodeApiKey:          linodeToken,
+		LinodeDNSAPIKey:       linodeDNSToken,
+		HTTPHeaders:           httpHeaders,
+		TopologyTags:          enableTopologyTags,
+		RemediationTags:       enableRemediationTags,
+		WaitForDNSPropagation: waitForDNSPropagation,
+		ReadinessGracePeriod:  readinessGracePeriod,
+		ShutdownTimeout:       shutdownTimeout,
+		ProtectedTags:         protectedInstanceTags,
+		ClusterLabelTags:      splitTags(clusterLabelTags),
+		EphemeralTags:         ephemeralInstanceTags,
+		TagTemplate:           tagTemplateRef,
+		ManagementCIDRs:       managementCIDRList,
 	}).SetupWithManager(mgr, crcontroller.Options{MaxConcurrentReconciles: linodeMachineConcurrency}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "LinodeMachine")
 		os.Exit(1)
@@ -246,6 +343,96 @@ func main() {
 	}
 }
 
+// parseHTTPHeaders parses a comma-separated list of Name=value HTTP headers. Header values
+// may be secrets, so they are never included in the returned errors.
+func parseHTTPHeaders(headers string) (map[string]string, error) {
+	parsed := map[string]string{}
+	for i, header := range splitTags(headers) {
+		name, value, ok := strings.Cut(header, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t:") {
+			return nil, fmt.Errorf("header %d must be a Name=value pair", i+1)
+		}
+		parsed[http.CanonicalHeaderKey(name)] = strings.TrimSpace(value)
+	}
+	if len(parsed) == 0 {
+		return nil, nil
+	}
+
+	return parsed, nil
+}
+
+// parseProtectedTags parses a comma-separated list of protected instance tags.
+func parseProtectedTags(tags string) ([]string, error) {
+	split := splitTags(tags)
+	for _, tag := range split {
+		if err := scope.ValidateTag(tag); err != nil {
+			return nil, fmt.Errorf("protected tag: %w", err)
+		}
+	}
+
+	return split, nil
+}
+
+// parseEphemeralTags parses a comma-separated list of prefix=TTL ephemeral tags.
+func parseEphemeralTags(tags string) ([]scope.EphemeralTag, error) {
+	var ephemeral []scope.EphemeralTag
+	for _, tag := range splitTags(tags) {
+		prefix, ttl, ok := strings.Cut(tag, "=")
+		if !ok {
+			return nil, fmt.Errorf("ephemeral tag %q must be a prefix=TTL pair", tag)
+		}
+		duration, err := time.ParseDuration(ttl)
+		if err != nil {
+			return nil, fmt.Errorf("ephemeral tag %q has an invalid TTL: %w", tag, err)
+		}
+		ephemeralTag := scope.EphemeralTag{Prefix: strings.TrimSpace(prefix), TTL: duration}
+		if err := scope.ValidateEphemeralTag(ephemeralTag); err != nil {
+			return nil, err
+		}
+		ephemeral = append(ephemeral, ephemeralTag)
+	}
+
+	return ephemeral, nil
+}
+
+// parseTagTemplate parses the name/key reference of the tag template ConfigMap key.
+func parseTagTemplate(ref string) (*scope.ConfigMapReference, error) {
+	if ref = strings.TrimSpace(ref); ref == "" {
+		return nil, nil
+	}
+	name, key, ok := strings.Cut(ref, "/")
+	if !ok || name == "" || key == "" {
+		return nil, fmt.Errorf("tag template %q must be a name/key pair", ref)
+	}
+
+	return &scope.ConfigMapReference{Name: name, Key: key}, nil
+}
+
+// parseManagementCIDRs parses a comma-separated list of management cluster egress CIDRs.
+func parseManagementCIDRs(cidrs string) ([]string, error) {
+	split := splitTags(cidrs)
+	for _, cidr := range split {
+		if _, err := netip.ParsePrefix(cidr); err != nil {
+			return nil, fmt.Errorf("management cidr %q: %w", cidr, err)
+		}
+	}
+
+	return split, nil
+}
+
+// splitTags splits a comma-separated list of tags, ignoring empty entries.
+func splitTags(tags string) []string {
+	var split []string
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			split = append(split, tag)
+		}
+	}
+
+	return split
+}
+
 func setupWebhooks(mgr manager.Manager) {
 	var err error
 	if err = (&infrastructurev1alpha1.LinodeCluster{}).SetupWebhookWithManager(mgr); err != nil {
@@ -20,6 +20,11 @@ import (
 	"context"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linode/cluster-api-provider-linode/cloud/scope"
 )
 
 func TestSetupObservabillity(t *testing.T) {
@@ -31,3 +36,214 @@ func TestSetupObservabillity(t *testing.T) {
 	shutdown := setupObservabillity(ctx)
 	shutdown()
 }
+
+func TestParseManagementCIDRs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		cidrs         string
+		expected      []string
+		expectedError string
+	}{
+		{
+			name: "No CIDRs",
+		},
+		{
+			name:     "IPv4 and IPv6 CIDRs",
+			cidrs:    "192.0.2.0/24, 2001:db8::/32",
+			expected: []string{"192.0.2.0/24", "2001:db8::/32"},
+		},
+		{
+			name:          "Error - address without prefix length",
+			cidrs:         "192.0.2.0/24,192.0.2.1",
+			expectedError: "management cidr \"192.0.2.1\"",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cidrs, err := parseManagementCIDRs(testcase.cidrs)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.expected, cidrs)
+		})
+	}
+}
+
+func TestParseTagTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		ref           string
+		expected      *scope.ConfigMapReference
+		expectedError string
+	}{
+		{
+			name: "No tag template",
+		},
+		{
+			name:     "ConfigMap key",
+			ref:      "tag-policy/tags",
+			expected: &scope.ConfigMapReference{Name: "tag-policy", Key: "tags"},
+		},
+		{
+			name:          "Error - missing key",
+			ref:           "tag-policy",
+			expectedError: `tag template "tag-policy" must be a name/key pair`,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ref, err := parseTagTemplate(testcase.ref)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.expected, ref)
+		})
+	}
+}
+
+func TestParseProtectedTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		tags          string
+		expected      []string
+		expectedError string
+	}{
+		{
+			name: "No tags",
+		},
+		{
+			name:     "Valid tags",
+			tags:     "terraform, owner:team",
+			expected: []string{"terraform", "owner:team"},
+		},
+		{
+			name:          "Error - invalid character",
+			tags:          "terraform,owner=team",
+			expectedError: "protected tag: tag \"owner=team\" must only contain",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			tags, err := parseProtectedTags(testcase.tags)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.expected, tags)
+		})
+	}
+}
+
+func TestParseEphemeralTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		tags          string
+		expected      []scope.EphemeralTag
+		expectedError string
+	}{
+		{
+			name: "No tags",
+		},
+		{
+			name:     "Valid tags",
+			tags:     "last-reconciled=24h, seen=1h",
+			expected: []scope.EphemeralTag{{Prefix: "last-reconciled", TTL: 24 * time.Hour}, {Prefix: "seen", TTL: time.Hour}},
+		},
+		{
+			name:          "Error - missing TTL",
+			tags:          "last-reconciled",
+			expectedError: "must be a prefix=TTL pair",
+		},
+		{
+			name:          "Error - invalid TTL",
+			tags:          "last-reconciled=1d",
+			expectedError: "has an invalid TTL",
+		},
+		{
+			name:          "Error - non-positive TTL",
+			tags:          "last-reconciled=0s",
+			expectedError: "ephemeral tag last-reconciled must have a positive TTL",
+		},
+		{
+			name:          "Error - invalid prefix",
+			tags:          "last reconciled=24h",
+			expectedError: "must only contain",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			tags, err := parseEphemeralTags(testcase.tags)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.expected, tags)
+		})
+	}
+}
+
+func TestParseHTTPHeaders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		headers       string
+		expected      map[string]string
+		expectedError string
+	}{
+		{
+			name: "No headers",
+		},
+		{
+			name:     "Headers are canonicalized",
+			headers:  "x-proxy-auth=s3cret, X-Team = platform",
+			expected: map[string]string{"X-Proxy-Auth": "s3cret", "X-Team": "platform"},
+		},
+		{
+			name:          "Error - missing value",
+			headers:       "X-Team=platform,x-proxy-auth:s3cret",
+			expectedError: "header 2 must be a Name=value pair",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			headers, err := parseHTTPHeaders(testcase.headers)
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				assert.NotContains(t, err.Error(), "s3cret")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.expected, headers)
+		})
+	}
+}
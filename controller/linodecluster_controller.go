@@ -54,8 +54,11 @@ type LinodeClusterReconciler struct {
 	client.Client
 	Recorder         record.EventRecorder
 	LinodeApiKey     string
+	LinodeDNSAPIKey  string
 	WatchFilterValue string
 	ReconcileTimeout time.Duration
+	// HTTPHeaders are added to every Linode API request, e.g. for an authenticating proxy.
+	HTTPHeaders map[string]string
 }
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=linodeclusters,verbs=get;list;watch;create;update;patch;delete
@@ -92,10 +95,12 @@ func (r *LinodeClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	clusterScope, err := scope.NewClusterScope(
 		ctx,
 		r.LinodeApiKey,
+		r.LinodeDNSAPIKey,
 		scope.ClusterScopeParams{
 			Client:        r.TracedClient(),
 			Cluster:       cluster,
 			LinodeCluster: linodeCluster,
+			HTTPHeaders:   r.HTTPHeaders,
 		},
 	)
 
@@ -156,6 +161,16 @@ func (r *LinodeClusterReconciler) reconcile(
 		r.Recorder.Event(clusterScope.LinodeCluster, corev1.EventTypeNormal, string(clusterv1.ReadyCondition), "Load balancer is ready")
 	}
 
+	if err := r.reconcileDNSRecords(ctx, clusterScope); err != nil {
+		logger.Error(err, "failed to reconcile DNS records")
+		setFailureReason(clusterScope, cerrs.UpdateClusterError, err, r)
+		if !reconciler.HasConditionSeverity(clusterScope.LinodeCluster, clusterv1.ReadyCondition, clusterv1.ConditionSeverityError) {
+			logger.Info("re-queuing cluster DNS records")
+			return ctrl.Result{RequeueAfter: reconciler.DefaultClusterControllerReconcileDelay}, nil
+		}
+		return res, err
+	}
+
 	clusterScope.LinodeCluster.Status.Ready = true
 	conditions.MarkTrue(clusterScope.LinodeCluster, clusterv1.ReadyCondition)
 
@@ -232,7 +247,7 @@ func (r *LinodeClusterReconciler) handleNBCreate(ctx context.Context, logger log
 }
 
 func (r *LinodeClusterReconciler) handleDNS(clusterScope *scope.ClusterScope) {
-	domainName := clusterScope.LinodeCluster.ObjectMeta.Name + "-" + clusterScope.LinodeCluster.Spec.Network.DNSUniqueIdentifier + "." + clusterScope.LinodeCluster.Spec.Network.DNSRootDomain
+	domainName := scope.ControlPlaneHostname(clusterScope.LinodeCluster) + "." + clusterScope.LinodeCluster.Spec.Network.DNSRootDomain
 	apiLBPort := services.DefaultApiserverLBPort
 	if clusterScope.LinodeCluster.Spec.Network.ApiserverLoadBalancerPort != 0 {
 		apiLBPort = clusterScope.LinodeCluster.Spec.Network.ApiserverLoadBalancerPort
@@ -243,8 +258,35 @@ func (r *LinodeClusterReconciler) handleDNS(clusterScope *scope.ClusterScope) {
 	}
 }
 
+// reconcileDNSRecords publishes the IP of the NodeBalancer and the CNAME records of the DNS
+// aliases under the DNS root domain, when one is configured. The records are removed when the
+// LinodeCluster is being deleted.
+func (r *LinodeClusterReconciler) reconcileDNSRecords(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	network := clusterScope.LinodeCluster.Spec.Network
+	if network.DNSRootDomain == "" {
+		return nil
+	}
+	if nbIP := clusterScope.LinodeCluster.Spec.ControlPlaneEndpoint.Host; nbIP != "" && network.LoadBalancerType != "dns" {
+		if err := clusterScope.ReconcileNodeBalancerDNS(ctx, nbIP); err != nil {
+			return fmt.Errorf("reconcile nodebalancer dns record: %w", err)
+		}
+	}
+	for _, alias := range network.DNSAliases {
+		if err := clusterScope.ReconcileCNAME(ctx, alias, ""); err != nil {
+			return fmt.Errorf("reconcile dns alias %s: %w", alias, err)
+		}
+	}
+
+	return nil
+}
+
 func (r *LinodeClusterReconciler) reconcileDelete(ctx context.Context, logger logr.Logger, clusterScope *scope.ClusterScope) error {
 	logger.Info("deleting cluster")
+	if err := r.reconcileDNSRecords(ctx, clusterScope); err != nil {
+		logger.Error(err, "failed to delete DNS records")
+		setFailureReason(clusterScope, cerrs.DeleteClusterError, err, r)
+		return err
+	}
 	if clusterScope.LinodeCluster.Spec.Network.NodeBalancerID == nil {
 		logger.Info("NodeBalancer ID is missing, nothing to do")
 
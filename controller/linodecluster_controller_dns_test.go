@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	cerrs "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/cloud/scope"
+	"github.com/linode/cluster-api-provider-linode/mock"
+)
+
+func TestReconcileDeleteDNSRecords(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+	linodeCluster := func(rootDomain string) *infrav1alpha2.LinodeCluster {
+		return &infrav1alpha2.LinodeCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "test-cluster",
+				DeletionTimestamp: &now,
+				Finalizers:        []string{infrav1alpha2.ClusterFinalizer},
+			},
+			Spec: infrav1alpha2.LinodeClusterSpec{
+				ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "172.0.0.10", Port: 6443},
+				Network: infrav1alpha2.NetworkSpec{
+					NodeBalancerID:      ptr.To(7),
+					DNSRootDomain:       rootDomain,
+					DNSUniqueIdentifier: "abc123",
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		linodeCluster   *infrav1alpha2.LinodeCluster
+		expects         func(linode, domains *mock.MockLinodeClient)
+		expectedError   string
+		expectFinalizer bool
+	}{
+		{
+			name:          "NodeBalancer record is removed with the NodeBalancer",
+			linodeCluster: linodeCluster("lkedevs.net"),
+			expects: func(linode, domains *mock.MockLinodeClient) {
+				domains.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return([]linodego.Domain{{ID: 1, Domain: "lkedevs.net"}}, nil)
+				domains.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{{ID: 5, Type: linodego.RecordTypeA, Name: "test-cluster-abc123", Target: "172.0.0.10"}}, nil)
+				domains.EXPECT().DeleteDomainRecord(gomock.Any(), 1, 5).Return(nil)
+				linode.EXPECT().DeleteNodeBalancer(gomock.Any(), 7).Return(nil)
+			},
+		},
+		{
+			name: "DNS aliases are removed with the NodeBalancer",
+			linodeCluster: func() *infrav1alpha2.LinodeCluster {
+				cluster := linodeCluster("lkedevs.net")
+				cluster.Spec.Network.DNSAliases = []string{"api"}
+				return cluster
+			}(),
+			expects: func(linode, domains *mock.MockLinodeClient) {
+				domains.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return([]linodego.Domain{{ID: 1, Domain: "lkedevs.net"}}, nil)
+				domains.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{{ID: 5, Type: linodego.RecordTypeA, Name: "test-cluster-abc123", Target: "172.0.0.10"}}, nil)
+				domains.EXPECT().DeleteDomainRecord(gomock.Any(), 1, 5).Return(nil)
+				domains.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).
+					Return([]linodego.DomainRecord{{ID: 6, Type: linodego.RecordTypeCNAME, Name: "api", Target: "test-cluster-abc123.lkedevs.net"}}, nil)
+				domains.EXPECT().DeleteDomainRecord(gomock.Any(), 1, 6).Return(nil)
+				linode.EXPECT().DeleteNodeBalancer(gomock.Any(), 7).Return(nil)
+			},
+		},
+		{
+			name:          "Cluster without DNS root domain only removes the NodeBalancer",
+			linodeCluster: linodeCluster(""),
+			expects: func(linode, domains *mock.MockLinodeClient) {
+				linode.EXPECT().DeleteNodeBalancer(gomock.Any(), 7).Return(nil)
+			},
+		},
+		{
+			name:          "Failed record removal keeps the NodeBalancer and finalizer",
+			linodeCluster: linodeCluster("lkedevs.net"),
+			expects: func(linode, domains *mock.MockLinodeClient) {
+				domains.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return([]linodego.Domain{{ID: 1, Domain: "lkedevs.net"}}, nil)
+				domains.EXPECT().ListDomainRecords(gomock.Any(), 1, gomock.Any()).Return(nil, errors.New("api error"))
+			},
+			expectedError:   "api error",
+			expectFinalizer: true,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			mockDomainsClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient, mockDomainsClient)
+
+			clusterScope := &scope.ClusterScope{
+				LinodeClient:        mockLinodeClient,
+				LinodeDomainsClient: mockDomainsClient,
+				LinodeCluster:       testcase.linodeCluster,
+			}
+			logger, _ := bufferLogger()
+			r := &LinodeClusterReconciler{Recorder: record.NewFakeRecorder(10)}
+
+			err := r.reconcileDelete(context.Background(), logger, clusterScope)
+			assert.Equal(t, testcase.expectFinalizer, controllerutil.ContainsFinalizer(testcase.linodeCluster, infrav1alpha2.ClusterFinalizer))
+			if testcase.expectedError != "" {
+				require.ErrorContains(t, err, testcase.expectedError)
+				assert.Equal(t, cerrs.DeleteClusterError, *testcase.linodeCluster.Status.FailureReason)
+				assert.NotNil(t, testcase.linodeCluster.Spec.Network.NodeBalancerID)
+				return
+			}
+			require.NoError(t, err)
+			assert.Nil(t, testcase.linodeCluster.Spec.Network.NodeBalancerID)
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"strings"
 	"time"
@@ -41,7 +42,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	infrav1alpha1 "github.com/linode/cluster-api-provider-linode/api/v1alpha1"
@@ -96,6 +99,34 @@ type LinodeMachineReconciler struct {
 	LinodeDNSAPIKey  string
 	WatchFilterValue string
 	ReconcileTimeout time.Duration
+	// HTTPHeaders are added to every Linode API request, e.g. for an authenticating proxy.
+	HTTPHeaders map[string]string
+	// TopologyTags enables tagging instances with the CAPI topology labels of their Machine.
+	TopologyTags bool
+	// ReadinessGracePeriod is how long an instance may be unhealthy before its machine is marked not ready.
+	ReadinessGracePeriod time.Duration
+	// ShutdownTimeout is how long an instance may take to shut down before it is deleted anyway.
+	// Defaults to reconciler.DefaultMachineControllerShutdownTimeout.
+	ShutdownTimeout time.Duration
+	// ProtectedTags are instance tags which are never removed, so tools such as Terraform
+	// which manage the same tags do not see drift.
+	ProtectedTags []string
+	// ClusterLabelTags are the keys of the owner Cluster's labels mirrored onto instance tags.
+	ClusterLabelTags []string
+	// RemediationTags enables tagging instances whose Machine is being remediated by a MachineHealthCheck.
+	RemediationTags bool
+	// EphemeralTags are timestamped instance tags, e.g. last-reconciled, which are refreshed
+	// periodically and removed once expired.
+	EphemeralTags []scope.EphemeralTag
+	// TagTemplate references the ConfigMap key holding the tag template applied to the
+	// instances of every machine in the ConfigMap's namespace.
+	TagTemplate *scope.ConfigMapReference
+	// ManagementCIDRs are the egress CIDRs of the management cluster which machine firewalls
+	// accept traffic from.
+	ManagementCIDRs []string
+	// WaitForDNSPropagation holds back control plane machines of DNS load-balanced clusters
+	// until their DNS records resolve on the authoritative nameservers.
+	WaitForDNSPropagation bool
 }
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=linodemachines,verbs=get;list;watch;create;update;patch;delete
@@ -105,7 +136,8 @@ type LinodeMachineReconciler struct {
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters,verbs=get;watch;list
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;watch;list
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
-// +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;create;update
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -143,11 +175,21 @@ func (r *LinodeMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		r.LinodeApiKey,
 		r.LinodeDNSAPIKey,
 		scope.MachineScopeParams{
-			Client:        r.TracedClient(),
-			Cluster:       cluster,
-			Machine:       machine,
-			LinodeCluster: &infrav1alpha2.LinodeCluster{},
-			LinodeMachine: linodeMachine,
+			Client:               r.TracedClient(),
+			Cluster:              cluster,
+			Machine:              machine,
+			LinodeCluster:        &infrav1alpha2.LinodeCluster{},
+			LinodeMachine:        linodeMachine,
+			HTTPHeaders:          r.HTTPHeaders,
+			ReadinessGracePeriod: r.ReadinessGracePeriod,
+			ProtectedTags:        r.ProtectedTags,
+			ClusterLabelTags:     r.ClusterLabelTags,
+			TopologyTags:         r.TopologyTags,
+			RemediationTags:      r.RemediationTags,
+			EphemeralTags:        r.EphemeralTags,
+			TagTemplate:          r.TagTemplate,
+			ManagementCIDRs:      r.ManagementCIDRs,
+			ChildAccountEUUID:    linodeMachine.Spec.ChildAccountEUUID,
 		},
 	)
 	if err != nil {
@@ -166,6 +208,13 @@ func (r *LinodeMachineReconciler) reconcile(
 ) (res ctrl.Result, err error) {
 	res = ctrl.Result{}
 
+	// Back off while the Linode API is failing for these credentials
+	if backoff, delay := machineScope.ShouldBackoff(); backoff {
+		logger.Info("Linode API is failing, backing off", "delay", delay)
+
+		return ctrl.Result{RequeueAfter: delay}, nil
+	}
+
 	machineScope.LinodeMachine.Status.Ready = false
 	machineScope.LinodeMachine.Status.FailureReason = nil
 	machineScope.LinodeMachine.Status.FailureMessage = util.Pointer("")
@@ -180,8 +229,12 @@ func (r *LinodeMachineReconciler) reconcile(
 			conditions.MarkFalse(machineScope.LinodeMachine, clusterv1.ReadyCondition, string(failureReason), clusterv1.ConditionSeverityError, err.Error())
 
 			r.Recorder.Event(machineScope.LinodeMachine, corev1.EventTypeWarning, string(failureReason), err.Error())
+
+			logger.V(1).Info("machine scope snapshot", "snapshot", machineScope.DebugSnapshot())
 		}
 
+		machineScope.SetInfoAnnotations()
+
 		// Always close the scope when exiting this function so we can persist any LinodeMachine changes.
 		// This ignores any resource not found errors when reconciling deletions.
 		if patchErr := machineScope.Close(ctx); patchErr != nil && utilerrors.FilterOut(util.UnwrapError(patchErr), apierrors.IsNotFound) != nil {
@@ -228,6 +281,13 @@ func (r *LinodeMachineReconciler) reconcile(
 		}
 	}
 
+	// External instances only need to be registered with the load balancer
+	if machineScope.IsExternalInstance() {
+		failureReason = cerrs.CreateMachineError
+
+		return r.reconcileExternalInstance(ctx, logger, machineScope)
+	}
+
 	// Update
 	if machineScope.LinodeMachine.Status.InstanceState != nil {
 		var linodeInstance *linodego.Instance
@@ -257,6 +317,11 @@ func (r *LinodeMachineReconciler) reconcile(
 		return
 	}
 	res, err = r.reconcileCreate(ctx, logger, machineScope)
+	if err == nil && machineScope.LinodeMachine.Spec.InstanceID != nil {
+		if err := machineScope.SetBootstrapChecksumAnnotation(ctx); err != nil {
+			logger.Error(err, "Failed to record bootstrap data checksum")
+		}
+	}
 
 	return
 }
@@ -285,9 +350,25 @@ func (r *LinodeMachineReconciler) reconcileCreate(
 
 	tags := []string{machineScope.LinodeCluster.Name}
 
+	label, err := machineScope.InstanceLabel()
+	if err != nil {
+		logger.Error(err, "Failed to render instance label")
+
+		return ctrl.Result{}, err
+	}
+
+	// Fail before creating the instance if its DNS entries cannot be registered
+	if machineScope.LinodeCluster.Spec.Network.LoadBalancerType == "dns" {
+		if _, err := machineScope.ValidateDNSZone(ctx); err != nil {
+			logger.Error(err, "Failed to validate DNS zone")
+
+			return ctrl.Result{}, err
+		}
+	}
+
 	listFilter := util.Filter{
 		ID:    machineScope.LinodeMachine.Spec.InstanceID,
-		Label: machineScope.LinodeMachine.Name,
+		Label: label,
 		Tags:  tags,
 	}
 	filter, err := listFilter.String()
@@ -357,6 +438,8 @@ func (r *LinodeMachineReconciler) reconcileInstanceCreate(
 			if reconciler.RecordDecayingCondition(machineScope.LinodeMachine,
 				ConditionPreflightConfigured, string(cerrs.CreateMachineError), err.Error(),
 				reconciler.DefaultTimeout(r.ReconcileTimeout, reconciler.DefaultMachineControllerWaitForPreflightTimeout)) {
+				r.rollbackPartialCreate(ctx, logger, machineScope, linodeInstance.ID)
+
 				return ctrl.Result{}, err
 			}
 
@@ -386,6 +469,8 @@ func (r *LinodeMachineReconciler) reconcileInstanceCreate(
 			if reconciler.RecordDecayingCondition(machineScope.LinodeMachine,
 				ConditionPreflightBootTriggered, string(cerrs.CreateMachineError), err.Error(),
 				reconciler.DefaultTimeout(r.ReconcileTimeout, reconciler.DefaultMachineControllerWaitForPreflightTimeout)) {
+				r.rollbackPartialCreate(ctx, logger, machineScope, linodeInstance.ID)
+
 				return ctrl.Result{}, err
 			}
 
@@ -403,6 +488,8 @@ func (r *LinodeMachineReconciler) reconcileInstanceCreate(
 			if reconciler.RecordDecayingCondition(machineScope.LinodeMachine,
 				ConditionPreflightReady, string(cerrs.CreateMachineError), err.Error(),
 				reconciler.DefaultTimeout(r.ReconcileTimeout, reconciler.DefaultMachineControllerWaitForPreflightTimeout)) {
+				r.rollbackPartialCreate(ctx, logger, machineScope, linodeInstance.ID)
+
 				return ctrl.Result{}, err
 			}
 
@@ -420,15 +507,28 @@ func (r *LinodeMachineReconciler) reconcileInstanceCreate(
 			if reconciler.RecordDecayingCondition(machineScope.LinodeMachine,
 				ConditionPreflightNetworking, string(cerrs.CreateMachineError), err.Error(),
 				reconciler.DefaultTimeout(r.ReconcileTimeout, reconciler.DefaultMachineControllerWaitForPreflightTimeout)) {
+				r.rollbackPartialCreate(ctx, logger, machineScope, linodeInstance.ID)
+
 				return ctrl.Result{}, err
 			}
 
 			return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerWaitForRunningDelay}, nil
 		}
+		if r.WaitForDNSPropagation {
+			propagated, err := r.dnsPropagated(ctx, machineScope)
+			if err != nil {
+				logger.Error(err, "Failed to check DNS propagation")
+			}
+			if !propagated {
+				logger.Info("Waiting for DNS records to propagate")
+
+				return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerWaitForRunningDelay}, nil
+			}
+		}
 		conditions.MarkTrue(machineScope.LinodeMachine, ConditionPreflightNetworking)
 	}
 
-	machineScope.LinodeMachine.Spec.ProviderID = util.Pointer(fmt.Sprintf("linode://%d", linodeInstance.ID))
+	machineScope.LinodeMachine.Spec.ProviderID = util.Pointer(machineScope.CCMProviderID())
 
 	// Set the instance state to signal preflight process is done
 	machineScope.LinodeMachine.Status.InstanceState = util.Pointer(linodego.InstanceOffline)
@@ -454,6 +554,32 @@ func (r *LinodeMachineReconciler) addMachineToLB(
 	return nil
 }
 
+// dnsPropagated reports whether the DNS records of a control plane machine in a DNS
+// load-balanced cluster resolve on the authoritative nameservers. Other machines have
+// no records to wait for.
+func (r *LinodeMachineReconciler) dnsPropagated(
+	ctx context.Context,
+	machineScope *scope.MachineScope,
+) (bool, error) {
+	network := machineScope.LinodeCluster.Spec.Network
+	if network.LoadBalancerType != "dns" || !kutil.IsControlPlaneMachine(machineScope.Machine) {
+		return true, nil
+	}
+
+	fqdn := scope.ControlPlaneHostname(machineScope.LinodeCluster) + "." + network.DNSRootDomain
+	for _, addr := range machineScope.LinodeMachine.Status.Addresses {
+		if addr.Type != clusterv1.MachineExternalIP {
+			continue
+		}
+		propagated, err := machineScope.DNSRecordPropagated(ctx, fqdn, addr.Address)
+		if err != nil || !propagated {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
 func (r *LinodeMachineReconciler) removeMachineFromLB(
 	ctx context.Context,
 	logger logr.Logger,
@@ -473,6 +599,115 @@ func (r *LinodeMachineReconciler) removeMachineFromLB(
 	return nil
 }
 
+// rollbackPartialCreate deletes an instance whose provisioning failed when the LinodeMachine's
+// CreateFailurePolicy is Rollback, and resets the preflight conditions so that the next
+// reconcile creates a new instance. A failed rollback is retried on the next failure.
+func (r *LinodeMachineReconciler) rollbackPartialCreate(
+	ctx context.Context,
+	logger logr.Logger,
+	machineScope *scope.MachineScope,
+	linodeInstanceID int,
+) {
+	if machineScope.LinodeMachine.Spec.CreateFailurePolicy != infrav1alpha2.CreateFailurePolicyRollback {
+		return
+	}
+
+	if reconciler.ConditionTrue(machineScope.LinodeMachine, ConditionPreflightReady) {
+		if err := r.removeMachineFromLB(ctx, logger, machineScope); err != nil {
+			return
+		}
+	}
+	if err := machineScope.RollbackPartialCreate(ctx, linodeInstanceID); err != nil {
+		logger.Error(err, "Failed to roll back partially created instance")
+
+		return
+	}
+
+	for _, condition := range []clusterv1.ConditionType{
+		ConditionPreflightCreated,
+		ConditionPreflightRootDiskResizing,
+		ConditionPreflightRootDiskResized,
+		ConditionPreflightAdditionalDisksCreated,
+		ConditionPreflightConfigured,
+		ConditionPreflightBootTriggered,
+		ConditionPreflightReady,
+		ConditionPreflightNetworking,
+	} {
+		conditions.Delete(machineScope.LinodeMachine, condition)
+	}
+
+	r.Recorder.Eventf(machineScope.LinodeMachine, corev1.EventTypeWarning, "CreateRolledBack",
+		"Deleted partially created instance %d", linodeInstanceID)
+}
+
+// captureBootDiagnostics captures the boot diagnostics of an instance which did not join the
+// cluster within the LinodeMachine's BootTimeout, and surfaces them with a warning event. A
+// failed capture is retried on the next reconcile.
+func (r *LinodeMachineReconciler) captureBootDiagnostics(
+	ctx context.Context,
+	logger logr.Logger,
+	machineScope *scope.MachineScope,
+	linodeInstance *linodego.Instance,
+) {
+	if !machineScope.BootTimedOut(linodeInstance, time.Now()) {
+		return
+	}
+
+	diagnostics, err := machineScope.CaptureBootDiagnostics(ctx, linodeInstance.ID)
+	if err != nil {
+		logger.Error(err, "Failed to capture boot diagnostics")
+
+		return
+	}
+
+	r.Recorder.Eventf(machineScope.LinodeMachine, corev1.EventTypeWarning, "BootTimeout",
+		"Instance %d is %s and did not join the cluster within %s, captured boot diagnostics in ConfigMap %s",
+		linodeInstance.ID, diagnostics.InstanceStatus, machineScope.LinodeMachine.Spec.BootTimeout.Duration, diagnostics.ConfigMapName)
+}
+
+// applyConfigChanges applies configuration changes to the instance and reboots it according to
+// the machine's reboot policy. While a reboot is pending it returns the delay after which the
+// pending reboot should be checked again.
+func (r *LinodeMachineReconciler) applyConfigChanges(
+	ctx context.Context,
+	logger logr.Logger,
+	machineScope *scope.MachineScope,
+	instanceID int,
+) (time.Duration, error) {
+	policy := machineScope.RebootPolicy()
+	rebootPending := machineScope.LinodeMachine.Status.RebootPendingSince != nil
+	if err := machineScope.ApplyConfigChangesWithReboot(ctx, instanceID, policy); err != nil {
+		if !errors.Is(err, scope.ErrRebootRequired) {
+			return 0, err
+		}
+
+		r.Recorder.Event(machineScope.LinodeMachine, corev1.EventTypeWarning, "RebootRequired", err.Error())
+	}
+
+	switch {
+	case machineScope.LinodeMachine.Status.RebootPendingSince == nil:
+		if rebootPending && policy != infrav1alpha2.RebootPolicyNever {
+			r.Recorder.Event(machineScope.LinodeMachine, corev1.EventTypeNormal, "Rebooted", "Rebooted instance to apply configuration changes")
+		}
+
+		return 0, nil
+	case policy == infrav1alpha2.RebootPolicyNever:
+		logger.Info("Instance must be rebooted to apply configuration changes")
+	default:
+		logger.Info("Reboot deferred to maintenance window")
+	}
+
+	return reconciler.DefaultMachineControllerMaintenanceWindowDelay, nil
+}
+
+// updateTransferStatus refreshes the transfer usage in the LinodeMachine's status once it is
+// stale. The status is informational, so a failed refresh is only logged.
+func (r *LinodeMachineReconciler) updateTransferStatus(ctx context.Context, logger logr.Logger, machineScope *scope.MachineScope) {
+	if err := machineScope.UpdateTransferPoolStatus(ctx, time.Now()); err != nil {
+		logger.Error(err, "Failed to update transfer pool status")
+	}
+}
+
 func (r *LinodeMachineReconciler) configureDisks(
 	ctx context.Context,
 	logger logr.Logger,
@@ -595,6 +830,16 @@ func (r *LinodeMachineReconciler) resizeRootDisk(
 		conditions.MarkTrue(machineScope.LinodeMachine, ConditionPreflightRootDiskResizing)
 	}
 
+	if label := machineScope.LinodeMachine.Spec.RootFSLabel; label != "" {
+		if err := machineScope.ReconcileDiskLabel(ctx, linodeInstanceID, rootDiskID, label); err != nil {
+			logger.Error(err, "Failed to label root disk")
+
+			conditions.MarkFalse(machineScope.LinodeMachine, ConditionPreflightRootDiskResized, string(cerrs.CreateMachineError), clusterv1.ConditionSeverityWarning, err.Error())
+
+			return err
+		}
+	}
+
 	conditions.Delete(machineScope.LinodeMachine, ConditionPreflightRootDiskResizing)
 	conditions.MarkTrue(machineScope.LinodeMachine, ConditionPreflightRootDiskResized)
 
@@ -675,29 +920,189 @@ func (r *LinodeMachineReconciler) reconcileUpdate(
 		}
 		return res, nil, err
 	}
+	if desiredOn, err := machineScope.ReconcileScheduledPower(ctx, linodeInstance.ID, time.Now()); err != nil {
+		logger.Error(err, "Failed to reconcile scheduled power state")
+
+		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}, linodeInstance, err
+	} else if !desiredOn {
+		logger.Info("Instance is powered off by its power schedule")
+
+		machineScope.MarkPoweredOff()
+
+		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerPowerScheduleDelay}, linodeInstance, nil
+	}
+	machineScope.MarkPoweredOn()
+	r.captureBootDiagnostics(ctx, logger, machineScope, linodeInstance)
 	if _, ok := requeueInstanceStatuses[linodeInstance.Status]; ok {
 		if linodeInstance.Updated.Add(reconciler.DefaultMachineControllerWaitForRunningTimeout).After(time.Now()) {
 			logger.Info("Instance has one operation running, re-queuing reconciliation", "status", linodeInstance.Status)
 
+			machineScope.MarkNotReady(string(linodeInstance.Status), "waiting for running operation")
+
 			return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerWaitForRunningDelay}, linodeInstance, nil
 		}
 
 		logger.Info("Instance has one operation long running, skipping reconciliation", "status", linodeInstance.Status)
 
-		conditions.MarkFalse(machineScope.LinodeMachine, clusterv1.ReadyCondition, string(linodeInstance.Status), clusterv1.ConditionSeverityInfo, "skipped due to long running operation")
+		if !machineScope.MarkNotReady(string(linodeInstance.Status), "skipped due to long running operation") {
+			return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerWaitForRunningDelay}, linodeInstance, nil
+		}
 
 		return res, linodeInstance, nil
 	} else if linodeInstance.Status != linodego.InstanceRunning {
 		logger.Info("Instance has incompatible status, skipping reconciliation", "status", linodeInstance.Status)
 
-		conditions.MarkFalse(machineScope.LinodeMachine, clusterv1.ReadyCondition, string(linodeInstance.Status), clusterv1.ConditionSeverityInfo, "incompatible status")
+		if !machineScope.MarkNotReady(string(linodeInstance.Status), "incompatible status") {
+			return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerWaitForRunningDelay}, linodeInstance, nil
+		}
 
 		return res, linodeInstance, nil
 	}
 
-	machineScope.LinodeMachine.Status.Ready = true
+	if requeueAfter, err := r.applyConfigChanges(ctx, logger, machineScope, linodeInstance.ID); err != nil {
+		logger.Error(err, "Failed to reconcile instance configuration")
+
+		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}, linodeInstance, err
+	} else if requeueAfter > 0 {
+		res = ctrl.Result{RequeueAfter: requeueAfter}
+	}
+
+	if err := machineScope.ReconcileManagedTags(ctx, linodeInstance.ID); err != nil {
+		logger.Error(err, "Failed to reconcile instance tags")
+
+		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}, linodeInstance, err
+	}
+
+	if _, err := machineScope.ReconcileAdditionalIPs(ctx, linodeInstance.ID); err != nil {
+		logger.Error(err, "Failed to reconcile additional IPv4 addresses")
+
+		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}, linodeInstance, err
+	}
+
+	if err := machineScope.ReconcileSplitHorizonDNS(ctx, nodeBalancerIP(machineScope)); err != nil {
+		logger.Error(err, "Failed to reconcile split-horizon DNS records")
+
+		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}, linodeInstance, err
+	}
+
+	if err := machineScope.ReconcileTXTRecords(ctx); err != nil {
+		logger.Error(err, "Failed to reconcile TXT records")
+
+		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}, linodeInstance, err
+	}
+
+	if dbID := machineScope.LinodeMachine.Spec.DatabaseID; dbID != nil {
+		if err := machineScope.ReconcileDatabaseAllowlist(ctx, *dbID); err != nil {
+			logger.Error(err, "Failed to reconcile database allow list", "databaseID", *dbID)
+
+			return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}, linodeInstance, err
+		}
+	}
+
+	if changed, err := machineScope.ReconcilePlacementGroup(ctx, linodeInstance.ID); err != nil {
+		logger.Error(err, "Failed to reconcile placement group membership")
+
+		r.Recorder.Event(machineScope.LinodeMachine, corev1.EventTypeWarning, "PlacementGroupMoveFailed", err.Error())
+	} else if changed {
+		logger.Info("Moved instance to placement group", "placementGroup", machineScope.LinodeMachine.Spec.PlacementGroupRef.Name)
+	}
+
+	if err := machineScope.ReconcileHostname(ctx, linodeInstance.ID); err != nil {
+		if !errors.Is(err, scope.ErrRenameDisruptive) {
+			logger.Error(err, "Failed to reconcile instance hostname")
+
+			return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}, linodeInstance, err
+		}
+
+		r.Recorder.Event(machineScope.LinodeMachine, corev1.EventTypeWarning, "RenameSkipped", err.Error())
+	}
+
+	if machineScope.MigrateProviderID() {
+		logger.Info("Migrated legacy provider ID", "providerID", *machineScope.LinodeMachine.Spec.ProviderID)
+	}
+	if err := machineScope.ValidateProviderID(); err != nil {
+		logger.Error(err, "Provider ID does not match the cloud controller manager format")
+	}
+
+	if changed, err := machineScope.ReconcileVolumes(ctx, linodeInstance.ID); err != nil {
+		logger.Error(err, "Failed to attach volumes")
+
+		r.Recorder.Event(machineScope.LinodeMachine, corev1.EventTypeWarning, "VolumeAttachFailed", err.Error())
+	} else if changed {
+		logger.Info("Attached volumes to their devices")
+	}
+
+	// Data disks are created while provisioning, once the root disk has been shrunk to make room
+	// for them. Afterwards, disks added to the spec are created if there is space left in the plan.
+	if reconciler.ConditionTrue(machineScope.LinodeMachine, ConditionPreflightAdditionalDisksCreated) {
+		if _, err := machineScope.ReconcileAdditionalDisks(ctx, linodeInstance.ID); err != nil {
+			logger.Error(err, "Failed to reconcile data disks")
+
+			r.Recorder.Event(machineScope.LinodeMachine, corev1.EventTypeWarning, "DataDiskFailed", err.Error())
+		}
+	}
+
+	for _, volume := range machineScope.LinodeMachine.Spec.Volumes {
+		if err := machineScope.ReconcileVolumeTags(ctx, volume.VolumeID); err != nil {
+			logger.Error(err, "Failed to tag volume", "volumeID", volume.VolumeID)
+		}
+	}
+
+	firewallChanges, err := machineScope.ReconcileFirewall(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to reconcile firewall")
+
+		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}, linodeInstance, err
+	}
+	if firewallChanges.Policy {
+		policy := machineScope.LinodeMachine.Spec.FirewallPolicy
+		r.Recorder.Eventf(machineScope.LinodeMachine, corev1.EventTypeNormal, "FirewallPolicyChanged",
+			"Set default policy of firewall %d to inbound %q, outbound %q", machineScope.LinodeMachine.Spec.FirewallID, policy.Inbound, policy.Outbound)
+	}
+	if firewallChanges.ClusterRules {
+		r.Recorder.Eventf(machineScope.LinodeMachine, corev1.EventTypeNormal, "FirewallRulesChanged",
+			"Updated rules of firewall %d for the cluster's CIDRs", machineScope.LinodeMachine.Spec.FirewallID)
+	}
+	if firewallChanges.SpecRules {
+		r.Recorder.Eventf(machineScope.LinodeMachine, corev1.EventTypeNormal, "FirewallRulesChanged",
+			"Updated rules of firewall %d from the spec", machineScope.LinodeMachine.Spec.FirewallID)
+	}
+
+	r.updateTransferStatus(ctx, logger, machineScope)
+
+	if pending, err := machineScope.ReconcileBackupSchedule(ctx, linodeInstance.ID); err != nil {
+		logger.Error(err, "Failed to reconcile backup schedule")
+
+		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}, linodeInstance, err
+	} else if pending {
+		logger.Info("Backups are not enabled on the instance yet, re-queuing to apply the backup schedule")
 
-	conditions.MarkTrue(machineScope.LinodeMachine, clusterv1.ReadyCondition)
+		if res.RequeueAfter == 0 || res.RequeueAfter > reconciler.DefaultMachineControllerRetryDelay {
+			res = ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}
+		}
+	}
+
+	if changed, err := machineScope.StackScriptUDFChanged(ctx); err != nil {
+		logger.Error(err, "Failed to check StackScript UDF values")
+	} else if changed {
+		r.Recorder.Event(machineScope.LinodeMachine, corev1.EventTypeWarning, "StackScriptUDFChanged",
+			"StackScript UDF values changed since the instance was bootstrapped, rebuild the instance to apply them")
+	}
+
+	ready, reasons, err := machineScope.ComputeReadiness(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to compute machine readiness")
+
+		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}, linodeInstance, err
+	}
+	if !ready {
+		logger.Info("Machine is not ready yet", "reasons", reasons)
+
+		machineScope.MarkNotReady("WaitingForReadiness", strings.Join(reasons, "; "))
+
+		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerWaitForRunningDelay}, linodeInstance, nil
+	}
+	machineScope.MarkReady()
 
 	return res, linodeInstance, nil
 }
@@ -709,6 +1114,42 @@ func (r *LinodeMachineReconciler) reconcileDelete(
 ) (ctrl.Result, error) {
 	logger.Info("deleting machine")
 
+	if machineScope.IsExternalInstance() {
+		return r.reconcileExternalInstanceDelete(ctx, logger, machineScope)
+	}
+
+	if err := machineScope.DeleteLongview(ctx); err != nil {
+		logger.Error(err, "Failed to delete Longview client")
+
+		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}, nil
+	}
+
+	if err := machineScope.DeleteObjectStorageKey(ctx); err != nil {
+		logger.Error(err, "Failed to revoke Object Storage key")
+
+		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}, nil
+	}
+
+	if err := machineScope.ReconcileSplitHorizonDNS(ctx, nodeBalancerIP(machineScope)); err != nil {
+		logger.Error(err, "Failed to remove split-horizon DNS records")
+
+		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}, nil
+	}
+
+	if err := machineScope.ReconcileTXTRecords(ctx); err != nil {
+		logger.Error(err, "Failed to remove TXT records")
+
+		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}, nil
+	}
+
+	if dbID := machineScope.LinodeMachine.Spec.DatabaseID; dbID != nil {
+		if err := machineScope.ReconcileDatabaseAllowlist(ctx, *dbID); err != nil {
+			logger.Error(err, "Failed to remove database allow list entries", "databaseID", *dbID)
+
+			return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}, nil
+		}
+	}
+
 	if machineScope.LinodeMachine.Spec.InstanceID == nil {
 		logger.Info("Machine ID is missing, nothing to do")
 
@@ -721,10 +1162,42 @@ func (r *LinodeMachineReconciler) reconcileDelete(
 		return ctrl.Result{}, nil
 	}
 
+	if err := machineScope.MarkInstanceTerminating(ctx, *machineScope.LinodeMachine.Spec.InstanceID); util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
+		logger.Error(err, "Failed to tag Linode instance as terminating")
+
+		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}, nil
+	}
+
 	if err := r.removeMachineFromLB(ctx, logger, machineScope); err != nil {
 		return ctrl.Result{}, fmt.Errorf("remove machine from loadbalancer: %w", err)
 	}
 
+	if _, err := machineScope.ReconcileAdditionalIPs(ctx, *machineScope.LinodeMachine.Spec.InstanceID); util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
+		logger.Error(err, "Failed to release additional IPv4 addresses")
+
+		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}, nil
+	}
+
+	if err := machineScope.AllowManagementAccess(ctx, machineScope.LinodeMachine.Spec.FirewallID, machineScope.ManagementCIDRs); util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
+		logger.Error(err, "Failed to remove management cluster firewall rules")
+
+		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}, nil
+	}
+
+	stopped, err := machineScope.GracefulShutdown(ctx, *machineScope.LinodeMachine.Spec.InstanceID, reconciler.DefaultTimeout(r.ShutdownTimeout, reconciler.DefaultMachineControllerShutdownTimeout), time.Now())
+	switch {
+	case errors.Is(err, scope.ErrShutdownTimeout):
+		logger.Info("Linode instance did not shut down in time, forcing deletion")
+	case err != nil:
+		logger.Error(err, "Failed to shut down Linode instance")
+
+		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerRetryDelay}, nil
+	case !stopped:
+		logger.Info("Waiting for Linode instance to shut down")
+
+		return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerWaitForRunningDelay}, nil
+	}
+
 	if err := machineScope.LinodeClient.DeleteInstance(ctx, *machineScope.LinodeMachine.Spec.InstanceID); err != nil {
 		if util.IgnoreLinodeAPIError(err, http.StatusNotFound) != nil {
 			logger.Error(err, "Failed to delete Linode instance")
@@ -760,6 +1233,55 @@ func (r *LinodeMachineReconciler) reconcileDelete(
 	return ctrl.Result{}, nil
 }
 
+// reconcileExternalInstance registers a host provisioned outside of CAPL with the
+// load balancer using its supplied IP address, skipping all instance operations.
+func (r *LinodeMachineReconciler) reconcileExternalInstance(
+	ctx context.Context,
+	logger logr.Logger,
+	machineScope *scope.MachineScope,
+) (ctrl.Result, error) {
+	machineScope.LinodeMachine.Status.Addresses = machineScope.ExternalInstanceAddresses()
+
+	if !reconciler.ConditionTrue(machineScope.LinodeMachine, ConditionPreflightNetworking) {
+		if err := r.addMachineToLB(ctx, machineScope); err != nil {
+			logger.Error(err, "Failed to add external instance to LB")
+
+			if reconciler.RecordDecayingCondition(machineScope.LinodeMachine,
+				ConditionPreflightNetworking, string(cerrs.CreateMachineError), err.Error(),
+				reconciler.DefaultTimeout(r.ReconcileTimeout, reconciler.DefaultMachineControllerWaitForPreflightTimeout)) {
+				return ctrl.Result{}, err
+			}
+
+			return ctrl.Result{RequeueAfter: reconciler.DefaultMachineControllerWaitForRunningDelay}, nil
+		}
+		conditions.MarkTrue(machineScope.LinodeMachine, ConditionPreflightNetworking)
+	}
+
+	machineScope.MarkReady()
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileExternalInstanceDelete removes an external host from the load balancer.
+// The host itself is not managed by CAPL and is left untouched.
+func (r *LinodeMachineReconciler) reconcileExternalInstanceDelete(
+	ctx context.Context,
+	logger logr.Logger,
+	machineScope *scope.MachineScope,
+) (ctrl.Result, error) {
+	if err := r.removeMachineFromLB(ctx, logger, machineScope); err != nil {
+		return ctrl.Result{}, fmt.Errorf("remove machine from loadbalancer: %w", err)
+	}
+
+	if err := machineScope.RemoveCredentialsRefFinalizer(ctx); err != nil {
+		logger.Error(err, "Failed to update credentials secret")
+		return ctrl.Result{}, err
+	}
+	controllerutil.RemoveFinalizer(machineScope.LinodeMachine, infrav1alpha2.MachineFinalizer)
+
+	return ctrl.Result{}, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *LinodeMachineReconciler) SetupWithManager(mgr ctrl.Manager, options crcontroller.Options) error {
 	linodeMachineMapper, err := kutil.ClusterToTypedObjectsMapper(
@@ -771,6 +1293,12 @@ func (r *LinodeMachineReconciler) SetupWithManager(mgr ctrl.Manager, options crc
 		return fmt.Errorf("failed to create mapper for LinodeMachines: %w", err)
 	}
 
+	clusterPredicates := predicates.ClusterUnpausedAndInfrastructureReady(mgr.GetLogger())
+	if len(r.ClusterLabelTags) > 0 {
+		// Label changes must reach the machines, as their instance tags mirror them
+		clusterPredicates = predicates.Any(mgr.GetLogger(), clusterPredicates, clusterLabelsChanged())
+	}
+
 	err = ctrl.NewControllerManagedBy(mgr).
 		For(&infrav1alpha2.LinodeMachine{}).
 		WithOptions(options).
@@ -785,7 +1313,7 @@ func (r *LinodeMachineReconciler) SetupWithManager(mgr ctrl.Manager, options crc
 		Watches(
 			&clusterv1.Cluster{},
 			handler.EnqueueRequestsFromMapFunc(linodeMachineMapper),
-			builder.WithPredicates(predicates.ClusterUnpausedAndInfrastructureReady(mgr.GetLogger())),
+			builder.WithPredicates(clusterPredicates),
 		).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(mgr.GetLogger(), r.WatchFilterValue)).
 		Complete(wrappedruntimereconciler.NewRuntimeReconcilerWithTracing(r, wrappedruntimereconciler.DefaultDecorator()))
@@ -796,6 +1324,18 @@ func (r *LinodeMachineReconciler) SetupWithManager(mgr ctrl.Manager, options crc
 	return nil
 }
 
+// clusterLabelsChanged returns a predicate which passes updates changing the labels of the object.
+func clusterLabelsChanged() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !maps.Equal(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+		},
+	}
+}
+
 func (r *LinodeMachineReconciler) TracedClient() client.Client {
 	return wrappedruntimeclient.NewRuntimeClientWithTracing(r.Client, wrappedruntimeclient.DefaultDecorator())
 }
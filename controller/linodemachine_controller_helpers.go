@@ -31,7 +31,6 @@ import (
 	"github.com/linode/linodego"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	kutil "sigs.k8s.io/cluster-api/util"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -69,10 +68,37 @@ func (r *LinodeMachineReconciler) newCreateConfig(ctx context.Context, machineSc
 
 	createConfig.Booted = util.Pointer(false)
 
+	createConfig.Type, err = machineScope.ResolveInstanceType(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to resolve instance type")
+
+		return nil, err
+	}
+
+	if err := machineScope.ValidateDiskEncryptionSupported(ctx); err != nil {
+		logger.Error(err, "Failed to validate disk encryption")
+
+		return nil, err
+	}
+
+	if err := machineScope.ValidateInstanceCapabilities(ctx, createConfig.Type, machineScope.LinodeMachine.Spec.RequiredCapabilities); err != nil {
+		logger.Error(err, "Failed to validate instance capabilities")
+
+		return nil, err
+	}
+
 	if err := setUserData(ctx, machineScope, createConfig, logger); err != nil {
 		return nil, err
 	}
 
+	authorizedKeys, err := machineScope.ResolveAuthorizedKeys(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to resolve authorized keys")
+
+		return nil, err
+	}
+	createConfig.AuthorizedKeys = append(createConfig.AuthorizedKeys, authorizedKeys...)
+
 	if machineScope.LinodeMachine.Spec.PrivateIP != nil {
 		createConfig.PrivateIP = *machineScope.LinodeMachine.Spec.PrivateIP
 	} else {
@@ -83,9 +109,27 @@ func (r *LinodeMachineReconciler) newCreateConfig(ctx context.Context, machineSc
 		createConfig.Tags = []string{}
 	}
 	createConfig.Tags = append(createConfig.Tags, tags...)
+	if machineScope.TagTemplate != nil {
+		templatedTags, err := machineScope.TemplatedTags(ctx)
+		if err != nil {
+			logger.Error(err, "Failed to render tag template")
+
+			return nil, err
+		}
+		for _, tag := range templatedTags {
+			if !slices.Contains(createConfig.Tags, tag) {
+				createConfig.Tags = append(createConfig.Tags, tag)
+			}
+		}
+	}
 
 	if createConfig.Label == "" {
-		createConfig.Label = machineScope.LinodeMachine.Name
+		createConfig.Label, err = machineScope.InstanceLabel()
+		if err != nil {
+			logger.Error(err, "Failed to render instance label")
+
+			return nil, err
+		}
 	}
 
 	if createConfig.Image == "" {
@@ -108,6 +152,12 @@ func (r *LinodeMachineReconciler) newCreateConfig(ctx context.Context, machineSc
 		createConfig.Interfaces = slices.Insert(createConfig.Interfaces, 0, *iface)
 	}
 
+	if _, err := machineScope.ApplyDefaultRoute(createConfig.Interfaces); err != nil {
+		logger.Error(err, "Failed to apply default route")
+
+		return nil, err
+	}
+
 	if machineScope.LinodeMachine.Spec.PlacementGroupRef != nil {
 		pgID, err := r.getPlacementGroupID(ctx, machineScope, logger)
 		if err != nil {
@@ -377,19 +427,28 @@ func (r *LinodeMachineReconciler) getVPCInterfaceConfig(ctx context.Context, mac
 
 		return nil, errors.New("failed to find subnet")
 	}
-	// Place node into the least busy subnet
-	sort.Slice(vpc.Subnets, func(i, j int) bool {
-		return len(vpc.Subnets[i].Linodes) > len(vpc.Subnets[j].Linodes)
-	})
+	// Place node into the subnet of its static address, or the least busy subnet
+	subnetID, err = machineScope.VPCIPv4Subnet(ctx, vpc)
+	if err != nil {
+		logger.Error(err, "Failed to validate VPC IPv4 address")
 
-	subnetID = vpc.Subnets[0].ID
+		return nil, err
+	}
+	if subnetID == 0 {
+		sort.Slice(vpc.Subnets, func(i, j int) bool {
+			return len(vpc.Subnets[i].Linodes) > len(vpc.Subnets[j].Linodes)
+		})
+
+		subnetID = vpc.Subnets[0].ID
+	}
 
 	return &linodego.InstanceConfigInterfaceCreateOptions{
 		Purpose:  linodego.InterfacePurposeVPC,
 		Primary:  true,
 		SubnetID: &subnetID,
 		IPv4: &linodego.VPCIPv4{
-			NAT1To1: ptr.To(("any")),
+			VPC:     machineScope.LinodeMachine.Spec.VPCIPv4,
+			NAT1To1: machineScope.VPCNAT1To1(),
 		},
 	}, nil
 }
@@ -419,20 +478,21 @@ func setUserData(ctx context.Context, machineScope *scope.MachineScope, createCo
 
 		return err
 	}
-	if len(bootstrapData) > maxBootstrapDataBytes {
-		err = errors.New("bootstrap data too large")
+	if err := machineScope.ValidateBootstrapSize(maxBootstrapDataBytes); err != nil {
 		logger.Error(err, "decoded bootstrap data exceeds size limit",
 			"limit", maxBootstrapDataBytes,
+			"size", len(bootstrapData),
 		)
 
 		return err
 	}
 
-	region, err := machineScope.LinodeClient.GetRegion(ctx, machineScope.LinodeMachine.Spec.Region)
-	if err != nil {
-		return fmt.Errorf("get region: %w", err)
+	regionMetadataSupport := true
+	if err := machineScope.ValidateMetadataServiceAvailable(ctx); errors.Is(err, scope.ErrMetadataServiceUnavailable) {
+		regionMetadataSupport = false
+	} else if err != nil {
+		return err
 	}
-	regionMetadataSupport := slices.Contains(region.Capabilities, "Metadata")
 	imageName := reconciler.DefaultMachineControllerLinodeImage
 	if machineScope.LinodeMachine.Spec.Image != "" {
 		imageName = machineScope.LinodeMachine.Spec.Image
@@ -456,17 +516,24 @@ func setUserData(ctx context.Context, machineScope *scope.MachineScope, createCo
 			return fmt.Errorf("ensure stackscript: %w", err)
 		}
 		createConfig.StackScriptID = capiStackScriptID
-		// WARNING: label, region and type are currently supported as cloud-init variables,
-		// any changes to this could be potentially backwards incompatible and should be noted through a backwards incompatible version update
-		instanceData := fmt.Sprintf("label: %s\nregion: %s\ntype: %s", machineScope.LinodeMachine.Name, machineScope.LinodeMachine.Spec.Region, machineScope.LinodeMachine.Spec.Type)
-		createConfig.StackScriptData = map[string]string{
-			"instancedata": b64.StdEncoding.EncodeToString([]byte(instanceData)),
-			"userdata":     b64.StdEncoding.EncodeToString(bootstrapData),
+		createConfig.StackScriptData = machineScope.StackScriptUDF(bootstrapData)
+		if err := machineScope.RecordStackScriptUDF(createConfig.StackScriptData); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
+// nodeBalancerIP returns the IP of the cluster's NodeBalancer, which is the host of the control
+// plane endpoint unless the cluster is load balanced by DNS.
+func nodeBalancerIP(machineScope *scope.MachineScope) string {
+	if machineScope.LinodeCluster.Spec.Network.LoadBalancerType == "dns" {
+		return ""
+	}
+
+	return machineScope.LinodeCluster.Spec.ControlPlaneEndpoint.Host
+}
+
 func createInstanceConfigDeviceMap(instanceDisks map[string]*infrav1alpha2.InstanceDisk, instanceConfig *linodego.InstanceConfigDeviceMap) error {
 	for deviceName, disk := range instanceDisks {
 		dev := linodego.InstanceConfigDevice{
@@ -273,7 +273,7 @@ var _ = Describe("create", Label("machine", "create"), func() {
 			getRegion := mockLinodeClient.EXPECT().
 				GetRegion(ctx, gomock.Any()).
 				After(listInst).
-				Return(&linodego.Region{Capabilities: []string{"Metadata"}}, nil)
+				Return(&linodego.Region{Capabilities: []string{linodego.CapabilityMetadata, linodego.CapabilityDiskEncryption}}, nil)
 			getImage := mockLinodeClient.EXPECT().
 				GetImage(ctx, gomock.Any()).
 				After(getRegion).
@@ -1170,6 +1170,8 @@ var _ = Describe("machine-delete", Ordered, Label("machine", "machine-delete"),
 		OneOf(
 			Path(
 				Call("machine is not deleted because there was an error deleting instance", func(ctx context.Context, mck Mock) {
+					mck.LinodeClient.EXPECT().GetInstance(gomock.Any(), gomock.Any()).
+						Return(&linodego.Instance{ID: instanceID, Status: linodego.InstanceOffline, Tags: []string{scope.TerminatingTag}}, nil).Times(2)
 					mck.LinodeClient.EXPECT().DeleteInstance(gomock.Any(), gomock.Any()).
 						Return(errors.New("failed to delete instance"))
 				}),
@@ -1192,6 +1194,8 @@ var _ = Describe("machine-delete", Ordered, Label("machine", "machine-delete"),
 			),
 			Path(
 				Call("machine deleted", func(ctx context.Context, mck Mock) {
+					mck.LinodeClient.EXPECT().GetInstance(gomock.Any(), gomock.Any()).
+						Return(&linodego.Instance{ID: instanceID, Status: linodego.InstanceOffline, Tags: []string{scope.TerminatingTag}}, nil).Times(2)
 					mck.LinodeClient.EXPECT().DeleteInstance(gomock.Any(), gomock.Any()).Return(nil)
 				}),
 				Result("machine deleted", func(ctx context.Context, mck Mock) {
@@ -0,0 +1,321 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/linode/linodego"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1alpha2 "github.com/linode/cluster-api-provider-linode/api/v1alpha2"
+	"github.com/linode/cluster-api-provider-linode/cloud/scope"
+	"github.com/linode/cluster-api-provider-linode/mock"
+	"github.com/linode/cluster-api-provider-linode/util/reconciler"
+)
+
+// bufferLogger returns a logger which writes every log line to the returned builder.
+func bufferLogger() (logr.Logger, *strings.Builder) {
+	logs := &strings.Builder{}
+
+	return funcr.New(func(prefix, args string) {
+		logs.WriteString(prefix + " " + args + "\n")
+	}, funcr.Options{}), logs
+}
+
+func TestUpdateTransferStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		current        *infrav1alpha2.TransferStatus
+		expects        func(mock *mock.MockLinodeClient)
+		expectedStatus *infrav1alpha2.TransferStatus
+		expectedLog    string
+	}{
+		{
+			name: "Refresh transfer status",
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceTransfer(gomock.Any(), 123).Return(&linodego.InstanceTransfer{Quota: 1000}, nil)
+				mock.EXPECT().GetAccountTransfer(gomock.Any()).Return(&linodego.AccountTransfer{Quota: 10000}, nil)
+			},
+			expectedStatus: &infrav1alpha2.TransferStatus{Quota: 1000, PoolQuota: 10000},
+		},
+		{
+			name:           "Skip recently refreshed transfer status",
+			current:        &infrav1alpha2.TransferStatus{Quota: 1000, UpdatedAt: &metav1.Time{Time: time.Now()}},
+			expects:        func(mock *mock.MockLinodeClient) {},
+			expectedStatus: &infrav1alpha2.TransferStatus{Quota: 1000},
+		},
+		{
+			name:    "Failed refresh is not fatal",
+			current: &infrav1alpha2.TransferStatus{Quota: 1000, UpdatedAt: &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}},
+			expects: func(mock *mock.MockLinodeClient) {
+				mock.EXPECT().GetInstanceTransfer(gomock.Any(), 123).Return(nil, errors.New("api error"))
+			},
+			expectedStatus: &infrav1alpha2.TransferStatus{Quota: 1000},
+			expectedLog:    "Failed to update transfer pool status",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			machineScope := &scope.MachineScope{
+				LinodeClient: mockLinodeClient,
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec:   infrav1alpha2.LinodeMachineSpec{InstanceID: ptr.To(123)},
+					Status: infrav1alpha2.LinodeMachineStatus{Transfer: testcase.current},
+				},
+			}
+			logger, logs := bufferLogger()
+
+			reconciler := &LinodeMachineReconciler{Recorder: record.NewFakeRecorder(10)}
+			reconciler.updateTransferStatus(context.Background(), logger, machineScope)
+
+			transfer := machineScope.LinodeMachine.Status.Transfer
+			assert.NotNil(t, transfer.UpdatedAt)
+			transfer.UpdatedAt = nil
+			assert.Equal(t, testcase.expectedStatus, transfer)
+			if testcase.expectedLog != "" {
+				assert.Contains(t, logs.String(), testcase.expectedLog)
+			} else {
+				assert.Empty(t, logs.String())
+			}
+		})
+	}
+}
+
+func TestCaptureBootDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+	mockK8sClient := mock.NewMockK8sClient(ctrl)
+	mockLinodeClient.EXPECT().GetInstance(gomock.Any(), 123).Return(&linodego.Instance{ID: 123, Status: linodego.InstanceRunning}, nil)
+	mockLinodeClient.EXPECT().ListEvents(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockLinodeClient.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return(nil, nil)
+	mockK8sClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "test-machine-boot-diagnostics"))
+	mockK8sClient.EXPECT().Scheme().DoAndReturn(func() *runtime.Scheme {
+		s := runtime.NewScheme()
+		_ = infrav1alpha2.AddToScheme(s)
+		return s
+	})
+	mockK8sClient.EXPECT().Create(gomock.Any(), gomock.AssignableToTypeOf(&corev1.ConfigMap{})).Return(nil)
+
+	machineScope := &scope.MachineScope{
+		Client:       mockK8sClient,
+		LinodeClient: mockLinodeClient,
+		Machine:      &clusterv1.Machine{},
+		LinodeMachine: &infrav1alpha2.LinodeMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: "default"},
+			Spec:       infrav1alpha2.LinodeMachineSpec{BootTimeout: &metav1.Duration{Duration: 10 * time.Minute}},
+		},
+	}
+	created := time.Now().Add(-time.Hour)
+	instance := &linodego.Instance{ID: 123, Status: linodego.InstanceRunning, Created: &created}
+	logger, logs := bufferLogger()
+	recorder := record.NewFakeRecorder(10)
+	reconciler := &LinodeMachineReconciler{Recorder: recorder}
+
+	// The ConfigMap is written on the first reconcile after the boot timeout, and the mocks
+	// fail on any further write once ConditionBootDiagnosticsCaptured is set.
+	reconciler.captureBootDiagnostics(context.Background(), logger, machineScope, instance)
+	assert.True(t, conditions.IsTrue(machineScope.LinodeMachine, scope.ConditionBootDiagnosticsCaptured))
+	reconciler.captureBootDiagnostics(context.Background(), logger, machineScope, instance)
+
+	assert.Empty(t, logs.String())
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "captured boot diagnostics in ConfigMap test-machine-boot-diagnostics")
+}
+
+func TestApplyConfigChanges(t *testing.T) {
+	t.Parallel()
+
+	// closedWindow opens at the current time on another day of the week.
+	now := time.Now().UTC()
+	closedWindow := &infrav1alpha2.TimeWindow{
+		Days:     []string{now.AddDate(0, 0, 3).Weekday().String()},
+		Start:    now.Format("15:04"),
+		Duration: metav1.Duration{Duration: time.Minute},
+	}
+	kernelChanged := func(mock *mock.MockLinodeClient) {
+		mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceConfig{{ID: 1, Kernel: "linode/grub2"}}, nil)
+		mock.EXPECT().GetKernel(gomock.Any(), "linode/direct-disk").Return(&linodego.LinodeKernel{ID: "linode/direct-disk"}, nil)
+		mock.EXPECT().UpdateInstanceConfig(gomock.Any(), 123, 1, linodego.InstanceConfigUpdateOptions{Kernel: "linode/direct-disk"}).
+			Return(&linodego.InstanceConfig{}, nil)
+	}
+	kernelUnchanged := func(mock *mock.MockLinodeClient) {
+		mock.EXPECT().ListInstanceConfigs(gomock.Any(), 123, gomock.Any()).Return([]linodego.InstanceConfig{{ID: 1, Kernel: "linode/direct-disk"}}, nil)
+	}
+
+	tests := []struct {
+		name          string
+		policy        infrav1alpha2.RebootPolicy
+		window        *infrav1alpha2.TimeWindow
+		pendingSince  *metav1.Time
+		expects       func(mock *mock.MockLinodeClient)
+		wantRequeue   time.Duration
+		wantPending   bool
+		wantEvents    []string
+		wantLog       string
+		expectedError string
+	}{
+		{
+			name:   "Immediate - reboot right away",
+			policy: infrav1alpha2.RebootPolicyImmediate,
+			expects: func(mock *mock.MockLinodeClient) {
+				kernelChanged(mock)
+				mock.EXPECT().RebootInstance(gomock.Any(), 123, 0).Return(nil)
+			},
+		},
+		{
+			name:   "Immediate - error reboot fails",
+			policy: infrav1alpha2.RebootPolicyImmediate,
+			expects: func(mock *mock.MockLinodeClient) {
+				kernelChanged(mock)
+				mock.EXPECT().RebootInstance(gomock.Any(), 123, 0).Return(errors.New("api error"))
+			},
+			expectedError: "reboot instance 123: api error",
+		},
+		{
+			name:        "MaintenanceWindow - defer reboot to the window",
+			policy:      infrav1alpha2.RebootPolicyMaintenanceWindow,
+			window:      closedWindow,
+			expects:     kernelChanged,
+			wantRequeue: reconciler.DefaultMachineControllerMaintenanceWindowDelay,
+			wantPending: true,
+			wantLog:     "Reboot deferred to maintenance window",
+		},
+		{
+			name:         "MaintenanceWindow - reboot once the window opens",
+			policy:       infrav1alpha2.RebootPolicyMaintenanceWindow,
+			pendingSince: &metav1.Time{Time: now.Add(-time.Hour)},
+			expects: func(mock *mock.MockLinodeClient) {
+				kernelUnchanged(mock)
+				mock.EXPECT().RebootInstance(gomock.Any(), 123, 0).Return(nil)
+			},
+			wantEvents: []string{"Normal Rebooted Rebooted instance to apply configuration changes"},
+		},
+		{
+			name:        "Never - report the required reboot",
+			policy:      infrav1alpha2.RebootPolicyNever,
+			expects:     kernelChanged,
+			wantRequeue: reconciler.DefaultMachineControllerMaintenanceWindowDelay,
+			wantPending: true,
+			wantEvents:  []string{"Warning RebootRequired kernel changed: instance must be rebooted for the change to take effect"},
+			wantLog:     "Instance must be rebooted to apply configuration changes",
+		},
+		{
+			name:         "Never - keep the pending reboot without reporting it again",
+			policy:       infrav1alpha2.RebootPolicyNever,
+			pendingSince: &metav1.Time{Time: now.Add(-time.Hour)},
+			expects: func(mock *mock.MockLinodeClient) {
+				kernelUnchanged(mock)
+				mock.EXPECT().ListEvents(gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+			wantRequeue: reconciler.DefaultMachineControllerMaintenanceWindowDelay,
+			wantPending: true,
+			wantLog:     "Instance must be rebooted to apply configuration changes",
+		},
+		{
+			name:         "Never - clear the pending reboot once rebooted by other means",
+			policy:       infrav1alpha2.RebootPolicyNever,
+			pendingSince: &metav1.Time{Time: now.Add(-time.Hour)},
+			expects: func(mock *mock.MockLinodeClient) {
+				kernelUnchanged(mock)
+				mock.EXPECT().ListEvents(gomock.Any(), gomock.Any()).
+					Return([]linodego.Event{{Action: linodego.ActionLinodeReboot, Created: &now}}, nil)
+			},
+		},
+		{
+			name:        "Default policy without a maintenance window never reboots",
+			expects:     kernelChanged,
+			wantRequeue: reconciler.DefaultMachineControllerMaintenanceWindowDelay,
+			wantPending: true,
+			wantEvents:  []string{"Warning RebootRequired kernel changed: instance must be rebooted for the change to take effect"},
+			wantLog:     "Instance must be rebooted to apply configuration changes",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinodeClient := mock.NewMockLinodeClient(ctrl)
+			testcase.expects(mockLinodeClient)
+
+			machineScope := &scope.MachineScope{
+				LinodeClient:  mockLinodeClient,
+				LinodeCluster: &infrav1alpha2.LinodeCluster{},
+				LinodeMachine: &infrav1alpha2.LinodeMachine{
+					Spec: infrav1alpha2.LinodeMachineSpec{
+						InstanceID:        ptr.To(123),
+						RebootPolicy:      testcase.policy,
+						MaintenanceWindow: testcase.window,
+						Configuration:     &infrav1alpha2.InstanceConfiguration{Kernel: "linode/direct-disk"},
+					},
+					Status: infrav1alpha2.LinodeMachineStatus{RebootPendingSince: testcase.pendingSince},
+				},
+			}
+			if testcase.pendingSince != nil {
+				conditions.Set(machineScope.LinodeMachine, &clusterv1.Condition{
+					Type:    scope.ConditionRebootRequired,
+					Status:  corev1.ConditionTrue,
+					Message: "kernel",
+				})
+			}
+			logger, logs := bufferLogger()
+			recorder := record.NewFakeRecorder(10)
+
+			reconciler := &LinodeMachineReconciler{Recorder: recorder}
+			requeueAfter, err := reconciler.applyConfigChanges(context.Background(), logger, machineScope, 123)
+			if testcase.expectedError != "" {
+				assert.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testcase.wantRequeue, requeueAfter)
+			assert.Equal(t, testcase.wantPending, machineScope.LinodeMachine.Status.RebootPendingSince != nil)
+			assert.Equal(t, testcase.wantPending, conditions.IsTrue(machineScope.LinodeMachine, scope.ConditionRebootRequired))
+			close(recorder.Events)
+			var events []string
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			assert.Equal(t, testcase.wantEvents, events)
+			if testcase.wantLog != "" {
+				assert.Contains(t, logs.String(), testcase.wantLog)
+			} else {
+				assert.Empty(t, logs.String())
+			}
+		})
+	}
+}
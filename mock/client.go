@@ -45,6 +45,21 @@ func (m *MockLinodeClient) EXPECT() *MockLinodeClientMockRecorder {
 	return m.recorder
 }
 
+// AddInstanceIPAddress mocks base method.
+func (m *MockLinodeClient) AddInstanceIPAddress(ctx context.Context, linodeID int, public bool) (*linodego.InstanceIP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddInstanceIPAddress", ctx, linodeID, public)
+	ret0, _ := ret[0].(*linodego.InstanceIP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddInstanceIPAddress indicates an expected call of AddInstanceIPAddress.
+func (mr *MockLinodeClientMockRecorder) AddInstanceIPAddress(ctx, linodeID, public any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddInstanceIPAddress", reflect.TypeOf((*MockLinodeClient)(nil).AddInstanceIPAddress), ctx, linodeID, public)
+}
+
 // AssignPlacementGroupLinodes mocks base method.
 func (m *MockLinodeClient) AssignPlacementGroupLinodes(ctx context.Context, id int, options linodego.PlacementGroupAssignOptions) (*linodego.PlacementGroup, error) {
 	m.ctrl.T.Helper()
@@ -74,6 +89,21 @@ func (mr *MockLinodeClientMockRecorder) BootInstance(ctx, linodeID, configID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootInstance", reflect.TypeOf((*MockLinodeClient)(nil).BootInstance), ctx, linodeID, configID)
 }
 
+// CreateChildAccountToken mocks base method.
+func (m *MockLinodeClient) CreateChildAccountToken(ctx context.Context, euuid string) (*linodego.ChildAccountToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChildAccountToken", ctx, euuid)
+	ret0, _ := ret[0].(*linodego.ChildAccountToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChildAccountToken indicates an expected call of CreateChildAccountToken.
+func (mr *MockLinodeClientMockRecorder) CreateChildAccountToken(ctx, euuid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChildAccountToken", reflect.TypeOf((*MockLinodeClient)(nil).CreateChildAccountToken), ctx, euuid)
+}
+
 // CreateDomainRecord mocks base method.
 func (m *MockLinodeClient) CreateDomainRecord(ctx context.Context, domainID int, recordReq linodego.DomainRecordCreateOptions) (*linodego.DomainRecord, error) {
 	m.ctrl.T.Helper()
@@ -89,6 +119,21 @@ func (mr *MockLinodeClientMockRecorder) CreateDomainRecord(ctx, domainID, record
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDomainRecord", reflect.TypeOf((*MockLinodeClient)(nil).CreateDomainRecord), ctx, domainID, recordReq)
 }
 
+// CreateFirewallDevice mocks base method.
+func (m *MockLinodeClient) CreateFirewallDevice(ctx context.Context, firewallID int, opts linodego.FirewallDeviceCreateOptions) (*linodego.FirewallDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFirewallDevice", ctx, firewallID, opts)
+	ret0, _ := ret[0].(*linodego.FirewallDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFirewallDevice indicates an expected call of CreateFirewallDevice.
+func (mr *MockLinodeClientMockRecorder) CreateFirewallDevice(ctx, firewallID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFirewallDevice", reflect.TypeOf((*MockLinodeClient)(nil).CreateFirewallDevice), ctx, firewallID, opts)
+}
+
 // CreateInstance mocks base method.
 func (m *MockLinodeClient) CreateInstance(ctx context.Context, opts linodego.InstanceCreateOptions) (*linodego.Instance, error) {
 	m.ctrl.T.Helper()
@@ -119,6 +164,21 @@ func (mr *MockLinodeClientMockRecorder) CreateInstanceDisk(ctx, linodeID, opts a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInstanceDisk", reflect.TypeOf((*MockLinodeClient)(nil).CreateInstanceDisk), ctx, linodeID, opts)
 }
 
+// CreateLongviewClient mocks base method.
+func (m *MockLinodeClient) CreateLongviewClient(ctx context.Context, opts linodego.LongviewClientCreateOptions) (*linodego.LongviewClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLongviewClient", ctx, opts)
+	ret0, _ := ret[0].(*linodego.LongviewClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLongviewClient indicates an expected call of CreateLongviewClient.
+func (mr *MockLinodeClientMockRecorder) CreateLongviewClient(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLongviewClient", reflect.TypeOf((*MockLinodeClient)(nil).CreateLongviewClient), ctx, opts)
+}
+
 // CreateNodeBalancer mocks base method.
 func (m *MockLinodeClient) CreateNodeBalancer(ctx context.Context, opts linodego.NodeBalancerCreateOptions) (*linodego.NodeBalancer, error) {
 	m.ctrl.T.Helper()
@@ -253,6 +313,20 @@ func (mr *MockLinodeClientMockRecorder) DeleteDomainRecord(ctx, domainID, domain
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDomainRecord", reflect.TypeOf((*MockLinodeClient)(nil).DeleteDomainRecord), ctx, domainID, domainRecordID)
 }
 
+// DeleteFirewallDevice mocks base method.
+func (m *MockLinodeClient) DeleteFirewallDevice(ctx context.Context, firewallID, deviceID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFirewallDevice", ctx, firewallID, deviceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFirewallDevice indicates an expected call of DeleteFirewallDevice.
+func (mr *MockLinodeClientMockRecorder) DeleteFirewallDevice(ctx, firewallID, deviceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFirewallDevice", reflect.TypeOf((*MockLinodeClient)(nil).DeleteFirewallDevice), ctx, firewallID, deviceID)
+}
+
 // DeleteInstance mocks base method.
 func (m *MockLinodeClient) DeleteInstance(ctx context.Context, linodeID int) error {
 	m.ctrl.T.Helper()
@@ -267,6 +341,34 @@ func (mr *MockLinodeClientMockRecorder) DeleteInstance(ctx, linodeID any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInstance", reflect.TypeOf((*MockLinodeClient)(nil).DeleteInstance), ctx, linodeID)
 }
 
+// DeleteInstanceIPAddress mocks base method.
+func (m *MockLinodeClient) DeleteInstanceIPAddress(ctx context.Context, linodeID int, ipAddress string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInstanceIPAddress", ctx, linodeID, ipAddress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInstanceIPAddress indicates an expected call of DeleteInstanceIPAddress.
+func (mr *MockLinodeClientMockRecorder) DeleteInstanceIPAddress(ctx, linodeID, ipAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInstanceIPAddress", reflect.TypeOf((*MockLinodeClient)(nil).DeleteInstanceIPAddress), ctx, linodeID, ipAddress)
+}
+
+// DeleteLongviewClient mocks base method.
+func (m *MockLinodeClient) DeleteLongviewClient(ctx context.Context, clientID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLongviewClient", ctx, clientID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLongviewClient indicates an expected call of DeleteLongviewClient.
+func (mr *MockLinodeClientMockRecorder) DeleteLongviewClient(ctx, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLongviewClient", reflect.TypeOf((*MockLinodeClient)(nil).DeleteLongviewClient), ctx, clientID)
+}
+
 // DeleteNodeBalancer mocks base method.
 func (m *MockLinodeClient) DeleteNodeBalancer(ctx context.Context, nodebalancerID int) error {
 	m.ctrl.T.Helper()
@@ -337,6 +439,50 @@ func (mr *MockLinodeClientMockRecorder) DeleteVPC(ctx, vpcID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVPC", reflect.TypeOf((*MockLinodeClient)(nil).DeleteVPC), ctx, vpcID)
 }
 
+// DeleteVolume mocks base method.
+func (m *MockLinodeClient) DeleteVolume(ctx context.Context, volumeID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVolume", ctx, volumeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVolume indicates an expected call of DeleteVolume.
+func (mr *MockLinodeClientMockRecorder) DeleteVolume(ctx, volumeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVolume", reflect.TypeOf((*MockLinodeClient)(nil).DeleteVolume), ctx, volumeID)
+}
+
+// GetAccountTransfer mocks base method.
+func (m *MockLinodeClient) GetAccountTransfer(ctx context.Context) (*linodego.AccountTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountTransfer", ctx)
+	ret0, _ := ret[0].(*linodego.AccountTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountTransfer indicates an expected call of GetAccountTransfer.
+func (mr *MockLinodeClientMockRecorder) GetAccountTransfer(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTransfer", reflect.TypeOf((*MockLinodeClient)(nil).GetAccountTransfer), ctx)
+}
+
+// GetFirewallRules mocks base method.
+func (m *MockLinodeClient) GetFirewallRules(ctx context.Context, firewallID int) (*linodego.FirewallRuleSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFirewallRules", ctx, firewallID)
+	ret0, _ := ret[0].(*linodego.FirewallRuleSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFirewallRules indicates an expected call of GetFirewallRules.
+func (mr *MockLinodeClientMockRecorder) GetFirewallRules(ctx, firewallID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFirewallRules", reflect.TypeOf((*MockLinodeClient)(nil).GetFirewallRules), ctx, firewallID)
+}
+
 // GetImage mocks base method.
 func (m *MockLinodeClient) GetImage(ctx context.Context, imageID string) (*linodego.Image, error) {
 	m.ctrl.T.Helper()
@@ -397,6 +543,51 @@ func (mr *MockLinodeClientMockRecorder) GetInstanceIPAddresses(ctx, linodeID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceIPAddresses", reflect.TypeOf((*MockLinodeClient)(nil).GetInstanceIPAddresses), ctx, linodeID)
 }
 
+// GetInstanceTransfer mocks base method.
+func (m *MockLinodeClient) GetInstanceTransfer(ctx context.Context, linodeID int) (*linodego.InstanceTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstanceTransfer", ctx, linodeID)
+	ret0, _ := ret[0].(*linodego.InstanceTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstanceTransfer indicates an expected call of GetInstanceTransfer.
+func (mr *MockLinodeClientMockRecorder) GetInstanceTransfer(ctx, linodeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceTransfer", reflect.TypeOf((*MockLinodeClient)(nil).GetInstanceTransfer), ctx, linodeID)
+}
+
+// GetKernel mocks base method.
+func (m *MockLinodeClient) GetKernel(ctx context.Context, kernelID string) (*linodego.LinodeKernel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKernel", ctx, kernelID)
+	ret0, _ := ret[0].(*linodego.LinodeKernel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKernel indicates an expected call of GetKernel.
+func (mr *MockLinodeClientMockRecorder) GetKernel(ctx, kernelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKernel", reflect.TypeOf((*MockLinodeClient)(nil).GetKernel), ctx, kernelID)
+}
+
+// GetLongviewClient mocks base method.
+func (m *MockLinodeClient) GetLongviewClient(ctx context.Context, clientID int) (*linodego.LongviewClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLongviewClient", ctx, clientID)
+	ret0, _ := ret[0].(*linodego.LongviewClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLongviewClient indicates an expected call of GetLongviewClient.
+func (mr *MockLinodeClientMockRecorder) GetLongviewClient(ctx, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLongviewClient", reflect.TypeOf((*MockLinodeClient)(nil).GetLongviewClient), ctx, clientID)
+}
+
 // GetNodeBalancer mocks base method.
 func (m *MockLinodeClient) GetNodeBalancer(ctx context.Context, nodebalancerID int) (*linodego.NodeBalancer, error) {
 	m.ctrl.T.Helper()
@@ -517,6 +708,36 @@ func (mr *MockLinodeClientMockRecorder) GetVPC(ctx, vpcID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVPC", reflect.TypeOf((*MockLinodeClient)(nil).GetVPC), ctx, vpcID)
 }
 
+// GetVolume mocks base method.
+func (m *MockLinodeClient) GetVolume(ctx context.Context, volumeID int) (*linodego.Volume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVolume", ctx, volumeID)
+	ret0, _ := ret[0].(*linodego.Volume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVolume indicates an expected call of GetVolume.
+func (mr *MockLinodeClientMockRecorder) GetVolume(ctx, volumeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVolume", reflect.TypeOf((*MockLinodeClient)(nil).GetVolume), ctx, volumeID)
+}
+
+// ListDatabases mocks base method.
+func (m *MockLinodeClient) ListDatabases(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Database, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDatabases", ctx, opts)
+	ret0, _ := ret[0].([]linodego.Database)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDatabases indicates an expected call of ListDatabases.
+func (mr *MockLinodeClientMockRecorder) ListDatabases(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDatabases", reflect.TypeOf((*MockLinodeClient)(nil).ListDatabases), ctx, opts)
+}
+
 // ListDomainRecords mocks base method.
 func (m *MockLinodeClient) ListDomainRecords(ctx context.Context, domainID int, opts *linodego.ListOptions) ([]linodego.DomainRecord, error) {
 	m.ctrl.T.Helper()
@@ -547,6 +768,51 @@ func (mr *MockLinodeClientMockRecorder) ListDomains(ctx, opts any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDomains", reflect.TypeOf((*MockLinodeClient)(nil).ListDomains), ctx, opts)
 }
 
+// ListEvents mocks base method.
+func (m *MockLinodeClient) ListEvents(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", ctx, opts)
+	ret0, _ := ret[0].([]linodego.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockLinodeClientMockRecorder) ListEvents(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockLinodeClient)(nil).ListEvents), ctx, opts)
+}
+
+// ListFirewallDevices mocks base method.
+func (m *MockLinodeClient) ListFirewallDevices(ctx context.Context, firewallID int, opts *linodego.ListOptions) ([]linodego.FirewallDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFirewallDevices", ctx, firewallID, opts)
+	ret0, _ := ret[0].([]linodego.FirewallDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFirewallDevices indicates an expected call of ListFirewallDevices.
+func (mr *MockLinodeClientMockRecorder) ListFirewallDevices(ctx, firewallID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFirewallDevices", reflect.TypeOf((*MockLinodeClient)(nil).ListFirewallDevices), ctx, firewallID, opts)
+}
+
+// ListFirewalls mocks base method.
+func (m *MockLinodeClient) ListFirewalls(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Firewall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFirewalls", ctx, opts)
+	ret0, _ := ret[0].([]linodego.Firewall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFirewalls indicates an expected call of ListFirewalls.
+func (mr *MockLinodeClientMockRecorder) ListFirewalls(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFirewalls", reflect.TypeOf((*MockLinodeClient)(nil).ListFirewalls), ctx, opts)
+}
+
 // ListInstanceConfigs mocks base method.
 func (m *MockLinodeClient) ListInstanceConfigs(ctx context.Context, linodeID int, opts *linodego.ListOptions) ([]linodego.InstanceConfig, error) {
 	m.ctrl.T.Helper()
@@ -562,6 +828,36 @@ func (mr *MockLinodeClientMockRecorder) ListInstanceConfigs(ctx, linodeID, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstanceConfigs", reflect.TypeOf((*MockLinodeClient)(nil).ListInstanceConfigs), ctx, linodeID, opts)
 }
 
+// ListInstanceDisks mocks base method.
+func (m *MockLinodeClient) ListInstanceDisks(ctx context.Context, linodeID int, opts *linodego.ListOptions) ([]linodego.InstanceDisk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInstanceDisks", ctx, linodeID, opts)
+	ret0, _ := ret[0].([]linodego.InstanceDisk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInstanceDisks indicates an expected call of ListInstanceDisks.
+func (mr *MockLinodeClientMockRecorder) ListInstanceDisks(ctx, linodeID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstanceDisks", reflect.TypeOf((*MockLinodeClient)(nil).ListInstanceDisks), ctx, linodeID, opts)
+}
+
+// ListInstanceFirewalls mocks base method.
+func (m *MockLinodeClient) ListInstanceFirewalls(ctx context.Context, linodeID int, opts *linodego.ListOptions) ([]linodego.Firewall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInstanceFirewalls", ctx, linodeID, opts)
+	ret0, _ := ret[0].([]linodego.Firewall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInstanceFirewalls indicates an expected call of ListInstanceFirewalls.
+func (mr *MockLinodeClientMockRecorder) ListInstanceFirewalls(ctx, linodeID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstanceFirewalls", reflect.TypeOf((*MockLinodeClient)(nil).ListInstanceFirewalls), ctx, linodeID, opts)
+}
+
 // ListInstances mocks base method.
 func (m *MockLinodeClient) ListInstances(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Instance, error) {
 	m.ctrl.T.Helper()
@@ -577,6 +873,66 @@ func (mr *MockLinodeClientMockRecorder) ListInstances(ctx, opts any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstances", reflect.TypeOf((*MockLinodeClient)(nil).ListInstances), ctx, opts)
 }
 
+// ListNodeBalancerConfigs mocks base method.
+func (m *MockLinodeClient) ListNodeBalancerConfigs(ctx context.Context, nodebalancerID int, opts *linodego.ListOptions) ([]linodego.NodeBalancerConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNodeBalancerConfigs", ctx, nodebalancerID, opts)
+	ret0, _ := ret[0].([]linodego.NodeBalancerConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNodeBalancerConfigs indicates an expected call of ListNodeBalancerConfigs.
+func (mr *MockLinodeClientMockRecorder) ListNodeBalancerConfigs(ctx, nodebalancerID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodeBalancerConfigs", reflect.TypeOf((*MockLinodeClient)(nil).ListNodeBalancerConfigs), ctx, nodebalancerID, opts)
+}
+
+// ListNodeBalancerFirewalls mocks base method.
+func (m *MockLinodeClient) ListNodeBalancerFirewalls(ctx context.Context, nodebalancerID int, opts *linodego.ListOptions) ([]linodego.Firewall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNodeBalancerFirewalls", ctx, nodebalancerID, opts)
+	ret0, _ := ret[0].([]linodego.Firewall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNodeBalancerFirewalls indicates an expected call of ListNodeBalancerFirewalls.
+func (mr *MockLinodeClientMockRecorder) ListNodeBalancerFirewalls(ctx, nodebalancerID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodeBalancerFirewalls", reflect.TypeOf((*MockLinodeClient)(nil).ListNodeBalancerFirewalls), ctx, nodebalancerID, opts)
+}
+
+// ListNodeBalancerNodes mocks base method.
+func (m *MockLinodeClient) ListNodeBalancerNodes(ctx context.Context, nodebalancerID, configID int, opts *linodego.ListOptions) ([]linodego.NodeBalancerNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNodeBalancerNodes", ctx, nodebalancerID, configID, opts)
+	ret0, _ := ret[0].([]linodego.NodeBalancerNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNodeBalancerNodes indicates an expected call of ListNodeBalancerNodes.
+func (mr *MockLinodeClientMockRecorder) ListNodeBalancerNodes(ctx, nodebalancerID, configID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodeBalancerNodes", reflect.TypeOf((*MockLinodeClient)(nil).ListNodeBalancerNodes), ctx, nodebalancerID, configID, opts)
+}
+
+// ListNodeBalancers mocks base method.
+func (m *MockLinodeClient) ListNodeBalancers(ctx context.Context, opts *linodego.ListOptions) ([]linodego.NodeBalancer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNodeBalancers", ctx, opts)
+	ret0, _ := ret[0].([]linodego.NodeBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNodeBalancers indicates an expected call of ListNodeBalancers.
+func (mr *MockLinodeClientMockRecorder) ListNodeBalancers(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodeBalancers", reflect.TypeOf((*MockLinodeClient)(nil).ListNodeBalancers), ctx, opts)
+}
+
 // ListPlacementGroups mocks base method.
 func (m *MockLinodeClient) ListPlacementGroups(ctx context.Context, options *linodego.ListOptions) ([]linodego.PlacementGroup, error) {
 	m.ctrl.T.Helper()
@@ -592,6 +948,36 @@ func (mr *MockLinodeClientMockRecorder) ListPlacementGroups(ctx, options any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlacementGroups", reflect.TypeOf((*MockLinodeClient)(nil).ListPlacementGroups), ctx, options)
 }
 
+// ListRegionsAvailability mocks base method.
+func (m *MockLinodeClient) ListRegionsAvailability(ctx context.Context, opts *linodego.ListOptions) ([]linodego.RegionAvailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRegionsAvailability", ctx, opts)
+	ret0, _ := ret[0].([]linodego.RegionAvailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRegionsAvailability indicates an expected call of ListRegionsAvailability.
+func (mr *MockLinodeClientMockRecorder) ListRegionsAvailability(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegionsAvailability", reflect.TypeOf((*MockLinodeClient)(nil).ListRegionsAvailability), ctx, opts)
+}
+
+// ListSSHKeys mocks base method.
+func (m *MockLinodeClient) ListSSHKeys(ctx context.Context, opts *linodego.ListOptions) ([]linodego.SSHKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSSHKeys", ctx, opts)
+	ret0, _ := ret[0].([]linodego.SSHKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSSHKeys indicates an expected call of ListSSHKeys.
+func (mr *MockLinodeClientMockRecorder) ListSSHKeys(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSSHKeys", reflect.TypeOf((*MockLinodeClient)(nil).ListSSHKeys), ctx, opts)
+}
+
 // ListStackscripts mocks base method.
 func (m *MockLinodeClient) ListStackscripts(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Stackscript, error) {
 	m.ctrl.T.Helper()
@@ -607,6 +993,21 @@ func (mr *MockLinodeClientMockRecorder) ListStackscripts(ctx, opts any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStackscripts", reflect.TypeOf((*MockLinodeClient)(nil).ListStackscripts), ctx, opts)
 }
 
+// ListVPCIPAddresses mocks base method.
+func (m *MockLinodeClient) ListVPCIPAddresses(ctx context.Context, vpcID int, opts *linodego.ListOptions) ([]linodego.VPCIP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVPCIPAddresses", ctx, vpcID, opts)
+	ret0, _ := ret[0].([]linodego.VPCIP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVPCIPAddresses indicates an expected call of ListVPCIPAddresses.
+func (mr *MockLinodeClientMockRecorder) ListVPCIPAddresses(ctx, vpcID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVPCIPAddresses", reflect.TypeOf((*MockLinodeClient)(nil).ListVPCIPAddresses), ctx, vpcID, opts)
+}
+
 // ListVPCs mocks base method.
 func (m *MockLinodeClient) ListVPCs(ctx context.Context, opts *linodego.ListOptions) ([]linodego.VPC, error) {
 	m.ctrl.T.Helper()
@@ -622,63 +1023,253 @@ func (mr *MockLinodeClientMockRecorder) ListVPCs(ctx, opts any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVPCs", reflect.TypeOf((*MockLinodeClient)(nil).ListVPCs), ctx, opts)
 }
 
-// ResizeInstanceDisk mocks base method.
-func (m *MockLinodeClient) ResizeInstanceDisk(ctx context.Context, linodeID, diskID, size int) error {
+// ListVolumes mocks base method.
+func (m *MockLinodeClient) ListVolumes(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Volume, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResizeInstanceDisk", ctx, linodeID, diskID, size)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListVolumes", ctx, opts)
+	ret0, _ := ret[0].([]linodego.Volume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ResizeInstanceDisk indicates an expected call of ResizeInstanceDisk.
-func (mr *MockLinodeClientMockRecorder) ResizeInstanceDisk(ctx, linodeID, diskID, size any) *gomock.Call {
+// ListVolumes indicates an expected call of ListVolumes.
+func (mr *MockLinodeClientMockRecorder) ListVolumes(ctx, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResizeInstanceDisk", reflect.TypeOf((*MockLinodeClient)(nil).ResizeInstanceDisk), ctx, linodeID, diskID, size)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVolumes", reflect.TypeOf((*MockLinodeClient)(nil).ListVolumes), ctx, opts)
 }
 
-// UnassignPlacementGroupLinodes mocks base method.
-func (m *MockLinodeClient) UnassignPlacementGroupLinodes(ctx context.Context, id int, options linodego.PlacementGroupUnAssignOptions) (*linodego.PlacementGroup, error) {
+// MigrateInstance mocks base method.
+func (m *MockLinodeClient) MigrateInstance(ctx context.Context, linodeID int, opts linodego.InstanceMigrateOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnassignPlacementGroupLinodes", ctx, id, options)
-	ret0, _ := ret[0].(*linodego.PlacementGroup)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "MigrateInstance", ctx, linodeID, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// UnassignPlacementGroupLinodes indicates an expected call of UnassignPlacementGroupLinodes.
-func (mr *MockLinodeClientMockRecorder) UnassignPlacementGroupLinodes(ctx, id, options any) *gomock.Call {
+// MigrateInstance indicates an expected call of MigrateInstance.
+func (mr *MockLinodeClientMockRecorder) MigrateInstance(ctx, linodeID, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignPlacementGroupLinodes", reflect.TypeOf((*MockLinodeClient)(nil).UnassignPlacementGroupLinodes), ctx, id, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateInstance", reflect.TypeOf((*MockLinodeClient)(nil).MigrateInstance), ctx, linodeID, opts)
 }
 
-// UpdateDomainRecord mocks base method.
-func (m *MockLinodeClient) UpdateDomainRecord(ctx context.Context, domainID, domainRecordID int, recordReq linodego.DomainRecordUpdateOptions) (*linodego.DomainRecord, error) {
+// RebootInstance mocks base method.
+func (m *MockLinodeClient) RebootInstance(ctx context.Context, linodeID, configID int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateDomainRecord", ctx, domainID, domainRecordID, recordReq)
-	ret0, _ := ret[0].(*linodego.DomainRecord)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RebootInstance", ctx, linodeID, configID)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// UpdateDomainRecord indicates an expected call of UpdateDomainRecord.
-func (mr *MockLinodeClientMockRecorder) UpdateDomainRecord(ctx, domainID, domainRecordID, recordReq any) *gomock.Call {
+// RebootInstance indicates an expected call of RebootInstance.
+func (mr *MockLinodeClientMockRecorder) RebootInstance(ctx, linodeID, configID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDomainRecord", reflect.TypeOf((*MockLinodeClient)(nil).UpdateDomainRecord), ctx, domainID, domainRecordID, recordReq)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebootInstance", reflect.TypeOf((*MockLinodeClient)(nil).RebootInstance), ctx, linodeID, configID)
 }
 
-// UpdateInstanceConfig mocks base method.
-func (m *MockLinodeClient) UpdateInstanceConfig(ctx context.Context, linodeID, configID int, opts linodego.InstanceConfigUpdateOptions) (*linodego.InstanceConfig, error) {
+// RebuildInstance mocks base method.
+func (m *MockLinodeClient) RebuildInstance(ctx context.Context, linodeID int, opts linodego.InstanceRebuildOptions) (*linodego.Instance, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateInstanceConfig", ctx, linodeID, configID, opts)
-	ret0, _ := ret[0].(*linodego.InstanceConfig)
+	ret := m.ctrl.Call(m, "RebuildInstance", ctx, linodeID, opts)
+	ret0, _ := ret[0].(*linodego.Instance)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateInstanceConfig indicates an expected call of UpdateInstanceConfig.
-func (mr *MockLinodeClientMockRecorder) UpdateInstanceConfig(ctx, linodeID, configID, opts any) *gomock.Call {
+// RebuildInstance indicates an expected call of RebuildInstance.
+func (mr *MockLinodeClientMockRecorder) RebuildInstance(ctx, linodeID, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstanceConfig", reflect.TypeOf((*MockLinodeClient)(nil).UpdateInstanceConfig), ctx, linodeID, configID, opts)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildInstance", reflect.TypeOf((*MockLinodeClient)(nil).RebuildInstance), ctx, linodeID, opts)
+}
+
+// RescueInstance mocks base method.
+func (m *MockLinodeClient) RescueInstance(ctx context.Context, linodeID int, opts linodego.InstanceRescueOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescueInstance", ctx, linodeID, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RescueInstance indicates an expected call of RescueInstance.
+func (mr *MockLinodeClientMockRecorder) RescueInstance(ctx, linodeID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescueInstance", reflect.TypeOf((*MockLinodeClient)(nil).RescueInstance), ctx, linodeID, opts)
+}
+
+// ResizeInstance mocks base method.
+func (m *MockLinodeClient) ResizeInstance(ctx context.Context, linodeID int, opts linodego.InstanceResizeOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResizeInstance", ctx, linodeID, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResizeInstance indicates an expected call of ResizeInstance.
+func (mr *MockLinodeClientMockRecorder) ResizeInstance(ctx, linodeID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResizeInstance", reflect.TypeOf((*MockLinodeClient)(nil).ResizeInstance), ctx, linodeID, opts)
+}
+
+// ResizeInstanceDisk mocks base method.
+func (m *MockLinodeClient) ResizeInstanceDisk(ctx context.Context, linodeID, diskID, size int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResizeInstanceDisk", ctx, linodeID, diskID, size)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResizeInstanceDisk indicates an expected call of ResizeInstanceDisk.
+func (mr *MockLinodeClientMockRecorder) ResizeInstanceDisk(ctx, linodeID, diskID, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResizeInstanceDisk", reflect.TypeOf((*MockLinodeClient)(nil).ResizeInstanceDisk), ctx, linodeID, diskID, size)
+}
+
+// ShutdownInstance mocks base method.
+func (m *MockLinodeClient) ShutdownInstance(ctx context.Context, linodeID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShutdownInstance", ctx, linodeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShutdownInstance indicates an expected call of ShutdownInstance.
+func (mr *MockLinodeClientMockRecorder) ShutdownInstance(ctx, linodeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShutdownInstance", reflect.TypeOf((*MockLinodeClient)(nil).ShutdownInstance), ctx, linodeID)
+}
+
+// UnassignPlacementGroupLinodes mocks base method.
+func (m *MockLinodeClient) UnassignPlacementGroupLinodes(ctx context.Context, id int, options linodego.PlacementGroupUnAssignOptions) (*linodego.PlacementGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnassignPlacementGroupLinodes", ctx, id, options)
+	ret0, _ := ret[0].(*linodego.PlacementGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnassignPlacementGroupLinodes indicates an expected call of UnassignPlacementGroupLinodes.
+func (mr *MockLinodeClientMockRecorder) UnassignPlacementGroupLinodes(ctx, id, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignPlacementGroupLinodes", reflect.TypeOf((*MockLinodeClient)(nil).UnassignPlacementGroupLinodes), ctx, id, options)
+}
+
+// UpdateDomainRecord mocks base method.
+func (m *MockLinodeClient) UpdateDomainRecord(ctx context.Context, domainID, domainRecordID int, recordReq linodego.DomainRecordUpdateOptions) (*linodego.DomainRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDomainRecord", ctx, domainID, domainRecordID, recordReq)
+	ret0, _ := ret[0].(*linodego.DomainRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDomainRecord indicates an expected call of UpdateDomainRecord.
+func (mr *MockLinodeClientMockRecorder) UpdateDomainRecord(ctx, domainID, domainRecordID, recordReq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDomainRecord", reflect.TypeOf((*MockLinodeClient)(nil).UpdateDomainRecord), ctx, domainID, domainRecordID, recordReq)
+}
+
+// UpdateFirewallRules mocks base method.
+func (m *MockLinodeClient) UpdateFirewallRules(ctx context.Context, firewallID int, rules linodego.FirewallRuleSet) (*linodego.FirewallRuleSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFirewallRules", ctx, firewallID, rules)
+	ret0, _ := ret[0].(*linodego.FirewallRuleSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFirewallRules indicates an expected call of UpdateFirewallRules.
+func (mr *MockLinodeClientMockRecorder) UpdateFirewallRules(ctx, firewallID, rules any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFirewallRules", reflect.TypeOf((*MockLinodeClient)(nil).UpdateFirewallRules), ctx, firewallID, rules)
+}
+
+// UpdateInstance mocks base method.
+func (m *MockLinodeClient) UpdateInstance(ctx context.Context, linodeID int, opts linodego.InstanceUpdateOptions) (*linodego.Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInstance", ctx, linodeID, opts)
+	ret0, _ := ret[0].(*linodego.Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateInstance indicates an expected call of UpdateInstance.
+func (mr *MockLinodeClientMockRecorder) UpdateInstance(ctx, linodeID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstance", reflect.TypeOf((*MockLinodeClient)(nil).UpdateInstance), ctx, linodeID, opts)
+}
+
+// UpdateInstanceConfig mocks base method.
+func (m *MockLinodeClient) UpdateInstanceConfig(ctx context.Context, linodeID, configID int, opts linodego.InstanceConfigUpdateOptions) (*linodego.InstanceConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInstanceConfig", ctx, linodeID, configID, opts)
+	ret0, _ := ret[0].(*linodego.InstanceConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateInstanceConfig indicates an expected call of UpdateInstanceConfig.
+func (mr *MockLinodeClientMockRecorder) UpdateInstanceConfig(ctx, linodeID, configID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstanceConfig", reflect.TypeOf((*MockLinodeClient)(nil).UpdateInstanceConfig), ctx, linodeID, configID, opts)
+}
+
+// UpdateInstanceDisk mocks base method.
+func (m *MockLinodeClient) UpdateInstanceDisk(ctx context.Context, linodeID, diskID int, opts linodego.InstanceDiskUpdateOptions) (*linodego.InstanceDisk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInstanceDisk", ctx, linodeID, diskID, opts)
+	ret0, _ := ret[0].(*linodego.InstanceDisk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateInstanceDisk indicates an expected call of UpdateInstanceDisk.
+func (mr *MockLinodeClientMockRecorder) UpdateInstanceDisk(ctx, linodeID, diskID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstanceDisk", reflect.TypeOf((*MockLinodeClient)(nil).UpdateInstanceDisk), ctx, linodeID, diskID, opts)
+}
+
+// UpdateMySQLDatabase mocks base method.
+func (m *MockLinodeClient) UpdateMySQLDatabase(ctx context.Context, databaseID int, opts linodego.MySQLUpdateOptions) (*linodego.MySQLDatabase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMySQLDatabase", ctx, databaseID, opts)
+	ret0, _ := ret[0].(*linodego.MySQLDatabase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMySQLDatabase indicates an expected call of UpdateMySQLDatabase.
+func (mr *MockLinodeClientMockRecorder) UpdateMySQLDatabase(ctx, databaseID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMySQLDatabase", reflect.TypeOf((*MockLinodeClient)(nil).UpdateMySQLDatabase), ctx, databaseID, opts)
+}
+
+// UpdateNodeBalancer mocks base method.
+func (m *MockLinodeClient) UpdateNodeBalancer(ctx context.Context, nodebalancerID int, opts linodego.NodeBalancerUpdateOptions) (*linodego.NodeBalancer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNodeBalancer", ctx, nodebalancerID, opts)
+	ret0, _ := ret[0].(*linodego.NodeBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNodeBalancer indicates an expected call of UpdateNodeBalancer.
+func (mr *MockLinodeClientMockRecorder) UpdateNodeBalancer(ctx, nodebalancerID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNodeBalancer", reflect.TypeOf((*MockLinodeClient)(nil).UpdateNodeBalancer), ctx, nodebalancerID, opts)
+}
+
+// UpdateNodeBalancerConfig mocks base method.
+func (m *MockLinodeClient) UpdateNodeBalancerConfig(ctx context.Context, nodebalancerID, configID int, opts linodego.NodeBalancerConfigUpdateOptions) (*linodego.NodeBalancerConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNodeBalancerConfig", ctx, nodebalancerID, configID, opts)
+	ret0, _ := ret[0].(*linodego.NodeBalancerConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNodeBalancerConfig indicates an expected call of UpdateNodeBalancerConfig.
+func (mr *MockLinodeClientMockRecorder) UpdateNodeBalancerConfig(ctx, nodebalancerID, configID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNodeBalancerConfig", reflect.TypeOf((*MockLinodeClient)(nil).UpdateNodeBalancerConfig), ctx, nodebalancerID, configID, opts)
 }
 
 // UpdatePlacementGroup mocks base method.
@@ -696,6 +1287,36 @@ func (mr *MockLinodeClientMockRecorder) UpdatePlacementGroup(ctx, id, options an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlacementGroup", reflect.TypeOf((*MockLinodeClient)(nil).UpdatePlacementGroup), ctx, id, options)
 }
 
+// UpdatePostgresDatabase mocks base method.
+func (m *MockLinodeClient) UpdatePostgresDatabase(ctx context.Context, databaseID int, opts linodego.PostgresUpdateOptions) (*linodego.PostgresDatabase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePostgresDatabase", ctx, databaseID, opts)
+	ret0, _ := ret[0].(*linodego.PostgresDatabase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePostgresDatabase indicates an expected call of UpdatePostgresDatabase.
+func (mr *MockLinodeClientMockRecorder) UpdatePostgresDatabase(ctx, databaseID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePostgresDatabase", reflect.TypeOf((*MockLinodeClient)(nil).UpdatePostgresDatabase), ctx, databaseID, opts)
+}
+
+// UpdateVolume mocks base method.
+func (m *MockLinodeClient) UpdateVolume(ctx context.Context, volumeID int, opts linodego.VolumeUpdateOptions) (*linodego.Volume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVolume", ctx, volumeID, opts)
+	ret0, _ := ret[0].(*linodego.Volume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVolume indicates an expected call of UpdateVolume.
+func (mr *MockLinodeClientMockRecorder) UpdateVolume(ctx, volumeID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVolume", reflect.TypeOf((*MockLinodeClient)(nil).UpdateVolume), ctx, volumeID, opts)
+}
+
 // MockAkamClient is a mock of AkamClient interface.
 type MockAkamClient struct {
 	ctrl     *gomock.Controller
@@ -909,6 +1530,21 @@ func (m *MockLinodeInstanceClient) EXPECT() *MockLinodeInstanceClientMockRecorde
 	return m.recorder
 }
 
+// AddInstanceIPAddress mocks base method.
+func (m *MockLinodeInstanceClient) AddInstanceIPAddress(ctx context.Context, linodeID int, public bool) (*linodego.InstanceIP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddInstanceIPAddress", ctx, linodeID, public)
+	ret0, _ := ret[0].(*linodego.InstanceIP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddInstanceIPAddress indicates an expected call of AddInstanceIPAddress.
+func (mr *MockLinodeInstanceClientMockRecorder) AddInstanceIPAddress(ctx, linodeID, public any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddInstanceIPAddress", reflect.TypeOf((*MockLinodeInstanceClient)(nil).AddInstanceIPAddress), ctx, linodeID, public)
+}
+
 // BootInstance mocks base method.
 func (m *MockLinodeInstanceClient) BootInstance(ctx context.Context, linodeID, configID int) error {
 	m.ctrl.T.Helper()
@@ -982,6 +1618,20 @@ func (mr *MockLinodeInstanceClientMockRecorder) DeleteInstance(ctx, linodeID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInstance", reflect.TypeOf((*MockLinodeInstanceClient)(nil).DeleteInstance), ctx, linodeID)
 }
 
+// DeleteInstanceIPAddress mocks base method.
+func (m *MockLinodeInstanceClient) DeleteInstanceIPAddress(ctx context.Context, linodeID int, ipAddress string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInstanceIPAddress", ctx, linodeID, ipAddress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInstanceIPAddress indicates an expected call of DeleteInstanceIPAddress.
+func (mr *MockLinodeInstanceClientMockRecorder) DeleteInstanceIPAddress(ctx, linodeID, ipAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInstanceIPAddress", reflect.TypeOf((*MockLinodeInstanceClient)(nil).DeleteInstanceIPAddress), ctx, linodeID, ipAddress)
+}
+
 // GetImage mocks base method.
 func (m *MockLinodeInstanceClient) GetImage(ctx context.Context, imageID string) (*linodego.Image, error) {
 	m.ctrl.T.Helper()
@@ -1042,6 +1692,36 @@ func (mr *MockLinodeInstanceClientMockRecorder) GetInstanceIPAddresses(ctx, lino
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceIPAddresses", reflect.TypeOf((*MockLinodeInstanceClient)(nil).GetInstanceIPAddresses), ctx, linodeID)
 }
 
+// GetInstanceTransfer mocks base method.
+func (m *MockLinodeInstanceClient) GetInstanceTransfer(ctx context.Context, linodeID int) (*linodego.InstanceTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstanceTransfer", ctx, linodeID)
+	ret0, _ := ret[0].(*linodego.InstanceTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstanceTransfer indicates an expected call of GetInstanceTransfer.
+func (mr *MockLinodeInstanceClientMockRecorder) GetInstanceTransfer(ctx, linodeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceTransfer", reflect.TypeOf((*MockLinodeInstanceClient)(nil).GetInstanceTransfer), ctx, linodeID)
+}
+
+// GetKernel mocks base method.
+func (m *MockLinodeInstanceClient) GetKernel(ctx context.Context, kernelID string) (*linodego.LinodeKernel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKernel", ctx, kernelID)
+	ret0, _ := ret[0].(*linodego.LinodeKernel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKernel indicates an expected call of GetKernel.
+func (mr *MockLinodeInstanceClientMockRecorder) GetKernel(ctx, kernelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKernel", reflect.TypeOf((*MockLinodeInstanceClient)(nil).GetKernel), ctx, kernelID)
+}
+
 // GetRegion mocks base method.
 func (m *MockLinodeInstanceClient) GetRegion(ctx context.Context, regionID string) (*linodego.Region, error) {
 	m.ctrl.T.Helper()
@@ -1087,6 +1767,21 @@ func (mr *MockLinodeInstanceClientMockRecorder) ListInstanceConfigs(ctx, linodeI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstanceConfigs", reflect.TypeOf((*MockLinodeInstanceClient)(nil).ListInstanceConfigs), ctx, linodeID, opts)
 }
 
+// ListInstanceDisks mocks base method.
+func (m *MockLinodeInstanceClient) ListInstanceDisks(ctx context.Context, linodeID int, opts *linodego.ListOptions) ([]linodego.InstanceDisk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInstanceDisks", ctx, linodeID, opts)
+	ret0, _ := ret[0].([]linodego.InstanceDisk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInstanceDisks indicates an expected call of ListInstanceDisks.
+func (mr *MockLinodeInstanceClientMockRecorder) ListInstanceDisks(ctx, linodeID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstanceDisks", reflect.TypeOf((*MockLinodeInstanceClient)(nil).ListInstanceDisks), ctx, linodeID, opts)
+}
+
 // ListInstances mocks base method.
 func (m *MockLinodeInstanceClient) ListInstances(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Instance, error) {
 	m.ctrl.T.Helper()
@@ -1102,6 +1797,21 @@ func (mr *MockLinodeInstanceClientMockRecorder) ListInstances(ctx, opts any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstances", reflect.TypeOf((*MockLinodeInstanceClient)(nil).ListInstances), ctx, opts)
 }
 
+// ListRegionsAvailability mocks base method.
+func (m *MockLinodeInstanceClient) ListRegionsAvailability(ctx context.Context, opts *linodego.ListOptions) ([]linodego.RegionAvailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRegionsAvailability", ctx, opts)
+	ret0, _ := ret[0].([]linodego.RegionAvailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRegionsAvailability indicates an expected call of ListRegionsAvailability.
+func (mr *MockLinodeInstanceClientMockRecorder) ListRegionsAvailability(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegionsAvailability", reflect.TypeOf((*MockLinodeInstanceClient)(nil).ListRegionsAvailability), ctx, opts)
+}
+
 // ListStackscripts mocks base method.
 func (m *MockLinodeInstanceClient) ListStackscripts(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Stackscript, error) {
 	m.ctrl.T.Helper()
@@ -1117,91 +1827,206 @@ func (mr *MockLinodeInstanceClientMockRecorder) ListStackscripts(ctx, opts any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStackscripts", reflect.TypeOf((*MockLinodeInstanceClient)(nil).ListStackscripts), ctx, opts)
 }
 
-// ResizeInstanceDisk mocks base method.
-func (m *MockLinodeInstanceClient) ResizeInstanceDisk(ctx context.Context, linodeID, diskID, size int) error {
+// MigrateInstance mocks base method.
+func (m *MockLinodeInstanceClient) MigrateInstance(ctx context.Context, linodeID int, opts linodego.InstanceMigrateOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResizeInstanceDisk", ctx, linodeID, diskID, size)
+	ret := m.ctrl.Call(m, "MigrateInstance", ctx, linodeID, opts)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ResizeInstanceDisk indicates an expected call of ResizeInstanceDisk.
-func (mr *MockLinodeInstanceClientMockRecorder) ResizeInstanceDisk(ctx, linodeID, diskID, size any) *gomock.Call {
+// MigrateInstance indicates an expected call of MigrateInstance.
+func (mr *MockLinodeInstanceClientMockRecorder) MigrateInstance(ctx, linodeID, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResizeInstanceDisk", reflect.TypeOf((*MockLinodeInstanceClient)(nil).ResizeInstanceDisk), ctx, linodeID, diskID, size)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateInstance", reflect.TypeOf((*MockLinodeInstanceClient)(nil).MigrateInstance), ctx, linodeID, opts)
 }
 
-// UpdateInstanceConfig mocks base method.
-func (m *MockLinodeInstanceClient) UpdateInstanceConfig(ctx context.Context, linodeID, configID int, opts linodego.InstanceConfigUpdateOptions) (*linodego.InstanceConfig, error) {
+// RebootInstance mocks base method.
+func (m *MockLinodeInstanceClient) RebootInstance(ctx context.Context, linodeID, configID int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateInstanceConfig", ctx, linodeID, configID, opts)
-	ret0, _ := ret[0].(*linodego.InstanceConfig)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RebootInstance", ctx, linodeID, configID)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// UpdateInstanceConfig indicates an expected call of UpdateInstanceConfig.
-func (mr *MockLinodeInstanceClientMockRecorder) UpdateInstanceConfig(ctx, linodeID, configID, opts any) *gomock.Call {
+// RebootInstance indicates an expected call of RebootInstance.
+func (mr *MockLinodeInstanceClientMockRecorder) RebootInstance(ctx, linodeID, configID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstanceConfig", reflect.TypeOf((*MockLinodeInstanceClient)(nil).UpdateInstanceConfig), ctx, linodeID, configID, opts)
-}
-
-// MockLinodeVPCClient is a mock of LinodeVPCClient interface.
-type MockLinodeVPCClient struct {
-	ctrl     *gomock.Controller
-	recorder *MockLinodeVPCClientMockRecorder
-}
-
-// MockLinodeVPCClientMockRecorder is the mock recorder for MockLinodeVPCClient.
-type MockLinodeVPCClientMockRecorder struct {
-	mock *MockLinodeVPCClient
-}
-
-// NewMockLinodeVPCClient creates a new mock instance.
-func NewMockLinodeVPCClient(ctrl *gomock.Controller) *MockLinodeVPCClient {
-	mock := &MockLinodeVPCClient{ctrl: ctrl}
-	mock.recorder = &MockLinodeVPCClientMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockLinodeVPCClient) EXPECT() *MockLinodeVPCClientMockRecorder {
-	return m.recorder
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebootInstance", reflect.TypeOf((*MockLinodeInstanceClient)(nil).RebootInstance), ctx, linodeID, configID)
 }
 
-// CreateVPC mocks base method.
-func (m *MockLinodeVPCClient) CreateVPC(ctx context.Context, opts linodego.VPCCreateOptions) (*linodego.VPC, error) {
+// RebuildInstance mocks base method.
+func (m *MockLinodeInstanceClient) RebuildInstance(ctx context.Context, linodeID int, opts linodego.InstanceRebuildOptions) (*linodego.Instance, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateVPC", ctx, opts)
-	ret0, _ := ret[0].(*linodego.VPC)
+	ret := m.ctrl.Call(m, "RebuildInstance", ctx, linodeID, opts)
+	ret0, _ := ret[0].(*linodego.Instance)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateVPC indicates an expected call of CreateVPC.
-func (mr *MockLinodeVPCClientMockRecorder) CreateVPC(ctx, opts any) *gomock.Call {
+// RebuildInstance indicates an expected call of RebuildInstance.
+func (mr *MockLinodeInstanceClientMockRecorder) RebuildInstance(ctx, linodeID, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVPC", reflect.TypeOf((*MockLinodeVPCClient)(nil).CreateVPC), ctx, opts)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildInstance", reflect.TypeOf((*MockLinodeInstanceClient)(nil).RebuildInstance), ctx, linodeID, opts)
 }
 
-// DeleteVPC mocks base method.
-func (m *MockLinodeVPCClient) DeleteVPC(ctx context.Context, vpcID int) error {
+// RescueInstance mocks base method.
+func (m *MockLinodeInstanceClient) RescueInstance(ctx context.Context, linodeID int, opts linodego.InstanceRescueOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteVPC", ctx, vpcID)
+	ret := m.ctrl.Call(m, "RescueInstance", ctx, linodeID, opts)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteVPC indicates an expected call of DeleteVPC.
-func (mr *MockLinodeVPCClientMockRecorder) DeleteVPC(ctx, vpcID any) *gomock.Call {
+// RescueInstance indicates an expected call of RescueInstance.
+func (mr *MockLinodeInstanceClientMockRecorder) RescueInstance(ctx, linodeID, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVPC", reflect.TypeOf((*MockLinodeVPCClient)(nil).DeleteVPC), ctx, vpcID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescueInstance", reflect.TypeOf((*MockLinodeInstanceClient)(nil).RescueInstance), ctx, linodeID, opts)
 }
 
-// GetVPC mocks base method.
-func (m *MockLinodeVPCClient) GetVPC(ctx context.Context, vpcID int) (*linodego.VPC, error) {
+// ResizeInstance mocks base method.
+func (m *MockLinodeInstanceClient) ResizeInstance(ctx context.Context, linodeID int, opts linodego.InstanceResizeOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetVPC", ctx, vpcID)
+	ret := m.ctrl.Call(m, "ResizeInstance", ctx, linodeID, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResizeInstance indicates an expected call of ResizeInstance.
+func (mr *MockLinodeInstanceClientMockRecorder) ResizeInstance(ctx, linodeID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResizeInstance", reflect.TypeOf((*MockLinodeInstanceClient)(nil).ResizeInstance), ctx, linodeID, opts)
+}
+
+// ResizeInstanceDisk mocks base method.
+func (m *MockLinodeInstanceClient) ResizeInstanceDisk(ctx context.Context, linodeID, diskID, size int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResizeInstanceDisk", ctx, linodeID, diskID, size)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResizeInstanceDisk indicates an expected call of ResizeInstanceDisk.
+func (mr *MockLinodeInstanceClientMockRecorder) ResizeInstanceDisk(ctx, linodeID, diskID, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResizeInstanceDisk", reflect.TypeOf((*MockLinodeInstanceClient)(nil).ResizeInstanceDisk), ctx, linodeID, diskID, size)
+}
+
+// ShutdownInstance mocks base method.
+func (m *MockLinodeInstanceClient) ShutdownInstance(ctx context.Context, linodeID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShutdownInstance", ctx, linodeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShutdownInstance indicates an expected call of ShutdownInstance.
+func (mr *MockLinodeInstanceClientMockRecorder) ShutdownInstance(ctx, linodeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShutdownInstance", reflect.TypeOf((*MockLinodeInstanceClient)(nil).ShutdownInstance), ctx, linodeID)
+}
+
+// UpdateInstance mocks base method.
+func (m *MockLinodeInstanceClient) UpdateInstance(ctx context.Context, linodeID int, opts linodego.InstanceUpdateOptions) (*linodego.Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInstance", ctx, linodeID, opts)
+	ret0, _ := ret[0].(*linodego.Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateInstance indicates an expected call of UpdateInstance.
+func (mr *MockLinodeInstanceClientMockRecorder) UpdateInstance(ctx, linodeID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstance", reflect.TypeOf((*MockLinodeInstanceClient)(nil).UpdateInstance), ctx, linodeID, opts)
+}
+
+// UpdateInstanceConfig mocks base method.
+func (m *MockLinodeInstanceClient) UpdateInstanceConfig(ctx context.Context, linodeID, configID int, opts linodego.InstanceConfigUpdateOptions) (*linodego.InstanceConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInstanceConfig", ctx, linodeID, configID, opts)
+	ret0, _ := ret[0].(*linodego.InstanceConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateInstanceConfig indicates an expected call of UpdateInstanceConfig.
+func (mr *MockLinodeInstanceClientMockRecorder) UpdateInstanceConfig(ctx, linodeID, configID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstanceConfig", reflect.TypeOf((*MockLinodeInstanceClient)(nil).UpdateInstanceConfig), ctx, linodeID, configID, opts)
+}
+
+// UpdateInstanceDisk mocks base method.
+func (m *MockLinodeInstanceClient) UpdateInstanceDisk(ctx context.Context, linodeID, diskID int, opts linodego.InstanceDiskUpdateOptions) (*linodego.InstanceDisk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInstanceDisk", ctx, linodeID, diskID, opts)
+	ret0, _ := ret[0].(*linodego.InstanceDisk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateInstanceDisk indicates an expected call of UpdateInstanceDisk.
+func (mr *MockLinodeInstanceClientMockRecorder) UpdateInstanceDisk(ctx, linodeID, diskID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstanceDisk", reflect.TypeOf((*MockLinodeInstanceClient)(nil).UpdateInstanceDisk), ctx, linodeID, diskID, opts)
+}
+
+// MockLinodeVPCClient is a mock of LinodeVPCClient interface.
+type MockLinodeVPCClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinodeVPCClientMockRecorder
+}
+
+// MockLinodeVPCClientMockRecorder is the mock recorder for MockLinodeVPCClient.
+type MockLinodeVPCClientMockRecorder struct {
+	mock *MockLinodeVPCClient
+}
+
+// NewMockLinodeVPCClient creates a new mock instance.
+func NewMockLinodeVPCClient(ctrl *gomock.Controller) *MockLinodeVPCClient {
+	mock := &MockLinodeVPCClient{ctrl: ctrl}
+	mock.recorder = &MockLinodeVPCClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinodeVPCClient) EXPECT() *MockLinodeVPCClientMockRecorder {
+	return m.recorder
+}
+
+// CreateVPC mocks base method.
+func (m *MockLinodeVPCClient) CreateVPC(ctx context.Context, opts linodego.VPCCreateOptions) (*linodego.VPC, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVPC", ctx, opts)
+	ret0, _ := ret[0].(*linodego.VPC)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVPC indicates an expected call of CreateVPC.
+func (mr *MockLinodeVPCClientMockRecorder) CreateVPC(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVPC", reflect.TypeOf((*MockLinodeVPCClient)(nil).CreateVPC), ctx, opts)
+}
+
+// DeleteVPC mocks base method.
+func (m *MockLinodeVPCClient) DeleteVPC(ctx context.Context, vpcID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVPC", ctx, vpcID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVPC indicates an expected call of DeleteVPC.
+func (mr *MockLinodeVPCClientMockRecorder) DeleteVPC(ctx, vpcID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVPC", reflect.TypeOf((*MockLinodeVPCClient)(nil).DeleteVPC), ctx, vpcID)
+}
+
+// GetVPC mocks base method.
+func (m *MockLinodeVPCClient) GetVPC(ctx context.Context, vpcID int) (*linodego.VPC, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVPC", ctx, vpcID)
 	ret0, _ := ret[0].(*linodego.VPC)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
@@ -1213,6 +2038,21 @@ func (mr *MockLinodeVPCClientMockRecorder) GetVPC(ctx, vpcID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVPC", reflect.TypeOf((*MockLinodeVPCClient)(nil).GetVPC), ctx, vpcID)
 }
 
+// ListVPCIPAddresses mocks base method.
+func (m *MockLinodeVPCClient) ListVPCIPAddresses(ctx context.Context, vpcID int, opts *linodego.ListOptions) ([]linodego.VPCIP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVPCIPAddresses", ctx, vpcID, opts)
+	ret0, _ := ret[0].([]linodego.VPCIP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVPCIPAddresses indicates an expected call of ListVPCIPAddresses.
+func (mr *MockLinodeVPCClientMockRecorder) ListVPCIPAddresses(ctx, vpcID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVPCIPAddresses", reflect.TypeOf((*MockLinodeVPCClient)(nil).ListVPCIPAddresses), ctx, vpcID, opts)
+}
+
 // ListVPCs mocks base method.
 func (m *MockLinodeVPCClient) ListVPCs(ctx context.Context, opts *linodego.ListOptions) ([]linodego.VPC, error) {
 	m.ctrl.T.Helper()
@@ -1354,6 +2194,96 @@ func (mr *MockLinodeNodeBalancerClientMockRecorder) GetNodeBalancerConfig(ctx, n
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeBalancerConfig", reflect.TypeOf((*MockLinodeNodeBalancerClient)(nil).GetNodeBalancerConfig), ctx, nodebalancerID, configID)
 }
 
+// ListNodeBalancerConfigs mocks base method.
+func (m *MockLinodeNodeBalancerClient) ListNodeBalancerConfigs(ctx context.Context, nodebalancerID int, opts *linodego.ListOptions) ([]linodego.NodeBalancerConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNodeBalancerConfigs", ctx, nodebalancerID, opts)
+	ret0, _ := ret[0].([]linodego.NodeBalancerConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNodeBalancerConfigs indicates an expected call of ListNodeBalancerConfigs.
+func (mr *MockLinodeNodeBalancerClientMockRecorder) ListNodeBalancerConfigs(ctx, nodebalancerID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodeBalancerConfigs", reflect.TypeOf((*MockLinodeNodeBalancerClient)(nil).ListNodeBalancerConfigs), ctx, nodebalancerID, opts)
+}
+
+// ListNodeBalancerFirewalls mocks base method.
+func (m *MockLinodeNodeBalancerClient) ListNodeBalancerFirewalls(ctx context.Context, nodebalancerID int, opts *linodego.ListOptions) ([]linodego.Firewall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNodeBalancerFirewalls", ctx, nodebalancerID, opts)
+	ret0, _ := ret[0].([]linodego.Firewall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNodeBalancerFirewalls indicates an expected call of ListNodeBalancerFirewalls.
+func (mr *MockLinodeNodeBalancerClientMockRecorder) ListNodeBalancerFirewalls(ctx, nodebalancerID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodeBalancerFirewalls", reflect.TypeOf((*MockLinodeNodeBalancerClient)(nil).ListNodeBalancerFirewalls), ctx, nodebalancerID, opts)
+}
+
+// ListNodeBalancerNodes mocks base method.
+func (m *MockLinodeNodeBalancerClient) ListNodeBalancerNodes(ctx context.Context, nodebalancerID, configID int, opts *linodego.ListOptions) ([]linodego.NodeBalancerNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNodeBalancerNodes", ctx, nodebalancerID, configID, opts)
+	ret0, _ := ret[0].([]linodego.NodeBalancerNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNodeBalancerNodes indicates an expected call of ListNodeBalancerNodes.
+func (mr *MockLinodeNodeBalancerClientMockRecorder) ListNodeBalancerNodes(ctx, nodebalancerID, configID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodeBalancerNodes", reflect.TypeOf((*MockLinodeNodeBalancerClient)(nil).ListNodeBalancerNodes), ctx, nodebalancerID, configID, opts)
+}
+
+// ListNodeBalancers mocks base method.
+func (m *MockLinodeNodeBalancerClient) ListNodeBalancers(ctx context.Context, opts *linodego.ListOptions) ([]linodego.NodeBalancer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNodeBalancers", ctx, opts)
+	ret0, _ := ret[0].([]linodego.NodeBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNodeBalancers indicates an expected call of ListNodeBalancers.
+func (mr *MockLinodeNodeBalancerClientMockRecorder) ListNodeBalancers(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodeBalancers", reflect.TypeOf((*MockLinodeNodeBalancerClient)(nil).ListNodeBalancers), ctx, opts)
+}
+
+// UpdateNodeBalancer mocks base method.
+func (m *MockLinodeNodeBalancerClient) UpdateNodeBalancer(ctx context.Context, nodebalancerID int, opts linodego.NodeBalancerUpdateOptions) (*linodego.NodeBalancer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNodeBalancer", ctx, nodebalancerID, opts)
+	ret0, _ := ret[0].(*linodego.NodeBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNodeBalancer indicates an expected call of UpdateNodeBalancer.
+func (mr *MockLinodeNodeBalancerClientMockRecorder) UpdateNodeBalancer(ctx, nodebalancerID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNodeBalancer", reflect.TypeOf((*MockLinodeNodeBalancerClient)(nil).UpdateNodeBalancer), ctx, nodebalancerID, opts)
+}
+
+// UpdateNodeBalancerConfig mocks base method.
+func (m *MockLinodeNodeBalancerClient) UpdateNodeBalancerConfig(ctx context.Context, nodebalancerID, configID int, opts linodego.NodeBalancerConfigUpdateOptions) (*linodego.NodeBalancerConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNodeBalancerConfig", ctx, nodebalancerID, configID, opts)
+	ret0, _ := ret[0].(*linodego.NodeBalancerConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNodeBalancerConfig indicates an expected call of UpdateNodeBalancerConfig.
+func (mr *MockLinodeNodeBalancerClientMockRecorder) UpdateNodeBalancerConfig(ctx, nodebalancerID, configID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNodeBalancerConfig", reflect.TypeOf((*MockLinodeNodeBalancerClient)(nil).UpdateNodeBalancerConfig), ctx, nodebalancerID, configID, opts)
+}
+
 // MockLinodeObjectStorageClient is a mock of LinodeObjectStorageClient interface.
 type MockLinodeObjectStorageClient struct {
 	ctrl     *gomock.Controller
@@ -1675,6 +2605,456 @@ func (mr *MockLinodePlacementGroupClientMockRecorder) UpdatePlacementGroup(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlacementGroup", reflect.TypeOf((*MockLinodePlacementGroupClient)(nil).UpdatePlacementGroup), ctx, id, options)
 }
 
+// MockLinodeVolumeClient is a mock of LinodeVolumeClient interface.
+type MockLinodeVolumeClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinodeVolumeClientMockRecorder
+}
+
+// MockLinodeVolumeClientMockRecorder is the mock recorder for MockLinodeVolumeClient.
+type MockLinodeVolumeClientMockRecorder struct {
+	mock *MockLinodeVolumeClient
+}
+
+// NewMockLinodeVolumeClient creates a new mock instance.
+func NewMockLinodeVolumeClient(ctrl *gomock.Controller) *MockLinodeVolumeClient {
+	mock := &MockLinodeVolumeClient{ctrl: ctrl}
+	mock.recorder = &MockLinodeVolumeClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinodeVolumeClient) EXPECT() *MockLinodeVolumeClientMockRecorder {
+	return m.recorder
+}
+
+// DeleteVolume mocks base method.
+func (m *MockLinodeVolumeClient) DeleteVolume(ctx context.Context, volumeID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVolume", ctx, volumeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVolume indicates an expected call of DeleteVolume.
+func (mr *MockLinodeVolumeClientMockRecorder) DeleteVolume(ctx, volumeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVolume", reflect.TypeOf((*MockLinodeVolumeClient)(nil).DeleteVolume), ctx, volumeID)
+}
+
+// GetVolume mocks base method.
+func (m *MockLinodeVolumeClient) GetVolume(ctx context.Context, volumeID int) (*linodego.Volume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVolume", ctx, volumeID)
+	ret0, _ := ret[0].(*linodego.Volume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVolume indicates an expected call of GetVolume.
+func (mr *MockLinodeVolumeClientMockRecorder) GetVolume(ctx, volumeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVolume", reflect.TypeOf((*MockLinodeVolumeClient)(nil).GetVolume), ctx, volumeID)
+}
+
+// ListVolumes mocks base method.
+func (m *MockLinodeVolumeClient) ListVolumes(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Volume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVolumes", ctx, opts)
+	ret0, _ := ret[0].([]linodego.Volume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVolumes indicates an expected call of ListVolumes.
+func (mr *MockLinodeVolumeClientMockRecorder) ListVolumes(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVolumes", reflect.TypeOf((*MockLinodeVolumeClient)(nil).ListVolumes), ctx, opts)
+}
+
+// UpdateVolume mocks base method.
+func (m *MockLinodeVolumeClient) UpdateVolume(ctx context.Context, volumeID int, opts linodego.VolumeUpdateOptions) (*linodego.Volume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVolume", ctx, volumeID, opts)
+	ret0, _ := ret[0].(*linodego.Volume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVolume indicates an expected call of UpdateVolume.
+func (mr *MockLinodeVolumeClientMockRecorder) UpdateVolume(ctx, volumeID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVolume", reflect.TypeOf((*MockLinodeVolumeClient)(nil).UpdateVolume), ctx, volumeID, opts)
+}
+
+// MockLinodeLongviewClient is a mock of LinodeLongviewClient interface.
+type MockLinodeLongviewClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinodeLongviewClientMockRecorder
+}
+
+// MockLinodeLongviewClientMockRecorder is the mock recorder for MockLinodeLongviewClient.
+type MockLinodeLongviewClientMockRecorder struct {
+	mock *MockLinodeLongviewClient
+}
+
+// NewMockLinodeLongviewClient creates a new mock instance.
+func NewMockLinodeLongviewClient(ctrl *gomock.Controller) *MockLinodeLongviewClient {
+	mock := &MockLinodeLongviewClient{ctrl: ctrl}
+	mock.recorder = &MockLinodeLongviewClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinodeLongviewClient) EXPECT() *MockLinodeLongviewClientMockRecorder {
+	return m.recorder
+}
+
+// CreateLongviewClient mocks base method.
+func (m *MockLinodeLongviewClient) CreateLongviewClient(ctx context.Context, opts linodego.LongviewClientCreateOptions) (*linodego.LongviewClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLongviewClient", ctx, opts)
+	ret0, _ := ret[0].(*linodego.LongviewClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLongviewClient indicates an expected call of CreateLongviewClient.
+func (mr *MockLinodeLongviewClientMockRecorder) CreateLongviewClient(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLongviewClient", reflect.TypeOf((*MockLinodeLongviewClient)(nil).CreateLongviewClient), ctx, opts)
+}
+
+// DeleteLongviewClient mocks base method.
+func (m *MockLinodeLongviewClient) DeleteLongviewClient(ctx context.Context, clientID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLongviewClient", ctx, clientID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLongviewClient indicates an expected call of DeleteLongviewClient.
+func (mr *MockLinodeLongviewClientMockRecorder) DeleteLongviewClient(ctx, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLongviewClient", reflect.TypeOf((*MockLinodeLongviewClient)(nil).DeleteLongviewClient), ctx, clientID)
+}
+
+// GetLongviewClient mocks base method.
+func (m *MockLinodeLongviewClient) GetLongviewClient(ctx context.Context, clientID int) (*linodego.LongviewClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLongviewClient", ctx, clientID)
+	ret0, _ := ret[0].(*linodego.LongviewClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLongviewClient indicates an expected call of GetLongviewClient.
+func (mr *MockLinodeLongviewClientMockRecorder) GetLongviewClient(ctx, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLongviewClient", reflect.TypeOf((*MockLinodeLongviewClient)(nil).GetLongviewClient), ctx, clientID)
+}
+
+// MockLinodeProfileClient is a mock of LinodeProfileClient interface.
+type MockLinodeProfileClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinodeProfileClientMockRecorder
+}
+
+// MockLinodeProfileClientMockRecorder is the mock recorder for MockLinodeProfileClient.
+type MockLinodeProfileClientMockRecorder struct {
+	mock *MockLinodeProfileClient
+}
+
+// NewMockLinodeProfileClient creates a new mock instance.
+func NewMockLinodeProfileClient(ctrl *gomock.Controller) *MockLinodeProfileClient {
+	mock := &MockLinodeProfileClient{ctrl: ctrl}
+	mock.recorder = &MockLinodeProfileClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinodeProfileClient) EXPECT() *MockLinodeProfileClientMockRecorder {
+	return m.recorder
+}
+
+// ListSSHKeys mocks base method.
+func (m *MockLinodeProfileClient) ListSSHKeys(ctx context.Context, opts *linodego.ListOptions) ([]linodego.SSHKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSSHKeys", ctx, opts)
+	ret0, _ := ret[0].([]linodego.SSHKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSSHKeys indicates an expected call of ListSSHKeys.
+func (mr *MockLinodeProfileClientMockRecorder) ListSSHKeys(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSSHKeys", reflect.TypeOf((*MockLinodeProfileClient)(nil).ListSSHKeys), ctx, opts)
+}
+
+// MockLinodeFirewallClient is a mock of LinodeFirewallClient interface.
+type MockLinodeFirewallClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinodeFirewallClientMockRecorder
+}
+
+// MockLinodeFirewallClientMockRecorder is the mock recorder for MockLinodeFirewallClient.
+type MockLinodeFirewallClientMockRecorder struct {
+	mock *MockLinodeFirewallClient
+}
+
+// NewMockLinodeFirewallClient creates a new mock instance.
+func NewMockLinodeFirewallClient(ctrl *gomock.Controller) *MockLinodeFirewallClient {
+	mock := &MockLinodeFirewallClient{ctrl: ctrl}
+	mock.recorder = &MockLinodeFirewallClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinodeFirewallClient) EXPECT() *MockLinodeFirewallClientMockRecorder {
+	return m.recorder
+}
+
+// CreateFirewallDevice mocks base method.
+func (m *MockLinodeFirewallClient) CreateFirewallDevice(ctx context.Context, firewallID int, opts linodego.FirewallDeviceCreateOptions) (*linodego.FirewallDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFirewallDevice", ctx, firewallID, opts)
+	ret0, _ := ret[0].(*linodego.FirewallDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFirewallDevice indicates an expected call of CreateFirewallDevice.
+func (mr *MockLinodeFirewallClientMockRecorder) CreateFirewallDevice(ctx, firewallID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFirewallDevice", reflect.TypeOf((*MockLinodeFirewallClient)(nil).CreateFirewallDevice), ctx, firewallID, opts)
+}
+
+// DeleteFirewallDevice mocks base method.
+func (m *MockLinodeFirewallClient) DeleteFirewallDevice(ctx context.Context, firewallID, deviceID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFirewallDevice", ctx, firewallID, deviceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFirewallDevice indicates an expected call of DeleteFirewallDevice.
+func (mr *MockLinodeFirewallClientMockRecorder) DeleteFirewallDevice(ctx, firewallID, deviceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFirewallDevice", reflect.TypeOf((*MockLinodeFirewallClient)(nil).DeleteFirewallDevice), ctx, firewallID, deviceID)
+}
+
+// GetFirewallRules mocks base method.
+func (m *MockLinodeFirewallClient) GetFirewallRules(ctx context.Context, firewallID int) (*linodego.FirewallRuleSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFirewallRules", ctx, firewallID)
+	ret0, _ := ret[0].(*linodego.FirewallRuleSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFirewallRules indicates an expected call of GetFirewallRules.
+func (mr *MockLinodeFirewallClientMockRecorder) GetFirewallRules(ctx, firewallID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFirewallRules", reflect.TypeOf((*MockLinodeFirewallClient)(nil).GetFirewallRules), ctx, firewallID)
+}
+
+// ListFirewallDevices mocks base method.
+func (m *MockLinodeFirewallClient) ListFirewallDevices(ctx context.Context, firewallID int, opts *linodego.ListOptions) ([]linodego.FirewallDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFirewallDevices", ctx, firewallID, opts)
+	ret0, _ := ret[0].([]linodego.FirewallDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFirewallDevices indicates an expected call of ListFirewallDevices.
+func (mr *MockLinodeFirewallClientMockRecorder) ListFirewallDevices(ctx, firewallID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFirewallDevices", reflect.TypeOf((*MockLinodeFirewallClient)(nil).ListFirewallDevices), ctx, firewallID, opts)
+}
+
+// ListFirewalls mocks base method.
+func (m *MockLinodeFirewallClient) ListFirewalls(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Firewall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFirewalls", ctx, opts)
+	ret0, _ := ret[0].([]linodego.Firewall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFirewalls indicates an expected call of ListFirewalls.
+func (mr *MockLinodeFirewallClientMockRecorder) ListFirewalls(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFirewalls", reflect.TypeOf((*MockLinodeFirewallClient)(nil).ListFirewalls), ctx, opts)
+}
+
+// ListInstanceFirewalls mocks base method.
+func (m *MockLinodeFirewallClient) ListInstanceFirewalls(ctx context.Context, linodeID int, opts *linodego.ListOptions) ([]linodego.Firewall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInstanceFirewalls", ctx, linodeID, opts)
+	ret0, _ := ret[0].([]linodego.Firewall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInstanceFirewalls indicates an expected call of ListInstanceFirewalls.
+func (mr *MockLinodeFirewallClientMockRecorder) ListInstanceFirewalls(ctx, linodeID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstanceFirewalls", reflect.TypeOf((*MockLinodeFirewallClient)(nil).ListInstanceFirewalls), ctx, linodeID, opts)
+}
+
+// UpdateFirewallRules mocks base method.
+func (m *MockLinodeFirewallClient) UpdateFirewallRules(ctx context.Context, firewallID int, rules linodego.FirewallRuleSet) (*linodego.FirewallRuleSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFirewallRules", ctx, firewallID, rules)
+	ret0, _ := ret[0].(*linodego.FirewallRuleSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFirewallRules indicates an expected call of UpdateFirewallRules.
+func (mr *MockLinodeFirewallClientMockRecorder) UpdateFirewallRules(ctx, firewallID, rules any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFirewallRules", reflect.TypeOf((*MockLinodeFirewallClient)(nil).UpdateFirewallRules), ctx, firewallID, rules)
+}
+
+// MockLinodeAccountClient is a mock of LinodeAccountClient interface.
+type MockLinodeAccountClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinodeAccountClientMockRecorder
+}
+
+// MockLinodeAccountClientMockRecorder is the mock recorder for MockLinodeAccountClient.
+type MockLinodeAccountClientMockRecorder struct {
+	mock *MockLinodeAccountClient
+}
+
+// NewMockLinodeAccountClient creates a new mock instance.
+func NewMockLinodeAccountClient(ctrl *gomock.Controller) *MockLinodeAccountClient {
+	mock := &MockLinodeAccountClient{ctrl: ctrl}
+	mock.recorder = &MockLinodeAccountClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinodeAccountClient) EXPECT() *MockLinodeAccountClientMockRecorder {
+	return m.recorder
+}
+
+// CreateChildAccountToken mocks base method.
+func (m *MockLinodeAccountClient) CreateChildAccountToken(ctx context.Context, euuid string) (*linodego.ChildAccountToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChildAccountToken", ctx, euuid)
+	ret0, _ := ret[0].(*linodego.ChildAccountToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChildAccountToken indicates an expected call of CreateChildAccountToken.
+func (mr *MockLinodeAccountClientMockRecorder) CreateChildAccountToken(ctx, euuid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChildAccountToken", reflect.TypeOf((*MockLinodeAccountClient)(nil).CreateChildAccountToken), ctx, euuid)
+}
+
+// GetAccountTransfer mocks base method.
+func (m *MockLinodeAccountClient) GetAccountTransfer(ctx context.Context) (*linodego.AccountTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountTransfer", ctx)
+	ret0, _ := ret[0].(*linodego.AccountTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountTransfer indicates an expected call of GetAccountTransfer.
+func (mr *MockLinodeAccountClientMockRecorder) GetAccountTransfer(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTransfer", reflect.TypeOf((*MockLinodeAccountClient)(nil).GetAccountTransfer), ctx)
+}
+
+// ListEvents mocks base method.
+func (m *MockLinodeAccountClient) ListEvents(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", ctx, opts)
+	ret0, _ := ret[0].([]linodego.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockLinodeAccountClientMockRecorder) ListEvents(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockLinodeAccountClient)(nil).ListEvents), ctx, opts)
+}
+
+// MockLinodeDatabaseClient is a mock of LinodeDatabaseClient interface.
+type MockLinodeDatabaseClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinodeDatabaseClientMockRecorder
+}
+
+// MockLinodeDatabaseClientMockRecorder is the mock recorder for MockLinodeDatabaseClient.
+type MockLinodeDatabaseClientMockRecorder struct {
+	mock *MockLinodeDatabaseClient
+}
+
+// NewMockLinodeDatabaseClient creates a new mock instance.
+func NewMockLinodeDatabaseClient(ctrl *gomock.Controller) *MockLinodeDatabaseClient {
+	mock := &MockLinodeDatabaseClient{ctrl: ctrl}
+	mock.recorder = &MockLinodeDatabaseClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinodeDatabaseClient) EXPECT() *MockLinodeDatabaseClientMockRecorder {
+	return m.recorder
+}
+
+// ListDatabases mocks base method.
+func (m *MockLinodeDatabaseClient) ListDatabases(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Database, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDatabases", ctx, opts)
+	ret0, _ := ret[0].([]linodego.Database)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDatabases indicates an expected call of ListDatabases.
+func (mr *MockLinodeDatabaseClientMockRecorder) ListDatabases(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDatabases", reflect.TypeOf((*MockLinodeDatabaseClient)(nil).ListDatabases), ctx, opts)
+}
+
+// UpdateMySQLDatabase mocks base method.
+func (m *MockLinodeDatabaseClient) UpdateMySQLDatabase(ctx context.Context, databaseID int, opts linodego.MySQLUpdateOptions) (*linodego.MySQLDatabase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMySQLDatabase", ctx, databaseID, opts)
+	ret0, _ := ret[0].(*linodego.MySQLDatabase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMySQLDatabase indicates an expected call of UpdateMySQLDatabase.
+func (mr *MockLinodeDatabaseClientMockRecorder) UpdateMySQLDatabase(ctx, databaseID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMySQLDatabase", reflect.TypeOf((*MockLinodeDatabaseClient)(nil).UpdateMySQLDatabase), ctx, databaseID, opts)
+}
+
+// UpdatePostgresDatabase mocks base method.
+func (m *MockLinodeDatabaseClient) UpdatePostgresDatabase(ctx context.Context, databaseID int, opts linodego.PostgresUpdateOptions) (*linodego.PostgresDatabase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePostgresDatabase", ctx, databaseID, opts)
+	ret0, _ := ret[0].(*linodego.PostgresDatabase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePostgresDatabase indicates an expected call of UpdatePostgresDatabase.
+func (mr *MockLinodeDatabaseClientMockRecorder) UpdatePostgresDatabase(ctx, databaseID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePostgresDatabase", reflect.TypeOf((*MockLinodeDatabaseClient)(nil).UpdatePostgresDatabase), ctx, databaseID, opts)
+}
+
 // MockK8sClient is a mock of K8sClient interface.
 type MockK8sClient struct {
 	ctrl     *gomock.Controller
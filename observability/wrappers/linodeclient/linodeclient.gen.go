@@ -35,6 +35,32 @@ func NewLinodeClientWithTracing(base clients.LinodeClient, spanDecorator ...func
 	return d
 }
 
+// AddInstanceIPAddress implements clients.LinodeClient
+func (_d LinodeClientWithTracing) AddInstanceIPAddress(ctx context.Context, linodeID int, public bool) (ip1 *linodego.InstanceIP, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.AddInstanceIPAddress")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":      ctx,
+				"linodeID": linodeID,
+				"public":   public}, map[string]interface{}{
+				"ip1": ip1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.AddInstanceIPAddress(ctx, linodeID, public)
+}
+
 // AssignPlacementGroupLinodes implements clients.LinodeClient
 func (_d LinodeClientWithTracing) AssignPlacementGroupLinodes(ctx context.Context, id int, options linodego.PlacementGroupAssignOptions) (pp1 *linodego.PlacementGroup, err error) {
 	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.AssignPlacementGroupLinodes")
@@ -86,6 +112,31 @@ func (_d LinodeClientWithTracing) BootInstance(ctx context.Context, linodeID int
 	return _d.LinodeClient.BootInstance(ctx, linodeID, configID)
 }
 
+// CreateChildAccountToken implements clients.LinodeClient
+func (_d LinodeClientWithTracing) CreateChildAccountToken(ctx context.Context, euuid string) (cp1 *linodego.ChildAccountToken, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.CreateChildAccountToken")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":   ctx,
+				"euuid": euuid}, map[string]interface{}{
+				"cp1": cp1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.CreateChildAccountToken(ctx, euuid)
+}
+
 // CreateDomainRecord implements clients.LinodeClient
 func (_d LinodeClientWithTracing) CreateDomainRecord(ctx context.Context, domainID int, recordReq linodego.DomainRecordCreateOptions) (dp1 *linodego.DomainRecord, err error) {
 	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.CreateDomainRecord")
@@ -112,6 +163,32 @@ func (_d LinodeClientWithTracing) CreateDomainRecord(ctx context.Context, domain
 	return _d.LinodeClient.CreateDomainRecord(ctx, domainID, recordReq)
 }
 
+// CreateFirewallDevice implements clients.LinodeClient
+func (_d LinodeClientWithTracing) CreateFirewallDevice(ctx context.Context, firewallID int, opts linodego.FirewallDeviceCreateOptions) (fp1 *linodego.FirewallDevice, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.CreateFirewallDevice")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":        ctx,
+				"firewallID": firewallID,
+				"opts":       opts}, map[string]interface{}{
+				"fp1": fp1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.CreateFirewallDevice(ctx, firewallID, opts)
+}
+
 // CreateInstance implements clients.LinodeClient
 func (_d LinodeClientWithTracing) CreateInstance(ctx context.Context, opts linodego.InstanceCreateOptions) (ip1 *linodego.Instance, err error) {
 	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.CreateInstance")
@@ -163,6 +240,31 @@ func (_d LinodeClientWithTracing) CreateInstanceDisk(ctx context.Context, linode
 	return _d.LinodeClient.CreateInstanceDisk(ctx, linodeID, opts)
 }
 
+// CreateLongviewClient implements clients.LinodeClient
+func (_d LinodeClientWithTracing) CreateLongviewClient(ctx context.Context, opts linodego.LongviewClientCreateOptions) (lp1 *linodego.LongviewClient, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.CreateLongviewClient")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":  ctx,
+				"opts": opts}, map[string]interface{}{
+				"lp1": lp1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.CreateLongviewClient(ctx, opts)
+}
+
 // CreateNodeBalancer implements clients.LinodeClient
 func (_d LinodeClientWithTracing) CreateNodeBalancer(ctx context.Context, opts linodego.NodeBalancerCreateOptions) (np1 *linodego.NodeBalancer, err error) {
 	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.CreateNodeBalancer")
@@ -391,6 +493,31 @@ func (_d LinodeClientWithTracing) DeleteDomainRecord(ctx context.Context, domain
 	return _d.LinodeClient.DeleteDomainRecord(ctx, domainID, domainRecordID)
 }
 
+// DeleteFirewallDevice implements clients.LinodeClient
+func (_d LinodeClientWithTracing) DeleteFirewallDevice(ctx context.Context, firewallID int, deviceID int) (err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.DeleteFirewallDevice")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":        ctx,
+				"firewallID": firewallID,
+				"deviceID":   deviceID}, map[string]interface{}{
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.DeleteFirewallDevice(ctx, firewallID, deviceID)
+}
+
 // DeleteInstance implements clients.LinodeClient
 func (_d LinodeClientWithTracing) DeleteInstance(ctx context.Context, linodeID int) (err error) {
 	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.DeleteInstance")
@@ -415,6 +542,55 @@ func (_d LinodeClientWithTracing) DeleteInstance(ctx context.Context, linodeID i
 	return _d.LinodeClient.DeleteInstance(ctx, linodeID)
 }
 
+// DeleteInstanceIPAddress implements clients.LinodeClient
+func (_d LinodeClientWithTracing) DeleteInstanceIPAddress(ctx context.Context, linodeID int, ipAddress string) (err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.DeleteInstanceIPAddress")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":       ctx,
+				"linodeID":  linodeID,
+				"ipAddress": ipAddress}, map[string]interface{}{
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.DeleteInstanceIPAddress(ctx, linodeID, ipAddress)
+}
+
+// DeleteLongviewClient implements clients.LinodeClient
+func (_d LinodeClientWithTracing) DeleteLongviewClient(ctx context.Context, clientID int) (err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.DeleteLongviewClient")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":      ctx,
+				"clientID": clientID}, map[string]interface{}{
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.DeleteLongviewClient(ctx, clientID)
+}
+
 // DeleteNodeBalancer implements clients.LinodeClient
 func (_d LinodeClientWithTracing) DeleteNodeBalancer(ctx context.Context, nodebalancerID int) (err error) {
 	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.DeleteNodeBalancer")
@@ -537,6 +713,79 @@ func (_d LinodeClientWithTracing) DeleteVPC(ctx context.Context, vpcID int) (err
 	return _d.LinodeClient.DeleteVPC(ctx, vpcID)
 }
 
+// DeleteVolume implements clients.LinodeClient
+func (_d LinodeClientWithTracing) DeleteVolume(ctx context.Context, volumeID int) (err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.DeleteVolume")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":      ctx,
+				"volumeID": volumeID}, map[string]interface{}{
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.DeleteVolume(ctx, volumeID)
+}
+
+// GetAccountTransfer implements clients.LinodeClient
+func (_d LinodeClientWithTracing) GetAccountTransfer(ctx context.Context) (ap1 *linodego.AccountTransfer, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.GetAccountTransfer")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx": ctx}, map[string]interface{}{
+				"ap1": ap1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.GetAccountTransfer(ctx)
+}
+
+// GetFirewallRules implements clients.LinodeClient
+func (_d LinodeClientWithTracing) GetFirewallRules(ctx context.Context, firewallID int) (fp1 *linodego.FirewallRuleSet, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.GetFirewallRules")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":        ctx,
+				"firewallID": firewallID}, map[string]interface{}{
+				"fp1": fp1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.GetFirewallRules(ctx, firewallID)
+}
+
 // GetImage implements clients.LinodeClient
 func (_d LinodeClientWithTracing) GetImage(ctx context.Context, imageID string) (ip1 *linodego.Image, err error) {
 	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.GetImage")
@@ -638,6 +887,81 @@ func (_d LinodeClientWithTracing) GetInstanceIPAddresses(ctx context.Context, li
 	return _d.LinodeClient.GetInstanceIPAddresses(ctx, linodeID)
 }
 
+// GetInstanceTransfer implements clients.LinodeClient
+func (_d LinodeClientWithTracing) GetInstanceTransfer(ctx context.Context, linodeID int) (ip1 *linodego.InstanceTransfer, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.GetInstanceTransfer")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":      ctx,
+				"linodeID": linodeID}, map[string]interface{}{
+				"ip1": ip1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.GetInstanceTransfer(ctx, linodeID)
+}
+
+// GetKernel implements clients.LinodeClient
+func (_d LinodeClientWithTracing) GetKernel(ctx context.Context, kernelID string) (lp1 *linodego.LinodeKernel, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.GetKernel")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":      ctx,
+				"kernelID": kernelID}, map[string]interface{}{
+				"lp1": lp1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.GetKernel(ctx, kernelID)
+}
+
+// GetLongviewClient implements clients.LinodeClient
+func (_d LinodeClientWithTracing) GetLongviewClient(ctx context.Context, clientID int) (lp1 *linodego.LongviewClient, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.GetLongviewClient")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":      ctx,
+				"clientID": clientID}, map[string]interface{}{
+				"lp1": lp1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.GetLongviewClient(ctx, clientID)
+}
+
 // GetNodeBalancer implements clients.LinodeClient
 func (_d LinodeClientWithTracing) GetNodeBalancer(ctx context.Context, nodebalancerID int) (np1 *linodego.NodeBalancer, err error) {
 	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.GetNodeBalancer")
@@ -840,15 +1164,65 @@ func (_d LinodeClientWithTracing) GetVPC(ctx context.Context, vpcID int) (vp1 *l
 	return _d.LinodeClient.GetVPC(ctx, vpcID)
 }
 
-// ListDomainRecords implements clients.LinodeClient
-func (_d LinodeClientWithTracing) ListDomainRecords(ctx context.Context, domainID int, opts *linodego.ListOptions) (da1 []linodego.DomainRecord, err error) {
-	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListDomainRecords")
+// GetVolume implements clients.LinodeClient
+func (_d LinodeClientWithTracing) GetVolume(ctx context.Context, volumeID int) (vp1 *linodego.Volume, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.GetVolume")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":      ctx,
-				"domainID": domainID,
-				"opts":     opts}, map[string]interface{}{
+				"volumeID": volumeID}, map[string]interface{}{
+				"vp1": vp1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.GetVolume(ctx, volumeID)
+}
+
+// ListDatabases implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListDatabases(ctx context.Context, opts *linodego.ListOptions) (da1 []linodego.Database, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListDatabases")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":  ctx,
+				"opts": opts}, map[string]interface{}{
+				"da1": da1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.ListDatabases(ctx, opts)
+}
+
+// ListDomainRecords implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListDomainRecords(ctx context.Context, domainID int, opts *linodego.ListOptions) (da1 []linodego.DomainRecord, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListDomainRecords")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":      ctx,
+				"domainID": domainID,
+				"opts":     opts}, map[string]interface{}{
 				"da1": da1,
 				"err": err})
 		}
@@ -863,18 +1237,477 @@ func (_d LinodeClientWithTracing) ListDomainRecords(ctx context.Context, domainI
 
 		_span.End()
 	}()
-	return _d.LinodeClient.ListDomainRecords(ctx, domainID, opts)
+	return _d.LinodeClient.ListDomainRecords(ctx, domainID, opts)
+}
+
+// ListDomains implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListDomains(ctx context.Context, opts *linodego.ListOptions) (da1 []linodego.Domain, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListDomains")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":  ctx,
+				"opts": opts}, map[string]interface{}{
+				"da1": da1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.ListDomains(ctx, opts)
+}
+
+// ListEvents implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListEvents(ctx context.Context, opts *linodego.ListOptions) (ea1 []linodego.Event, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListEvents")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":  ctx,
+				"opts": opts}, map[string]interface{}{
+				"ea1": ea1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.ListEvents(ctx, opts)
+}
+
+// ListFirewallDevices implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListFirewallDevices(ctx context.Context, firewallID int, opts *linodego.ListOptions) (fa1 []linodego.FirewallDevice, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListFirewallDevices")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":        ctx,
+				"firewallID": firewallID,
+				"opts":       opts}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.ListFirewallDevices(ctx, firewallID, opts)
+}
+
+// ListFirewalls implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListFirewalls(ctx context.Context, opts *linodego.ListOptions) (fa1 []linodego.Firewall, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListFirewalls")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":  ctx,
+				"opts": opts}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.ListFirewalls(ctx, opts)
+}
+
+// ListInstanceConfigs implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListInstanceConfigs(ctx context.Context, linodeID int, opts *linodego.ListOptions) (ia1 []linodego.InstanceConfig, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListInstanceConfigs")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":      ctx,
+				"linodeID": linodeID,
+				"opts":     opts}, map[string]interface{}{
+				"ia1": ia1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.ListInstanceConfigs(ctx, linodeID, opts)
+}
+
+// ListInstanceDisks implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListInstanceDisks(ctx context.Context, linodeID int, opts *linodego.ListOptions) (ia1 []linodego.InstanceDisk, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListInstanceDisks")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":      ctx,
+				"linodeID": linodeID,
+				"opts":     opts}, map[string]interface{}{
+				"ia1": ia1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.ListInstanceDisks(ctx, linodeID, opts)
+}
+
+// ListInstanceFirewalls implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListInstanceFirewalls(ctx context.Context, linodeID int, opts *linodego.ListOptions) (fa1 []linodego.Firewall, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListInstanceFirewalls")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":      ctx,
+				"linodeID": linodeID,
+				"opts":     opts}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.ListInstanceFirewalls(ctx, linodeID, opts)
+}
+
+// ListInstances implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListInstances(ctx context.Context, opts *linodego.ListOptions) (ia1 []linodego.Instance, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListInstances")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":  ctx,
+				"opts": opts}, map[string]interface{}{
+				"ia1": ia1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.ListInstances(ctx, opts)
+}
+
+// ListNodeBalancerConfigs implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListNodeBalancerConfigs(ctx context.Context, nodebalancerID int, opts *linodego.ListOptions) (na1 []linodego.NodeBalancerConfig, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListNodeBalancerConfigs")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":            ctx,
+				"nodebalancerID": nodebalancerID,
+				"opts":           opts}, map[string]interface{}{
+				"na1": na1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.ListNodeBalancerConfigs(ctx, nodebalancerID, opts)
+}
+
+// ListNodeBalancerFirewalls implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListNodeBalancerFirewalls(ctx context.Context, nodebalancerID int, opts *linodego.ListOptions) (fa1 []linodego.Firewall, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListNodeBalancerFirewalls")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":            ctx,
+				"nodebalancerID": nodebalancerID,
+				"opts":           opts}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.ListNodeBalancerFirewalls(ctx, nodebalancerID, opts)
+}
+
+// ListNodeBalancerNodes implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListNodeBalancerNodes(ctx context.Context, nodebalancerID int, configID int, opts *linodego.ListOptions) (na1 []linodego.NodeBalancerNode, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListNodeBalancerNodes")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":            ctx,
+				"nodebalancerID": nodebalancerID,
+				"configID":       configID,
+				"opts":           opts}, map[string]interface{}{
+				"na1": na1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.ListNodeBalancerNodes(ctx, nodebalancerID, configID, opts)
+}
+
+// ListNodeBalancers implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListNodeBalancers(ctx context.Context, opts *linodego.ListOptions) (na1 []linodego.NodeBalancer, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListNodeBalancers")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":  ctx,
+				"opts": opts}, map[string]interface{}{
+				"na1": na1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.ListNodeBalancers(ctx, opts)
+}
+
+// ListPlacementGroups implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListPlacementGroups(ctx context.Context, options *linodego.ListOptions) (pa1 []linodego.PlacementGroup, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListPlacementGroups")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":     ctx,
+				"options": options}, map[string]interface{}{
+				"pa1": pa1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.ListPlacementGroups(ctx, options)
+}
+
+// ListRegionsAvailability implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListRegionsAvailability(ctx context.Context, opts *linodego.ListOptions) (ra1 []linodego.RegionAvailability, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListRegionsAvailability")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":  ctx,
+				"opts": opts}, map[string]interface{}{
+				"ra1": ra1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.ListRegionsAvailability(ctx, opts)
+}
+
+// ListSSHKeys implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListSSHKeys(ctx context.Context, opts *linodego.ListOptions) (sa1 []linodego.SSHKey, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListSSHKeys")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":  ctx,
+				"opts": opts}, map[string]interface{}{
+				"sa1": sa1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.ListSSHKeys(ctx, opts)
+}
+
+// ListStackscripts implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListStackscripts(ctx context.Context, opts *linodego.ListOptions) (sa1 []linodego.Stackscript, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListStackscripts")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":  ctx,
+				"opts": opts}, map[string]interface{}{
+				"sa1": sa1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.ListStackscripts(ctx, opts)
+}
+
+// ListVPCIPAddresses implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListVPCIPAddresses(ctx context.Context, vpcID int, opts *linodego.ListOptions) (va1 []linodego.VPCIP, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListVPCIPAddresses")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":   ctx,
+				"vpcID": vpcID,
+				"opts":  opts}, map[string]interface{}{
+				"va1": va1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.ListVPCIPAddresses(ctx, vpcID, opts)
+}
+
+// ListVPCs implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListVPCs(ctx context.Context, opts *linodego.ListOptions) (va1 []linodego.VPC, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListVPCs")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":  ctx,
+				"opts": opts}, map[string]interface{}{
+				"va1": va1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.ListVPCs(ctx, opts)
 }
 
-// ListDomains implements clients.LinodeClient
-func (_d LinodeClientWithTracing) ListDomains(ctx context.Context, opts *linodego.ListOptions) (da1 []linodego.Domain, err error) {
-	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListDomains")
+// ListVolumes implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ListVolumes(ctx context.Context, opts *linodego.ListOptions) (va1 []linodego.Volume, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListVolumes")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":  ctx,
 				"opts": opts}, map[string]interface{}{
-				"da1": da1,
+				"va1": va1,
 				"err": err})
 		}
 
@@ -888,19 +1721,18 @@ func (_d LinodeClientWithTracing) ListDomains(ctx context.Context, opts *linodeg
 
 		_span.End()
 	}()
-	return _d.LinodeClient.ListDomains(ctx, opts)
+	return _d.LinodeClient.ListVolumes(ctx, opts)
 }
 
-// ListInstanceConfigs implements clients.LinodeClient
-func (_d LinodeClientWithTracing) ListInstanceConfigs(ctx context.Context, linodeID int, opts *linodego.ListOptions) (ia1 []linodego.InstanceConfig, err error) {
-	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListInstanceConfigs")
+// MigrateInstance implements clients.LinodeClient
+func (_d LinodeClientWithTracing) MigrateInstance(ctx context.Context, linodeID int, opts linodego.InstanceMigrateOptions) (err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.MigrateInstance")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":      ctx,
 				"linodeID": linodeID,
 				"opts":     opts}, map[string]interface{}{
-				"ia1": ia1,
 				"err": err})
 		}
 
@@ -914,18 +1746,18 @@ func (_d LinodeClientWithTracing) ListInstanceConfigs(ctx context.Context, linod
 
 		_span.End()
 	}()
-	return _d.LinodeClient.ListInstanceConfigs(ctx, linodeID, opts)
+	return _d.LinodeClient.MigrateInstance(ctx, linodeID, opts)
 }
 
-// ListInstances implements clients.LinodeClient
-func (_d LinodeClientWithTracing) ListInstances(ctx context.Context, opts *linodego.ListOptions) (ia1 []linodego.Instance, err error) {
-	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListInstances")
+// RebootInstance implements clients.LinodeClient
+func (_d LinodeClientWithTracing) RebootInstance(ctx context.Context, linodeID int, configID int) (err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.RebootInstance")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":  ctx,
-				"opts": opts}, map[string]interface{}{
-				"ia1": ia1,
+				"ctx":      ctx,
+				"linodeID": linodeID,
+				"configID": configID}, map[string]interface{}{
 				"err": err})
 		}
 
@@ -939,18 +1771,19 @@ func (_d LinodeClientWithTracing) ListInstances(ctx context.Context, opts *linod
 
 		_span.End()
 	}()
-	return _d.LinodeClient.ListInstances(ctx, opts)
+	return _d.LinodeClient.RebootInstance(ctx, linodeID, configID)
 }
 
-// ListPlacementGroups implements clients.LinodeClient
-func (_d LinodeClientWithTracing) ListPlacementGroups(ctx context.Context, options *linodego.ListOptions) (pa1 []linodego.PlacementGroup, err error) {
-	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListPlacementGroups")
+// RebuildInstance implements clients.LinodeClient
+func (_d LinodeClientWithTracing) RebuildInstance(ctx context.Context, linodeID int, opts linodego.InstanceRebuildOptions) (ip1 *linodego.Instance, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.RebuildInstance")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":     ctx,
-				"options": options}, map[string]interface{}{
-				"pa1": pa1,
+				"ctx":      ctx,
+				"linodeID": linodeID,
+				"opts":     opts}, map[string]interface{}{
+				"ip1": ip1,
 				"err": err})
 		}
 
@@ -964,18 +1797,18 @@ func (_d LinodeClientWithTracing) ListPlacementGroups(ctx context.Context, optio
 
 		_span.End()
 	}()
-	return _d.LinodeClient.ListPlacementGroups(ctx, options)
+	return _d.LinodeClient.RebuildInstance(ctx, linodeID, opts)
 }
 
-// ListStackscripts implements clients.LinodeClient
-func (_d LinodeClientWithTracing) ListStackscripts(ctx context.Context, opts *linodego.ListOptions) (sa1 []linodego.Stackscript, err error) {
-	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListStackscripts")
+// RescueInstance implements clients.LinodeClient
+func (_d LinodeClientWithTracing) RescueInstance(ctx context.Context, linodeID int, opts linodego.InstanceRescueOptions) (err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.RescueInstance")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":  ctx,
-				"opts": opts}, map[string]interface{}{
-				"sa1": sa1,
+				"ctx":      ctx,
+				"linodeID": linodeID,
+				"opts":     opts}, map[string]interface{}{
 				"err": err})
 		}
 
@@ -989,18 +1822,18 @@ func (_d LinodeClientWithTracing) ListStackscripts(ctx context.Context, opts *li
 
 		_span.End()
 	}()
-	return _d.LinodeClient.ListStackscripts(ctx, opts)
+	return _d.LinodeClient.RescueInstance(ctx, linodeID, opts)
 }
 
-// ListVPCs implements clients.LinodeClient
-func (_d LinodeClientWithTracing) ListVPCs(ctx context.Context, opts *linodego.ListOptions) (va1 []linodego.VPC, err error) {
-	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ListVPCs")
+// ResizeInstance implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ResizeInstance(ctx context.Context, linodeID int, opts linodego.InstanceResizeOptions) (err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ResizeInstance")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":  ctx,
-				"opts": opts}, map[string]interface{}{
-				"va1": va1,
+				"ctx":      ctx,
+				"linodeID": linodeID,
+				"opts":     opts}, map[string]interface{}{
 				"err": err})
 		}
 
@@ -1014,7 +1847,7 @@ func (_d LinodeClientWithTracing) ListVPCs(ctx context.Context, opts *linodego.L
 
 		_span.End()
 	}()
-	return _d.LinodeClient.ListVPCs(ctx, opts)
+	return _d.LinodeClient.ResizeInstance(ctx, linodeID, opts)
 }
 
 // ResizeInstanceDisk implements clients.LinodeClient
@@ -1043,6 +1876,30 @@ func (_d LinodeClientWithTracing) ResizeInstanceDisk(ctx context.Context, linode
 	return _d.LinodeClient.ResizeInstanceDisk(ctx, linodeID, diskID, size)
 }
 
+// ShutdownInstance implements clients.LinodeClient
+func (_d LinodeClientWithTracing) ShutdownInstance(ctx context.Context, linodeID int) (err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.ShutdownInstance")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":      ctx,
+				"linodeID": linodeID}, map[string]interface{}{
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.ShutdownInstance(ctx, linodeID)
+}
+
 // UnassignPlacementGroupLinodes implements clients.LinodeClient
 func (_d LinodeClientWithTracing) UnassignPlacementGroupLinodes(ctx context.Context, id int, options linodego.PlacementGroupUnAssignOptions) (pp1 *linodego.PlacementGroup, err error) {
 	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.UnassignPlacementGroupLinodes")
@@ -1096,6 +1953,58 @@ func (_d LinodeClientWithTracing) UpdateDomainRecord(ctx context.Context, domain
 	return _d.LinodeClient.UpdateDomainRecord(ctx, domainID, domainRecordID, recordReq)
 }
 
+// UpdateFirewallRules implements clients.LinodeClient
+func (_d LinodeClientWithTracing) UpdateFirewallRules(ctx context.Context, firewallID int, rules linodego.FirewallRuleSet) (fp1 *linodego.FirewallRuleSet, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.UpdateFirewallRules")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":        ctx,
+				"firewallID": firewallID,
+				"rules":      rules}, map[string]interface{}{
+				"fp1": fp1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.UpdateFirewallRules(ctx, firewallID, rules)
+}
+
+// UpdateInstance implements clients.LinodeClient
+func (_d LinodeClientWithTracing) UpdateInstance(ctx context.Context, linodeID int, opts linodego.InstanceUpdateOptions) (ip1 *linodego.Instance, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.UpdateInstance")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":      ctx,
+				"linodeID": linodeID,
+				"opts":     opts}, map[string]interface{}{
+				"ip1": ip1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.UpdateInstance(ctx, linodeID, opts)
+}
+
 // UpdateInstanceConfig implements clients.LinodeClient
 func (_d LinodeClientWithTracing) UpdateInstanceConfig(ctx context.Context, linodeID int, configID int, opts linodego.InstanceConfigUpdateOptions) (ip1 *linodego.InstanceConfig, err error) {
 	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.UpdateInstanceConfig")
@@ -1123,6 +2032,112 @@ func (_d LinodeClientWithTracing) UpdateInstanceConfig(ctx context.Context, lino
 	return _d.LinodeClient.UpdateInstanceConfig(ctx, linodeID, configID, opts)
 }
 
+// UpdateInstanceDisk implements clients.LinodeClient
+func (_d LinodeClientWithTracing) UpdateInstanceDisk(ctx context.Context, linodeID int, diskID int, opts linodego.InstanceDiskUpdateOptions) (ip1 *linodego.InstanceDisk, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.UpdateInstanceDisk")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":      ctx,
+				"linodeID": linodeID,
+				"diskID":   diskID,
+				"opts":     opts}, map[string]interface{}{
+				"ip1": ip1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.UpdateInstanceDisk(ctx, linodeID, diskID, opts)
+}
+
+// UpdateMySQLDatabase implements clients.LinodeClient
+func (_d LinodeClientWithTracing) UpdateMySQLDatabase(ctx context.Context, databaseID int, opts linodego.MySQLUpdateOptions) (mp1 *linodego.MySQLDatabase, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.UpdateMySQLDatabase")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":        ctx,
+				"databaseID": databaseID,
+				"opts":       opts}, map[string]interface{}{
+				"mp1": mp1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.UpdateMySQLDatabase(ctx, databaseID, opts)
+}
+
+// UpdateNodeBalancer implements clients.LinodeClient
+func (_d LinodeClientWithTracing) UpdateNodeBalancer(ctx context.Context, nodebalancerID int, opts linodego.NodeBalancerUpdateOptions) (np1 *linodego.NodeBalancer, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.UpdateNodeBalancer")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":            ctx,
+				"nodebalancerID": nodebalancerID,
+				"opts":           opts}, map[string]interface{}{
+				"np1": np1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.UpdateNodeBalancer(ctx, nodebalancerID, opts)
+}
+
+// UpdateNodeBalancerConfig implements clients.LinodeClient
+func (_d LinodeClientWithTracing) UpdateNodeBalancerConfig(ctx context.Context, nodebalancerID int, configID int, opts linodego.NodeBalancerConfigUpdateOptions) (np1 *linodego.NodeBalancerConfig, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.UpdateNodeBalancerConfig")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":            ctx,
+				"nodebalancerID": nodebalancerID,
+				"configID":       configID,
+				"opts":           opts}, map[string]interface{}{
+				"np1": np1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.UpdateNodeBalancerConfig(ctx, nodebalancerID, configID, opts)
+}
+
 // UpdatePlacementGroup implements clients.LinodeClient
 func (_d LinodeClientWithTracing) UpdatePlacementGroup(ctx context.Context, id int, options linodego.PlacementGroupUpdateOptions) (pp1 *linodego.PlacementGroup, err error) {
 	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.UpdatePlacementGroup")
@@ -1148,3 +2163,55 @@ func (_d LinodeClientWithTracing) UpdatePlacementGroup(ctx context.Context, id i
 	}()
 	return _d.LinodeClient.UpdatePlacementGroup(ctx, id, options)
 }
+
+// UpdatePostgresDatabase implements clients.LinodeClient
+func (_d LinodeClientWithTracing) UpdatePostgresDatabase(ctx context.Context, databaseID int, opts linodego.PostgresUpdateOptions) (pp1 *linodego.PostgresDatabase, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.UpdatePostgresDatabase")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":        ctx,
+				"databaseID": databaseID,
+				"opts":       opts}, map[string]interface{}{
+				"pp1": pp1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.UpdatePostgresDatabase(ctx, databaseID, opts)
+}
+
+// UpdateVolume implements clients.LinodeClient
+func (_d LinodeClientWithTracing) UpdateVolume(ctx context.Context, volumeID int, opts linodego.VolumeUpdateOptions) (vp1 *linodego.Volume, err error) {
+	ctx, _span := tracing.Start(ctx, "clients.LinodeClient.UpdateVolume")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":      ctx,
+				"volumeID": volumeID,
+				"opts":     opts}, map[string]interface{}{
+				"vp1": vp1,
+				"err": err})
+		}
+
+		if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.LinodeClient.UpdateVolume(ctx, volumeID, opts)
+}
@@ -36,8 +36,16 @@ const (
 	DefaultMachineControllerWaitForPreflightTimeout = 5 * time.Minute
 	// DefaultMachineControllerWaitForRunningTimeout is the default timeout if instance is not running.
 	DefaultMachineControllerWaitForRunningTimeout = 20 * time.Minute
+	// DefaultMachineControllerShutdownTimeout is the default timeout for an instance to shut down before it is deleted.
+	DefaultMachineControllerShutdownTimeout = 2 * time.Minute
 	// DefaultMachineControllerRetryDelay is the default requeue delay if there is an error.
 	DefaultMachineControllerRetryDelay = 10 * time.Second
+	// DefaultMachineControllerMaintenanceWindowDelay is the default requeue delay while a reboot waits for the maintenance window.
+	DefaultMachineControllerMaintenanceWindowDelay = 5 * time.Minute
+	// DefaultMachineControllerPowerScheduleDelay is the default requeue delay while an instance is powered off by its power schedule.
+	DefaultMachineControllerPowerScheduleDelay = 5 * time.Minute
+	// DefaultMachineControllerTransferStatusInterval is the default interval at which the transfer usage in the status is refreshed.
+	DefaultMachineControllerTransferStatusInterval = time.Hour
 	// DefaultLinodeTooManyRequestsErrorRetryDelay is the default requeue delay if there is a Linode API error.
 	DefaultLinodeTooManyRequestsErrorRetryDelay = time.Minute
 
@@ -62,6 +70,13 @@ const (
 
 	// DefaultDNSTTLSec is the default TTL used for DNS entries for api server loadbalancing
 	DefaultDNSTTLSec = 30
+
+	// DefaultCircuitBreakerFailureThreshold is the default number of recent Linode API failures that opens the circuit breaker.
+	DefaultCircuitBreakerFailureThreshold = 5
+	// DefaultCircuitBreakerWindow is the default period over which Linode API failures are counted.
+	DefaultCircuitBreakerWindow = time.Minute
+	// DefaultCircuitBreakerCooldown is the default period reconciles back off for once the circuit breaker opens.
+	DefaultCircuitBreakerCooldown = time.Minute
 )
 
 // DefaultedLoopTimeout will default the timeout if it is zero-valued.